@@ -32,6 +32,7 @@ import (
 	"runtime"
 	"runtime/debug"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -40,6 +41,9 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/httphandler"
 	"github.com/prometheus-community/windows_exporter/internal/log"
 	"github.com/prometheus-community/windows_exporter/internal/log/flag"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/roles"
+	"github.com/prometheus-community/windows_exporter/internal/state"
 	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus-community/windows_exporter/pkg/collector"
 	"github.com/prometheus/common/version"
@@ -48,6 +52,9 @@ import (
 	"golang.org/x/sys/windows"
 )
 
+// defaultMemoryLimit is the default soft memory limit of the exporter in bytes.
+const defaultMemoryLimit = 200000000
+
 func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
 
@@ -71,6 +78,8 @@ func run(ctx context.Context, args []string) int {
 
 	app := kingpin.New("windows_exporter", "A metrics collector for Windows.")
 
+	var memoryLimitSet, runtimeMemoryLimitSet bool
+
 	var (
 		configFile = app.Flag(
 			"config.file",
@@ -81,10 +90,18 @@ func run(ctx context.Context, args []string) int {
 			"telemetry.path",
 			"URL path for surfacing collected metrics.",
 		).Default("/metrics").String()
+		nodeExporterCompat = app.Flag(
+			"telemetry.node-exporter-compat",
+			"If true, a curated set of metrics is additionally exposed under node_exporter names, e.g. node_cpu_seconds_total.",
+		).Default("false").Bool()
 		disableExporterMetrics = app.Flag(
 			"web.disable-exporter-metrics",
 			"Exclude metrics about the exporter itself (promhttp_*, process_*, go_*).",
 		).Bool()
+		httpClientInfoLimit = app.Flag(
+			"web.client-info-limit",
+			"Number of distinct remote IPs exposed by windows_exporter_http_client_info. 0 disables the metric.",
+		).Default("0").Int()
 		enabledCollectors = app.Flag(
 			"collectors.enabled",
 			"Comma-separated list of collectors to use. Use '[defaults]' as a placeholder for all the collectors enabled by default.").
@@ -93,6 +110,26 @@ func run(ctx context.Context, args []string) int {
 			"collectors.disabled",
 			"Comma-separated list of collectors to exclude. Can be used to disable collector from the defaults.").
 			Default("").String()
+		criticalCollectors = app.Flag(
+			"collectors.critical",
+			"Comma-separated list of enabled collectors, which fail the whole scrape with HTTP 500 if they fail or time out.").
+			Default("").String()
+		maxSeriesPerCollector = app.Flag(
+			"collectors.max-series-per-collector",
+			"Maximum number of series a single collector may emit per scrape. Further series are dropped and windows_exporter_collector_series_truncated is set to 1. 0 means unlimited.",
+		).Default("0").Int()
+		pdhLogFile = app.Flag(
+			"collectors.pdh-log-file",
+			"Read performance counters from a performance counter log (.blg or .csv) instead of the live system. Each scrape reads the next sample. For testing only.",
+		).Hidden().String()
+		pdhStaleThreshold = app.Flag(
+			"collectors.pdh-stale-threshold",
+			"Number of consecutive scrapes with identical raw performance counter values and timestamp, after which windows_exporter_pdh_data_stale is set to 1. 0 disables the metric.",
+		).Default(strconv.Itoa(pdh.DefaultStaleThreshold)).Int()
+		pdhSharedQuery = app.Flag(
+			"collectors.pdh-shared-query",
+			"If true, all performance counter based collectors read from a single query, which is collected once per scrape. The values of all collectors belong to the same sample.",
+		).Default("false").Bool()
 		timeoutMargin = app.Flag(
 			"scrape.timeout-margin",
 			"Seconds to subtract from the timeout allowed by the client. Tune to allow for overhead or high loads.",
@@ -101,14 +138,46 @@ func run(ctx context.Context, args []string) int {
 			"debug.enabled",
 			"If true, windows_exporter will expose debug endpoints under /debug/pprof.",
 		).Default("false").Bool()
+		estimateEnabled = app.Flag(
+			"web.estimate.enabled",
+			"If true, windows_exporter will expose /estimate?collector=<name>, which runs a single collection of a collector and returns the number of series. Collectors are executed on demand, even if disabled.",
+		).Default("false").Bool()
+		estimateTimeout = app.Flag(
+			"web.estimate.timeout",
+			"Maximum duration of a collection triggered by /estimate, including build and close of the collector.",
+		).Default("30s").Duration()
 		processPriority = app.Flag(
 			"process.priority",
 			"Priority of the exporter process. Higher priorities may improve exporter responsiveness during periods of system load. Can be one of [\"realtime\", \"high\", \"abovenormal\", \"normal\", \"belownormal\", \"low\"]",
 		).Default("normal").String()
 		memoryLimit = app.Flag(
 			"process.memory-limit",
-			"Limit memory usage in bytes. This is a soft-limit and not guaranteed. 0 means no limit. Read more at https://pkg.go.dev/runtime/debug#SetMemoryLimit .",
-		).Default("200000000").Int64()
+			"Deprecated: use --runtime.memory-limit. Limit memory usage in bytes. This is a soft-limit and not guaranteed. 0 means no limit. Ignored if --runtime.memory-limit is set. Read more at https://pkg.go.dev/runtime/debug#SetMemoryLimit .",
+		).Default(strconv.FormatInt(defaultMemoryLimit, 10)).IsSetByUser(&memoryLimitSet).Int64()
+		runtimeMemoryLimit = app.Flag(
+			"runtime.memory-limit",
+			"Soft memory limit of the exporter in bytes. The garbage collector runs more often when the limit is approached. 0 means no limit. Takes precedence over the deprecated --process.memory-limit. Read more at https://pkg.go.dev/runtime/debug#SetMemoryLimit .",
+		).Default(strconv.FormatInt(defaultMemoryLimit, 10)).IsSetByUser(&runtimeMemoryLimitSet).Int64()
+		runtimeGCPercent = app.Flag(
+			"runtime.gc-percent",
+			"Garbage collection target percentage, like GOGC. -1 disables proportional garbage collection, so that only the memory limit triggers a collection. Defaults to the GOGC environment variable or 100.",
+		).Default("").String()
+		runtimeAllocationWarningThreshold = app.Flag(
+			"runtime.allocation-warning-threshold",
+			"Log a warning, if a single scrape allocates more than the given number of bytes. 0 disables the warning.",
+		).Default("0").Uint64()
+		stateEnabled = app.Flag(
+			"state.enabled",
+			"If true, counters maintained by windows_exporter itself, e.g. windows_ad_account_lockouts_total, are persisted in a state file and restored on startup.",
+		).Default("false").Bool()
+		statePath = app.Flag(
+			"state.path",
+			"Path of the state file.",
+		).Default(state.DefaultPath()).String()
+		stateSaveInterval = app.Flag(
+			"state.save-interval",
+			"Interval in which the state file is written. The state file is also written on shutdown. 0 disables periodic writes.",
+		).Default("5m").Duration()
 	)
 
 	logFile := &log.AllowedFile{}
@@ -136,7 +205,31 @@ func run(ctx context.Context, args []string) int {
 		return 1
 	}
 
-	debug.SetMemoryLimit(*memoryLimit)
+	// Values of the configuration file are applied as flag defaults,
+	// so a flag is also considered set, if it differs from the default.
+	memoryLimitSet = memoryLimitSet || *memoryLimit != defaultMemoryLimit
+	runtimeMemoryLimitSet = runtimeMemoryLimitSet || *runtimeMemoryLimit != defaultMemoryLimit
+
+	// --runtime.memory-limit takes precedence over the deprecated --process.memory-limit.
+	if memoryLimitSet && !runtimeMemoryLimitSet {
+		debug.SetMemoryLimit(*memoryLimit)
+	} else {
+		debug.SetMemoryLimit(*runtimeMemoryLimit)
+	}
+
+	if *runtimeGCPercent != "" {
+		gcPercent, err := strconv.Atoi(*runtimeGCPercent)
+		if err != nil {
+			//nolint:sloglint // we do not have an logger yet
+			slog.LogAttrs(ctx, slog.LevelError, "Failed to parse --runtime.gc-percent",
+				slog.Any("err", err),
+			)
+
+			return 1
+		}
+
+		debug.SetGCPercent(gcPercent)
+	}
 
 	logger, err := log.New(logConfig)
 	if err != nil {
@@ -147,8 +240,25 @@ func run(ctx context.Context, args []string) int {
 		return 1
 	}
 
+	defer func() {
+		if err := log.Close(logger); err != nil {
+			logger.LogAttrs(ctx, slog.LevelWarn, "failed to close event log",
+				slog.Any("err", err),
+			)
+		}
+	}()
+
 	logger.LogAttrs(ctx, slog.LevelDebug, "logging has Started")
 
+	switch {
+	case memoryLimitSet && runtimeMemoryLimitSet:
+		logger.LogAttrs(ctx, slog.LevelWarn, "both --process.memory-limit and --runtime.memory-limit are set, ignoring the deprecated --process.memory-limit",
+			slog.Int64("memory_limit", *runtimeMemoryLimit),
+		)
+	case memoryLimitSet:
+		logger.LogAttrs(ctx, slog.LevelWarn, "--process.memory-limit is deprecated, use --runtime.memory-limit instead")
+	}
+
 	if configFile != nil && *configFile != "" {
 		logger.LogAttrs(ctx, slog.LevelInfo, "using configuration file: "+*configFile)
 	}
@@ -174,6 +284,61 @@ func run(ctx context.Context, args []string) int {
 		collectors.Disable(slices.Compact(strings.Split(*disabledCollectors, ",")))
 	}
 
+	critical := make([]string, 0)
+
+	if *criticalCollectors != "" {
+		critical = append(critical, strings.Split(*criticalCollectors, ",")...)
+	}
+
+	if *configFile != "" {
+		configCritical, err := config.ParseCriticalCollectors(*configFile)
+		if err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "couldn't load critical collectors",
+				slog.Any("err", err),
+			)
+
+			return 1
+		}
+
+		critical = append(critical, configCritical...)
+	}
+
+	if len(critical) > 0 {
+		slices.Sort(critical)
+
+		if err := collectors.SetCriticalCollectors(slices.Compact(critical)); err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "invalid critical collectors",
+				slog.Any("err", err),
+			)
+
+			return 1
+		}
+	}
+
+	collectors.SetMaxSeriesPerCollector(*maxSeriesPerCollector)
+	collectors.SetAllocationWarningThreshold(*runtimeAllocationWarningThreshold)
+
+	if *stateEnabled {
+		collectors.SetStateStore(state.Open(logger, *statePath))
+	}
+
+	pdh.SetStaleThreshold(*pdhStaleThreshold)
+	pdh.SetSharedQuery(*pdhSharedQuery)
+
+	if *pdhLogFile != "" {
+		if err := pdh.SetLogFile(*pdhLogFile); err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "couldn't open performance counter log",
+				slog.Any("err", err),
+			)
+
+			return 1
+		}
+
+		logger.LogAttrs(ctx, slog.LevelWarn, "reading performance counters from log file instead of the live system",
+			slog.String("file", *pdhLogFile),
+		)
+	}
+
 	// Initialize collectors before loading
 	if err = collectors.Build(ctx, logger); err != nil {
 		for _, err := range utils.SplitError(err) {
@@ -185,17 +350,67 @@ func run(ctx context.Context, args []string) int {
 		}
 	}
 
+	if *configFile != "" {
+		counterOverrides, err := config.ParseCounterOverrides(*configFile)
+		if err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "couldn't load counter overrides",
+				slog.Any("err", err),
+			)
+
+			return 1
+		}
+
+		if err = collectors.SetCounterOverrides(counterOverrides); err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "invalid counter overrides",
+				slog.Any("err", err),
+			)
+
+			return 1
+		}
+
+		clusterRoleLabels, err := config.ParseClusterRoleLabels(*configFile)
+		if err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "couldn't load cluster role labels",
+				slog.Any("err", err),
+			)
+
+			return 1
+		}
+
+		if err = collectors.SetClusterRoleLabels(clusterRoleLabels); err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "invalid cluster role labels",
+				slog.Any("err", err),
+			)
+
+			return 1
+		}
+	}
+
+	if *stateEnabled && *stateSaveInterval > 0 {
+		go saveStatePeriodically(ctx, logger, collectors, *stateSaveInterval)
+	}
+
 	logCurrentUser(ctx, logger)
 
 	logger.InfoContext(ctx, "Enabled collectors: "+strings.Join(enabledCollectorList, ", "))
 
-	mux := http.NewServeMux()
-	mux.Handle("GET /health", httphandler.NewHealthHandler())
-	mux.Handle("GET /version", httphandler.NewVersionHandler())
-	mux.Handle("GET "+*metricsPath, httphandler.New(logger, collectors, &httphandler.Options{
+	metricsHandler := httphandler.New(logger, collectors, &httphandler.Options{
 		DisableExporterMetrics: *disableExporterMetrics,
 		TimeoutMargin:          *timeoutMargin,
-	}))
+		HTTPClientInfoLimit:    *httpClientInfoLimit,
+		NodeExporterCompat:     *nodeExporterCompat,
+		Roles:                  roles.NewCollector(logger, roles.SystemSource{}),
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /{$}", metricsHandler.Instrument("/", httphandler.NewLandingHandler(collectors, *metricsPath, *configFile)))
+	mux.Handle("GET /health", metricsHandler.Instrument("/health", httphandler.NewHealthHandler()))
+	mux.Handle("GET /version", metricsHandler.Instrument("/version", httphandler.NewVersionHandler()))
+	mux.Handle("GET "+*metricsPath, metricsHandler.Instrument(*metricsPath, metricsHandler))
+
+	if *estimateEnabled {
+		mux.Handle("GET /estimate", metricsHandler.Instrument("/estimate", httphandler.NewEstimateHandler(logger, collectors, *estimateTimeout)))
+	}
 
 	if *debugEnabled {
 		mux.HandleFunc("GET /debug/pprof/", pprof.Index)
@@ -261,9 +476,35 @@ func run(ctx context.Context, args []string) int {
 		logger.LogAttrs(ctx, slog.LevelInfo, "windows_exporter has shut down")
 	}
 
+	//nolint:contextcheck
+	if err = collectors.Close(); err != nil {
+		logger.LogAttrs(ctx, slog.LevelWarn, "Failed to close collectors",
+			slog.Any("err", err),
+		)
+	}
+
 	return 0
 }
 
+// saveStatePeriodically writes the collector state file in the given interval, so that a crash loses at most one interval.
+func saveStatePeriodically(ctx context.Context, logger *slog.Logger, collectors *collector.Collection, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := collectors.SaveState(); err != nil {
+				logger.LogAttrs(ctx, slog.LevelWarn, "Failed to save collector state",
+					slog.Any("err", err),
+				)
+			}
+		}
+	}
+}
+
 func logCurrentUser(ctx context.Context, logger *slog.Logger) {
 	u, err := user.Current()
 	if err != nil {
@@ -18,25 +18,46 @@
 package ad
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-const Name = "ad"
+const (
+	Name = "ad"
 
-type Config struct{}
+	subCollectorMetrics         = "metrics"
+	subCollectorAccountLockouts = "account_lockouts"
+)
+
+type Config struct {
+	CollectorsEnabled []string `yaml:"enabled"`
+	// DisableDeprecatedMetrics suppresses the deprecated metrics that were renamed to use base units.
+	DisableDeprecatedMetrics bool `yaml:"disable-deprecated-metrics"`
+}
 
 //nolint:gochecknoglobals
-var ConfigDefaults = Config{}
+var ConfigDefaults = Config{
+	CollectorsEnabled: []string{
+		subCollectorMetrics,
+	},
+	DisableDeprecatedMetrics: false,
+}
 
 type Collector struct {
+	collectorAccountLockouts
+
 	config Config
 
 	perfDataCollector *pdh.Collector
@@ -46,8 +67,10 @@ type Collector struct {
 	addressBookOperationsTotal                          *prometheus.Desc
 	approximateHighestDistinguishedNameTag              *prometheus.Desc
 	atqAverageRequestLatency                            *prometheus.Desc
+	atqAverageRequestLatencySeconds                     *prometheus.Desc
 	atqCurrentThreads                                   *prometheus.Desc
 	atqEstimatedDelaySeconds                            *prometheus.Desc
+	atqThreads                                          *prometheus.Desc
 	atqOutstandingRequests                              *prometheus.Desc
 	bindsTotal                                          *prometheus.Desc
 	changeMonitorUpdatesPending                         *prometheus.Desc
@@ -55,6 +78,7 @@ type Collector struct {
 	databaseOperationsTotal                             *prometheus.Desc
 	directoryOperationsTotal                            *prometheus.Desc
 	directorySearchSubOperationsTotal                   *prometheus.Desc
+	directorySearchesTotal                              *prometheus.Desc
 	directoryServiceThreads                             *prometheus.Desc
 	interSiteReplicationDataBytesTotal                  *prometheus.Desc
 	intraSiteReplicationDataBytesTotal                  *prometheus.Desc
@@ -88,6 +112,7 @@ type Collector struct {
 	samComputerCreationSuccessfulRequestsTotal          *prometheus.Desc
 	samEnumerationsTotal                                *prometheus.Desc
 	samGroupEvaluationLatency                           *prometheus.Desc
+	samGroupEvaluationLatencySeconds                    *prometheus.Desc
 	samGroupMembershipEvaluationsNonTransitiveTotal     *prometheus.Desc
 	samGroupMembershipEvaluationsTotal                  *prometheus.Desc
 	samGroupMembershipEvaluationsTransitiveTotal        *prometheus.Desc
@@ -111,6 +136,10 @@ func New(config *Config) *Collector {
 		config = &ConfigDefaults
 	}
 
+	if config.CollectorsEnabled == nil {
+		config.CollectorsEnabled = ConfigDefaults.CollectorsEnabled
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -118,21 +147,76 @@ func New(config *Config) *Collector {
 	return c
 }
 
-func NewWithFlags(_ *kingpin.Application) *Collector {
-	return &Collector{}
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+	c.config.CollectorsEnabled = make([]string, 0)
+
+	var collectorsEnabled string
+
+	app.Flag(
+		"collector.ad.enabled",
+		"Comma-separated list of collectors to use. Available collectors: metrics, account_lockouts.",
+	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
+
+	app.Flag(
+		"collector.ad.disable-deprecated-metrics",
+		"Do not emit the deprecated metrics with non-base units, e.g. windows_ad_atq_average_request_latency.",
+	).Default(strconv.FormatBool(c.config.DisableDeprecatedMetrics)).BoolVar(&c.config.DisableDeprecatedMetrics)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
+
+		return nil
+	})
+
+	return c
 }
 
 func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
-	c.perfDataCollector.Close()
+	if c.perfDataCollector != nil {
+		c.perfDataCollector.Close()
+	}
 
-	return nil
+	return c.closeAccountLockouts()
 }
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+	for _, collector := range c.config.CollectorsEnabled {
+		if !slices.Contains([]string{subCollectorMetrics, subCollectorAccountLockouts}, collector) {
+			return fmt.Errorf("unknown sub collector: %s. Possible values: %s", collector,
+				strings.Join([]string{subCollectorMetrics, subCollectorAccountLockouts}, ", "),
+			)
+		}
+	}
+
+	logger = logger.With(slog.String("collector", Name))
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+		if err := c.buildMetrics(logger); err != nil {
+			return err
+		}
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorAccountLockouts) {
+		if err := c.buildAccountLockouts(logger); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) buildMetrics(logger *slog.Logger) error {
 	c.addressBookOperationsTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "address_book_operations_total"),
 		"",
@@ -165,7 +249,13 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	)
 	c.atqAverageRequestLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "atq_average_request_latency"),
-		"",
+		"(deprecated, use windows_ad_atq_average_request_latency_seconds)",
+		nil,
+		nil,
+	)
+	c.atqAverageRequestLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "atq_average_request_latency_seconds"),
+		"The average time in seconds to process an LDAP request",
 		nil,
 		nil,
 	)
@@ -175,6 +265,18 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		[]string{"service"},
 		nil,
 	)
+	c.atqThreads = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "atq_threads"),
+		"Number of ATQ threads. total is the number of threads allocated, ldap and other are the threads servicing LDAP and other requests",
+		[]string{"state"},
+		nil,
+	)
+	c.directorySearchesTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "directory_searches_total"),
+		"Number of directory searches by scope. all contains the searches of all clients",
+		[]string{"scope"},
+		nil,
+	)
 	c.searchesTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "searches_total"),
 		"",
@@ -441,7 +543,13 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	)
 	c.samGroupEvaluationLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "sam_group_evaluation_latency"),
-		"The mean latency of the last 100 group evaluations performed for authentication",
+		"The mean latency of the last 100 group evaluations performed for authentication (deprecated, use windows_ad_sam_group_evaluation_latency_seconds)",
+		[]string{"evaluation_type"},
+		nil,
+	)
+	c.samGroupEvaluationLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "sam_group_evaluation_latency_seconds"),
+		"The mean latency in seconds of the last 100 group evaluations performed for authentication",
 		[]string{"evaluation_type"},
 		nil,
 	)
@@ -507,11 +615,16 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 	)
 
-	var err error
+	var (
+		object string
+		err    error
+	)
 
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "DirectoryServices", pdh.InstancesAll)
+	c.perfDataCollector, object, err = newPerfDataCollector(func(object string) (*pdh.Collector, error) {
+		return pdh.NewCollector[perfDataCounterValues](logger, pdh.CounterTypeRaw, object, pdh.InstancesAll)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create DirectoryServices collector: %w", err)
+		return fmt.Errorf("failed to create %s collector: %w", object, err)
 	}
 
 	return nil
@@ -520,6 +633,24 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+	errs := make([]error, 0)
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+		if err := c.collectMetrics(ch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorAccountLockouts) {
+		if err := c.collectAccountLockouts(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed collecting account lockout metrics: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) collectMetrics(ch chan<- prometheus.Metric) error {
 	err := c.perfDataCollector.Collect(&c.perfDataObject)
 	if err != nil {
 		return fmt.Errorf("failed to collect DirectoryServices (AD) metrics: %w", err)
@@ -584,7 +715,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 	ch <- prometheus.MustNewConstMetric(
 		c.atqEstimatedDelaySeconds,
 		prometheus.GaugeValue,
-		c.perfDataObject[0].AtqEstimatedQueueDelay/1000,
+		atqEstimatedDelaySeconds(c.perfDataObject[0]),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
@@ -593,10 +724,18 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		c.perfDataObject[0].AtqOutstandingQueuedRequests,
 	)
 
+	if !c.config.DisableDeprecatedMetrics {
+		ch <- prometheus.MustNewConstMetric(
+			c.atqAverageRequestLatency,
+			prometheus.GaugeValue,
+			c.perfDataObject[0].AtqRequestLatency,
+		)
+	}
+
 	ch <- prometheus.MustNewConstMetric(
-		c.atqAverageRequestLatency,
+		c.atqAverageRequestLatencySeconds,
 		prometheus.GaugeValue,
-		c.perfDataObject[0].AtqRequestLatency,
+		utils.MilliSecToSec(c.perfDataObject[0].AtqRequestLatency),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
@@ -613,6 +752,15 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		"other",
 	)
 
+	for state, value := range atqThreadsByState(c.perfDataObject[0]) {
+		ch <- prometheus.MustNewConstMetric(
+			c.atqThreads,
+			prometheus.GaugeValue,
+			value,
+			state,
+		)
+	}
+
 	ch <- prometheus.MustNewConstMetric(
 		c.searchesTotal,
 		prometheus.CounterValue,
@@ -620,6 +768,15 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		"base",
 	)
 
+	for scope, value := range directorySearchesByScope(c.perfDataObject[0]) {
+		ch <- prometheus.MustNewConstMetric(
+			c.directorySearchesTotal,
+			prometheus.CounterValue,
+			value,
+			scope,
+		)
+	}
+
 	ch <- prometheus.MustNewConstMetric(
 		c.searchesTotal,
 		prometheus.CounterValue,
@@ -1215,17 +1372,33 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		c.perfDataObject[0].SamTransitiveMembershipEvaluationsPerSec,
 	)
 
+	if !c.config.DisableDeprecatedMetrics {
+		ch <- prometheus.MustNewConstMetric(
+			c.samGroupEvaluationLatency,
+			prometheus.GaugeValue,
+			c.perfDataObject[0].SamAccountGroupEvaluationLatency,
+			"account_group",
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.samGroupEvaluationLatency,
+			prometheus.GaugeValue,
+			c.perfDataObject[0].SamResourceGroupEvaluationLatency,
+			"resource_group",
+		)
+	}
+
 	ch <- prometheus.MustNewConstMetric(
-		c.samGroupEvaluationLatency,
+		c.samGroupEvaluationLatencySeconds,
 		prometheus.GaugeValue,
-		c.perfDataObject[0].SamAccountGroupEvaluationLatency,
+		utils.MilliSecToSec(c.perfDataObject[0].SamAccountGroupEvaluationLatency),
 		"account_group",
 	)
 
 	ch <- prometheus.MustNewConstMetric(
-		c.samGroupEvaluationLatency,
+		c.samGroupEvaluationLatencySeconds,
 		prometheus.GaugeValue,
-		c.perfDataObject[0].SamResourceGroupEvaluationLatency,
+		utils.MilliSecToSec(c.perfDataObject[0].SamResourceGroupEvaluationLatency),
 		"resource_group",
 	)
 
@@ -0,0 +1,324 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package ad
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/wevtapi"
+	"github.com/prometheus-community/windows_exporter/internal/headers/wldap32"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// eventIDAccountLockout is "A user account was locked out".
+	//
+	// https://learn.microsoft.com/en-us/previous-versions/windows/it-pro/windows-10/security/threat-protection/auditing/event-4740
+	eventIDAccountLockout = 4740
+	securityChannel       = "Security"
+	// verNTDomainController is VER_NT_DOMAIN_CONTROLLER of OSVERSIONINFOEX.wProductType.
+	verNTDomainController = 2
+	eventBatchSize        = 64
+)
+
+type collectorAccountLockouts struct {
+	accountLockoutsMu sync.Mutex
+	// accountLockoutsDisabled is set on non-domain controllers and without elevation.
+	accountLockoutsDisabled bool
+	renderContext           wevtapi.Handle
+	lastLockoutRecordID     uint64
+	accountLockouts         float64
+	passwordMaxAgeSeconds   float64
+	passwordMaxAgeValid     bool
+
+	accountLockoutsTotal        *prometheus.Desc
+	passwordPolicyMaxAgeSeconds *prometheus.Desc
+}
+
+func (c *Collector) buildAccountLockouts(logger *slog.Logger) error {
+	c.accountLockoutsTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "account_lockouts_total"),
+		"Number of account lockouts (Security event 4740) logged on this domain controller since the exporter started. "+
+			"Persisted across restarts, if --state.enabled is set",
+		nil,
+		nil,
+	)
+	c.passwordPolicyMaxAgeSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "password_policy_max_age_seconds"),
+		"Maximum password age of the domain password policy (maxPwdAge). 0 if passwords do not expire",
+		nil,
+		nil,
+	)
+
+	if windows.RtlGetVersion().ProductType != verNTDomainController {
+		logger.Info("host is not a domain controller, skipping account lockout metrics")
+
+		c.accountLockoutsDisabled = true
+
+		return nil
+	}
+
+	if !windows.GetCurrentProcessToken().IsElevated() {
+		logger.Warn("windows_exporter is not running elevated, skipping account lockout metrics")
+
+		c.accountLockoutsDisabled = true
+
+		return nil
+	}
+
+	var err error
+
+	c.renderContext, err = wevtapi.CreateSystemRenderContext()
+	if err != nil {
+		return fmt.Errorf("failed to create event render context: %w", err)
+	}
+
+	// Only count lockouts logged after the exporter started.
+	c.lastLockoutRecordID, err = c.latestLockoutRecordID()
+	if err != nil {
+		return fmt.Errorf("failed to query latest account lockout event: %w", err)
+	}
+
+	// The password policy is read once, since it rarely changes.
+	c.passwordMaxAgeSeconds, err = readPasswordMaxAge()
+	if err != nil {
+		logger.Warn("failed to read domain password policy",
+			slog.Any("err", err),
+		)
+	} else {
+		c.passwordMaxAgeValid = true
+	}
+
+	return nil
+}
+
+func (c *Collector) closeAccountLockouts() error {
+	if c.renderContext == 0 {
+		return nil
+	}
+
+	err := wevtapi.Close(c.renderContext)
+	c.renderContext = 0
+
+	return err
+}
+
+func (c *Collector) collectAccountLockouts(ch chan<- prometheus.Metric) error {
+	if c.accountLockoutsDisabled {
+		return nil
+	}
+
+	c.accountLockoutsMu.Lock()
+	defer c.accountLockoutsMu.Unlock()
+
+	count, lastRecordID, err := c.countLockoutEvents(lockoutQuery(c.lastLockoutRecordID), 0)
+	if err != nil {
+		return fmt.Errorf("failed to query account lockout events: %w", err)
+	}
+
+	c.accountLockouts += float64(count)
+	c.lastLockoutRecordID = max(c.lastLockoutRecordID, lastRecordID)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.accountLockoutsTotal,
+		prometheus.CounterValue,
+		c.accountLockouts,
+	)
+
+	if c.passwordMaxAgeValid {
+		ch <- prometheus.MustNewConstMetric(
+			c.passwordPolicyMaxAgeSeconds,
+			prometheus.GaugeValue,
+			c.passwordMaxAgeSeconds,
+		)
+	}
+
+	return nil
+}
+
+// accountLockoutsState is the persisted state of the account lockouts sub collector.
+type accountLockoutsState struct {
+	AccountLockouts     float64 `json:"account_lockouts"`
+	LastLockoutRecordID uint64  `json:"last_lockout_record_id"`
+}
+
+// SaveState returns the account lockout count and the EventRecordID of the last counted lockout.
+func (c *Collector) SaveState() (json.RawMessage, error) {
+	if !slices.Contains(c.config.CollectorsEnabled, subCollectorAccountLockouts) || c.accountLockoutsDisabled {
+		return json.RawMessage("null"), nil
+	}
+
+	c.accountLockoutsMu.Lock()
+	defer c.accountLockoutsMu.Unlock()
+
+	return json.Marshal(accountLockoutsState{
+		AccountLockouts:     c.accountLockouts,
+		LastLockoutRecordID: c.lastLockoutRecordID,
+	})
+}
+
+// RestoreState restores the account lockout count. Lockouts logged while the exporter was stopped
+// are counted by the next collection. If the Security log was cleared in between, the record IDs
+// start from the beginning and only lockouts logged after the startup are counted.
+func (c *Collector) RestoreState(state json.RawMessage) error {
+	if !slices.Contains(c.config.CollectorsEnabled, subCollectorAccountLockouts) || c.accountLockoutsDisabled {
+		return nil
+	}
+
+	var lockoutsState *accountLockoutsState
+
+	if err := json.Unmarshal(state, &lockoutsState); err != nil {
+		return fmt.Errorf("failed to parse account lockouts state: %w", err)
+	}
+
+	if lockoutsState == nil {
+		return nil
+	}
+
+	if lockoutsState.AccountLockouts < 0 || math.IsNaN(lockoutsState.AccountLockouts) {
+		return fmt.Errorf("invalid account lockout count %f", lockoutsState.AccountLockouts)
+	}
+
+	c.accountLockoutsMu.Lock()
+	defer c.accountLockoutsMu.Unlock()
+
+	c.accountLockouts = lockoutsState.AccountLockouts
+
+	if lockoutsState.LastLockoutRecordID <= c.lastLockoutRecordID {
+		c.lastLockoutRecordID = lockoutsState.LastLockoutRecordID
+	}
+
+	return nil
+}
+
+// latestLockoutRecordID returns the EventRecordID of the newest account lockout event, or 0 if there is none.
+func (c *Collector) latestLockoutRecordID() (uint64, error) {
+	_, lastRecordID, err := c.countLockoutEvents(lockoutQuery(0), 1)
+
+	return lastRecordID, err
+}
+
+// countLockoutEvents runs the query against the Security channel and returns the number of events
+// and the highest EventRecordID. If limit is greater than 0, the newest limit events are read.
+func (c *Collector) countLockoutEvents(query string, limit int) (int, uint64, error) {
+	flags := uint32(wevtapi.EvtQueryChannelPath)
+	if limit > 0 {
+		flags |= wevtapi.EvtQueryReverseDirection
+	}
+
+	resultSet, err := wevtapi.Query(securityChannel, query, flags)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer wevtapi.Close(resultSet) //nolint:errcheck
+
+	var (
+		count        int
+		lastRecordID uint64
+	)
+
+	events := make([]wevtapi.Handle, eventBatchSize)
+
+	for limit <= 0 || count < limit {
+		n, err := wevtapi.Next(resultSet, events, 0)
+		if err != nil {
+			return 0, 0, err
+		} else if n == 0 {
+			break
+		}
+
+		for _, event := range events[:n] {
+			recordID, renderErr := wevtapi.RenderEventRecordID(c.renderContext, event)
+			_ = wevtapi.Close(event)
+
+			if renderErr != nil {
+				err = errors.Join(err, renderErr)
+
+				continue
+			}
+
+			lastRecordID = max(lastRecordID, recordID)
+		}
+
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to render event: %w", err)
+		}
+
+		count += n
+	}
+
+	return count, lastRecordID, nil
+}
+
+// lockoutQuery returns the XPath query for account lockout events after the given EventRecordID.
+func lockoutQuery(afterRecordID uint64) string {
+	return fmt.Sprintf("*[System[(EventID=%d) and (EventRecordID>%d)]]", eventIDAccountLockout, afterRecordID)
+}
+
+// readPasswordMaxAge reads maxPwdAge of the domain object. The domain is located via the defaultNamingContext of the rootDSE.
+func readPasswordMaxAge() (float64, error) {
+	conn, err := wldap32.Dial("")
+	if err != nil {
+		return 0, err
+	}
+
+	defer conn.Close()
+
+	namingContexts, err := conn.ReadAttribute("", "defaultNamingContext")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read rootDSE: %w", err)
+	} else if len(namingContexts) == 0 {
+		return 0, errors.New("rootDSE has no defaultNamingContext")
+	}
+
+	maxPwdAge, err := conn.ReadAttribute(namingContexts[0], "maxPwdAge")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read maxPwdAge of %s: %w", namingContexts[0], err)
+	} else if len(maxPwdAge) == 0 {
+		return 0, fmt.Errorf("%s has no maxPwdAge", namingContexts[0])
+	}
+
+	return parseMaxPasswordAge(maxPwdAge[0])
+}
+
+// parseMaxPasswordAge converts maxPwdAge, a negative interval in 100 nanoseconds, to seconds.
+// Both 0 and the minimum int64 value mean that passwords do not expire.
+//
+// https://learn.microsoft.com/en-us/windows/win32/adschema/a-maxpwdage
+func parseMaxPasswordAge(value string) (float64, error) {
+	interval, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse maxPwdAge %q: %w", value, err)
+	}
+
+	if interval == math.MinInt64 || interval == 0 {
+		return 0, nil
+	}
+
+	return math.Abs(float64(interval)) / 1e7, nil
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package ad
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMaxPasswordAge(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		value    string
+		expected float64
+	}{
+		{"-36288000000000", 42 * 24 * 60 * 60},
+		{"-9223372036854775808", 0},
+		{"0", 0},
+	} {
+		maxAge, err := parseMaxPasswordAge(tc.value)
+		require.NoError(t, err, tc.value)
+		require.InDelta(t, tc.expected, maxAge, 0.001, tc.value)
+	}
+
+	_, err := parseMaxPasswordAge("never")
+	require.Error(t, err)
+}
+
+func TestLockoutQuery(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "*[System[(EventID=4740) and (EventRecordID>1234)]]", lockoutQuery(1234))
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package ad
+
+import (
+	"errors"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+)
+
+// perfObjectNames are the names of the performance counter object of the directory service.
+// The object was renamed from NTDS to DirectoryServices in Windows Server 2008.
+//
+//nolint:gochecknoglobals
+var perfObjectNames = []string{"DirectoryServices", "NTDS"}
+
+// newPerfDataCollector creates the performance counter collector for the first existing object of perfObjectNames.
+func newPerfDataCollector(newCollector func(object string) (*pdh.Collector, error)) (*pdh.Collector, string, error) {
+	var (
+		collector *pdh.Collector
+		object    string
+		err       error
+	)
+
+	for _, object = range perfObjectNames {
+		collector, err = newCollector(object)
+		if !errors.Is(err, pdh.NewPdhError(pdh.CstatusNoObject)) {
+			break
+		}
+	}
+
+	return collector, object, err
+}
+
+// atqEstimatedDelaySeconds returns the ATQ Estimated Queue Delay, which is reported in milliseconds, in seconds.
+func atqEstimatedDelaySeconds(data perfDataCounterValues) float64 {
+	return utils.MilliSecToSec(data.AtqEstimatedQueueDelay)
+}
+
+// atqThreadsByState returns the number of ATQ threads by state.
+func atqThreadsByState(data perfDataCounterValues) map[string]float64 {
+	return map[string]float64{
+		"total": data.AtqThreadsTotal,
+		"ldap":  data.AtqThreadsLDAP,
+		"other": data.AtqThreadsOther,
+	}
+}
+
+// directorySearchesByScope returns the number of directory searches by scope.
+// all contains the searches of all clients, including searches that are not issued via LDAP.
+func directorySearchesByScope(data perfDataCounterValues) map[string]float64 {
+	return map[string]float64{
+		"all":       data.DsDirectorySearchesPerSec,
+		"base":      data.BaseSearchesPerSec,
+		"one_level": data.OneLevelSearchesPerSec,
+		"subtree":   data.SubtreeSearchesPerSec,
+	}
+}
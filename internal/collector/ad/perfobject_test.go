@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package ad
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPerfDataCollector(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DirectoryServices", func(t *testing.T) {
+		t.Parallel()
+
+		var requested []string
+
+		collector, object, err := newPerfDataCollector(func(object string) (*pdh.Collector, error) {
+			requested = append(requested, object)
+
+			return &pdh.Collector{}, nil
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, collector)
+		require.Equal(t, "DirectoryServices", object)
+		require.Equal(t, []string{"DirectoryServices"}, requested)
+	})
+
+	t.Run("NTDS", func(t *testing.T) {
+		t.Parallel()
+
+		collector, object, err := newPerfDataCollector(func(object string) (*pdh.Collector, error) {
+			if object == "DirectoryServices" {
+				return nil, pdh.NewPdhError(pdh.CstatusNoObject)
+			}
+
+			return &pdh.Collector{}, nil
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, collector)
+		require.Equal(t, "NTDS", object)
+	})
+
+	t.Run("no object", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := newPerfDataCollector(func(string) (*pdh.Collector, error) {
+			return nil, pdh.NewPdhError(pdh.CstatusNoObject)
+		})
+
+		require.ErrorIs(t, err, pdh.NewPdhError(pdh.CstatusNoObject))
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		t.Parallel()
+
+		var requested []string
+
+		_, object, err := newPerfDataCollector(func(object string) (*pdh.Collector, error) {
+			requested = append(requested, object)
+
+			return nil, errors.New("access denied")
+		})
+
+		require.EqualError(t, err, "access denied")
+		require.Equal(t, "DirectoryServices", object)
+		require.Equal(t, []string{"DirectoryServices"}, requested)
+	})
+}
+
+func TestUnitConversions(t *testing.T) {
+	t.Parallel()
+
+	data := perfDataCounterValues{
+		AtqEstimatedQueueDelay:    1500,
+		AtqThreadsTotal:           8,
+		AtqThreadsLDAP:            3,
+		AtqThreadsOther:           1,
+		DsDirectorySearchesPerSec: 100,
+		BaseSearchesPerSec:        60,
+		OneLevelSearchesPerSec:    10,
+		SubtreeSearchesPerSec:     25,
+	}
+
+	require.InDelta(t, 1.5, atqEstimatedDelaySeconds(data), 1e-9)
+	require.Equal(t, map[string]float64{"total": 8, "ldap": 3, "other": 1}, atqThreadsByState(data))
+	require.Equal(t, map[string]float64{"all": 100, "base": 60, "one_level": 10, "subtree": 25}, directorySearchesByScope(data))
+}
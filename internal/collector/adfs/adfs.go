@@ -108,6 +108,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH}
+}
+
 func (c *Collector) Close() error {
 	c.perfDataCollector.Close()
 
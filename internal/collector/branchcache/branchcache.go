@@ -0,0 +1,228 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package branchcache
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Name = "branchcache"
+
+type Config struct{}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{}
+
+// A Collector is a Prometheus Collector for BranchCache metrics.
+type Collector struct {
+	config Config
+	logger *slog.Logger
+
+	perfDataCollector *pdh.Collector
+	perfDataObject    []perfDataCounterValues
+
+	// notAvailable is set, if the BranchCache feature is not installed.
+	notAvailable bool
+
+	bytesFromCache           *prometheus.Desc
+	bytesFromServer          *prometheus.Desc
+	bytesServed              *prometheus.Desc
+	discoveryAttempts        *prometheus.Desc
+	discoverySuccesses       *prometheus.Desc
+	cacheCompleteSegments    *prometheus.Desc
+	cachePartialSegments     *prometheus.Desc
+	hostedCacheSegmentOffers *prometheus.Desc
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(_ *kingpin.Application) *Collector {
+	return &Collector{}
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH}
+}
+
+func (c *Collector) Close() error {
+	c.perfDataCollector.Close()
+
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+	c.logger = logger.With(slog.String("collector", Name))
+
+	c.bytesFromCache = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "bytes_from_cache_total"),
+		"Number of content bytes retrieved from the BranchCache of peers or the hosted cache (Retrieval: Bytes from cache)",
+		nil,
+		nil,
+	)
+	c.bytesFromServer = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "bytes_from_server_total"),
+		"Number of content bytes retrieved from the content server (Retrieval: Bytes from server)",
+		nil,
+		nil,
+	)
+	c.bytesServed = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "bytes_served_total"),
+		"Number of content bytes served to peers from the local cache (Retrieval: Bytes served)",
+		nil,
+		nil,
+	)
+	c.discoveryAttempts = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "discovery_attempts_total"),
+		"Number of attempts to discover content in the cache of peers or the hosted cache (Discovery: Attempted discoveries)",
+		nil,
+		nil,
+	)
+	c.discoverySuccesses = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "discovery_successes_total"),
+		"Number of successful attempts to discover content in the cache of peers or the hosted cache (Discovery: Successful discoveries)",
+		nil,
+		nil,
+	)
+	c.cacheCompleteSegments = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "cache_complete_segments"),
+		"Number of complete file segments in the local cache (Local Cache: Cache complete file segments)",
+		nil,
+		nil,
+	)
+	c.cachePartialSegments = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "cache_partial_segments"),
+		"Number of partial file segments in the local cache (Local Cache: Cache partial file segments)",
+		nil,
+		nil,
+	)
+	c.hostedCacheSegmentOffers = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "hosted_cache_segment_offers_total"),
+		"Number of file segments offered to the hosted cache (Hosted Cache: Client file segment offers made)",
+		nil,
+		nil,
+	)
+
+	var err error
+
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](c.logger, pdh.CounterTypeRaw, "BranchCache", pdh.InstancesAll)
+	if err != nil {
+		if errors.Is(err, pdh.NewPdhError(pdh.CstatusNoObject)) {
+			c.logger.Debug("BranchCache is not installed on this host. The branchcache collector is disabled",
+				slog.Any("err", err),
+			)
+
+			c.notAvailable = true
+
+			return nil
+		}
+
+		return fmt.Errorf("failed to create BranchCache collector: %w", err)
+	}
+
+	return nil
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+	if c.notAvailable {
+		return nil
+	}
+
+	err := c.perfDataCollector.Collect(&c.perfDataObject)
+	if err != nil {
+		return fmt.Errorf("failed to collect BranchCache metrics: %w", err)
+	} else if len(c.perfDataObject) == 0 {
+		return fmt.Errorf("failed to collect BranchCache metrics: %w", types.ErrNoDataUnexpected)
+	}
+
+	data := c.perfDataObject[0]
+
+	ch <- prometheus.MustNewConstMetric(
+		c.bytesFromCache,
+		prometheus.CounterValue,
+		data.BytesFromCache,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.bytesFromServer,
+		prometheus.CounterValue,
+		data.BytesFromServer,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.bytesServed,
+		prometheus.CounterValue,
+		data.BytesServed,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.discoveryAttempts,
+		prometheus.CounterValue,
+		data.AttemptedDiscoveries,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.discoverySuccesses,
+		prometheus.CounterValue,
+		data.SuccessfulDiscoveries,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.cacheCompleteSegments,
+		prometheus.GaugeValue,
+		data.CacheCompleteSegments,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.cachePartialSegments,
+		prometheus.GaugeValue,
+		data.CachePartialSegments,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.hostedCacheSegmentOffers,
+		prometheus.CounterValue,
+		data.HostedCacheSegmentOffers,
+	)
+
+	return nil
+}
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package branchcache
+
+// Perflib "BranchCache".
+// The object is only registered if the BranchCache feature is installed.
+type perfDataCounterValues struct {
+	BytesFromCache           float64 `perfdata:"Retrieval: Bytes from cache"`
+	BytesFromServer          float64 `perfdata:"Retrieval: Bytes from server"`
+	BytesServed              float64 `perfdata:"Retrieval: Bytes served"`
+	AttemptedDiscoveries     float64 `perfdata:"Discovery: Attempted discoveries"`
+	SuccessfulDiscoveries    float64 `perfdata:"Discovery: Successful discoveries"`
+	CacheCompleteSegments    float64 `perfdata:"Local Cache: Cache complete file segments" perfdata_optional:"true"`
+	CachePartialSegments     float64 `perfdata:"Local Cache: Cache partial file segments" perfdata_optional:"true"`
+	HostedCacheSegmentOffers float64 `perfdata:"Hosted Cache: Client file segment offers made" perfdata_optional:"true"`
+}
@@ -31,6 +31,10 @@ import (
 
 const Name = "cache"
 
+// asyncInterval is the interval, in which the Cache object is collected in the background.
+// The Cache object is slow to collect, so it is kept off the scrape path.
+const asyncInterval = 5 * time.Second
+
 type Config struct{}
 
 //nolint:gochecknoglobals
@@ -49,10 +53,13 @@ type Collector struct {
 	asyncMDLReadsTotal          *prometheus.Desc
 	asyncPinReadsTotal          *prometheus.Desc
 	copyReadHitsTotal           *prometheus.Desc
+	copyReadMissesTotal         *prometheus.Desc
 	copyReadsTotal              *prometheus.Desc
 	dataFlushesTotal            *prometheus.Desc
 	dataFlushPagesTotal         *prometheus.Desc
 	dataMapHitsPercent          *prometheus.Desc
+	dataMapHitsTotal            *prometheus.Desc
+	dataMapMissesTotal          *prometheus.Desc
 	dataMapPinsTotal            *prometheus.Desc
 	dataMapsTotal               *prometheus.Desc
 	dirtyPages                  *prometheus.Desc
@@ -63,8 +70,10 @@ type Collector struct {
 	lazyWriteFlushesTotal       *prometheus.Desc
 	lazyWritePagesTotal         *prometheus.Desc
 	mdlReadHitsTotal            *prometheus.Desc
+	mdlReadMissesTotal          *prometheus.Desc
 	mdlReadsTotal               *prometheus.Desc
 	pinReadHitsTotal            *prometheus.Desc
+	pinReadMissesTotal          *prometheus.Desc
 	pinReadsTotal               *prometheus.Desc
 	readAheadsTotal             *prometheus.Desc
 	syncCopyReadsTotal          *prometheus.Desc
@@ -94,6 +103,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH}
+}
+
 func (c *Collector) Close() error {
 	c.perfDataCollector.Close()
 
@@ -137,6 +150,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 		nil,
 	)
+	c.copyReadMissesTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "copy_read_misses_total"),
+		"(CopyReadHitsBase - CopyReadHitsTotal)",
+		nil,
+		nil,
+	)
 	c.copyReadsTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "copy_reads_total"),
 		"(CopyReadsTotal)",
@@ -161,6 +180,18 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 		nil,
 	)
+	c.dataMapHitsTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "data_map_hits_total"),
+		"(DataMapHitsPercent)",
+		nil,
+		nil,
+	)
+	c.dataMapMissesTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "data_map_misses_total"),
+		"(DataMapHitsBase - DataMapHitsPercent)",
+		nil,
+		nil,
+	)
 	c.dataMapPinsTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "data_map_pins_total"),
 		"(DataMapPinsTotal)",
@@ -221,6 +252,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 		nil,
 	)
+	c.mdlReadMissesTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "mdl_read_misses_total"),
+		"(MDLReadHitsBase - MDLReadHitsTotal)",
+		nil,
+		nil,
+	)
 	c.mdlReadsTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "mdl_reads_total"),
 		"(MDLReadsTotal)",
@@ -233,6 +270,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 		nil,
 	)
+	c.pinReadMissesTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "pin_read_misses_total"),
+		"(PinReadHitsBase - PinReadHitsTotal)",
+		nil,
+		nil,
+	)
 	c.pinReadsTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "pin_reads_total"),
 		"(PinReadsTotal)",
@@ -278,7 +321,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	var err error
 
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "Cache", pdh.InstancesAll)
+	c.perfDataCollector, err = pdh.NewAsyncCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "Cache", pdh.InstancesAll, asyncInterval)
 	if err != nil {
 		return fmt.Errorf("failed to create Cache collector: %w", err)
 	}
@@ -327,10 +370,16 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 
 	ch <- prometheus.MustNewConstMetric(
 		c.copyReadHitsTotal,
-		prometheus.GaugeValue,
+		prometheus.CounterValue,
 		c.perfDataObject[0].CopyReadHitsTotal,
 	)
 
+	ch <- prometheus.MustNewConstMetric(
+		c.copyReadMissesTotal,
+		prometheus.CounterValue,
+		cacheMisses(c.perfDataObject[0].CopyReadHitsTotal, c.perfDataObject[0].CopyReadHitsBase),
+	)
+
 	ch <- prometheus.MustNewConstMetric(
 		c.copyReadsTotal,
 		prometheus.CounterValue,
@@ -355,6 +404,18 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		c.perfDataObject[0].DataMapHitsPercent,
 	)
 
+	ch <- prometheus.MustNewConstMetric(
+		c.dataMapHitsTotal,
+		prometheus.CounterValue,
+		c.perfDataObject[0].DataMapHitsPercent,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.dataMapMissesTotal,
+		prometheus.CounterValue,
+		cacheMisses(c.perfDataObject[0].DataMapHitsPercent, c.perfDataObject[0].DataMapHitsBase),
+	)
+
 	ch <- prometheus.MustNewConstMetric(
 		c.dataMapPinsTotal,
 		prometheus.CounterValue,
@@ -415,6 +476,12 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		c.perfDataObject[0].MdlReadHitsTotal,
 	)
 
+	ch <- prometheus.MustNewConstMetric(
+		c.mdlReadMissesTotal,
+		prometheus.CounterValue,
+		cacheMisses(c.perfDataObject[0].MdlReadHitsTotal, c.perfDataObject[0].MdlReadHitsBase),
+	)
+
 	ch <- prometheus.MustNewConstMetric(
 		c.mdlReadsTotal,
 		prometheus.CounterValue,
@@ -427,6 +494,12 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		c.perfDataObject[0].PinReadHitsTotal,
 	)
 
+	ch <- prometheus.MustNewConstMetric(
+		c.pinReadMissesTotal,
+		prometheus.CounterValue,
+		cacheMisses(c.perfDataObject[0].PinReadHitsTotal, c.perfDataObject[0].PinReadHitsBase),
+	)
+
 	ch <- prometheus.MustNewConstMetric(
 		c.pinReadsTotal,
 		prometheus.CounterValue,
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package cache
+
+// cacheMisses returns the number of misses of a "Hits %" counter.
+//
+// The raw value of a "Hits %" counter (PERF_SAMPLE_FRACTION) is the cumulative number of hits
+// and its base is the cumulative number of requests. Exposing hits and misses as counters
+// allows computing the hit ratio over arbitrary windows.
+func cacheMisses(hits, base float64) float64 {
+	return max(base-hits, 0)
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheMisses(t *testing.T) {
+	t.Parallel()
+
+	// Raw samples of "Copy Read Hits %" taken 15 seconds apart.
+	// Perfmon reported a formatted value of 97.25 for the interval.
+	samples := []struct {
+		hits float64
+		base float64
+	}{
+		{hits: 2846213, base: 2911738},
+		{hits: 2850102, base: 2915737},
+	}
+
+	misses0 := cacheMisses(samples[0].hits, samples[0].base)
+	misses1 := cacheMisses(samples[1].hits, samples[1].base)
+
+	require.InDelta(t, 65525.0, misses0, 0)
+	require.InDelta(t, 65635.0, misses1, 0)
+
+	deltaHits := samples[1].hits - samples[0].hits
+	deltaMisses := misses1 - misses0
+
+	// rate(hits) / (rate(hits) + rate(misses)) equals the formatted counter value.
+	require.InDelta(t, 97.25, deltaHits/(deltaHits+deltaMisses)*100, 0.01)
+
+	// The base is never smaller than the hits, but a torn read must not produce negative misses.
+	require.InDelta(t, 0.0, cacheMisses(10, 9), 0)
+}
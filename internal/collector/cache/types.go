@@ -26,10 +26,12 @@ type perfDataCounterValues struct {
 	AsyncMDLReadsTotal          float64 `perfdata:"Async MDL Reads/sec"`
 	AsyncPinReadsTotal          float64 `perfdata:"Async Pin Reads/sec"`
 	CopyReadHitsTotal           float64 `perfdata:"Copy Read Hits %"`
+	CopyReadHitsBase            float64 `perfdata:"Copy Read Hits %,secondvalue"`
 	CopyReadsTotal              float64 `perfdata:"Copy Reads/sec"`
 	DataFlushesTotal            float64 `perfdata:"Data Flushes/sec"`
 	DataFlushPagesTotal         float64 `perfdata:"Data Flush Pages/sec"`
 	DataMapHitsPercent          float64 `perfdata:"Data Map Hits %"`
+	DataMapHitsBase             float64 `perfdata:"Data Map Hits %,secondvalue"`
 	DataMapPinsTotal            float64 `perfdata:"Data Map Pins/sec"`
 	DataMapsTotal               float64 `perfdata:"Data Maps/sec"`
 	DirtyPages                  float64 `perfdata:"Dirty Pages"`
@@ -40,8 +42,10 @@ type perfDataCounterValues struct {
 	LazyWriteFlushesTotal       float64 `perfdata:"Lazy Write Flushes/sec"`
 	LazyWritePagesTotal         float64 `perfdata:"Lazy Write Pages/sec"`
 	MdlReadHitsTotal            float64 `perfdata:"MDL Read Hits %"`
+	MdlReadHitsBase             float64 `perfdata:"MDL Read Hits %,secondvalue"`
 	MdlReadsTotal               float64 `perfdata:"MDL Reads/sec"`
 	PinReadHitsTotal            float64 `perfdata:"Pin Read Hits %"`
+	PinReadHitsBase             float64 `perfdata:"Pin Read Hits %,secondvalue"`
 	PinReadsTotal               float64 `perfdata:"Pin Reads/sec"`
 	ReadAheadsTotal             float64 `perfdata:"Read Aheads/sec"`
 	SyncCopyReadsTotal          float64 `perfdata:"Sync Copy Reads/sec"`
@@ -24,6 +24,7 @@ import (
 	"io/fs"
 	"log/slog"
 	"os"
+	"regexp"
 	"slices"
 	"strings"
 	"time"
@@ -45,13 +46,19 @@ const (
 
 	subCollectorHCS         = "hcs"
 	subCollectorHostprocess = "hostprocess"
+	subCollectorStorage     = "storage"
 
 	JobObjectMemoryUsageInformation = 28
 )
 
 type Config struct {
-	CollectorsEnabled  []string `yaml:"enabled"`
-	ContainerDStateDir string   `yaml:"containerd-state-dir"`
+	CollectorsEnabled      []string       `yaml:"enabled"`
+	ContainerDStateDir     string         `yaml:"containerd-state-dir"`
+	ContainerInclude       *regexp.Regexp `yaml:"container-include"`
+	ContainerExclude       *regexp.Regexp `yaml:"container-exclude"`
+	StorageLayerDirs       []string       `yaml:"storage-layer-dirs"`
+	StorageRefreshInterval time.Duration  `yaml:"storage-refresh-interval"`
+	DockerPipe             string         `yaml:"docker-pipe"`
 }
 
 //nolint:gochecknoglobals
@@ -61,6 +68,14 @@ var ConfigDefaults = Config{
 		subCollectorHostprocess,
 	},
 	ContainerDStateDir: `C:\ProgramData\containerd\state\io.containerd.runtime.v2.task\k8s.io\`,
+	ContainerInclude:   types.RegExpAny,
+	ContainerExclude:   types.RegExpEmpty,
+	StorageLayerDirs: []string{
+		`C:\ProgramData\docker\windowsfilter`,
+		`C:\ProgramData\containerd\root\io.containerd.snapshotter.v1.windows\snapshots`,
+	},
+	StorageRefreshInterval: 15 * time.Minute,
+	DockerPipe:             `\\.\pipe\docker_engine`,
 }
 
 // A Collector is a Prometheus Collector for containers metrics.
@@ -72,6 +87,8 @@ type Collector struct {
 	annotationsCacheHCS map[string]containerInfo
 	annotationsCacheJob map[string]containerInfo
 
+	layerDirCaches []*layerDirCache
+
 	// Presence
 	containerAvailable *prometheus.Desc
 
@@ -101,6 +118,10 @@ type Collector struct {
 	readSizeBytes        *prometheus.Desc
 	writeCountNormalized *prometheus.Desc
 	writeSizeBytes       *prometheus.Desc
+
+	// Images
+	storageLayerBytes *prometheus.Desc
+	images            *prometheus.Desc
 }
 
 type containerInfo struct {
@@ -124,6 +145,22 @@ func New(config *Config) *Collector {
 		config.CollectorsEnabled = ConfigDefaults.CollectorsEnabled
 	}
 
+	if config.ContainerExclude == nil {
+		config.ContainerExclude = ConfigDefaults.ContainerExclude
+	}
+
+	if config.ContainerInclude == nil {
+		config.ContainerInclude = ConfigDefaults.ContainerInclude
+	}
+
+	if config.StorageLayerDirs == nil {
+		config.StorageLayerDirs = ConfigDefaults.StorageLayerDirs
+	}
+
+	if config.StorageRefreshInterval == 0 {
+		config.StorageRefreshInterval = ConfigDefaults.StorageRefreshInterval
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -137,11 +174,21 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 	}
 	c.config.CollectorsEnabled = make([]string, 0)
 
-	var collectorsEnabled string
+	var collectorsEnabled, containerExclude, containerInclude, storageLayerDirs string
+
+	app.Flag(
+		"collector.container.container-exclude",
+		"Regexp of containers to exclude. Container name must both match include and not match exclude to be included. Containers without a name are matched by their ID.",
+	).Default("").StringVar(&containerExclude)
+
+	app.Flag(
+		"collector.container.container-include",
+		"Regexp of containers to include. Container name must both match include and not match exclude to be included. Containers without a name are matched by their ID.",
+	).Default(".+").StringVar(&containerInclude)
 
 	app.Flag(
 		"collector.container.enabled",
-		"Comma-separated list of collectors to use. Defaults to all, if not specified.",
+		"Comma-separated list of collectors to use. Available collectors: hcs, hostprocess, storage. Defaults to hcs and hostprocess, if not specified.",
 	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
 
 	app.Flag(
@@ -149,9 +196,41 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		"Path to the containerd state directory. Defaults to C:\\ProgramData\\containerd\\state\\io.containerd.runtime.v2.task\\k8s.io\\",
 	).Default(ConfigDefaults.ContainerDStateDir).StringVar(&c.config.ContainerDStateDir)
 
+	app.Flag(
+		"collector.container.storage-layer-dirs",
+		"Comma-separated list of image layer directories reported by windows_container_storage_layer_bytes. Requires the storage collector.",
+	).Default(strings.Join(ConfigDefaults.StorageLayerDirs, ",")).StringVar(&storageLayerDirs)
+
+	app.Flag(
+		"collector.container.storage-refresh-interval",
+		"Minimum interval between two walks of the image layer directories. Requires the storage collector.",
+	).Default(ConfigDefaults.StorageRefreshInterval.String()).DurationVar(&c.config.StorageRefreshInterval)
+
+	app.Flag(
+		"collector.container.docker-pipe",
+		"Named pipe of the Docker Engine API used to count the images. Empty disables the image count. Requires the storage collector.",
+	).Default(ConfigDefaults.DockerPipe).StringVar(&c.config.DockerPipe)
+
 	app.Action(func(*kingpin.ParseContext) error {
 		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
 
+		c.config.StorageLayerDirs = make([]string, 0)
+		if storageLayerDirs != "" {
+			c.config.StorageLayerDirs = strings.Split(storageLayerDirs, ",")
+		}
+
+		var err error
+
+		c.config.ContainerExclude, err = regexp.Compile(fmt.Sprintf("^(?:%s)$", containerExclude))
+		if err != nil {
+			return fmt.Errorf("collector.container.container-exclude: %w", err)
+		}
+
+		c.config.ContainerInclude, err = regexp.Compile(fmt.Sprintf("^(?:%s)$", containerInclude))
+		if err != nil {
+			return fmt.Errorf("collector.container.container-include: %w", err)
+		}
+
 		return nil
 	})
 
@@ -162,6 +241,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
 	return nil
 }
@@ -170,13 +253,23 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	c.logger = logger.With(slog.String("collector", Name))
 
 	for _, collector := range c.config.CollectorsEnabled {
-		if !slices.Contains([]string{subCollectorHCS, subCollectorHostprocess}, collector) {
+		if !slices.Contains([]string{subCollectorHCS, subCollectorHostprocess, subCollectorStorage}, collector) {
 			return fmt.Errorf("unknown collector: %s", collector)
 		}
 	}
 
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorHCS) {
+		if err := hcs.Available(); err != nil {
+			return fmt.Errorf("the Host Compute Service API is not available. The Containers feature must be installed to use the %s collector: %w", subCollectorHCS, err)
+		}
+	}
+
 	c.annotationsCacheHCS = make(map[string]containerInfo)
 	c.annotationsCacheJob = make(map[string]containerInfo)
+	c.layerDirCaches = make([]*layerDirCache, 0, len(c.config.StorageLayerDirs))
+	for _, dir := range c.config.StorageLayerDirs {
+		c.layerDirCaches = append(c.layerDirCaches, newLayerDirCache(dir, c.config.StorageRefreshInterval))
+	}
 
 	c.containerAvailable = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "available"),
@@ -286,13 +379,25 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		[]string{"container_id", "namespace", "pod", "container"},
 		nil,
 	)
+	c.storageLayerBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "storage_layer_bytes"),
+		"Total size of the image and container layers in the layer directory",
+		[]string{"directory"},
+		nil,
+	)
+	c.images = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "images"),
+		"Number of images known to the container runtime",
+		[]string{"runtime"},
+		nil,
+	)
 
 	return nil
 }
 
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
-func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
 	errs := make([]error, 0)
 
 	if slices.Contains(c.config.CollectorsEnabled, subCollectorHCS) {
@@ -307,6 +412,12 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		}
 	}
 
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorStorage) {
+		if err := c.collectStorage(ch, maxScrapeDuration); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	return errors.Join(errs...)
 }
 
@@ -340,8 +451,6 @@ func (c *Collector) collectHCS(ch chan<- prometheus.Metric) error {
 
 		containerIDs = append(containerIDs, container.ID)
 
-		countersCount++
-
 		var (
 			namespace     string
 			podName       string
@@ -363,6 +472,12 @@ func (c *Collector) collectHCS(ch chan<- prometheus.Metric) error {
 			}
 		}
 
+		if c.isContainerExcluded(c.annotationsCacheHCS[container.ID], container.ID) {
+			continue
+		}
+
+		countersCount++
+
 		if err = c.collectHCSContainer(ch, container, c.annotationsCacheHCS[container.ID]); err != nil {
 			if errors.Is(err, hcs.ErrIDNotFound) {
 				c.logger.Debug("err in fetching container statistics",
@@ -554,6 +669,10 @@ func (c *Collector) collectNetworkMetrics(ch chan<- prometheus.Metric) error {
 				continue
 			}
 
+			if c.isContainerExcluded(containerInfo, containerId) {
+				continue
+			}
+
 			// Skip if the container is a pause container
 			if containerInfo.pod != "" && containerInfo.container == "" {
 				continue
@@ -680,6 +799,10 @@ func (c *Collector) collectJobContainers(ch chan<- prometheus.Metric) error {
 	errs := make([]error, 0)
 
 	for _, containerID := range allContainerIDs {
+		if c.isContainerExcluded(c.annotationsCacheJob[containerID], containerID) {
+			continue
+		}
+
 		if err := c.collectJobContainer(ch, containerID); err != nil {
 			errs = append(errs, err)
 		} else {
@@ -833,6 +956,17 @@ func (c *Collector) collectJobContainer(ch chan<- prometheus.Metric, containerID
 	return nil
 }
 
+// isContainerExcluded matches the container name against the include and exclude regexps.
+// Containers without a name, e.g. containers not managed by Kubernetes, are matched by their ID.
+func (c *Collector) isContainerExcluded(info containerInfo, containerID string) bool {
+	name := info.container
+	if name == "" {
+		name = containerID
+	}
+
+	return c.config.ContainerExclude.MatchString(name) || !c.config.ContainerInclude.MatchString(name)
+}
+
 func getContainerIdWithPrefix(container hcs.Properties) string {
 	switch container.Owner {
 	case "containerd-shim-runhcs-v1.exe":
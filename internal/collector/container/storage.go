@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package container
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+// layerDirCache caches the size of a container image layer directory.
+//
+// Image layers are immutable once they are extracted, so the subdirectories of a layer are
+// only walked again, if the modification time of the layer directory changed. Files directly
+// inside a layer directory, e.g. the sandbox.vhdx of a container scratch layer, are read on
+// every refresh. A refresh runs in the background and at most once per interval.
+type layerDirCache struct {
+	dir    string
+	size   *utils.TTLCache[float64]
+	layers map[string]layerSize
+}
+
+type layerSize struct {
+	modTime time.Time
+	// subdirBytes is the total size of the files in the subdirectories of the layer.
+	subdirBytes int64
+}
+
+func newLayerDirCache(dir string, interval time.Duration) *layerDirCache {
+	return &layerDirCache{
+		dir:    dir,
+		size:   utils.NewTTLCache[float64](interval),
+		layers: make(map[string]layerSize),
+	}
+}
+
+// load walks all changed layers of the directory. It is only called by one refresh at a time.
+func (l *layerDirCache) load() (float64, error) {
+	layers := make(map[string]layerSize, len(l.layers))
+
+	total, err := sizeOfLayerDir(l.dir, l.layers, layers)
+	if err != nil {
+		return 0, err
+	}
+
+	l.layers = layers
+
+	return float64(total), nil
+}
+
+// sizeOfLayerDir returns the total size of the files inside dir. Each subdirectory of dir is
+// treated as a layer. Unchanged layers are taken from cached, all layers are stored in layers.
+func sizeOfLayerDir(dir string, cached, layers map[string]layerSize) (int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read layer directory %s: %w", dir, err)
+	}
+
+	var total int64
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			// The layer was removed while reading the directory.
+			continue
+		}
+
+		if !entry.IsDir() {
+			total += info.Size()
+
+			continue
+		}
+
+		layerDir := filepath.Join(dir, entry.Name())
+
+		fileBytes, subdirs, err := readLayerDir(layerDir)
+		if err != nil {
+			continue
+		}
+
+		layer, ok := cached[layerDir]
+		if !ok || !layer.modTime.Equal(info.ModTime()) {
+			layer = layerSize{modTime: info.ModTime()}
+
+			for _, subdir := range subdirs {
+				layer.subdirBytes += dirSize(subdir)
+			}
+		}
+
+		layers[layerDir] = layer
+		total += fileBytes + layer.subdirBytes
+	}
+
+	return total, nil
+}
+
+// readLayerDir returns the total size of the files directly inside the layer directory and its subdirectories.
+func readLayerDir(layerDir string) (int64, []string, error) {
+	entries, err := os.ReadDir(layerDir)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var fileBytes int64
+
+	subdirs := make([]string, 0)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			subdirs = append(subdirs, filepath.Join(layerDir, entry.Name()))
+
+			continue
+		}
+
+		if info, err := entry.Info(); err == nil {
+			fileBytes += info.Size()
+		}
+	}
+
+	return fileBytes, subdirs, nil
+}
+
+// dirSize returns the total size of the regular files below dir.
+// Reparse points are not followed and files, which can't be read, are skipped.
+func dirSize(dir string) int64 {
+	var size int64
+
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+
+		return nil
+	})
+
+	return size
+}
+
+// collectStorage sends the size of the image layer directories and the number of images known to the Docker Engine.
+func (c *Collector) collectStorage(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	errs := make([]error, 0)
+
+	for _, cache := range c.layerDirCaches {
+		size, ok, err := cache.size.Get(cache.load)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.storageLayerBytes,
+			prometheus.GaugeValue,
+			size,
+			cache.dir,
+		)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		c.logger.Debug("failed to read container layer directories",
+			slog.Any("err", err),
+		)
+	}
+
+	if c.config.DockerPipe == "" {
+		return nil
+	}
+
+	images, err := getDockerImageCount(c.config.DockerPipe, maxScrapeDuration)
+	if err != nil {
+		if errors.Is(err, windows.ERROR_FILE_NOT_FOUND) {
+			c.logger.Debug("Docker Engine is not running, skipping image count",
+				slog.Any("err", err),
+			)
+
+			return nil
+		}
+
+		return fmt.Errorf("failed to get Docker images: %w", err)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.images,
+		prometheus.GaugeValue,
+		float64(images),
+		"docker",
+	)
+
+	return nil
+}
+
+// getDockerImageCount returns the number of images from the Docker Engine API listening on the given named pipe.
+//
+// https://docs.docker.com/reference/api/engine/version/v1.43/#tag/Image/operation/ImageList
+func getDockerImageCount(pipe string, timeout time.Duration) (int, error) {
+	pipePath, err := windows.UTF16PtrFromString(pipe)
+	if err != nil {
+		return 0, err
+	}
+
+	// The pipe is opened for overlapped I/O, so the deadline of the os.File is applied.
+	handle, err := windows.CreateFile(pipePath, windows.GENERIC_READ|windows.GENERIC_WRITE, 0, nil, windows.OPEN_EXISTING, windows.FILE_FLAG_OVERLAPPED, 0)
+	if err != nil {
+		return 0, fmt.Errorf("could not open %s: %w", pipe, err)
+	}
+
+	conn := os.NewFile(uintptr(handle), pipe)
+
+	defer func(conn *os.File) {
+		_ = conn.Close()
+	}(conn)
+
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	req, err := http.NewRequest(http.MethodGet, "http://docker/images/json", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = req.Write(conn); err != nil {
+		return 0, fmt.Errorf("failed to send request to %s: %w", pipe, err)
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response from %s: %w", pipe, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code from %s: %s", pipe, resp.Status)
+	}
+
+	var images []json.RawMessage
+
+	if err = json.NewDecoder(resp.Body).Decode(&images); err != nil {
+		return 0, fmt.Errorf("failed to decode image list: %w", err)
+	}
+
+	return len(images), nil
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package container
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizeOfLayerDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	layerDir := filepath.Join(dir, "layer1")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(layerDir, "Files", "Windows"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(layerDir, "sandbox.vhdx"), make([]byte, 100), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(layerDir, "Files", "Windows", "a.dll"), make([]byte, 1000), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "layerchain.json"), make([]byte, 10), 0o644))
+
+	layers := make(map[string]layerSize)
+
+	total, err := sizeOfLayerDir(dir, nil, layers)
+	require.NoError(t, err)
+	require.Equal(t, int64(1110), total)
+	require.Equal(t, int64(1000), layers[layerDir].subdirBytes)
+
+	// Files directly inside the layer are always read, unchanged subdirectories are taken from the cache.
+	require.NoError(t, os.WriteFile(filepath.Join(layerDir, "sandbox.vhdx"), make([]byte, 200), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(layerDir, "Files", "Windows", "b.dll"), make([]byte, 500), 0o644))
+
+	modTime := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(layerDir, modTime, modTime))
+
+	cached := map[string]layerSize{layerDir: {modTime: modTime, subdirBytes: 1000}}
+	layers = make(map[string]layerSize)
+
+	total, err = sizeOfLayerDir(dir, cached, layers)
+	require.NoError(t, err)
+	require.Equal(t, int64(1210), total)
+
+	// A changed layer is walked again.
+	cached = map[string]layerSize{layerDir: {modTime: modTime.Add(-time.Hour), subdirBytes: 1000}}
+	layers = make(map[string]layerSize)
+
+	total, err = sizeOfLayerDir(dir, cached, layers)
+	require.NoError(t, err)
+	require.Equal(t, int64(1710), total)
+
+	_, err = sizeOfLayerDir(filepath.Join(dir, "missing"), nil, layers)
+	require.Error(t, err)
+}
@@ -20,6 +20,8 @@ package cpu
 import (
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -29,26 +31,46 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
 )
 
-const Name = "cpu"
+const (
+	Name = "cpu"
 
-type Config struct{}
+	subCollectorMetrics = "metrics"
+	subCollectorNUMA    = "numa"
+)
+
+type Config struct {
+	CollectorsEnabled []string `yaml:"enabled"`
+}
 
 //nolint:gochecknoglobals
-var ConfigDefaults = Config{}
+var ConfigDefaults = Config{
+	CollectorsEnabled: []string{
+		subCollectorMetrics,
+	},
+}
 
 type Collector struct {
 	config Config
+	logger *slog.Logger
 
 	perfDataCollector *pdh.Collector
 	perfDataObject    []perfDataCounterValues
 
 	mu sync.Mutex
 
+	// missingCoresLogged is set after a mismatch between the active processors
+	// and the collected instances was logged, to log it only once.
+	missingCoresLogged bool
+
 	processorRTCValues   map[string]utils.Counter
 	processorMPerfValues map[string]utils.Counter
 
+	// numaNodes maps the core label to the NUMA node of the processor.
+	numaNodes map[string]string
+
 	logicalProcessors          *prometheus.Desc
 	cStateSecondsTotal         *prometheus.Desc
 	timeTotal                  *prometheus.Desc
@@ -58,11 +80,16 @@ type Collector struct {
 	idleBreakEventsTotal       *prometheus.Desc
 	parkingStatus              *prometheus.Desc
 	processorFrequencyMHz      *prometheus.Desc
+	performanceLimit           *prometheus.Desc
 	processorPerformance       *prometheus.Desc
 	processorMPerf             *prometheus.Desc
 	processorRTC               *prometheus.Desc
 	processorUtility           *prometheus.Desc
 	processorPrivilegedUtility *prometheus.Desc
+
+	numaNodeIdleSecondsTotal       *prometheus.Desc
+	numaNodePrivilegedSecondsTotal *prometheus.Desc
+	numaNodeUserSecondsTotal       *prometheus.Desc
 }
 
 func New(config *Config) *Collector {
@@ -70,6 +97,10 @@ func New(config *Config) *Collector {
 		config = &ConfigDefaults
 	}
 
+	if config.CollectorsEnabled == nil {
+		config.CollectorsEnabled = ConfigDefaults.CollectorsEnabled
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -77,14 +108,39 @@ func New(config *Config) *Collector {
 	return c
 }
 
-func NewWithFlags(_ *kingpin.Application) *Collector {
-	return &Collector{}
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+	c.config.CollectorsEnabled = make([]string, 0)
+
+	var collectorsEnabled string
+
+	app.Flag(
+		"collector.cpu.enabled",
+		fmt.Sprintf("Comma-separated list of collectors to use. Available collectors: %s, %s. Defaults to metrics, if not specified.",
+			subCollectorMetrics,
+			subCollectorNUMA,
+		),
+	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
+
+		return nil
+	})
+
+	return c
 }
 
 func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH}
+}
+
 func (c *Collector) Close() error {
 	c.perfDataCollector.Close()
 
@@ -93,6 +149,15 @@ func (c *Collector) Close() error {
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	c.mu = sync.Mutex{}
+	c.logger = logger.With(slog.String("collector", Name))
+
+	for _, collector := range c.config.CollectorsEnabled {
+		if !slices.Contains([]string{subCollectorMetrics, subCollectorNUMA}, collector) {
+			return fmt.Errorf("unknown sub collector: %s. Possible values: %s", collector,
+				strings.Join([]string{subCollectorMetrics, subCollectorNUMA}, ", "),
+			)
+		}
+	}
 
 	c.logicalProcessors = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "logical_processor"),
@@ -148,6 +213,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		[]string{"core"},
 		nil,
 	)
+	c.performanceLimit = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "performance_limit_ratio"),
+		"Performance Limit is the performance the processor is limited to, e.g. by power or thermal limits, as a ratio of the nominal performance of the processor",
+		[]string{"core"},
+		nil,
+	)
 	c.processorPerformance = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "processor_performance_total"),
 		"Processor Performance is the average performance of the processor while it is executing instructions, as a percentage of the nominal performance of the processor. On some processors, Processor Performance may exceed 100%",
@@ -179,11 +250,37 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 	)
 
+	c.numaNodeIdleSecondsTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "numa_node_idle_seconds_total"),
+		"Time that the processors of the NUMA node spent idle",
+		[]string{"numa_node"},
+		nil,
+	)
+	c.numaNodePrivilegedSecondsTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "numa_node_privileged_seconds_total"),
+		"Time that the processors of the NUMA node spent in privileged mode",
+		[]string{"numa_node"},
+		nil,
+	)
+	c.numaNodeUserSecondsTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "numa_node_user_seconds_total"),
+		"Time that the processors of the NUMA node spent in user mode",
+		[]string{"numa_node"},
+		nil,
+	)
+
 	c.processorRTCValues = map[string]utils.Counter{}
 	c.processorMPerfValues = map[string]utils.Counter{}
 
 	var err error
 
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorNUMA) {
+		c.numaNodes, err = getNumaNodes()
+		if err != nil {
+			return fmt.Errorf("failed to get NUMA topology: %w", err)
+		}
+	}
+
 	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "Processor Information", pdh.InstancesAll)
 	if err != nil {
 		return fmt.Errorf("failed to create Processor Information collector: %w", err)
@@ -203,14 +300,31 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 
 	var coreCount float64
 
+	numaNodeTimes := make(map[string]numaTimes)
+
 	for _, coreData := range c.perfDataObject {
-		core := coreData.Name
+		core, ok := processorCore(coreData.Name)
+		if !ok {
+			continue
+		}
+
 		coreCount++
 
+		if node, ok := c.numaNodes[core]; ok {
+			times := numaNodeTimes[node]
+			times.idle += coreData.IdleTimeSeconds
+			times.privileged += coreData.PrivilegedTimeSeconds
+			times.user += coreData.UserTimeSeconds
+			numaNodeTimes[node] = times
+		}
+
+		if !slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+			continue
+		}
+
 		var (
 			counterProcessorRTCValues   utils.Counter
 			counterProcessorMPerfValues utils.Counter
-			ok                          bool
 		)
 
 		if counterProcessorRTCValues, ok = c.processorRTCValues[core]; ok {
@@ -327,6 +441,13 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 			core,
 		)
 
+		ch <- prometheus.MustNewConstMetric(
+			c.performanceLimit,
+			prometheus.GaugeValue,
+			coreData.PerformanceLimitPercent/100,
+			core,
+		)
+
 		ch <- prometheus.MustNewConstMetric(
 			c.processorPerformance,
 			prometheus.CounterValue,
@@ -369,5 +490,39 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		coreCount,
 	)
 
+	for node, times := range numaNodeTimes {
+		ch <- prometheus.MustNewConstMetric(
+			c.numaNodeIdleSecondsTotal,
+			prometheus.CounterValue,
+			times.idle,
+			node,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.numaNodePrivilegedSecondsTotal,
+			prometheus.CounterValue,
+			times.privileged,
+			node,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.numaNodeUserSecondsTotal,
+			prometheus.CounterValue,
+			times.user,
+			node,
+		)
+	}
+
+	// The number of active processors across all processor groups. Collecting fewer instances
+	// indicates that processors of some groups are missing from the performance counters.
+	if activeProcessors := windows.GetActiveProcessorCount(windows.ALL_PROCESSOR_GROUPS); coreCount < float64(activeProcessors) && !c.missingCoresLogged {
+		c.logger.Warn("Processor Information reports fewer processors than active on the system",
+			slog.Float64("collected", coreCount),
+			slog.Uint64("active", uint64(activeProcessors)),
+		)
+
+		c.missingCoresLogged = true
+	}
+
 	return nil
 }
@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package cpu
+
+import (
+	"strconv"
+	"strings"
+)
+
+// processorCore returns the core label for an instance of the "Processor Information" object.
+// Instance names have the form "<group>,<number>", where the number is relative to the processor group.
+// Machines with more than 64 logical processors have multiple processor groups, so the group is part
+// of the label to keep it unique. ok is false for aggregated instances like "_Total" or "0,_Total".
+func processorCore(instanceName string) (string, bool) {
+	group, number, found := strings.Cut(instanceName, ",")
+	if !found {
+		return "", false
+	}
+
+	if _, err := strconv.ParseUint(group, 10, 16); err != nil {
+		return "", false
+	}
+
+	if _, err := strconv.ParseUint(number, 10, 8); err != nil {
+		return "", false
+	}
+
+	return instanceName, true
+}
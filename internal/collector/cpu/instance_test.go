@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package cpu
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessorCore(t *testing.T) {
+	t.Parallel()
+
+	// 4 processor groups with 32 logical processors each, as reported on a 4-socket machine.
+	const (
+		groups          = 4
+		processorsGroup = 32
+	)
+
+	instanceNames := []string{"_Total"}
+
+	for group := range groups {
+		instanceNames = append(instanceNames, strconv.Itoa(group)+",_Total")
+
+		for number := range processorsGroup {
+			instanceNames = append(instanceNames, strconv.Itoa(group)+","+strconv.Itoa(number))
+		}
+	}
+
+	cores := make(map[string]struct{})
+
+	for _, instanceName := range instanceNames {
+		core, ok := processorCore(instanceName)
+		if !ok {
+			continue
+		}
+
+		require.Equal(t, instanceName, core)
+		require.NotContains(t, cores, core)
+
+		cores[core] = struct{}{}
+	}
+
+	require.Len(t, cores, groups*processorsGroup)
+	require.Contains(t, cores, "0,0")
+	require.Contains(t, cores, "3,31")
+
+	for _, instanceName := range []string{"", "0", "0,", ",0", "a,0", "0,a", "65536,0"} {
+		_, ok := processorCore(instanceName)
+		require.False(t, ok, instanceName)
+	}
+}
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package cpu
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/kernel32"
+)
+
+// numaTimes are the aggregated processor times of a NUMA node.
+type numaTimes struct {
+	idle       float64
+	privileged float64
+	user       float64
+}
+
+// getNumaNodes returns the NUMA node of each logical processor, keyed by the core label.
+func getNumaNodes() (map[string]string, error) {
+	highestNodeNumber, err := kernel32.GetNumaHighestNodeNumber()
+	if err != nil {
+		return nil, fmt.Errorf("GetNumaHighestNodeNumber: %w", err)
+	}
+
+	affinities := make(map[uint16]kernel32.GroupAffinity, highestNodeNumber+1)
+
+	for node := range uint16(highestNodeNumber) + 1 {
+		affinity, err := kernel32.GetNumaNodeProcessorMaskEx(node)
+		if err != nil {
+			return nil, fmt.Errorf("GetNumaNodeProcessorMaskEx for node %d: %w", node, err)
+		}
+
+		affinities[node] = affinity
+	}
+
+	return numaNodesFromAffinities(affinities), nil
+}
+
+// numaNodesFromAffinities maps each processor set in the affinity masks to its node.
+// The keys match the core labels returned by processorCore.
+func numaNodesFromAffinities(affinities map[uint16]kernel32.GroupAffinity) map[string]string {
+	nodes := make(map[string]string)
+
+	for node, affinity := range affinities {
+		nodeLabel := strconv.FormatUint(uint64(node), 10)
+
+		for mask := uint64(affinity.Mask); mask != 0; mask &= mask - 1 {
+			nodes[fmt.Sprintf("%d,%d", affinity.Group, bits.TrailingZeros64(mask))] = nodeLabel
+		}
+	}
+
+	return nodes
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package cpu
+
+import (
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/kernel32"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumaNodesFromAffinities(t *testing.T) {
+	t.Parallel()
+
+	// Two sockets with 4 logical processors each, in separate processor groups,
+	// and a memory-only node without processors.
+	nodes := numaNodesFromAffinities(map[uint16]kernel32.GroupAffinity{
+		0: {Mask: 0b1111, Group: 0},
+		1: {Mask: 0b1111, Group: 1},
+		2: {Mask: 0, Group: 0},
+	})
+
+	require.Equal(t, map[string]string{
+		"0,0": "0", "0,1": "0", "0,2": "0", "0,3": "0",
+		"1,0": "1", "1,1": "1", "1,2": "1", "1,3": "1",
+	}, nodes)
+
+	// Two nodes sharing a processor group.
+	nodes = numaNodesFromAffinities(map[uint16]kernel32.GroupAffinity{
+		0: {Mask: 0b0011, Group: 0},
+		1: {Mask: 0b1100, Group: 0},
+	})
+
+	require.Equal(t, map[string]string{"0,0": "0", "0,1": "0", "0,2": "1", "0,3": "1"}, nodes)
+}
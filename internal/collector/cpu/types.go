@@ -17,7 +17,8 @@
 
 package cpu
 
-// Processor performance counters.
+// Processor performance counters. Counters are read raw, except for instantaneous
+// percentages whose raw value is a fraction of two values.
 type perfDataCounterValues struct {
 	Name string
 
@@ -35,7 +36,7 @@ type perfDataCounterValues struct {
 	InterruptsTotal                 float64 `perfdata:"Interrupts/sec"`
 	InterruptTimeSeconds            float64 `perfdata:"% Interrupt Time"`
 	ParkingStatus                   float64 `perfdata:"Parking Status"`
-	PerformanceLimitPercent         float64 `perfdata:"% Performance Limit"`
+	PerformanceLimitPercent         float64 `perfdata:"% Performance Limit" pdhmode:"formatted"`
 	PriorityTimeSeconds             float64 `perfdata:"% Priority Time"`
 	PrivilegedTimeSeconds           float64 `perfdata:"% Privileged Time"`
 	PrivilegedUtilitySeconds        float64 `perfdata:"% Privileged Utility"`
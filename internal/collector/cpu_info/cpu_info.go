@@ -73,6 +73,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceWMI}
+}
+
 func (c *Collector) Close() error {
 	return nil
 }
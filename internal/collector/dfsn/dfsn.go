@@ -0,0 +1,219 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package dfsn
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Name = "dfsn"
+
+type Config struct{}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{}
+
+// A Collector is a Prometheus Collector for DFS Namespace server metrics.
+type Collector struct {
+	config    Config
+	miSession *mi.Session
+	miQuery   mi.Query
+
+	perfDataCollector *pdh.Collector
+	perfDataObject    []perfDataCounterValues
+
+	referrals               *prometheus.Desc
+	referralFailures        *prometheus.Desc
+	averageReferralResponse *prometheus.Desc
+	roots                   *prometheus.Desc
+	folders                 *prometheus.Desc
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(_ *kingpin.Application) *Collector {
+	return &Collector{}
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceWMI}
+}
+
+func (c *Collector) Close() error {
+	c.perfDataCollector.Close()
+
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	c.referrals = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "referrals_total"),
+		"Total number of referral requests processed by the namespace server",
+		[]string{"namespace"},
+		nil,
+	)
+	c.referralFailures = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "referral_failures_total"),
+		"Total number of referral requests which failed",
+		[]string{"namespace"},
+		nil,
+	)
+	c.averageReferralResponse = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "average_referral_response_seconds"),
+		"Average time taken to process a referral request since the previous scrape",
+		[]string{"namespace"},
+		nil,
+	)
+	c.roots = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "roots"),
+		"Number of namespace roots hosted on the server",
+		nil,
+		nil,
+	)
+	c.folders = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "folders"),
+		"Number of namespace folders with targets hosted on the server",
+		nil,
+		nil,
+	)
+
+	var err error
+
+	// The performance counters only exist on servers with the DFS Namespace role service.
+	// On other servers, the error is reported as CstatusNoObject and the collector is skipped.
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "DFS Namespace Service Referrals", pdh.InstancesAll)
+	if err != nil {
+		return fmt.Errorf("failed to create DFS Namespace Service Referrals collector: %w", err)
+	}
+
+	miQuery, err := mi.NewQuery("SELECT Name, Root FROM Win32_DfsNode")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQuery = miQuery
+	c.miSession = miSession
+
+	return nil
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	errs := make([]error, 0)
+
+	if err := c.collectReferrals(ch); err != nil {
+		errs = append(errs, fmt.Errorf("failed collecting DFS Namespace referral metrics: %w", err))
+	}
+
+	if err := c.collectNodes(ch, maxScrapeDuration); err != nil {
+		errs = append(errs, fmt.Errorf("failed collecting DFS Namespace node metrics: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) collectReferrals(ch chan<- prometheus.Metric) error {
+	err := c.perfDataCollector.Collect(&c.perfDataObject)
+	if err != nil {
+		return fmt.Errorf("failed to collect DFS Namespace Service Referrals metrics: %w", err)
+	}
+
+	for _, data := range c.perfDataObject {
+		ch <- prometheus.MustNewConstMetric(
+			c.referrals,
+			prometheus.CounterValue,
+			data.RequestsProcessed,
+			data.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.referralFailures,
+			prometheus.CounterValue,
+			data.RequestsFailed,
+			data.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.averageReferralResponse,
+			prometheus.GaugeValue,
+			data.AverageResponseTime/1000,
+			data.Name,
+		)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectNodes(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	var dst []miDfsNode
+	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, c.miQuery, maxScrapeDuration); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var roots, folders float64
+
+	for _, node := range dst {
+		if node.Root {
+			roots++
+		} else {
+			folders++
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.roots,
+		prometheus.GaugeValue,
+		roots,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.folders,
+		prometheus.GaugeValue,
+		folders,
+	)
+
+	return nil
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package dfsn
+
+// perfDataCounterValues are the counters of the "DFS Namespace Service Referrals" object.
+// Each instance is a namespace hosted on the server.
+type perfDataCounterValues struct {
+	Name string
+
+	RequestsProcessed   float64 `perfdata:"Requests Processed"`
+	RequestsFailed      float64 `perfdata:"Requests Failed"`
+	AverageResponseTime float64 `perfdata:"Avg. Response Time" pdhmode:"formatted"`
+}
+
+// Win32_DfsNode docs:
+// https://learn.microsoft.com/en-us/previous-versions/windows/desktop/dfs/win32-dfsnode
+type miDfsNode struct {
+	Name string `mi:"Name"`
+	Root bool   `mi:"Root"`
+}
@@ -142,6 +142,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH}
+}
+
 func (c *Collector) Close() error {
 	if slices.Contains(c.config.CollectorsEnabled, "connection") {
 		c.perfDataCollectorConnection.Close()
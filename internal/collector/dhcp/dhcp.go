@@ -31,7 +31,9 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows/registry"
 )
 
 const (
@@ -87,6 +89,8 @@ type Collector struct {
 	packetsReceivedTotal                             *prometheus.Desc
 	releasesTotal                                    *prometheus.Desc
 	requestsTotal                                    *prometheus.Desc
+	rogueDetectionEnabled                            *prometheus.Desc
+	serverRogue                                      *prometheus.Desc
 
 	scopeInfo                               *prometheus.Desc
 	scopeState                              *prometheus.Desc
@@ -142,6 +146,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
 	if slices.Contains(c.config.CollectorsEnabled, subCollectorServerMetrics) {
 		c.perfDataCollector.Close()
@@ -379,6 +387,19 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 			nil,
 		)
 
+		c.rogueDetectionEnabled = prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, Name, "rogue_detection_enabled"),
+			"Whether the DHCP server checks its authorization in Active Directory (DisableRogueDetection)",
+			nil,
+			nil,
+		)
+		c.serverRogue = prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, Name, "server_rogue"),
+			"Whether the DHCP server considers itself unauthorized and does not lease addresses (DHCP_ATTRIB_BOOL_IS_ROGUE)",
+			nil,
+			nil,
+		)
+
 		c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](c.logger, pdh.CounterTypeRaw, "DHCP Server", nil)
 		if err != nil {
 			return fmt.Errorf("failed to create DHCP Server collector: %w", err)
@@ -395,6 +416,10 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		if err := c.collectServerMetrics(ch); err != nil {
 			errs = append(errs, err)
 		}
+
+		if err := c.collectRogueDetection(ch); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
 	if slices.Contains(c.config.CollectorsEnabled, subCollectorScopeMetrics) {
@@ -567,6 +592,54 @@ func (c *Collector) collectServerMetrics(ch chan<- prometheus.Metric) error {
 	return nil
 }
 
+func (c *Collector) collectRogueDetection(ch chan<- prometheus.Metric) error {
+	rogueDetectionEnabled, err := isRogueDetectionEnabled()
+	if err != nil {
+		return fmt.Errorf("failed to read DHCP Server rogue detection setting: %w", err)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.rogueDetectionEnabled,
+		prometheus.GaugeValue,
+		utils.BoolToFloat(rogueDetectionEnabled),
+	)
+
+	isRogue, err := dhcpsapi.IsRogue()
+	if err != nil {
+		return fmt.Errorf("failed to get DHCP Server rogue state: %w", err)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.serverRogue,
+		prometheus.GaugeValue,
+		utils.BoolToFloat(isRogue),
+	)
+
+	return nil
+}
+
+// isRogueDetectionEnabled reads the DisableRogueDetection value of the DHCP Server service.
+// The rogue detection switch is not exposed by dhcpsapi. If the value is absent, rogue detection is enabled.
+func isRogueDetectionEnabled() (bool, error) {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, `SYSTEM\CurrentControlSet\Services\DHCPServer\Parameters`, registry.QUERY_VALUE)
+	if err != nil {
+		return false, err
+	}
+
+	defer func(key registry.Key) {
+		_ = key.Close()
+	}(k)
+
+	disableRogueDetection, _, err := k.GetIntegerValue("DisableRogueDetection")
+	if errors.Is(err, registry.ErrNotExist) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return disableRogueDetection == 0, nil
+}
+
 func (c *Collector) collectScopeMetrics(ch chan<- prometheus.Metric) error {
 	dhcpScopes, err := dhcpsapi.GetDHCPV4ScopeStatistics()
 	if err != nil {
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package dhcp
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// dhcpServerCounters is the counter list of the "DHCP Server" performance object,
+// captured on Windows Server 2022 with
+// (Get-Counter -ListSet 'DHCP Server').Counter.
+//
+//nolint:gochecknoglobals
+var dhcpServerCounters = []string{
+	"Packets Received/sec",
+	"Duplicates Dropped/sec",
+	"Packets Expired/sec",
+	"Milliseconds per packet (Avg).",
+	"Active Queue Length",
+	"Conflict Check Queue Length",
+	"Discovers/sec",
+	"Offers/sec",
+	"Requests/sec",
+	"Informs/sec",
+	"Acks/sec",
+	"Nacks/sec",
+	"Declines/sec",
+	"Releases/sec",
+	"Denied due to match.",
+	"Denied due to nonmatch.",
+	"Offer Queue Length",
+	"Failover: BndUpd sent/sec.",
+	"Failover: BndUpd received/sec.",
+	"Failover: BndAck sent/sec.",
+	"Failover: BndAck received/sec.",
+	"Failover: BndUpd pending in outbound queue.",
+	"Failover: Transitions to COMMUNICATION-INTERRUPTED state.",
+	"Failover: Transitions to PARTNER-DOWN state.",
+	"Failover: Transitions to RECOVER state.",
+	"Failover: BndUpd Dropped.",
+}
+
+// unsupportedCounters are counters of the "DHCP Server" performance object which are not collected.
+//
+//nolint:gochecknoglobals
+var unsupportedCounters = []string{
+	// Average timer, which can't be computed from a single raw sample.
+	"Milliseconds per packet (Avg).",
+}
+
+func TestPerfDataCounterValuesComplete(t *testing.T) {
+	t.Parallel()
+
+	perfDataType := reflect.TypeFor[perfDataCounterValues]()
+	counters := make([]string, 0, perfDataType.NumField())
+
+	for i := range perfDataType.NumField() {
+		field := perfDataType.Field(i)
+
+		counter, ok := field.Tag.Lookup("perfdata")
+		require.True(t, ok, "field %s has no perfdata tag", field.Name)
+		require.NotContains(t, counters, counter, "counter %q is collected by multiple fields", counter)
+
+		counters = append(counters, counter)
+	}
+
+	for _, counter := range dhcpServerCounters {
+		if slices.Contains(unsupportedCounters, counter) {
+			require.NotContains(t, counters, counter)
+
+			continue
+		}
+
+		require.Contains(t, counters, counter, "counter %q is not collected", counter)
+	}
+
+	require.Len(t, counters, len(dhcpServerCounters)-len(unsupportedCounters))
+}
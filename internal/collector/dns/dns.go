@@ -22,10 +22,12 @@ import (
 	"fmt"
 	"log/slog"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/headers/etw"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
@@ -33,13 +35,15 @@ import (
 )
 
 const (
-	Name                 = "dns"
-	subCollectorMetrics  = "metrics"
-	subCollectorWMIStats = "wmi_stats"
+	Name                        = "dns"
+	subCollectorMetrics         = "metrics"
+	subCollectorWMIStats        = "wmi_stats"
+	subCollectorQueriesBySubnet = "queries_by_subnet"
 )
 
 type Config struct {
-	CollectorsEnabled []string `yaml:"enabled"`
+	CollectorsEnabled   []string `yaml:"enabled"`
+	QueriesBySubnetTopN int      `yaml:"queries-by-subnet-top-n"`
 }
 
 //nolint:gochecknoglobals
@@ -48,6 +52,7 @@ var ConfigDefaults = Config{
 		subCollectorMetrics,
 		subCollectorWMIStats,
 	},
+	QueriesBySubnetTopN: 20,
 }
 
 // A Collector is a Prometheus Collector for WMI Win32_PerfRawData_DNS_DNS metrics.
@@ -60,6 +65,9 @@ type Collector struct {
 	miSession *mi.Session
 	miQuery   mi.Query
 
+	etwSession *etw.Session
+	queryStats *queryStats
+
 	dynamicUpdatesFailures        *prometheus.Desc
 	dynamicUpdatesQueued          *prometheus.Desc
 	dynamicUpdatesReceived        *prometheus.Desc
@@ -83,6 +91,7 @@ type Collector struct {
 	zoneTransferSuccessReceived   *prometheus.Desc
 	zoneTransferSuccessSent       *prometheus.Desc
 	dnsWMIStats                   *prometheus.Desc
+	queriesBySubnet               *prometheus.Desc
 }
 
 func New(config *Config) *Collector {
@@ -94,6 +103,10 @@ func New(config *Config) *Collector {
 		config.CollectorsEnabled = ConfigDefaults.CollectorsEnabled
 	}
 
+	if config.QueriesBySubnetTopN == 0 {
+		config.QueriesBySubnetTopN = ConfigDefaults.QueriesBySubnetTopN
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -114,6 +127,11 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		"Comma-separated list of collectors to use. Defaults to all, if not specified.",
 	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
 
+	app.Flag(
+		"collector.dns.queries-by-subnet-top-n",
+		"Maximum number of client subnets exposed by the queries_by_subnet sub collector. The first subnets seen are exposed until the exporter restarts, queries from all other subnets are counted as \"other\".",
+	).Default(strconv.Itoa(ConfigDefaults.QueriesBySubnetTopN)).IntVar(&c.config.QueriesBySubnetTopN)
+
 	app.Action(func(*kingpin.ParseContext) error {
 		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
 
@@ -127,17 +145,23 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceWMI, types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
-	c.perfDataCollector.Close()
+	if c.perfDataCollector != nil {
+		c.perfDataCollector.Close()
+	}
 
-	return nil
+	return c.closeQueriesBySubnetCollector()
 }
 
 func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 	for _, collector := range c.config.CollectorsEnabled {
-		if !slices.Contains([]string{subCollectorMetrics, subCollectorWMIStats}, collector) {
+		if !slices.Contains([]string{subCollectorMetrics, subCollectorWMIStats, subCollectorQueriesBySubnet}, collector) {
 			return fmt.Errorf("unknown sub collector: %s. Possible values: %s", collector,
-				strings.Join([]string{subCollectorMetrics, subCollectorWMIStats}, ", "),
+				strings.Join([]string{subCollectorMetrics, subCollectorWMIStats, subCollectorQueriesBySubnet}, ", "),
 			)
 		}
 	}
@@ -154,6 +178,12 @@ func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 		}
 	}
 
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorQueriesBySubnet) {
+		if err := c.buildQueriesBySubnetCollector(logger); err != nil {
+			return fmt.Errorf("failed to build queries_by_subnet collector: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -346,6 +376,10 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.
 		}
 	}
 
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorQueriesBySubnet) {
+		c.collectQueriesBySubnet(ch)
+	}
+
 	return errors.Join(errs...)
 }
 
@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"strconv"
+	"sync"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/etw"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// etwSessionName is the name of the real-time trace session used by the queries_by_subnet sub collector.
+	etwSessionName = "windows_exporter_dns_queries"
+	// etwEventQueryReceived is the QUERY_RECEIVED event of the DNS server analytic log.
+	etwEventQueryReceived = 256
+	// subnetOther is the subnet label for all queries outside the exposed subnets.
+	subnetOther = "other"
+)
+
+// dnsServerProvider is the Microsoft-Windows-DNSServer ETW provider.
+//
+//nolint:gochecknoglobals
+var dnsServerProvider = windows.GUID{
+	Data1: 0xEB79061A,
+	Data2: 0xA566,
+	Data3: 0x4698,
+	Data4: [8]byte{0x91, 0x19, 0x3E, 0xD2, 0x80, 0x70, 0x60, 0xE7},
+}
+
+// qtypeNames maps common DNS query types to their mnemonic.
+//
+//nolint:gochecknoglobals
+var qtypeNames = map[string]string{
+	"1":   "A",
+	"2":   "NS",
+	"5":   "CNAME",
+	"6":   "SOA",
+	"12":  "PTR",
+	"15":  "MX",
+	"16":  "TXT",
+	"28":  "AAAA",
+	"33":  "SRV",
+	"35":  "NAPTR",
+	"43":  "DS",
+	"48":  "DNSKEY",
+	"64":  "SVCB",
+	"65":  "HTTPS",
+	"251": "IXFR",
+	"252": "AXFR",
+	"255": "ANY",
+}
+
+// queryStats aggregates DNS queries by client subnet and query type.
+// The first maxSubnets subnets are exposed for the lifetime of the collector, queries from
+// additional subnets are counted in the "other" bucket. The label set is stable, so that
+// all series are monotonic counters.
+type queryStats struct {
+	mu         sync.Mutex
+	maxSubnets int
+	subnets    map[string]map[string]uint64
+	other      map[string]uint64
+}
+
+func newQueryStats(maxSubnets int) *queryStats {
+	return &queryStats{
+		maxSubnets: maxSubnets,
+		subnets:    make(map[string]map[string]uint64),
+		other:      make(map[string]uint64),
+	}
+}
+
+// observe counts a single query from the given source address.
+func (s *queryStats) observe(source, qtype string) {
+	subnet, ok := clientSubnet(source)
+	if !ok {
+		return
+	}
+
+	if name, ok := qtypeNames[qtype]; ok {
+		qtype = name
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	qtypes, ok := s.subnets[subnet]
+	if !ok {
+		if len(s.subnets) >= s.maxSubnets {
+			s.other[qtype]++
+
+			return
+		}
+
+		qtypes = make(map[string]uint64)
+		s.subnets[subnet] = qtypes
+	}
+
+	qtypes[qtype]++
+}
+
+// collect sends the query counts of the exposed subnets and of the "other" subnet.
+func (s *queryStats) collect(ch chan<- prometheus.Metric, desc *prometheus.Desc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for subnet, qtypes := range s.subnets {
+		for qtype, count := range qtypes {
+			ch <- prometheus.MustNewConstMetric(
+				desc,
+				prometheus.CounterValue,
+				float64(count),
+				subnet,
+				qtype,
+			)
+		}
+	}
+
+	for qtype, count := range s.other {
+		ch <- prometheus.MustNewConstMetric(
+			desc,
+			prometheus.CounterValue,
+			float64(count),
+			subnetOther,
+			qtype,
+		)
+	}
+}
+
+// clientSubnet returns the /24 subnet of an IPv4 address or the /64 subnet of an IPv6 address.
+// The source may contain a port.
+func clientSubnet(source string) (string, bool) {
+	addr, err := netip.ParseAddr(source)
+	if err != nil {
+		addrPort, err := netip.ParseAddrPort(source)
+		if err != nil {
+			return "", false
+		}
+
+		addr = addrPort.Addr()
+	}
+
+	addr = addr.Unmap()
+
+	bits := 64
+	if addr.Is4() {
+		bits = 24
+	}
+
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return "", false
+	}
+
+	return prefix.String(), true
+}
+
+// buildQueriesBySubnetCollector starts a real-time ETW session with the DNS server analytic provider.
+// The session is stopped in Close.
+func (c *Collector) buildQueriesBySubnetCollector(logger *slog.Logger) error {
+	if c.config.QueriesBySubnetTopN <= 0 {
+		return errors.New("queries-by-subnet-top-n must be greater than 0, got " + strconv.Itoa(c.config.QueriesBySubnetTopN))
+	}
+
+	c.queriesBySubnet = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "queries_by_subnet_total"),
+		"Number of queries received by client subnet and query type. Requires the DNS server analytic ETW provider.",
+		[]string{"subnet", "qtype"},
+		nil,
+	)
+
+	c.queryStats = newQueryStats(c.config.QueriesBySubnetTopN)
+
+	session, err := etw.StartSession(etwSessionName)
+	if err != nil {
+		return err
+	}
+
+	if err = session.EnableProvider(dnsServerProvider, etw.LevelInformation, 0); err != nil {
+		return errors.Join(err, session.Close())
+	}
+
+	decoder := etw.NewDecoder()
+	logger = logger.With(slog.String("collector", Name))
+
+	err = session.Process(func(record *etw.EventRecord) {
+		if record.EventHeader.ProviderID != dnsServerProvider ||
+			record.EventHeader.EventDescriptor.ID != etwEventQueryReceived {
+			return
+		}
+
+		properties, err := decoder.Properties(record, "Source", "QTYPE")
+		if err != nil {
+			logger.Debug("failed to decode DNS query event",
+				slog.Any("err", err),
+			)
+
+			return
+		}
+
+		c.queryStats.observe(properties["Source"], properties["QTYPE"])
+	})
+	if err != nil {
+		return errors.Join(err, session.Close())
+	}
+
+	c.etwSession = session
+
+	return nil
+}
+
+func (c *Collector) collectQueriesBySubnet(ch chan<- prometheus.Metric) {
+	c.queryStats.collect(ch, c.queriesBySubnet)
+}
+
+func (c *Collector) closeQueriesBySubnetCollector() error {
+	if c.etwSession == nil {
+		return nil
+	}
+
+	err := c.etwSession.Close()
+	c.etwSession = nil
+
+	if err != nil {
+		return fmt.Errorf("failed to stop ETW session: %w", err)
+	}
+
+	return nil
+}
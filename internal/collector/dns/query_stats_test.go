@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package dns
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientSubnet(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		source string
+		subnet string
+		ok     bool
+	}{
+		{"192.0.2.17", "192.0.2.0/24", true},
+		{"192.0.2.17:53124", "192.0.2.0/24", true},
+		{"::ffff:192.0.2.17", "192.0.2.0/24", true},
+		{"2001:db8:1:2:3::4", "2001:db8:1:2::/64", true},
+		{"[2001:db8:1:2:3::4]:53", "2001:db8:1:2::/64", true},
+		{"invalid", "", false},
+	} {
+		subnet, ok := clientSubnet(tc.source)
+		require.Equal(t, tc.ok, ok, tc.source)
+		require.Equal(t, tc.subnet, subnet, tc.source)
+	}
+}
+
+func TestQueryStatsMaxSubnets(t *testing.T) {
+	t.Parallel()
+
+	stats := newQueryStats(1)
+	desc := prometheus.NewDesc("test", "", []string{"subnet", "qtype"}, nil)
+
+	stats.observe("198.51.100.1", "28")
+
+	for range 3 {
+		stats.observe("192.0.2.1", "1")
+	}
+
+	stats.observe("203.0.113.1", "99")
+
+	// The first subnet stays exposed, even though another subnet has more queries.
+	require.Equal(t, map[[2]string]float64{
+		{"198.51.100.0/24", "AAAA"}: 1,
+		{"other", "A"}:              3,
+		{"other", "99"}:             1,
+	}, collectQueryStats(t, stats, desc))
+
+	stats.observe("198.51.100.2", "28")
+	stats.observe("192.0.2.1", "1")
+
+	require.Equal(t, map[[2]string]float64{
+		{"198.51.100.0/24", "AAAA"}: 2,
+		{"other", "A"}:              4,
+		{"other", "99"}:             1,
+	}, collectQueryStats(t, stats, desc))
+}
+
+func collectQueryStats(t *testing.T, stats *queryStats, desc *prometheus.Desc) map[[2]string]float64 {
+	t.Helper()
+
+	ch := make(chan prometheus.Metric, 10)
+
+	stats.collect(ch, desc)
+	close(ch)
+
+	got := make(map[[2]string]float64)
+
+	for metric := range ch {
+		var m dto.Metric
+
+		require.NoError(t, metric.Write(&m))
+
+		labels := make(map[string]string)
+		for _, label := range m.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+
+		got[[2]string{labels["subnet"], labels["qtype"]}] = m.GetCounter().GetValue()
+	}
+
+	return got
+}
@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package dns_client
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/headers/dnsapi"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	Name = "dns_client"
+
+	subCollectorCache   = "cache"
+	subCollectorQueries = "queries"
+)
+
+type Config struct {
+	CollectorsEnabled []string `yaml:"enabled"`
+}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{
+	CollectorsEnabled: []string{
+		subCollectorCache,
+		subCollectorQueries,
+	},
+}
+
+// A Collector is a Prometheus Collector for the DNS client (resolver) of the host.
+type Collector struct {
+	collectorQueries
+
+	config Config
+
+	// cacheDisabled is set if DnsGetCacheDataTable is not available.
+	cacheDisabled bool
+
+	cacheEntries *prometheus.Desc
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	if config.CollectorsEnabled == nil {
+		config.CollectorsEnabled = ConfigDefaults.CollectorsEnabled
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+	c.config.CollectorsEnabled = make([]string, 0)
+
+	var collectorsEnabled string
+
+	app.Flag(
+		"collector.dns_client.enabled",
+		"Comma-separated list of collectors to use. Available collectors: cache, queries.",
+	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
+
+		return nil
+	})
+
+	return c
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceAPI}
+}
+
+func (c *Collector) Close() error {
+	return c.closeQueries()
+}
+
+func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+	for _, collector := range c.config.CollectorsEnabled {
+		if !slices.Contains([]string{subCollectorCache, subCollectorQueries}, collector) {
+			return fmt.Errorf("unknown sub collector: %s. Possible values: %s", collector,
+				strings.Join([]string{subCollectorCache, subCollectorQueries}, ", "),
+			)
+		}
+	}
+
+	logger = logger.With(slog.String("collector", Name))
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorCache) {
+		c.cacheEntries = prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, Name, "cache_entries"),
+			"Number of entries in the DNS client resolver cache",
+			nil,
+			nil,
+		)
+
+		if !dnsapi.Available() {
+			logger.Warn("DnsGetCacheDataTable is not available, skipping DNS client cache metrics")
+
+			c.cacheDisabled = true
+		}
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorQueries) {
+		c.buildQueries(logger)
+	}
+
+	return nil
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+	errs := make([]error, 0)
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorCache) && !c.cacheDisabled {
+		if err := c.collectCache(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed collecting DNS client cache metrics: %w", err))
+		}
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorQueries) {
+		c.collectQueries(ch)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) collectCache(ch chan<- prometheus.Metric) error {
+	entries, err := dnsapi.GetCacheDataTable()
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.cacheEntries,
+		prometheus.GaugeValue,
+		float64(len(entries)),
+	)
+
+	return nil
+}
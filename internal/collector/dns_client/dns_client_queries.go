@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package dns_client
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/etw"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// etwSessionName is the name of the real-time trace session used by the queries sub collector.
+	etwSessionName = "windows_exporter_dns_client_queries"
+	// etwEventQueryCompleted is emitted by the DNS client once a query has been completed.
+	etwEventQueryCompleted = 3008
+
+	statusSuccess       = "success"
+	statusNameError     = "name_error"
+	statusNoRecords     = "no_records"
+	statusServerFailure = "server_failure"
+	statusTimeout       = "timeout"
+	statusError         = "error"
+)
+
+// dnsClientProvider is the Microsoft-Windows-DNS-Client ETW provider.
+//
+//nolint:gochecknoglobals
+var dnsClientProvider = windows.GUID{
+	Data1: 0x1C95126E,
+	Data2: 0x7EEA,
+	Data3: 0x49A9,
+	Data4: [8]byte{0xA3, 0xFE, 0xA3, 0x78, 0xB0, 0x3D, 0xDB, 0x4D},
+}
+
+// queryStatuses maps the QueryStatus of the query completed event to a status label.
+// All other non-zero codes are reported as "error".
+//
+//nolint:gochecknoglobals
+var queryStatuses = map[string]string{
+	"0":    statusSuccess,
+	"9002": statusServerFailure, // DNS_ERROR_RCODE_SERVER_FAILURE
+	"9003": statusNameError,     // DNS_ERROR_RCODE_NAME_ERROR
+	"9501": statusNoRecords,     // DNS_INFO_NO_RECORDS
+	"1460": statusTimeout,       // ERROR_TIMEOUT
+}
+
+type collectorQueries struct {
+	etwSession *etw.Session
+
+	queriesMu sync.Mutex
+	// queries is nil if the ETW session could not be started.
+	queries map[string]uint64
+
+	queriesTotal *prometheus.Desc
+}
+
+// buildQueries starts a real-time ETW session with the DNS client provider.
+// If the session can not be started, e.g. without elevation, the sub collector is disabled.
+func (c *Collector) buildQueries(logger *slog.Logger) {
+	c.queriesTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "queries_total"),
+		"Number of DNS queries completed by the DNS client since the exporter started, by status",
+		[]string{"status"},
+		nil,
+	)
+
+	session, err := etw.StartSession(etwSessionName)
+	if err != nil {
+		logger.Warn("failed to start ETW session, skipping DNS client query metrics",
+			slog.Any("err", err),
+		)
+
+		return
+	}
+
+	if err = session.EnableProvider(dnsClientProvider, etw.LevelInformation, 0); err != nil {
+		logger.Warn("failed to enable DNS client ETW provider, skipping DNS client query metrics",
+			slog.Any("err", errors.Join(err, session.Close())),
+		)
+
+		return
+	}
+
+	c.queries = newQueryCounts()
+	decoder := etw.NewDecoder()
+
+	err = session.Process(func(record *etw.EventRecord) {
+		if record.EventHeader.ProviderID != dnsClientProvider ||
+			record.EventHeader.EventDescriptor.ID != etwEventQueryCompleted {
+			return
+		}
+
+		properties, err := decoder.Properties(record, "QueryStatus")
+		if err != nil {
+			logger.Debug("failed to decode DNS client query event",
+				slog.Any("err", err),
+			)
+
+			return
+		}
+
+		c.observeQuery(properties["QueryStatus"])
+	})
+	if err != nil {
+		c.queriesMu.Lock()
+		c.queries = nil
+		c.queriesMu.Unlock()
+
+		logger.Warn("failed to process DNS client ETW events, skipping DNS client query metrics",
+			slog.Any("err", errors.Join(err, session.Close())),
+		)
+
+		return
+	}
+
+	c.etwSession = session
+}
+
+func newQueryCounts() map[string]uint64 {
+	return map[string]uint64{
+		statusSuccess:       0,
+		statusNameError:     0,
+		statusNoRecords:     0,
+		statusServerFailure: 0,
+		statusTimeout:       0,
+		statusError:         0,
+	}
+}
+
+// queryStatus returns the status label of a QueryStatus value.
+func queryStatus(code string) string {
+	if status, ok := queryStatuses[code]; ok {
+		return status
+	}
+
+	return statusError
+}
+
+func (c *Collector) observeQuery(code string) {
+	c.queriesMu.Lock()
+	defer c.queriesMu.Unlock()
+
+	c.queries[queryStatus(code)]++
+}
+
+func (c *Collector) collectQueries(ch chan<- prometheus.Metric) {
+	c.queriesMu.Lock()
+	defer c.queriesMu.Unlock()
+
+	if c.queries == nil {
+		return
+	}
+
+	for status, count := range c.queries {
+		ch <- prometheus.MustNewConstMetric(
+			c.queriesTotal,
+			prometheus.CounterValue,
+			float64(count),
+			status,
+		)
+	}
+}
+
+func (c *Collector) closeQueries() error {
+	if c.etwSession == nil {
+		return nil
+	}
+
+	err := c.etwSession.Close()
+	c.etwSession = nil
+
+	return err
+}
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package dns_client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryStatus(t *testing.T) {
+	t.Parallel()
+
+	for code, status := range map[string]string{
+		"0":    statusSuccess,
+		"9003": statusNameError,
+		"9501": statusNoRecords,
+		"9002": statusServerFailure,
+		"1460": statusTimeout,
+		"87":   statusError,
+		"":     statusError,
+	} {
+		require.Equal(t, status, queryStatus(code), code)
+	}
+}
@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package etw_latency
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/headers/etw"
+	"github.com/prometheus-community/windows_exporter/internal/headers/kernel32"
+	"github.com/prometheus-community/windows_exporter/internal/headers/psapi"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	Name = "etw_latency"
+
+	// etwSessionName is the name of the real-time system trace session.
+	etwSessionName = "windows_exporter_etw_latency"
+
+	// PerfInfo event opcodes.
+	// https://learn.microsoft.com/en-us/windows/win32/etw/perfinfo
+	opcodeThreadDPC = 66
+	opcodeISR       = 67
+	opcodeDPC       = 68
+	opcodeTimerDPC  = 69
+)
+
+// perfInfoProvider is the PerfInfo event class of the kernel, which contains the DPC and ISR events.
+//
+//nolint:gochecknoglobals
+var perfInfoProvider = windows.GUID{
+	Data1: 0xCE1DBFB4,
+	Data2: 0x137E,
+	Data3: 0x4DA6,
+	Data4: [8]byte{0x87, 0xB0, 0x3F, 0x59, 0xAA, 0x10, 0x2C, 0xBC},
+}
+
+type Config struct {
+	TopModules int `yaml:"top-modules"`
+}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{
+	TopModules: 10,
+}
+
+// A Collector is a Prometheus Collector for the DPC and ISR latency of kernel modules, based on ETW kernel events.
+type Collector struct {
+	config Config
+	logger *slog.Logger
+
+	// disabled is set if windows_exporter is not running elevated.
+	disabled bool
+
+	etwSession *etw.Session
+	stats      *latencyStats
+	modules    *moduleResolver
+
+	dpcDuration *prometheus.Desc
+	isrTotal    *prometheus.Desc
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	if config.TopModules == 0 {
+		config.TopModules = ConfigDefaults.TopModules
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{}
+
+	app.Flag(
+		"collector.etw_latency.top-modules",
+		"Maximum number of kernel modules exposed by the etw_latency collector. The first modules seen are exposed until the exporter restarts, all other modules are summed up as \"other\".",
+	).Default(strconv.Itoa(ConfigDefaults.TopModules)).IntVar(&c.config.TopModules)
+
+	return c
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceAPI}
+}
+
+func (c *Collector) Close() error {
+	if c.etwSession == nil {
+		return nil
+	}
+
+	err := c.etwSession.Close()
+	c.etwSession = nil
+
+	if err != nil {
+		return fmt.Errorf("failed to stop ETW session: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+	c.logger = logger.With(slog.String("collector", Name))
+
+	if c.config.TopModules <= 0 {
+		return errors.New("top-modules must be greater than 0, got " + strconv.Itoa(c.config.TopModules))
+	}
+
+	c.dpcDuration = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, "", "dpc_duration_seconds"),
+		"Histogram of the execution time of deferred procedure calls (DPCs) by kernel module.",
+		[]string{"module"},
+		nil,
+	)
+	c.isrTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, "", "isr_count_total"),
+		"Number of interrupt service routines (ISRs) executed by kernel module.",
+		[]string{"module"},
+		nil,
+	)
+
+	if !windows.GetCurrentProcessToken().IsElevated() {
+		c.logger.Warn("windows_exporter is not running elevated, etw_latency collector is disabled")
+
+		c.disabled = true
+
+		return nil
+	}
+
+	frequency, err := kernel32.QueryPerformanceFrequency()
+	if err != nil {
+		return fmt.Errorf("failed to query performance counter frequency: %w", err)
+	}
+
+	drivers, err := psapi.EnumDeviceDrivers()
+	if err != nil {
+		return fmt.Errorf("failed to enumerate kernel modules: %w", err)
+	}
+
+	c.modules = newModuleResolver(drivers)
+	c.stats = newLatencyStats(c.config.TopModules)
+
+	c.logger.Warn("etw_latency collector traces every DPC and interrupt of the system. " +
+		"This adds a measurable CPU overhead on systems with high interrupt rates, enable it only for troubleshooting.",
+	)
+
+	session, err := etw.StartSystemSession(etwSessionName, etw.EnableFlagDPC|etw.EnableFlagInterrupt)
+	if err != nil {
+		return fmt.Errorf("failed to start ETW session: %w", err)
+	}
+
+	err = session.Process(func(record *etw.EventRecord) {
+		c.handleEvent(record, float64(frequency))
+	})
+	if err != nil {
+		return errors.Join(err, session.Close())
+	}
+
+	c.etwSession = session
+
+	return nil
+}
+
+// handleEvent records a single PerfInfo event. The duration of a DPC is the difference
+// between the timestamp of the event, which is written at the end of the DPC, and its InitialTime.
+func (c *Collector) handleEvent(record *etw.EventRecord, frequency float64) {
+	if record.EventHeader.ProviderID != perfInfoProvider {
+		return
+	}
+
+	opcode := record.EventHeader.EventDescriptor.Opcode
+
+	switch opcode {
+	case opcodeThreadDPC, opcodeISR, opcodeDPC, opcodeTimerDPC:
+	default:
+		return
+	}
+
+	initialTime, routine, ok := parseRoutineEvent(record.Data(), record.PointerSize())
+	if !ok {
+		return
+	}
+
+	module := c.modules.resolve(routine)
+
+	if opcode == opcodeISR {
+		c.stats.observeISR(module)
+
+		return
+	}
+
+	timestamp := uint64(record.EventHeader.TimeStamp)
+	if timestamp < initialTime {
+		return
+	}
+
+	c.stats.observeDPC(module, float64(timestamp-initialTime)/frequency)
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+	if c.disabled {
+		return nil
+	}
+
+	if c.modules.isStale() {
+		drivers, err := psapi.EnumDeviceDrivers()
+		if err != nil {
+			return fmt.Errorf("failed to enumerate kernel modules: %w", err)
+		}
+
+		c.modules.update(drivers)
+	}
+
+	c.stats.collect(ch, c.dpcDuration, c.isrTotal)
+
+	return nil
+}
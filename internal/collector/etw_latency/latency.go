@@ -0,0 +1,244 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package etw_latency
+
+import (
+	"cmp"
+	"encoding/binary"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/psapi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// moduleOther is the module label for all modules outside the exposed modules.
+	moduleOther = "other"
+	// moduleUnknown is the module label for routines outside all loaded kernel modules.
+	moduleUnknown = "unknown"
+)
+
+// dpcDurationBuckets are the upper bounds of the DPC duration histogram in seconds.
+// Microsoft recommends that DPCs run for less than 100 microseconds.
+//
+//nolint:gochecknoglobals
+var dpcDurationBuckets = []float64{.00001, .000025, .00005, .0001, .00025, .0005, .001, .0025, .005, .01}
+
+// parseRoutineEvent parses the payload of a PerfInfo DPC or ISR event.
+// The payload starts with the InitialTime of the event, followed by the address of the routine.
+//
+// https://learn.microsoft.com/en-us/windows/win32/etw/dpc
+// https://learn.microsoft.com/en-us/windows/win32/etw/isr
+func parseRoutineEvent(data []byte, pointerSize int) (uint64, uint64, bool) {
+	if len(data) < 8+pointerSize {
+		return 0, 0, false
+	}
+
+	initialTime := binary.LittleEndian.Uint64(data)
+
+	if pointerSize == 4 {
+		return initialTime, uint64(binary.LittleEndian.Uint32(data[8:])), true
+	}
+
+	return initialTime, binary.LittleEndian.Uint64(data[8:]), true
+}
+
+// moduleResolver maps routine addresses to the kernel module containing them.
+type moduleResolver struct {
+	mu      sync.RWMutex
+	drivers []psapi.DeviceDriver
+	// stale is set if a routine could not be resolved. Drivers loaded after the
+	// last refresh are picked up by the next refresh.
+	stale bool
+}
+
+func newModuleResolver(drivers []psapi.DeviceDriver) *moduleResolver {
+	r := &moduleResolver{}
+	r.update(drivers)
+
+	return r
+}
+
+// update replaces the known kernel modules.
+func (r *moduleResolver) update(drivers []psapi.DeviceDriver) {
+	drivers = slices.Clone(drivers)
+	slices.SortFunc(drivers, func(a, b psapi.DeviceDriver) int {
+		return cmp.Compare(a.ImageBase, b.ImageBase)
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.drivers = drivers
+	r.stale = false
+}
+
+// resolve returns the lower-cased name of the module with the highest load address below the routine.
+func (r *moduleResolver) resolve(routine uint64) string {
+	r.mu.RLock()
+
+	i, _ := slices.BinarySearchFunc(r.drivers, routine, func(driver psapi.DeviceDriver, routine uint64) int {
+		return cmp.Compare(uint64(driver.ImageBase), routine)
+	})
+
+	if i < len(r.drivers) && uint64(r.drivers[i].ImageBase) == routine {
+		i++
+	}
+
+	if i == 0 {
+		r.mu.RUnlock()
+
+		r.mu.Lock()
+		r.stale = true
+		r.mu.Unlock()
+
+		return moduleUnknown
+	}
+
+	name := strings.ToLower(r.drivers[i-1].Name)
+
+	r.mu.RUnlock()
+
+	return name
+}
+
+// isStale reports whether a routine could not be resolved since the last update.
+func (r *moduleResolver) isStale() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.stale
+}
+
+// moduleLatency holds the DPC and ISR statistics of a single kernel module.
+type moduleLatency struct {
+	dpcCount uint64
+	dpcSum   float64
+	// dpcBuckets holds the non-cumulative number of DPCs per bucket of dpcDurationBuckets.
+	dpcBuckets []uint64
+	isrCount   uint64
+}
+
+func newModuleLatency() *moduleLatency {
+	return &moduleLatency{
+		dpcBuckets: make([]uint64, len(dpcDurationBuckets)),
+	}
+}
+
+// latencyStats aggregates DPC durations and ISR counts by kernel module.
+// The first maxModules modules are exposed for the lifetime of the collector, the statistics
+// of additional modules are summed up in the "other" module. The label set is stable, so that
+// the histograms and counters are monotonic.
+type latencyStats struct {
+	mu         sync.Mutex
+	maxModules int
+	modules    map[string]*moduleLatency
+	other      *moduleLatency
+}
+
+func newLatencyStats(maxModules int) *latencyStats {
+	return &latencyStats{
+		maxModules: maxModules,
+		modules:    make(map[string]*moduleLatency),
+	}
+}
+
+// module returns the statistics of the given module, or the statistics of the "other" module,
+// if the maximum number of modules is exposed already.
+func (s *latencyStats) module(name string) *moduleLatency {
+	if stats, ok := s.modules[name]; ok {
+		return stats
+	}
+
+	if len(s.modules) < s.maxModules {
+		stats := newModuleLatency()
+		s.modules[name] = stats
+
+		return stats
+	}
+
+	if s.other == nil {
+		s.other = newModuleLatency()
+	}
+
+	return s.other
+}
+
+// observeDPC records a DPC of the given module, which ran for the given number of seconds.
+func (s *latencyStats) observeDPC(module string, seconds float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.module(module)
+	stats.dpcCount++
+	stats.dpcSum += seconds
+
+	if i, _ := slices.BinarySearch(dpcDurationBuckets, seconds); i < len(dpcDurationBuckets) {
+		stats.dpcBuckets[i]++
+	}
+}
+
+// observeISR records an ISR of the given module.
+func (s *latencyStats) observeISR(module string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.module(module).isrCount++
+}
+
+// collect sends the statistics of the exposed modules and of the "other" module.
+func (s *latencyStats) collect(ch chan<- prometheus.Metric, dpcDurationDesc, isrDesc *prometheus.Desc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, stats := range s.modules {
+		s.send(ch, dpcDurationDesc, isrDesc, name, stats)
+	}
+
+	if s.other != nil {
+		s.send(ch, dpcDurationDesc, isrDesc, moduleOther, s.other)
+	}
+}
+
+func (s *latencyStats) send(ch chan<- prometheus.Metric, dpcDurationDesc, isrDesc *prometheus.Desc, module string, stats *moduleLatency) {
+	buckets := make(map[float64]uint64, len(dpcDurationBuckets))
+
+	var cumulative uint64
+
+	for i, upperBound := range dpcDurationBuckets {
+		cumulative += stats.dpcBuckets[i]
+		buckets[upperBound] = cumulative
+	}
+
+	ch <- prometheus.MustNewConstHistogram(
+		dpcDurationDesc,
+		stats.dpcCount,
+		stats.dpcSum,
+		buckets,
+		module,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		isrDesc,
+		prometheus.CounterValue,
+		float64(stats.isrCount),
+		module,
+	)
+}
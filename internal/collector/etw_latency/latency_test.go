@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package etw_latency
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/psapi"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRoutineEvent(t *testing.T) {
+	t.Parallel()
+
+	data := binary.LittleEndian.AppendUint64(nil, 1000)
+	data = binary.LittleEndian.AppendUint64(data, 0xFFFFF80012345678)
+
+	initialTime, routine, ok := parseRoutineEvent(data, 8)
+	require.True(t, ok)
+	require.Equal(t, uint64(1000), initialTime)
+	require.Equal(t, uint64(0xFFFFF80012345678), routine)
+
+	initialTime, routine, ok = parseRoutineEvent(data[:12], 4)
+	require.True(t, ok)
+	require.Equal(t, uint64(1000), initialTime)
+	require.Equal(t, uint64(0x12345678), routine)
+
+	_, _, ok = parseRoutineEvent(data[:12], 8)
+	require.False(t, ok)
+}
+
+func TestModuleResolver(t *testing.T) {
+	t.Parallel()
+
+	resolver := newModuleResolver([]psapi.DeviceDriver{
+		{ImageBase: 0x3000, Name: "ndis.sys"},
+		{ImageBase: 0x1000, Name: "ntoskrnl.exe"},
+		{ImageBase: 0x2000, Name: "Storport.sys"},
+	})
+
+	require.Equal(t, "ntoskrnl.exe", resolver.resolve(0x1000))
+	require.Equal(t, "ntoskrnl.exe", resolver.resolve(0x1FFF))
+	require.Equal(t, "storport.sys", resolver.resolve(0x2ABC))
+	require.Equal(t, "ndis.sys", resolver.resolve(0x9000))
+	require.False(t, resolver.isStale())
+
+	require.Equal(t, moduleUnknown, resolver.resolve(0x0FFF))
+	require.True(t, resolver.isStale())
+
+	resolver.update([]psapi.DeviceDriver{{ImageBase: 0x0800, Name: "new.sys"}})
+	require.False(t, resolver.isStale())
+	require.Equal(t, "new.sys", resolver.resolve(0x0FFF))
+}
+
+func TestLatencyStatsMaxModules(t *testing.T) {
+	t.Parallel()
+
+	stats := newLatencyStats(1)
+
+	stats.observeDPC("usbxhci.sys", 1)
+	stats.observeISR("usbxhci.sys")
+	stats.observeDPC("ndis.sys", .00002)
+	stats.observeDPC("ndis.sys", .0003)
+	stats.observeDPC("storport.sys", .00001)
+	stats.observeISR("storport.sys")
+
+	dpcDesc := prometheus.NewDesc("dpc", "", []string{"module"}, nil)
+	isrDesc := prometheus.NewDesc("isr", "", []string{"module"}, nil)
+	ch := make(chan prometheus.Metric, 10)
+
+	stats.collect(ch, dpcDesc, isrDesc)
+	close(ch)
+
+	histograms := make(map[string]*dto.Histogram)
+	isrs := make(map[string]float64)
+
+	for metric := range ch {
+		var m dto.Metric
+
+		require.NoError(t, metric.Write(&m))
+
+		module := m.GetLabel()[0].GetValue()
+
+		if m.GetHistogram() != nil {
+			histograms[module] = m.GetHistogram()
+		} else {
+			isrs[module] = m.GetCounter().GetValue()
+		}
+	}
+
+	// The first module stays exposed, even though other modules spent less time in DPCs.
+	require.Equal(t, map[string]float64{"usbxhci.sys": 1, "other": 1}, isrs)
+	require.Len(t, histograms, 2)
+
+	require.Equal(t, uint64(1), histograms["usbxhci.sys"].GetSampleCount())
+	require.Equal(t, uint64(0), histograms["usbxhci.sys"].GetBucket()[len(dpcDurationBuckets)-1].GetCumulativeCount())
+
+	other := histograms["other"]
+	require.Equal(t, uint64(3), other.GetSampleCount())
+	require.InDelta(t, .00033, other.GetSampleSum(), 1e-12)
+
+	for _, bucket := range other.GetBucket() {
+		switch bucket.GetUpperBound() {
+		case .00001:
+			require.Equal(t, uint64(1), bucket.GetCumulativeCount())
+		case .000025:
+			require.Equal(t, uint64(2), bucket.GetCumulativeCount())
+		case .0005:
+			require.Equal(t, uint64(3), bucket.GetCumulativeCount())
+		}
+	}
+}
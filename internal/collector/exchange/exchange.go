@@ -22,12 +22,15 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -44,10 +47,16 @@ const (
 	subCollectorWorkloadManagement  = "WorkloadManagement"
 	subCollectorRpcClientAccess     = "RpcClientAccess"
 	subCollectorMapiHTTPEmsmdb      = "MapiHttpEmsmdb"
+	subCollectorDatabases           = "databases"
 )
 
 type Config struct {
 	CollectorsEnabled []string `yaml:"enabled"`
+	// DisableDeprecatedMetrics suppresses the deprecated metrics that were renamed to use base units.
+	DisableDeprecatedMetrics bool `yaml:"disable-deprecated-metrics"`
+	// DatabasePaths are glob patterns of mailbox database EDB files used by the databases collector.
+	// If empty, the databases in the default location of the Exchange installation are used.
+	DatabasePaths []string `yaml:"database-paths"`
 }
 
 //nolint:gochecknoglobals
@@ -64,6 +73,8 @@ var ConfigDefaults = Config{
 		subCollectorRpcClientAccess,
 		subCollectorMapiHTTPEmsmdb,
 	},
+	DisableDeprecatedMetrics: false,
+	DatabasePaths:            []string{},
 }
 
 type Collector struct {
@@ -71,6 +82,7 @@ type Collector struct {
 	collectorActiveSync
 	collectorAutoDiscover
 	collectorAvailabilityService
+	collectorDatabases
 	collectorHTTPProxy
 	collectorMapiHTTPEmsMDB
 	collectorOWA
@@ -109,7 +121,7 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 
 	var listAllCollectors bool
 
-	var collectorsEnabled string
+	var collectorsEnabled, databasePaths string
 
 	app.Flag(
 		"collector.exchange.list",
@@ -118,9 +130,19 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 
 	app.Flag(
 		"collector.exchange.enabled",
-		"Comma-separated list of collectors to use. Defaults to all, if not specified.",
+		"Comma-separated list of collectors to use. Defaults to all, if not specified. The databases collector is not enabled by default.",
 	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
 
+	app.Flag(
+		"collector.exchange.database-paths",
+		"Comma-separated list of glob patterns of mailbox database EDB files for the databases collector. Defaults to the databases in the Exchange installation directory.",
+	).Default(strings.Join(ConfigDefaults.DatabasePaths, ",")).StringVar(&databasePaths)
+
+	app.Flag(
+		"collector.exchange.disable-deprecated-metrics",
+		"Do not emit the deprecated metrics with non-base units, e.g. windows_exchange_rpc_avg_latency_sec.",
+	).Default(strconv.FormatBool(c.config.DisableDeprecatedMetrics)).BoolVar(&c.config.DisableDeprecatedMetrics)
+
 	app.PreAction(func(*kingpin.ParseContext) error {
 		if listAllCollectors {
 			collectorDesc := map[string]string{
@@ -134,12 +156,13 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 				subCollectorWorkloadManagement:  "[19430] MSExchange WorkloadManagement Workloads",
 				subCollectorRpcClientAccess:     "[29336] MSExchange RpcClientAccess",
 				subCollectorMapiHTTPEmsmdb:      "[26463] MSExchange MapiHttp Emsmdb",
+				subCollectorDatabases:           "Mailbox database files",
 			}
 
 			sb := strings.Builder{}
 			_, _ = fmt.Fprintf(&sb, "%-32s %-32s\n", "Collector Name", "[PerfID] Perflib Object")
 
-			for _, cname := range ConfigDefaults.CollectorsEnabled {
+			for _, cname := range slices.Concat(ConfigDefaults.CollectorsEnabled, []string{subCollectorDatabases}) {
 				_, _ = fmt.Fprintf(&sb, "%-32s %-32s\n", cname, collectorDesc[cname])
 			}
 
@@ -154,6 +177,10 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 	app.Action(func(*kingpin.ParseContext) error {
 		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
 
+		if databasePaths != "" {
+			c.config.DatabasePaths = strings.Split(databasePaths, ",")
+		}
+
 		return nil
 	})
 
@@ -164,6 +191,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
 	for _, fn := range c.closeFns {
 		fn()
@@ -230,6 +261,11 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 			collect: c.collectMapiHTTPEmsMDB,
 			close:   c.perfDataCollectorMapiHTTPEmsMDB.Close,
 		},
+		subCollectorDatabases: {
+			build:   c.buildDatabases,
+			collect: c.collectDatabases,
+			close:   func() {},
+		},
 	}
 
 	errs := make([]error, 0, len(c.config.CollectorsEnabled))
@@ -31,9 +31,12 @@ type collectorADAccessProcesses struct {
 	perfDataObjectADAccessProcesses    []perfDataCounterValuesADAccessProcesses
 
 	ldapReadTime                    *prometheus.Desc
+	ldapReadTimeSeconds             *prometheus.Desc
 	ldapSearchTime                  *prometheus.Desc
+	ldapSearchTimeSeconds           *prometheus.Desc
 	ldapTimeoutErrorsPerSec         *prometheus.Desc
 	ldapWriteTime                   *prometheus.Desc
+	ldapWriteTimeSeconds            *prometheus.Desc
 	longRunningLDAPOperationsPerMin *prometheus.Desc
 }
 
@@ -57,19 +60,37 @@ func (c *Collector) buildADAccessProcesses() error {
 
 	c.ldapReadTime = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_read_time_sec"),
-		"Time (sec) to send an LDAP read request and receive a response",
+		"Time (sec) to send an LDAP read request and receive a response (deprecated, use windows_exchange_ldap_read_time_seconds)",
+		[]string{"name"},
+		nil,
+	)
+	c.ldapReadTimeSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "ldap_read_time_seconds"),
+		"Time in seconds to send an LDAP read request and receive a response",
 		[]string{"name"},
 		nil,
 	)
 	c.ldapSearchTime = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_search_time_sec"),
-		"Time (sec) to send an LDAP search request and receive a response",
+		"Time (sec) to send an LDAP search request and receive a response (deprecated, use windows_exchange_ldap_search_time_seconds)",
+		[]string{"name"},
+		nil,
+	)
+	c.ldapSearchTimeSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "ldap_search_time_seconds"),
+		"Time in seconds to send an LDAP search request and receive a response",
 		[]string{"name"},
 		nil,
 	)
 	c.ldapWriteTime = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ldap_write_time_sec"),
-		"Time (sec) to send an LDAP Add/Modify/Delete request and receive a response",
+		"Time (sec) to send an LDAP Add/Modify/Delete request and receive a response (deprecated, use windows_exchange_ldap_write_time_seconds)",
+		[]string{"name"},
+		nil,
+	)
+	c.ldapWriteTimeSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "ldap_write_time_seconds"),
+		"Time in seconds to send an LDAP Add/Modify/Delete request and receive a response",
 		[]string{"name"},
 		nil,
 	)
@@ -107,22 +128,49 @@ func (c *Collector) collectADAccessProcesses(ch chan<- prometheus.Metric) error
 			labelName = fmt.Sprintf("%s_%d", labelName, labelUseCount[labelName])
 		}
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.ldapReadTime,
+				prometheus.CounterValue,
+				utils.MilliSecToSec(data.LdapReadTime),
+				labelName,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.ldapReadTime,
+			c.ldapReadTimeSeconds,
 			prometheus.CounterValue,
 			utils.MilliSecToSec(data.LdapReadTime),
 			labelName,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.ldapSearchTime,
+				prometheus.CounterValue,
+				utils.MilliSecToSec(data.LdapSearchTime),
+				labelName,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.ldapSearchTime,
+			c.ldapSearchTimeSeconds,
 			prometheus.CounterValue,
 			utils.MilliSecToSec(data.LdapSearchTime),
 			labelName,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.ldapWriteTime,
+				prometheus.CounterValue,
+				utils.MilliSecToSec(data.LdapWriteTime),
+				labelName,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.ldapWriteTime,
+			c.ldapWriteTimeSeconds,
 			prometheus.CounterValue,
 			utils.MilliSecToSec(data.LdapWriteTime),
 			labelName,
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows/registry"
+)
+
+// exchangeSetupRegistryKey contains the installation path of Exchange Server 2013 and later.
+const exchangeSetupRegistryKey = `SOFTWARE\Microsoft\ExchangeServer\v15\Setup`
+
+type collectorDatabases struct {
+	databasePatterns []string
+	// databaseWarned contains the patterns and paths a warning was already logged for.
+	databaseWarned map[string]struct{}
+
+	databaseFileSize *prometheus.Desc
+}
+
+func (c *Collector) buildDatabases() error {
+	c.databasePatterns = c.config.DatabasePaths
+	c.databaseWarned = make(map[string]struct{})
+
+	if len(c.databasePatterns) == 0 {
+		installPath, err := exchangeInstallPath()
+		if err != nil {
+			return fmt.Errorf("failed to discover Exchange installation path, use --collector.exchange.database-paths: %w", err)
+		}
+
+		// Mailbox databases are created in <install path>\Mailbox\<database>\<database>.edb by default.
+		c.databasePatterns = []string{filepath.Join(installPath, "Mailbox", "*", "*.edb")}
+	}
+
+	c.databaseFileSize = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "database_file_size_bytes"),
+		"Size of the mailbox database EDB file",
+		[]string{"database"},
+		nil,
+	)
+
+	return nil
+}
+
+func (c *Collector) collectDatabases(ch chan<- prometheus.Metric) error {
+	seen := make(map[string]struct{})
+
+	for _, pattern := range c.databasePatterns {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid database path pattern %s: %w", pattern, err)
+		}
+
+		if len(paths) == 0 {
+			c.warnDatabaseOnce(pattern, "no mailbox database found")
+
+			continue
+		}
+
+		for _, path := range paths {
+			database := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+			if _, ok := seen[database]; ok {
+				continue
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					c.warnDatabaseOnce(path, "mailbox database file not found")
+
+					continue
+				}
+
+				return fmt.Errorf("failed to stat mailbox database %s: %w", path, err)
+			}
+
+			seen[database] = struct{}{}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.databaseFileSize,
+				prometheus.GaugeValue,
+				float64(info.Size()),
+				database,
+			)
+		}
+	}
+
+	return nil
+}
+
+// warnDatabaseOnce logs a warning only once per path, to avoid flooding the log on every scrape.
+func (c *Collector) warnDatabaseOnce(path, msg string) {
+	if _, ok := c.databaseWarned[path]; ok {
+		return
+	}
+
+	c.databaseWarned[path] = struct{}{}
+
+	c.logger.Warn(msg,
+		slog.String("path", path),
+	)
+}
+
+func exchangeInstallPath() (string, error) {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, exchangeSetupRegistryKey, registry.QUERY_VALUE)
+	if err != nil {
+		return "", err
+	}
+
+	defer func(key registry.Key) {
+		_ = key.Close()
+	}(key)
+
+	installPath, _, err := key.GetStringValue("MsiInstallPath")
+	if err != nil {
+		return "", err
+	}
+
+	return installPath, nil
+}
@@ -30,12 +30,15 @@ type collectorHTTPProxy struct {
 	perfDataCollectorHTTPProxy *pdh.Collector
 	perfDataObjectHTTPProxy    []perfDataCounterValuesHTTPProxy
 
-	mailboxServerLocatorAverageLatency *prometheus.Desc
-	averageAuthenticationLatency       *prometheus.Desc
-	outstandingProxyRequests           *prometheus.Desc
-	proxyRequestsPerSec                *prometheus.Desc
-	averageCASProcessingLatency        *prometheus.Desc
-	mailboxServerProxyFailureRate      *prometheus.Desc
+	mailboxServerLocatorAverageLatency        *prometheus.Desc
+	mailboxServerLocatorAverageLatencySeconds *prometheus.Desc
+	averageAuthenticationLatency              *prometheus.Desc
+	averageAuthenticationLatencySeconds       *prometheus.Desc
+	outstandingProxyRequests                  *prometheus.Desc
+	proxyRequestsPerSec                       *prometheus.Desc
+	averageCASProcessingLatency               *prometheus.Desc
+	averageCASProcessingLatencySeconds        *prometheus.Desc
+	mailboxServerProxyFailureRate             *prometheus.Desc
 }
 
 type perfDataCounterValuesHTTPProxy struct {
@@ -59,13 +62,25 @@ func (c *Collector) buildHTTPProxy() error {
 
 	c.mailboxServerLocatorAverageLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "http_proxy_mailbox_server_locator_avg_latency_sec"),
-		"Average latency (sec) of MailboxServerLocator web service calls",
+		"Average latency (sec) of MailboxServerLocator web service calls (deprecated, use windows_exchange_http_proxy_mailbox_server_locator_avg_latency_seconds)",
+		[]string{"name"},
+		nil,
+	)
+	c.mailboxServerLocatorAverageLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "http_proxy_mailbox_server_locator_avg_latency_seconds"),
+		"Average latency in seconds of MailboxServerLocator web service calls",
 		[]string{"name"},
 		nil,
 	)
 	c.averageAuthenticationLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "http_proxy_avg_auth_latency"),
-		"Average time spent authenticating CAS requests over the last 200 samples",
+		"Average time spent authenticating CAS requests over the last 200 samples (deprecated, use windows_exchange_http_proxy_avg_auth_latency_seconds)",
+		[]string{"name"},
+		nil,
+	)
+	c.averageAuthenticationLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "http_proxy_avg_auth_latency_seconds"),
+		"Average time in seconds spent authenticating CAS requests over the last 200 samples",
 		[]string{"name"},
 		nil,
 	)
@@ -83,7 +98,13 @@ func (c *Collector) buildHTTPProxy() error {
 	)
 	c.averageCASProcessingLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "http_proxy_avg_cas_processing_latency_sec"),
-		"Average latency (sec) of CAS processing time over the last 200 reqs",
+		"Average latency (sec) of CAS processing time over the last 200 reqs (deprecated, use windows_exchange_http_proxy_avg_cas_processing_latency_seconds)",
+		[]string{"name"},
+		nil,
+	)
+	c.averageCASProcessingLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "http_proxy_avg_cas_processing_latency_seconds"),
+		"Average latency in seconds of CAS processing time over the last 200 reqs",
 		[]string{"name"},
 		nil,
 	)
@@ -105,22 +126,50 @@ func (c *Collector) collectHTTPProxy(ch chan<- prometheus.Metric) error {
 
 	for _, data := range c.perfDataObjectHTTPProxy {
 		labelName := c.toLabelName(data.Name)
+
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.mailboxServerLocatorAverageLatency,
+				prometheus.GaugeValue,
+				utils.MilliSecToSec(data.MailboxServerLocatorAverageLatency),
+				labelName,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.mailboxServerLocatorAverageLatency,
+			c.mailboxServerLocatorAverageLatencySeconds,
 			prometheus.GaugeValue,
 			utils.MilliSecToSec(data.MailboxServerLocatorAverageLatency),
 			labelName,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.averageAuthenticationLatency,
+				prometheus.GaugeValue,
+				data.AverageAuthenticationLatency,
+				labelName,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.averageAuthenticationLatency,
+			c.averageAuthenticationLatencySeconds,
 			prometheus.GaugeValue,
-			data.AverageAuthenticationLatency,
+			utils.MilliSecToSec(data.AverageAuthenticationLatency),
 			labelName,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.averageCASProcessingLatency,
+				prometheus.GaugeValue,
+				utils.MilliSecToSec(data.AverageCASProcessingLatency),
+				labelName,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.averageCASProcessingLatency,
+			c.averageCASProcessingLatencySeconds,
 			prometheus.GaugeValue,
 			utils.MilliSecToSec(data.AverageCASProcessingLatency),
 			labelName,
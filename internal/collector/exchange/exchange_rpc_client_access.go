@@ -30,12 +30,13 @@ type collectorRpcClientAccess struct {
 	perfDataCollectorRpcClientAccess *pdh.Collector
 	perfDataObjectRpcClientAccess    []perfDataCounterValuesRpcClientAccess
 
-	activeUserCount     *prometheus.Desc
-	connectionCount     *prometheus.Desc
-	rpcAveragedLatency  *prometheus.Desc
-	rpcOperationsPerSec *prometheus.Desc
-	rpcRequests         *prometheus.Desc
-	userCount           *prometheus.Desc
+	activeUserCount           *prometheus.Desc
+	connectionCount           *prometheus.Desc
+	rpcAveragedLatency        *prometheus.Desc
+	rpcAveragedLatencySeconds *prometheus.Desc
+	rpcOperationsPerSec       *prometheus.Desc
+	rpcRequests               *prometheus.Desc
+	userCount                 *prometheus.Desc
 }
 
 type perfDataCounterValuesRpcClientAccess struct {
@@ -57,7 +58,13 @@ func (c *Collector) buildRpcClientAccess() error {
 
 	c.rpcAveragedLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "rpc_avg_latency_sec"),
-		"The latency (sec) averaged for the past 1024 packets",
+		"The latency (sec) averaged for the past 1024 packets (deprecated, use windows_exchange_rpc_avg_latency_seconds)",
+		nil,
+		nil,
+	)
+	c.rpcAveragedLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "rpc_avg_latency_seconds"),
+		"The latency in seconds averaged for the past 1024 packets",
 		nil,
 		nil,
 	)
@@ -102,8 +109,16 @@ func (c *Collector) collectRpcClientAccess(ch chan<- prometheus.Metric) error {
 	}
 
 	for _, data := range c.perfDataObjectRpcClientAccess {
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.rpcAveragedLatency,
+				prometheus.GaugeValue,
+				utils.MilliSecToSec(data.RpcAveragedLatency),
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.rpcAveragedLatency,
+			c.rpcAveragedLatencySeconds,
 			prometheus.GaugeValue,
 			utils.MilliSecToSec(data.RpcAveragedLatency),
 		)
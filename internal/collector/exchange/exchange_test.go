@@ -18,10 +18,13 @@
 package exchange_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/prometheus-community/windows_exporter/internal/collector/exchange"
 	"github.com/prometheus-community/windows_exporter/internal/utils/testutils"
+	"github.com/stretchr/testify/require"
 )
 
 func BenchmarkCollector(b *testing.B) {
@@ -31,3 +34,13 @@ func BenchmarkCollector(b *testing.B) {
 func TestCollector(t *testing.T) {
 	testutils.TestCollector(t, exchange.New, nil)
 }
+
+func TestCollectorDatabases(t *testing.T) {
+	databasePath := filepath.Join(t.TempDir(), "DB01.edb")
+	require.NoError(t, os.WriteFile(databasePath, make([]byte, 1024), 0o600))
+
+	testutils.TestCollector(t, exchange.New, &exchange.Config{
+		CollectorsEnabled: []string{"databases"},
+		DatabasePaths:     []string{databasePath},
+	})
+}
@@ -99,6 +99,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
 	return nil
 }
@@ -0,0 +1,267 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package gpo
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	Name = "gpo"
+
+	subCollectorMachine = "machine"
+	subCollectorUser    = "user"
+)
+
+type Config struct {
+	CollectorsEnabled []string `yaml:"enabled"`
+}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{
+	CollectorsEnabled: []string{
+		subCollectorMachine,
+	},
+}
+
+// A Collector is a Prometheus Collector for the Group Policy processing state.
+type Collector struct {
+	config Config
+	logger *slog.Logger
+
+	// accountNames caches the account names of user SIDs.
+	accountNames map[string]string
+
+	lastProcessingTimestamp *prometheus.Desc
+	lastProcessingDuration  *prometheus.Desc
+	processingSuccess       *prometheus.Desc
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	if config.CollectorsEnabled == nil {
+		config.CollectorsEnabled = ConfigDefaults.CollectorsEnabled
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+	c.config.CollectorsEnabled = make([]string, 0)
+
+	var collectorsEnabled string
+
+	app.Flag(
+		"collector.gpo.enabled",
+		fmt.Sprintf("Comma-separated list of Group Policy processing scopes to report. Available: %s, %s.",
+			subCollectorMachine, subCollectorUser,
+		),
+	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
+
+		return nil
+	})
+
+	return c
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceAPI}
+}
+
+func (c *Collector) Close() error {
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+	c.logger = logger.With(slog.String("collector", Name))
+
+	for _, collector := range c.config.CollectorsEnabled {
+		if !slices.Contains([]string{subCollectorMachine, subCollectorUser}, collector) {
+			return fmt.Errorf("unknown collector: %s", collector)
+		}
+	}
+
+	c.accountNames = make(map[string]string)
+
+	c.lastProcessingTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "last_processing_timestamp_seconds"),
+		"Time the last Group Policy processing finished, as a unix timestamp.",
+		[]string{"scope", "user"},
+		nil,
+	)
+	c.lastProcessingDuration = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "last_processing_duration_seconds"),
+		"Duration of the last Group Policy processing, in seconds.",
+		[]string{"scope", "user"},
+		nil,
+	)
+	c.processingSuccess = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "processing_success"),
+		"Whether the last Group Policy processing succeeded (1) or failed (0).",
+		[]string{"scope", "user"},
+		nil,
+	)
+
+	return nil
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+	errs := make([]error, 0)
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMachine) {
+		if err := c.collectMachine(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed collecting machine Group Policy state: %w", err))
+		}
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorUser) {
+		if err := c.collectUsers(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed collecting user Group Policy state: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) collectMachine(ch chan<- prometheus.Metric) error {
+	state, err := readProcessingState(stateKeyMachine)
+	if err != nil {
+		return err
+	}
+
+	c.collectState(ch, state, subCollectorMachine, "")
+
+	return nil
+}
+
+func (c *Collector) collectUsers(ch chan<- prometheus.Metric) error {
+	sids, err := userStateKeys()
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, 0)
+
+	for _, sid := range sids {
+		state, err := readProcessingState(sid)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", sid, err))
+
+			continue
+		}
+
+		c.collectState(ch, state, subCollectorUser, c.accountName(sid))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) collectState(ch chan<- prometheus.Metric, state processingState, scope, user string) {
+	if state.end.IsZero() {
+		// Group Policy has never finished processing for this scope.
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.lastProcessingTimestamp,
+		prometheus.GaugeValue,
+		float64(state.end.UnixMicro())/1e6,
+		scope,
+		user,
+	)
+
+	// The end time is older than the start time while a processing is in progress.
+	if duration, ok := state.duration(); ok {
+		ch <- prometheus.MustNewConstMetric(
+			c.lastProcessingDuration,
+			prometheus.GaugeValue,
+			duration.Seconds(),
+			scope,
+			user,
+		)
+	}
+
+	success := 0.0
+	if state.status == 0 {
+		success = 1.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.processingSuccess,
+		prometheus.GaugeValue,
+		success,
+		scope,
+		user,
+	)
+}
+
+// accountName resolves a SID to DOMAIN\user. The SID itself is returned if it can not be resolved.
+func (c *Collector) accountName(sidString string) string {
+	if name, ok := c.accountNames[sidString]; ok {
+		return name
+	}
+
+	sid, err := windows.StringToSid(sidString)
+	if err != nil {
+		return sidString
+	}
+
+	account, domain, _, err := sid.LookupAccount("")
+	if err != nil {
+		return sidString
+	}
+
+	name := account
+	if domain != "" {
+		name = fmt.Sprintf(`%s\%s`, domain, account)
+	}
+
+	c.accountNames[sidString] = name
+
+	return name
+}
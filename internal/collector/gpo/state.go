@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package gpo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+const (
+	// stateKeyPath contains the state of the last Group Policy processing, as
+	// recorded by the Group Policy service after every foreground and background refresh.
+	stateKeyPath = `SOFTWARE\Microsoft\Windows\CurrentVersion\Group Policy\State`
+
+	// stateKeyMachine is the subkey of stateKeyPath for the computer scope.
+	// User scopes are stored in subkeys named after the user SID.
+	stateKeyMachine = "Machine"
+
+	// coreExtensionKey is the Extension-List entry of the Group Policy engine itself.
+	// Its status and timestamps cover the whole processing cycle.
+	coreExtensionKey = `Extension-List\{00000000-0000-0000-0000-000000000000}`
+)
+
+type processingState struct {
+	start  time.Time
+	end    time.Time
+	status uint32
+}
+
+// duration returns the duration of the processing, if it has finished.
+func (s processingState) duration() (time.Duration, bool) {
+	if s.start.IsZero() || s.end.Before(s.start) {
+		return 0, false
+	}
+
+	return s.end.Sub(s.start), true
+}
+
+// readProcessingState reads the state of the last Group Policy processing of the given state subkey.
+func readProcessingState(scopeKey string) (processingState, error) {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, stateKeyPath+`\`+scopeKey+`\`+coreExtensionKey, registry.QUERY_VALUE)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotExist) {
+			return processingState{}, nil
+		}
+
+		return processingState{}, fmt.Errorf("failed to open registry key: %w", err)
+	}
+
+	defer key.Close()
+
+	values := make(map[string]uint32, 5)
+
+	for _, name := range []string{"StartTimeLo", "StartTimeHi", "EndTimeLo", "EndTimeHi", "Status"} {
+		value, _, err := key.GetIntegerValue(name)
+		if err != nil && !errors.Is(err, registry.ErrNotExist) {
+			return processingState{}, fmt.Errorf("failed to read '%s' value: %w", name, err)
+		}
+
+		values[name] = uint32(value)
+	}
+
+	return processingState{
+		start:  filetimeToTime(values["StartTimeLo"], values["StartTimeHi"]),
+		end:    filetimeToTime(values["EndTimeLo"], values["EndTimeHi"]),
+		status: values["Status"],
+	}, nil
+}
+
+// userStateKeys returns the SIDs of all users with a recorded Group Policy state.
+func userStateKeys() ([]string, error) {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, stateKeyPath, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open registry key: %w", err)
+	}
+
+	defer key.Close()
+
+	names, err := key.ReadSubKeyNames(-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enumerate registry key: %w", err)
+	}
+
+	sids := make([]string, 0, len(names))
+
+	for _, name := range names {
+		if strings.HasPrefix(name, "S-1-") {
+			sids = append(sids, name)
+		}
+	}
+
+	return sids, nil
+}
+
+// filetimeToTime converts the two halves of a FILETIME into a time.
+// A zero FILETIME is returned as the zero time.
+func filetimeToTime(low, high uint32) time.Time {
+	if low == 0 && high == 0 {
+		return time.Time{}
+	}
+
+	ft := windows.Filetime{LowDateTime: low, HighDateTime: high}
+
+	return time.Unix(0, ft.Nanoseconds())
+}
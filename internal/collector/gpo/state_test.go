@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package gpo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFiletimeToTime(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, filetimeToTime(0, 0).IsZero())
+
+	// 2025-01-01T00:00:00Z is 133801632000000000 in 100-nanosecond intervals since 1601-01-01.
+	filetime := uint64(133801632000000000)
+
+	actual := filetimeToTime(uint32(filetime), uint32(filetime>>32))
+	require.True(t, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC).Equal(actual), actual.String())
+}
+
+func TestProcessingStateDuration(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	duration, ok := processingState{start: start, end: start.Add(1500 * time.Millisecond)}.duration()
+	require.True(t, ok)
+	require.Equal(t, 1500*time.Millisecond, duration)
+
+	// A processing that is in progress has an end time older than the start time.
+	_, ok = processingState{start: start, end: start.Add(-time.Hour)}.duration()
+	require.False(t, ok)
+
+	_, ok = processingState{end: start}.duration()
+	require.False(t, ok)
+}
@@ -84,6 +84,14 @@ type Collector struct {
 	gpuProcessMemoryNonLocalUsage  *prometheus.Desc
 	gpuProcessMemorySharedUsage    *prometheus.Desc
 	gpuProcessMemoryTotalCommitted *prometheus.Desc
+
+	// NVML
+	nvmlDevices []nvmlDevice
+
+	nvmlUtilization *prometheus.Desc
+	nvmlMemoryUsed  *prometheus.Desc
+	nvmlTemperature *prometheus.Desc
+	nvmlPowerDraw   *prometheus.Desc
 }
 
 type gpuDevice struct {
@@ -112,6 +120,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
 	c.gpuEnginePerfDataCollector.Close()
 	c.gpuAdapterMemoryPerfDataCollector.Close()
@@ -119,7 +131,7 @@ func (c *Collector) Close() error {
 	c.gpuNonLocalAdapterMemoryPerfDataCollector.Close()
 	c.gpuProcessMemoryPerfDataCollector.Close()
 
-	return nil
+	return c.closeNVML()
 }
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
@@ -222,6 +234,44 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 	)
 
+	nvmlLabels := []string{"gpu_index", "name", "uuid"}
+
+	c.nvmlUtilization = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "utilization_ratio"),
+		"Fraction of the last sample period, during which one or more kernels were executing on the GPU (NVML).",
+		nvmlLabels,
+		nil,
+	)
+	c.nvmlMemoryUsed = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "memory_used_bytes"),
+		"Allocated device memory in bytes (NVML).",
+		nvmlLabels,
+		nil,
+	)
+	c.nvmlTemperature = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "temperature_celsius"),
+		"Temperature of the GPU die in degrees Celsius (NVML).",
+		nvmlLabels,
+		nil,
+	)
+	c.nvmlPowerDraw = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "power_draw_watts"),
+		"Power usage of the GPU and its associated circuitry in watts (NVML).",
+		nvmlLabels,
+		nil,
+	)
+
+	if c.buildNVML(logger) {
+		logger.Info("using NVML for GPU utilization, memory, temperature and power metrics",
+			slog.String("collector", Name),
+			slog.Int("devices", len(c.nvmlDevices)),
+		)
+	} else {
+		logger.Debug("no vendor library found, only performance counter metrics are collected",
+			slog.String("collector", Name),
+		)
+	}
+
 	errs := make([]error, 0)
 
 	c.gpuEnginePerfDataCollector, err = pdh.NewCollector[gpuEnginePerfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "GPU Engine", pdh.InstancesAll)
@@ -340,6 +390,10 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		errs = append(errs, err)
 	}
 
+	if err := c.collectNVML(ch); err != nil {
+		errs = append(errs, err)
+	}
+
 	return errors.Join(errs...)
 }
 
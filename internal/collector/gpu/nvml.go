@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package gpu
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/nvml"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// nvmlDevice is a GPU enumerated via NVML.
+type nvmlDevice struct {
+	handle nvml.Device
+	index  string
+	name   string
+	uuid   string
+}
+
+// buildNVML initializes NVML and enumerates the NVIDIA GPUs.
+// It returns false, if NVML is not installed or not usable.
+func (c *Collector) buildNVML(logger *slog.Logger) bool {
+	logger = logger.With(slog.String("collector", Name))
+
+	if !nvml.Available() {
+		return false
+	}
+
+	if err := nvml.Init(); err != nil {
+		logger.Debug("nvml.dll is installed, but NVML could not be initialized",
+			slog.Any("err", err),
+		)
+
+		return false
+	}
+
+	count, err := nvml.DeviceGetCount()
+	if err != nil {
+		logger.Debug("failed to get NVML device count",
+			slog.Any("err", err),
+		)
+
+		_ = nvml.Shutdown()
+
+		return false
+	}
+
+	c.nvmlDevices = make([]nvmlDevice, 0, count)
+
+	for i := range count {
+		device := nvmlDevice{
+			index: strconv.FormatUint(uint64(i), 10),
+		}
+
+		if device.handle, err = nvml.DeviceGetHandleByIndex(i); err != nil {
+			// Devices without permission to access are skipped, see nvmlDeviceGetHandleByIndex_v2.
+			logger.Debug("failed to get NVML device handle",
+				slog.String("gpu_index", device.index),
+				slog.Any("err", err),
+			)
+
+			continue
+		}
+
+		if device.name, err = nvml.DeviceGetName(device.handle); err != nil {
+			logger.Debug("failed to get NVML device name",
+				slog.String("gpu_index", device.index),
+				slog.Any("err", err),
+			)
+		}
+
+		if device.uuid, err = nvml.DeviceGetUUID(device.handle); err != nil {
+			logger.Debug("failed to get NVML device UUID",
+				slog.String("gpu_index", device.index),
+				slog.Any("err", err),
+			)
+		}
+
+		c.nvmlDevices = append(c.nvmlDevices, device)
+	}
+
+	return true
+}
+
+func (c *Collector) closeNVML() error {
+	if c.nvmlDevices == nil {
+		return nil
+	}
+
+	c.nvmlDevices = nil
+
+	return nvml.Shutdown()
+}
+
+// collectNVML sends the utilization, memory, temperature and power metrics of all NVIDIA GPUs.
+// Metrics which are not supported by a GPU, e.g. the power draw of most consumer GPUs, are skipped.
+func (c *Collector) collectNVML(ch chan<- prometheus.Metric) error {
+	errs := make([]error, 0)
+
+	for _, device := range c.nvmlDevices {
+		labels := []string{device.index, device.name, device.uuid}
+
+		if utilization, err := nvml.DeviceGetUtilizationRates(device.handle); err == nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.nvmlUtilization,
+				prometheus.GaugeValue,
+				float64(utilization.GPU)/100,
+				labels...,
+			)
+		} else if !errors.Is(err, nvml.ERROR_NOT_SUPPORTED) {
+			errs = append(errs, fmt.Errorf("failed to get utilization of GPU %s: %w", device.index, err))
+		}
+
+		if memory, err := nvml.DeviceGetMemoryInfo(device.handle); err == nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.nvmlMemoryUsed,
+				prometheus.GaugeValue,
+				float64(memory.Used),
+				labels...,
+			)
+		} else if !errors.Is(err, nvml.ERROR_NOT_SUPPORTED) {
+			errs = append(errs, fmt.Errorf("failed to get memory usage of GPU %s: %w", device.index, err))
+		}
+
+		if temperature, err := nvml.DeviceGetTemperature(device.handle, nvml.TEMPERATURE_GPU); err == nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.nvmlTemperature,
+				prometheus.GaugeValue,
+				float64(temperature),
+				labels...,
+			)
+		} else if !errors.Is(err, nvml.ERROR_NOT_SUPPORTED) {
+			errs = append(errs, fmt.Errorf("failed to get temperature of GPU %s: %w", device.index, err))
+		}
+
+		if power, err := nvml.DeviceGetPowerUsage(device.handle); err == nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.nvmlPowerDraw,
+				prometheus.GaugeValue,
+				float64(power)/1000,
+				labels...,
+			)
+		} else if !errors.Is(err, nvml.ERROR_NOT_SUPPORTED) {
+			errs = append(errs, fmt.Errorf("failed to get power usage of GPU %s: %w", device.index, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
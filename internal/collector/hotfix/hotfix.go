@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hotfix
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Name = "hotfix"
+
+type Config struct {
+	KBInclude *regexp.Regexp `yaml:"kb-include"`
+}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{
+	KBInclude: types.RegExpEmpty,
+}
+
+// A Collector is a Prometheus Collector for the hotfixes reported by Win32_QuickFixEngineering.
+type Collector struct {
+	config    Config
+	miSession *mi.Session
+	miQuery   mi.Query
+
+	info                 *prometheus.Desc
+	count                *prometheus.Desc
+	lastInstalledSeconds *prometheus.Desc
+}
+
+// win32QuickFixEngineering represents the Win32_QuickFixEngineering WMI class.
+// https://learn.microsoft.com/en-us/previous-versions/windows/desktop/legacy/aa394391(v=vs.85)
+type win32QuickFixEngineering struct {
+	HotFixID    string `mi:"HotFixID"`
+	InstalledOn string `mi:"InstalledOn"`
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	if config.KBInclude == nil {
+		config.KBInclude = ConfigDefaults.KBInclude
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+
+	var kbInclude string
+
+	app.Flag(
+		"collector.hotfix.kb-include",
+		"Regular expression to match hotfix IDs (e.g. KB5034441) exposed by windows_hotfix_info. By default, no hotfix is exposed individually.",
+	).Default("").StringVar(&kbInclude)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		var err error
+
+		c.config.KBInclude, err = regexp.Compile(fmt.Sprintf("^(?:%s)$", kbInclude))
+		if err != nil {
+			return fmt.Errorf("collector.hotfix.kb-include: %w", err)
+		}
+
+		return nil
+	})
+
+	return c
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceWMI}
+}
+
+func (c *Collector) Close() error {
+	return nil
+}
+
+func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	miQuery, err := mi.NewQuery("SELECT HotFixID, InstalledOn FROM Win32_QuickFixEngineering")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQuery = miQuery
+	c.miSession = miSession
+
+	c.info = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "info"),
+		"Installed hotfix matching collector.hotfix.kb-include. installed_on is empty, if the install date could not be parsed.",
+		[]string{"kb", "installed_on"},
+		nil,
+	)
+	c.count = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "count"),
+		"Number of installed hotfixes.",
+		nil,
+		nil,
+	)
+	c.lastInstalledSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "last_installed_timestamp_seconds"),
+		"Install date of the most recently installed hotfix as Unix timestamp. Hotfixes are installed on a date, the time of day is always midnight UTC.",
+		nil,
+		nil,
+	)
+
+	return nil
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	var dst []win32QuickFixEngineering
+	if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, c.miQuery, maxScrapeDuration); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	hotfixes := installedHotfixes(dst)
+
+	var lastInstalled time.Time
+
+	for kb, installedOn := range hotfixes {
+		if installedOn.After(lastInstalled) {
+			lastInstalled = installedOn
+		}
+
+		if !c.config.KBInclude.MatchString(kb) {
+			continue
+		}
+
+		var installedOnLabel string
+		if !installedOn.IsZero() {
+			installedOnLabel = installedOn.Format(time.DateOnly)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.info,
+			prometheus.GaugeValue,
+			1,
+			kb,
+			installedOnLabel,
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.count,
+		prometheus.GaugeValue,
+		float64(len(hotfixes)),
+	)
+
+	if !lastInstalled.IsZero() {
+		ch <- prometheus.MustNewConstMetric(
+			c.lastInstalledSeconds,
+			prometheus.GaugeValue,
+			float64(lastInstalled.Unix()),
+		)
+	}
+
+	return nil
+}
+
+// installedHotfixes returns the install date by upper-cased hotfix ID. Win32_QuickFixEngineering may return
+// a hotfix more than once, e.g. for each installed component. The most recent date is kept.
+// The install date is zero, if it could not be parsed.
+func installedHotfixes(qfes []win32QuickFixEngineering) map[string]time.Time {
+	hotfixes := make(map[string]time.Time, len(qfes))
+
+	for _, qfe := range qfes {
+		kb := strings.ToUpper(strings.TrimSpace(qfe.HotFixID))
+		if kb == "" {
+			continue
+		}
+
+		installedOn, _ := parseInstalledOn(qfe.InstalledOn)
+
+		if previous, ok := hotfixes[kb]; !ok || installedOn.After(previous) {
+			hotfixes[kb] = installedOn
+		}
+	}
+
+	return hotfixes
+}
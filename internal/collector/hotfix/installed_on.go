@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hotfix
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fileTimeUnixEpoch is the Unix epoch in 100-nanosecond intervals since January 1, 1601.
+const fileTimeUnixEpoch = 116444736000000000
+
+// installedOnLayouts are the date formats seen in the InstalledOn property. Month-first dates
+// are preferred, since the property is usually written by the Windows Update agent in en-US format.
+//
+//nolint:gochecknoglobals
+var installedOnLayouts = []string{
+	"1/2/2006",
+	"2/1/2006",
+	"2006-1-2",
+	"2006/1/2",
+	"20060102",
+	"2.1.2006",
+}
+
+// parseInstalledOn parses the InstalledOn property of Win32_QuickFixEngineering.
+// The property is a free-form string written by the installer of the hotfix. Besides several date formats,
+// older hotfixes store a FILETIME as hexadecimal string. A time of day after the date is ignored.
+//
+// https://learn.microsoft.com/en-us/previous-versions/windows/desktop/legacy/aa394391(v=vs.85)
+func parseInstalledOn(value string) (time.Time, bool) {
+	value, _, _ = strings.Cut(strings.TrimSpace(value), " ")
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	if len(value) == 16 {
+		if fileTime, err := strconv.ParseUint(value, 16, 64); err == nil {
+			if fileTime < fileTimeUnixEpoch {
+				return time.Time{}, false
+			}
+
+			t := time.Unix(0, 0).Add(time.Duration(fileTime-fileTimeUnixEpoch) * 100).UTC()
+
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), true
+		}
+	}
+
+	for _, layout := range installedOnLayouts {
+		t, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+
+		// Reject dates before the first Windows NT release, e.g. zero values.
+		if t.Year() < 1993 {
+			return time.Time{}, false
+		}
+
+		return t, true
+	}
+
+	return time.Time{}, false
+}
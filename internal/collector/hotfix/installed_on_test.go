@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hotfix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInstalledOn(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		value    string
+		expected string
+		ok       bool
+	}{
+		{"3/14/2023", "2023-03-14", true},
+		{"03/04/2023", "2023-03-04", true},
+		{"14/3/2023", "2023-03-14", true},
+		{"2023-03-14", "2023-03-14", true},
+		{"2023/3/14", "2023-03-14", true},
+		{"20230314", "2023-03-14", true},
+		{"14.03.2023", "2023-03-14", true},
+		{"3/14/2023 12:00:00 AM", "2023-03-14", true},
+		{" 3/14/2023 ", "2023-03-14", true},
+		{"01d956641ef17800", "2023-03-14", true},
+		{"0000000000000000", "", false},
+		{"1/1/1601", "", false},
+		{"13/13/2023", "", false},
+		{"", "", false},
+		{"unknown", "", false},
+	} {
+		installedOn, ok := parseInstalledOn(tc.value)
+		require.Equal(t, tc.ok, ok, tc.value)
+
+		if tc.ok {
+			require.Equal(t, tc.expected, installedOn.Format(time.DateOnly), tc.value)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"log/slog"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -29,6 +30,7 @@ import (
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/osversion"
+	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -38,6 +40,7 @@ const (
 	subCollectorDataStore                        = "datastore"
 	subCollectorDynamicMemoryBalancer            = "dynamic_memory_balancer"
 	subCollectorDynamicMemoryVM                  = "dynamic_memory_vm"
+	subCollectorGPUPartition                     = "gpu_partition"
 	subCollectorHypervisorLogicalProcessor       = "hypervisor_logical_processor"
 	subCollectorHypervisorRootPartition          = "hypervisor_root_partition"
 	subCollectorHypervisorRootVirtualProcessor   = "hypervisor_root_virtual_processor"
@@ -54,6 +57,8 @@ const (
 
 type Config struct {
 	CollectorsEnabled []string `yaml:"enabled"`
+	// DisableDeprecatedMetrics suppresses the deprecated metrics that were renamed to use base units.
+	DisableDeprecatedMetrics bool `yaml:"disable-deprecated-metrics"`
 }
 
 //nolint:gochecknoglobals
@@ -75,6 +80,7 @@ var ConfigDefaults = Config{
 		subCollectorVirtualStorageDevice,
 		subCollectorVirtualSwitch,
 	},
+	DisableDeprecatedMetrics: false,
 }
 
 // Collector is a Prometheus Collector for hyper-v.
@@ -82,6 +88,7 @@ type Collector struct {
 	collectorDataStore
 	collectorDynamicMemoryBalancer
 	collectorDynamicMemoryVM
+	collectorGPUPartition
 	collectorHypervisorLogicalProcessor
 	collectorHypervisorRootPartition
 	collectorHypervisorRootVirtualProcessor
@@ -95,8 +102,9 @@ type Collector struct {
 	collectorVirtualStorageDevice
 	collectorVirtualSwitch
 
-	config Config
-	logger *slog.Logger
+	config    Config
+	logger    *slog.Logger
+	miSession *mi.Session
 
 	collectorFns []func(ch chan<- prometheus.Metric) error
 	closeFns     []func()
@@ -131,6 +139,11 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		"Comma-separated list of collectors to use.",
 	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
 
+	app.Flag(
+		"collector.hyperv.disable-deprecated-metrics",
+		"Do not emit the deprecated metrics with non-base units, e.g. windows_hyperv_datastore_reconnect_latency_microseconds.",
+	).Default(strconv.FormatBool(c.config.DisableDeprecatedMetrics)).BoolVar(&c.config.DisableDeprecatedMetrics)
+
 	app.Action(func(*kingpin.ParseContext) error {
 		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
 
@@ -144,6 +157,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceWMI}
+}
+
 func (c *Collector) Close() error {
 	for _, fn := range c.closeFns {
 		fn()
@@ -152,8 +169,9 @@ func (c *Collector) Close() error {
 	return nil
 }
 
-func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 	c.logger = logger.With(slog.String("collector", Name))
+	c.miSession = miSession
 	c.collectorFns = make([]func(ch chan<- prometheus.Metric) error, 0, len(c.config.CollectorsEnabled))
 	c.closeFns = make([]func(), 0, len(c.config.CollectorsEnabled))
 
@@ -183,6 +201,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 			collect: c.collectDynamicMemoryVM,
 			close:   c.perfDataCollectorDynamicMemoryVM.Close,
 		},
+		subCollectorGPUPartition: {
+			build:          c.buildGPUPartition,
+			collect:        c.collectGPUPartition,
+			close:          func() {},
+			minBuildNumber: osversion.LTSC2022,
+		},
 		subCollectorHypervisorLogicalProcessor: {
 			build:   c.buildHypervisorLogicalProcessor,
 			collect: c.collectHypervisorLogicalProcessor,
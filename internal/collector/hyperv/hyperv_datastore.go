@@ -22,6 +22,7 @@ import (
 
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -30,52 +31,65 @@ type collectorDataStore struct {
 	perfDataCollectorDataStore *pdh.Collector
 	perfDataObjectDataStore    []perfDataCounterValuesDataStore
 
-	dataStoreFragmentationRatio          *prometheus.Desc // \Hyper-V DataStore(*)\Fragmentation ratio
-	dataStoreSectorSize                  *prometheus.Desc // \Hyper-V DataStore(*)\Sector size
-	dataStoreDataAlignment               *prometheus.Desc // \Hyper-V DataStore(*)\Data alignment
-	dataStoreCurrentReplayLogSize        *prometheus.Desc // \Hyper-V DataStore(*)\Current replay logSize
-	dataStoreAvailableEntries            *prometheus.Desc // \Hyper-V DataStore(*)\Number of available entries inside object tables
-	dataStoreEmptyEntries                *prometheus.Desc // \Hyper-V DataStore(*)\Number of empty entries inside object tables
-	dataStoreFreeBytes                   *prometheus.Desc // \Hyper-V DataStore(*)\Number of free bytes inside key tables
-	dataStoreDataEnd                     *prometheus.Desc // \Hyper-V DataStore(*)\Data end
-	dataStoreFileObjects                 *prometheus.Desc // \Hyper-V DataStore(*)\Number of file objects
-	dataStoreObjectTables                *prometheus.Desc // \Hyper-V DataStore(*)\Number of object tables
-	dataStoreKeyTables                   *prometheus.Desc // \Hyper-V DataStore(*)\Number of key tables
-	dataStoreFileDataSize                *prometheus.Desc // \Hyper-V DataStore(*)\File data size in bytes
-	dataStoreTableDataSize               *prometheus.Desc // \Hyper-V DataStore(*)\Table data size in bytes
-	dataStoreNamesSize                   *prometheus.Desc // \Hyper-V DataStore(*)\Names size in bytes
-	dataStoreNumberOfKeys                *prometheus.Desc // \Hyper-V DataStore(*)\Number of keys
-	dataStoreReconnectLatencyMicro       *prometheus.Desc // \Hyper-V DataStore(*)\Reconnect latency microseconds
-	dataStoreDisconnectCount             *prometheus.Desc // \Hyper-V DataStore(*)\Disconnect count
-	dataStoreWriteToFileByteLatency      *prometheus.Desc // \Hyper-V DataStore(*)\Write to file byte latency microseconds
-	dataStoreWriteToFileByteCount        *prometheus.Desc // \Hyper-V DataStore(*)\Write to file byte count
-	dataStoreWriteToFileCount            *prometheus.Desc // \Hyper-V DataStore(*)\Write to file count
-	dataStoreReadFromFileByteLatency     *prometheus.Desc // \Hyper-V DataStore(*)\Read from file byte latency microseconds
-	dataStoreReadFromFileByteCount       *prometheus.Desc // \Hyper-V DataStore(*)\Read from file byte count
-	dataStoreReadFromFileCount           *prometheus.Desc // \Hyper-V DataStore(*)\Read from file count
-	dataStoreWriteToStorageByteLatency   *prometheus.Desc // \Hyper-V DataStore(*)\Write to storage byte latency microseconds
-	dataStoreWriteToStorageByteCount     *prometheus.Desc // \Hyper-V DataStore(*)\Write to storage byte count
-	dataStoreWriteToStorageCount         *prometheus.Desc // \Hyper-V DataStore(*)\Write to storage count
-	dataStoreReadFromStorageByteLatency  *prometheus.Desc // \Hyper-V DataStore(*)\Read from storage byte latency microseconds
-	dataStoreReadFromStorageByteCount    *prometheus.Desc // \Hyper-V DataStore(*)\Read from storage byte count
-	dataStoreReadFromStorageCount        *prometheus.Desc // \Hyper-V DataStore(*)\Read from storage count
-	dataStoreCommitByteLatency           *prometheus.Desc // \Hyper-V DataStore(*)\Commit byte latency microseconds
-	dataStoreCommitByteCount             *prometheus.Desc // \Hyper-V DataStore(*)\Commit byte count
-	dataStoreCommitCount                 *prometheus.Desc // \Hyper-V DataStore(*)\Commit count
-	dataStoreCacheUpdateOperationLatency *prometheus.Desc // \Hyper-V DataStore(*)\Cache update operation latency microseconds
-	dataStoreCacheUpdateOperationCount   *prometheus.Desc // \Hyper-V DataStore(*)\Cache update operation count
-	dataStoreCommitOperationLatency      *prometheus.Desc // \Hyper-V DataStore(*)\Commit operation latency microseconds
-	dataStoreCommitOperationCount        *prometheus.Desc // \Hyper-V DataStore(*)\Commit operation count
-	dataStoreCompactOperationLatency     *prometheus.Desc // \Hyper-V DataStore(*)\Compact operation latency microseconds
-	dataStoreCompactOperationCount       *prometheus.Desc // \Hyper-V DataStore(*)\Compact operation count
-	dataStoreLoadFileOperationLatency    *prometheus.Desc // \Hyper-V DataStore(*)\Load file operation latency microseconds
-	dataStoreLoadFileOperationCount      *prometheus.Desc // \Hyper-V DataStore(*)\Load file operation count
-	dataStoreRemoveOperationLatency      *prometheus.Desc // \Hyper-V DataStore(*)\Remove operation latency microseconds
-	dataStoreRemoveOperationCount        *prometheus.Desc // \Hyper-V DataStore(*)\Remove operation count
-	dataStoreQuerySizeOperationLatency   *prometheus.Desc // \Hyper-V DataStore(*)\Query size operation latency microseconds
-	dataStoreQuerySizeOperationCount     *prometheus.Desc // \Hyper-V DataStore(*)\Query size operation count
-	dataStoreSetOperationLatencyMicro    *prometheus.Desc // \Hyper-V DataStore(*)\Set operation latency microseconds
-	dataStoreSetOperationCount           *prometheus.Desc // \Hyper-V DataStore(*)\Set operation count
+	dataStoreFragmentationRatio                 *prometheus.Desc // \Hyper-V DataStore(*)\Fragmentation ratio
+	dataStoreSectorSize                         *prometheus.Desc // \Hyper-V DataStore(*)\Sector size
+	dataStoreDataAlignment                      *prometheus.Desc // \Hyper-V DataStore(*)\Data alignment
+	dataStoreCurrentReplayLogSize               *prometheus.Desc // \Hyper-V DataStore(*)\Current replay logSize
+	dataStoreAvailableEntries                   *prometheus.Desc // \Hyper-V DataStore(*)\Number of available entries inside object tables
+	dataStoreEmptyEntries                       *prometheus.Desc // \Hyper-V DataStore(*)\Number of empty entries inside object tables
+	dataStoreFreeBytes                          *prometheus.Desc // \Hyper-V DataStore(*)\Number of free bytes inside key tables
+	dataStoreDataEnd                            *prometheus.Desc // \Hyper-V DataStore(*)\Data end
+	dataStoreFileObjects                        *prometheus.Desc // \Hyper-V DataStore(*)\Number of file objects
+	dataStoreObjectTables                       *prometheus.Desc // \Hyper-V DataStore(*)\Number of object tables
+	dataStoreKeyTables                          *prometheus.Desc // \Hyper-V DataStore(*)\Number of key tables
+	dataStoreFileDataSize                       *prometheus.Desc // \Hyper-V DataStore(*)\File data size in bytes
+	dataStoreTableDataSize                      *prometheus.Desc // \Hyper-V DataStore(*)\Table data size in bytes
+	dataStoreNamesSize                          *prometheus.Desc // \Hyper-V DataStore(*)\Names size in bytes
+	dataStoreNumberOfKeys                       *prometheus.Desc // \Hyper-V DataStore(*)\Number of keys
+	dataStoreReconnectLatencyMicro              *prometheus.Desc // \Hyper-V DataStore(*)\Reconnect latency microseconds
+	dataStoreReconnectLatencySeconds            *prometheus.Desc
+	dataStoreDisconnectCount                    *prometheus.Desc // \Hyper-V DataStore(*)\Disconnect count
+	dataStoreWriteToFileByteLatency             *prometheus.Desc // \Hyper-V DataStore(*)\Write to file byte latency microseconds
+	dataStoreWriteToFileByteLatencySeconds      *prometheus.Desc
+	dataStoreWriteToFileByteCount               *prometheus.Desc // \Hyper-V DataStore(*)\Write to file byte count
+	dataStoreWriteToFileCount                   *prometheus.Desc // \Hyper-V DataStore(*)\Write to file count
+	dataStoreReadFromFileByteLatency            *prometheus.Desc // \Hyper-V DataStore(*)\Read from file byte latency microseconds
+	dataStoreReadFromFileByteLatencySeconds     *prometheus.Desc
+	dataStoreReadFromFileByteCount              *prometheus.Desc // \Hyper-V DataStore(*)\Read from file byte count
+	dataStoreReadFromFileCount                  *prometheus.Desc // \Hyper-V DataStore(*)\Read from file count
+	dataStoreWriteToStorageByteLatency          *prometheus.Desc // \Hyper-V DataStore(*)\Write to storage byte latency microseconds
+	dataStoreWriteToStorageByteLatencySeconds   *prometheus.Desc
+	dataStoreWriteToStorageByteCount            *prometheus.Desc // \Hyper-V DataStore(*)\Write to storage byte count
+	dataStoreWriteToStorageCount                *prometheus.Desc // \Hyper-V DataStore(*)\Write to storage count
+	dataStoreReadFromStorageByteLatency         *prometheus.Desc // \Hyper-V DataStore(*)\Read from storage byte latency microseconds
+	dataStoreReadFromStorageByteLatencySeconds  *prometheus.Desc
+	dataStoreReadFromStorageByteCount           *prometheus.Desc // \Hyper-V DataStore(*)\Read from storage byte count
+	dataStoreReadFromStorageCount               *prometheus.Desc // \Hyper-V DataStore(*)\Read from storage count
+	dataStoreCommitByteLatency                  *prometheus.Desc // \Hyper-V DataStore(*)\Commit byte latency microseconds
+	dataStoreCommitByteLatencySeconds           *prometheus.Desc
+	dataStoreCommitByteCount                    *prometheus.Desc // \Hyper-V DataStore(*)\Commit byte count
+	dataStoreCommitCount                        *prometheus.Desc // \Hyper-V DataStore(*)\Commit count
+	dataStoreCacheUpdateOperationLatency        *prometheus.Desc // \Hyper-V DataStore(*)\Cache update operation latency microseconds
+	dataStoreCacheUpdateOperationLatencySeconds *prometheus.Desc
+	dataStoreCacheUpdateOperationCount          *prometheus.Desc // \Hyper-V DataStore(*)\Cache update operation count
+	dataStoreCommitOperationLatency             *prometheus.Desc // \Hyper-V DataStore(*)\Commit operation latency microseconds
+	dataStoreCommitOperationLatencySeconds      *prometheus.Desc
+	dataStoreCommitOperationCount               *prometheus.Desc // \Hyper-V DataStore(*)\Commit operation count
+	dataStoreCompactOperationLatency            *prometheus.Desc // \Hyper-V DataStore(*)\Compact operation latency microseconds
+	dataStoreCompactOperationLatencySeconds     *prometheus.Desc
+	dataStoreCompactOperationCount              *prometheus.Desc // \Hyper-V DataStore(*)\Compact operation count
+	dataStoreLoadFileOperationLatency           *prometheus.Desc // \Hyper-V DataStore(*)\Load file operation latency microseconds
+	dataStoreLoadFileOperationLatencySeconds    *prometheus.Desc
+	dataStoreLoadFileOperationCount             *prometheus.Desc // \Hyper-V DataStore(*)\Load file operation count
+	dataStoreRemoveOperationLatency             *prometheus.Desc // \Hyper-V DataStore(*)\Remove operation latency microseconds
+	dataStoreRemoveOperationLatencySeconds      *prometheus.Desc
+	dataStoreRemoveOperationCount               *prometheus.Desc // \Hyper-V DataStore(*)\Remove operation count
+	dataStoreQuerySizeOperationLatency          *prometheus.Desc // \Hyper-V DataStore(*)\Query size operation latency microseconds
+	dataStoreQuerySizeOperationLatencySeconds   *prometheus.Desc
+	dataStoreQuerySizeOperationCount            *prometheus.Desc // \Hyper-V DataStore(*)\Query size operation count
+	dataStoreSetOperationLatencyMicro           *prometheus.Desc // \Hyper-V DataStore(*)\Set operation latency microseconds
+	dataStoreSetOperationLatencySeconds         *prometheus.Desc
+	dataStoreSetOperationCount                  *prometheus.Desc // \Hyper-V DataStore(*)\Set operation count
 }
 
 type perfDataCounterValuesDataStore struct {
@@ -229,7 +243,13 @@ func (c *Collector) buildDataStore() error {
 	)
 	c.dataStoreReconnectLatencyMicro = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_reconnect_latency_microseconds"),
-		"Represents the reconnect latency in microseconds of the DataStore.",
+		"Represents the reconnect latency in microseconds of the DataStore (deprecated, use windows_hyperv_datastore_reconnect_latency_seconds).",
+		[]string{"datastore"},
+		nil,
+	)
+	c.dataStoreReconnectLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "datastore_reconnect_latency_seconds"),
+		"Represents the reconnect latency in seconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
@@ -241,7 +261,13 @@ func (c *Collector) buildDataStore() error {
 	)
 	c.dataStoreWriteToFileByteLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_write_to_file_byte_latency_microseconds"),
-		"Represents the write to file byte latency in microseconds of the DataStore.",
+		"Represents the write to file byte latency in microseconds of the DataStore (deprecated, use windows_hyperv_datastore_write_to_file_byte_latency_seconds).",
+		[]string{"datastore"},
+		nil,
+	)
+	c.dataStoreWriteToFileByteLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "datastore_write_to_file_byte_latency_seconds"),
+		"Represents the write to file byte latency in seconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
@@ -259,7 +285,13 @@ func (c *Collector) buildDataStore() error {
 	)
 	c.dataStoreReadFromFileByteLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_read_from_file_byte_latency_microseconds"),
-		"Represents the read from file byte latency in microseconds of the DataStore.",
+		"Represents the read from file byte latency in microseconds of the DataStore (deprecated, use windows_hyperv_datastore_read_from_file_byte_latency_seconds).",
+		[]string{"datastore"},
+		nil,
+	)
+	c.dataStoreReadFromFileByteLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "datastore_read_from_file_byte_latency_seconds"),
+		"Represents the read from file byte latency in seconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
@@ -277,7 +309,13 @@ func (c *Collector) buildDataStore() error {
 	)
 	c.dataStoreWriteToStorageByteLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_write_to_storage_byte_latency_microseconds"),
-		"Represents the write to storage byte latency in microseconds of the DataStore.",
+		"Represents the write to storage byte latency in microseconds of the DataStore (deprecated, use windows_hyperv_datastore_write_to_storage_byte_latency_seconds).",
+		[]string{"datastore"},
+		nil,
+	)
+	c.dataStoreWriteToStorageByteLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "datastore_write_to_storage_byte_latency_seconds"),
+		"Represents the write to storage byte latency in seconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
@@ -295,7 +333,13 @@ func (c *Collector) buildDataStore() error {
 	)
 	c.dataStoreReadFromStorageByteLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_read_from_storage_byte_latency_microseconds"),
-		"Represents the read from storage byte latency in microseconds of the DataStore.",
+		"Represents the read from storage byte latency in microseconds of the DataStore (deprecated, use windows_hyperv_datastore_read_from_storage_byte_latency_seconds).",
+		[]string{"datastore"},
+		nil,
+	)
+	c.dataStoreReadFromStorageByteLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "datastore_read_from_storage_byte_latency_seconds"),
+		"Represents the read from storage byte latency in seconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
@@ -313,7 +357,13 @@ func (c *Collector) buildDataStore() error {
 	)
 	c.dataStoreCommitByteLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_commit_byte_latency_microseconds"),
-		"Represents the commit byte latency in microseconds of the DataStore.",
+		"Represents the commit byte latency in microseconds of the DataStore (deprecated, use windows_hyperv_datastore_commit_byte_latency_seconds).",
+		[]string{"datastore"},
+		nil,
+	)
+	c.dataStoreCommitByteLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "datastore_commit_byte_latency_seconds"),
+		"Represents the commit byte latency in seconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
@@ -331,7 +381,13 @@ func (c *Collector) buildDataStore() error {
 	)
 	c.dataStoreCacheUpdateOperationLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_cache_update_operation_latency_microseconds"),
-		"Represents the cache update operation latency in microseconds of the DataStore.",
+		"Represents the cache update operation latency in microseconds of the DataStore (deprecated, use windows_hyperv_datastore_cache_update_operation_latency_seconds).",
+		[]string{"datastore"},
+		nil,
+	)
+	c.dataStoreCacheUpdateOperationLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "datastore_cache_update_operation_latency_seconds"),
+		"Represents the cache update operation latency in seconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
@@ -343,7 +399,13 @@ func (c *Collector) buildDataStore() error {
 	)
 	c.dataStoreCommitOperationLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_commit_operation_latency_microseconds"),
-		"Represents the commit operation latency in microseconds of the DataStore.",
+		"Represents the commit operation latency in microseconds of the DataStore (deprecated, use windows_hyperv_datastore_commit_operation_latency_seconds).",
+		[]string{"datastore"},
+		nil,
+	)
+	c.dataStoreCommitOperationLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "datastore_commit_operation_latency_seconds"),
+		"Represents the commit operation latency in seconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
@@ -355,7 +417,13 @@ func (c *Collector) buildDataStore() error {
 	)
 	c.dataStoreCompactOperationLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_compact_operation_latency_microseconds"),
-		"Represents the compact operation latency in microseconds of the DataStore.",
+		"Represents the compact operation latency in microseconds of the DataStore (deprecated, use windows_hyperv_datastore_compact_operation_latency_seconds).",
+		[]string{"datastore"},
+		nil,
+	)
+	c.dataStoreCompactOperationLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "datastore_compact_operation_latency_seconds"),
+		"Represents the compact operation latency in seconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
@@ -367,7 +435,13 @@ func (c *Collector) buildDataStore() error {
 	)
 	c.dataStoreLoadFileOperationLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_load_file_operation_latency_microseconds"),
-		"Represents the load file operation latency in microseconds of the DataStore.",
+		"Represents the load file operation latency in microseconds of the DataStore (deprecated, use windows_hyperv_datastore_load_file_operation_latency_seconds).",
+		[]string{"datastore"},
+		nil,
+	)
+	c.dataStoreLoadFileOperationLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "datastore_load_file_operation_latency_seconds"),
+		"Represents the load file operation latency in seconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
@@ -379,7 +453,13 @@ func (c *Collector) buildDataStore() error {
 	)
 	c.dataStoreRemoveOperationLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_remove_operation_latency_microseconds"),
-		"Represents the remove operation latency in microseconds of the DataStore.",
+		"Represents the remove operation latency in microseconds of the DataStore (deprecated, use windows_hyperv_datastore_remove_operation_latency_seconds).",
+		[]string{"datastore"},
+		nil,
+	)
+	c.dataStoreRemoveOperationLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "datastore_remove_operation_latency_seconds"),
+		"Represents the remove operation latency in seconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
@@ -391,7 +471,13 @@ func (c *Collector) buildDataStore() error {
 	)
 	c.dataStoreQuerySizeOperationLatency = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_query_size_operation_latency_microseconds"),
-		"Represents the query size operation latency in microseconds of the DataStore.",
+		"Represents the query size operation latency in microseconds of the DataStore (deprecated, use windows_hyperv_datastore_query_size_operation_latency_seconds).",
+		[]string{"datastore"},
+		nil,
+	)
+	c.dataStoreQuerySizeOperationLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "datastore_query_size_operation_latency_seconds"),
+		"Represents the query size operation latency in seconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
@@ -403,7 +489,13 @@ func (c *Collector) buildDataStore() error {
 	)
 	c.dataStoreSetOperationLatencyMicro = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "datastore_set_operation_latency_microseconds"),
-		"Represents the set operation latency in microseconds of the DataStore.",
+		"Represents the set operation latency in microseconds of the DataStore (deprecated, use windows_hyperv_datastore_set_operation_latency_seconds).",
+		[]string{"datastore"},
+		nil,
+	)
+	c.dataStoreSetOperationLatencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "datastore_set_operation_latency_seconds"),
+		"Represents the set operation latency in seconds of the DataStore.",
 		[]string{"datastore"},
 		nil,
 	)
@@ -529,10 +621,19 @@ func (c *Collector) collectDataStore(ch chan<- prometheus.Metric) error {
 			data.Name,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.dataStoreReconnectLatencyMicro,
+				prometheus.GaugeValue,
+				data.DataStoreReconnectLatencyMicro,
+				data.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.dataStoreReconnectLatencyMicro,
+			c.dataStoreReconnectLatencySeconds,
 			prometheus.GaugeValue,
-			data.DataStoreReconnectLatencyMicro,
+			utils.MicroSecToSec(data.DataStoreReconnectLatencyMicro),
 			data.Name,
 		)
 
@@ -543,10 +644,19 @@ func (c *Collector) collectDataStore(ch chan<- prometheus.Metric) error {
 			data.Name,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.dataStoreWriteToFileByteLatency,
+				prometheus.GaugeValue,
+				data.DataStoreWriteToFileByteLatency,
+				data.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.dataStoreWriteToFileByteLatency,
+			c.dataStoreWriteToFileByteLatencySeconds,
 			prometheus.GaugeValue,
-			data.DataStoreWriteToFileByteLatency,
+			utils.MicroSecToSec(data.DataStoreWriteToFileByteLatency),
 			data.Name,
 		)
 
@@ -564,10 +674,19 @@ func (c *Collector) collectDataStore(ch chan<- prometheus.Metric) error {
 			data.Name,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.dataStoreReadFromFileByteLatency,
+				prometheus.GaugeValue,
+				data.DataStoreReadFromFileByteLatency,
+				data.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.dataStoreReadFromFileByteLatency,
+			c.dataStoreReadFromFileByteLatencySeconds,
 			prometheus.GaugeValue,
-			data.DataStoreReadFromFileByteLatency,
+			utils.MicroSecToSec(data.DataStoreReadFromFileByteLatency),
 			data.Name,
 		)
 
@@ -585,10 +704,19 @@ func (c *Collector) collectDataStore(ch chan<- prometheus.Metric) error {
 			data.Name,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.dataStoreWriteToStorageByteLatency,
+				prometheus.GaugeValue,
+				data.DataStoreWriteToStorageByteLatency,
+				data.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.dataStoreWriteToStorageByteLatency,
+			c.dataStoreWriteToStorageByteLatencySeconds,
 			prometheus.GaugeValue,
-			data.DataStoreWriteToStorageByteLatency,
+			utils.MicroSecToSec(data.DataStoreWriteToStorageByteLatency),
 			data.Name,
 		)
 
@@ -606,10 +734,19 @@ func (c *Collector) collectDataStore(ch chan<- prometheus.Metric) error {
 			data.Name,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.dataStoreReadFromStorageByteLatency,
+				prometheus.GaugeValue,
+				data.DataStoreReadFromStorageByteLatency,
+				data.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.dataStoreReadFromStorageByteLatency,
+			c.dataStoreReadFromStorageByteLatencySeconds,
 			prometheus.GaugeValue,
-			data.DataStoreReadFromStorageByteLatency,
+			utils.MicroSecToSec(data.DataStoreReadFromStorageByteLatency),
 			data.Name,
 		)
 
@@ -627,10 +764,19 @@ func (c *Collector) collectDataStore(ch chan<- prometheus.Metric) error {
 			data.Name,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.dataStoreCommitByteLatency,
+				prometheus.GaugeValue,
+				data.DataStoreCommitByteLatency,
+				data.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.dataStoreCommitByteLatency,
+			c.dataStoreCommitByteLatencySeconds,
 			prometheus.GaugeValue,
-			data.DataStoreCommitByteLatency,
+			utils.MicroSecToSec(data.DataStoreCommitByteLatency),
 			data.Name,
 		)
 
@@ -648,10 +794,19 @@ func (c *Collector) collectDataStore(ch chan<- prometheus.Metric) error {
 			data.Name,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.dataStoreCacheUpdateOperationLatency,
+				prometheus.GaugeValue,
+				data.DataStoreCacheUpdateOperationLatency,
+				data.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.dataStoreCacheUpdateOperationLatency,
+			c.dataStoreCacheUpdateOperationLatencySeconds,
 			prometheus.GaugeValue,
-			data.DataStoreCacheUpdateOperationLatency,
+			utils.MicroSecToSec(data.DataStoreCacheUpdateOperationLatency),
 			data.Name,
 		)
 
@@ -662,10 +817,19 @@ func (c *Collector) collectDataStore(ch chan<- prometheus.Metric) error {
 			data.Name,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.dataStoreCommitOperationLatency,
+				prometheus.GaugeValue,
+				data.DataStoreCommitOperationLatency,
+				data.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.dataStoreCommitOperationLatency,
+			c.dataStoreCommitOperationLatencySeconds,
 			prometheus.GaugeValue,
-			data.DataStoreCommitOperationLatency,
+			utils.MicroSecToSec(data.DataStoreCommitOperationLatency),
 			data.Name,
 		)
 
@@ -676,10 +840,19 @@ func (c *Collector) collectDataStore(ch chan<- prometheus.Metric) error {
 			data.Name,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.dataStoreCompactOperationLatency,
+				prometheus.GaugeValue,
+				data.DataStoreCompactOperationLatency,
+				data.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.dataStoreCompactOperationLatency,
+			c.dataStoreCompactOperationLatencySeconds,
 			prometheus.GaugeValue,
-			data.DataStoreCompactOperationLatency,
+			utils.MicroSecToSec(data.DataStoreCompactOperationLatency),
 			data.Name,
 		)
 
@@ -690,10 +863,19 @@ func (c *Collector) collectDataStore(ch chan<- prometheus.Metric) error {
 			data.Name,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.dataStoreLoadFileOperationLatency,
+				prometheus.GaugeValue,
+				data.DataStoreLoadFileOperationLatency,
+				data.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.dataStoreLoadFileOperationLatency,
+			c.dataStoreLoadFileOperationLatencySeconds,
 			prometheus.GaugeValue,
-			data.DataStoreLoadFileOperationLatency,
+			utils.MicroSecToSec(data.DataStoreLoadFileOperationLatency),
 			data.Name,
 		)
 
@@ -704,10 +886,19 @@ func (c *Collector) collectDataStore(ch chan<- prometheus.Metric) error {
 			data.Name,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.dataStoreRemoveOperationLatency,
+				prometheus.GaugeValue,
+				data.DataStoreRemoveOperationLatency,
+				data.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.dataStoreRemoveOperationLatency,
+			c.dataStoreRemoveOperationLatencySeconds,
 			prometheus.GaugeValue,
-			data.DataStoreRemoveOperationLatency,
+			utils.MicroSecToSec(data.DataStoreRemoveOperationLatency),
 			data.Name,
 		)
 
@@ -718,10 +909,19 @@ func (c *Collector) collectDataStore(ch chan<- prometheus.Metric) error {
 			data.Name,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.dataStoreQuerySizeOperationLatency,
+				prometheus.GaugeValue,
+				data.DataStoreQuerySizeOperationLatency,
+				data.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.dataStoreQuerySizeOperationLatency,
+			c.dataStoreQuerySizeOperationLatencySeconds,
 			prometheus.GaugeValue,
-			data.DataStoreQuerySizeOperationLatency,
+			utils.MicroSecToSec(data.DataStoreQuerySizeOperationLatency),
 			data.Name,
 		)
 
@@ -732,10 +932,19 @@ func (c *Collector) collectDataStore(ch chan<- prometheus.Metric) error {
 			data.Name,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.dataStoreSetOperationLatencyMicro,
+				prometheus.GaugeValue,
+				data.DataStoreSetOperationLatencyMicro,
+				data.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.dataStoreSetOperationLatencyMicro,
+			c.dataStoreSetOperationLatencySeconds,
 			prometheus.GaugeValue,
-			data.DataStoreSetOperationLatencyMicro,
+			utils.MicroSecToSec(data.DataStoreSetOperationLatencyMicro),
 			data.Name,
 		)
 
@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectorGPUPartition Hyper-V GPU partitioning (GPU-P) metrics
+type collectorGPUPartition struct {
+	miQueryGPUPartition      mi.Query
+	miQueryVirtualMachine    mi.Query
+	gpuPartitionNotAvailable bool
+
+	gpuPartitionDedicatedMemory *prometheus.Desc
+	gpuPartitionMinMemory       *prometheus.Desc
+	gpuPartitionMaxMemory       *prometheus.Desc
+	gpuPartitionCompute         *prometheus.Desc
+	gpuPartitionMinCompute      *prometheus.Desc
+	gpuPartitionMaxCompute      *prometheus.Desc
+}
+
+// miGPUPartitionSettingData represents a GPU partition assigned to a virtual machine.
+// https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-gpupartitionsettingdata
+type miGPUPartitionSettingData struct {
+	InstanceID              string `mi:"InstanceID"`
+	MinPartitionVRAM        uint64 `mi:"MinPartitionVRAM"`
+	MaxPartitionVRAM        uint64 `mi:"MaxPartitionVRAM"`
+	OptimalPartitionVRAM    uint64 `mi:"OptimalPartitionVRAM"`
+	MinPartitionCompute     uint64 `mi:"MinPartitionCompute"`
+	MaxPartitionCompute     uint64 `mi:"MaxPartitionCompute"`
+	OptimalPartitionCompute uint64 `mi:"OptimalPartitionCompute"`
+}
+
+// miVirtualMachine represents a virtual machine.
+// https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-computersystem
+type miVirtualMachine struct {
+	Name        string `mi:"Name"`
+	ElementName string `mi:"ElementName"`
+}
+
+func (c *Collector) buildGPUPartition() error {
+	if c.miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	var err error
+
+	c.miQueryGPUPartition, err = mi.NewQuery("SELECT InstanceID, MinPartitionVRAM, MaxPartitionVRAM, OptimalPartitionVRAM, MinPartitionCompute, MaxPartitionCompute, OptimalPartitionCompute FROM Msvm_GpuPartitionSettingData")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQueryVirtualMachine, err = mi.NewQuery("SELECT Name, ElementName FROM Msvm_ComputerSystem WHERE Caption = 'Virtual Machine'")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	var dst []miGPUPartitionSettingData
+	if err := c.miSession.Query(&dst, mi.NamespaceRootVirtualizationV2, c.miQueryGPUPartition, 0); err != nil {
+		if errors.Is(err, mi.MI_RESULT_INVALID_NAMESPACE) || errors.Is(err, mi.MI_RESULT_INVALID_CLASS) {
+			c.logger.Debug("GPU partitioning is not available on this host. The gpu_partition collector is disabled",
+				"err", err,
+			)
+
+			c.gpuPartitionNotAvailable = true
+
+			return nil
+		}
+
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	c.gpuPartitionDedicatedMemory = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "gpu_partition_dedicated_memory_bytes"),
+		"The optimal amount of dedicated GPU memory assigned to the GPU partition",
+		[]string{"vm", "adapter"},
+		nil,
+	)
+	c.gpuPartitionMinMemory = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "gpu_partition_min_dedicated_memory_bytes"),
+		"The minimum amount of dedicated GPU memory assigned to the GPU partition",
+		[]string{"vm", "adapter"},
+		nil,
+	)
+	c.gpuPartitionMaxMemory = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "gpu_partition_max_dedicated_memory_bytes"),
+		"The maximum amount of dedicated GPU memory assigned to the GPU partition",
+		[]string{"vm", "adapter"},
+		nil,
+	)
+	c.gpuPartitionCompute = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "gpu_partition_compute"),
+		"The optimal share of GPU compute assigned to the GPU partition, in driver-defined units",
+		[]string{"vm", "adapter"},
+		nil,
+	)
+	c.gpuPartitionMinCompute = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "gpu_partition_min_compute"),
+		"The minimum share of GPU compute assigned to the GPU partition, in driver-defined units",
+		[]string{"vm", "adapter"},
+		nil,
+	)
+	c.gpuPartitionMaxCompute = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "gpu_partition_max_compute"),
+		"The maximum share of GPU compute assigned to the GPU partition, in driver-defined units",
+		[]string{"vm", "adapter"},
+		nil,
+	)
+
+	return nil
+}
+
+func (c *Collector) collectGPUPartition(ch chan<- prometheus.Metric) error {
+	if c.gpuPartitionNotAvailable {
+		return nil
+	}
+
+	var partitions []miGPUPartitionSettingData
+	if err := c.miSession.Query(&partitions, mi.NamespaceRootVirtualizationV2, c.miQueryGPUPartition, 0); err != nil {
+		return fmt.Errorf("failed to collect Hyper-V GPU partition metrics: %w", err)
+	}
+
+	if len(partitions) == 0 {
+		return nil
+	}
+
+	var virtualMachines []miVirtualMachine
+	if err := c.miSession.Query(&virtualMachines, mi.NamespaceRootVirtualizationV2, c.miQueryVirtualMachine, 0); err != nil {
+		return fmt.Errorf("failed to collect Hyper-V virtual machines: %w", err)
+	}
+
+	vmNames := make(map[string]string, len(virtualMachines))
+	for _, vm := range virtualMachines {
+		vmNames[strings.ToUpper(vm.Name)] = vm.ElementName
+	}
+
+	for _, partition := range partitions {
+		vmID, adapter := parseGPUPartitionInstanceID(partition.InstanceID)
+
+		// Setting data of checkpoints does not belong to a running virtual machine.
+		vm, ok := vmNames[strings.ToUpper(vmID)]
+		if !ok {
+			continue
+		}
+
+		for desc, value := range map[*prometheus.Desc]uint64{
+			c.gpuPartitionDedicatedMemory: partition.OptimalPartitionVRAM,
+			c.gpuPartitionMinMemory:       partition.MinPartitionVRAM,
+			c.gpuPartitionMaxMemory:       partition.MaxPartitionVRAM,
+			c.gpuPartitionCompute:         partition.OptimalPartitionCompute,
+			c.gpuPartitionMinCompute:      partition.MinPartitionCompute,
+			c.gpuPartitionMaxCompute:      partition.MaxPartitionCompute,
+		} {
+			ch <- prometheus.MustNewConstMetric(
+				desc,
+				prometheus.GaugeValue,
+				float64(value),
+				vm, adapter,
+			)
+		}
+	}
+
+	return nil
+}
+
+// parseGPUPartitionInstanceID splits an InstanceID like
+// Microsoft:<vm guid>\<adapter id> into the VM ID and the adapter ID.
+func parseGPUPartitionInstanceID(instanceID string) (string, string) {
+	instanceID = strings.TrimPrefix(instanceID, "Microsoft:")
+
+	vmID, adapter, _ := strings.Cut(instanceID, `\`)
+
+	return vmID, adapter
+}
@@ -31,3 +31,9 @@ func BenchmarkCollector(b *testing.B) {
 func TestCollector(t *testing.T) {
 	testutils.TestCollector(t, hyperv.New, nil)
 }
+
+func TestCollectorGPUPartition(t *testing.T) {
+	testutils.TestCollector(t, hyperv.New, &hyperv.Config{
+		CollectorsEnabled: []string{"gpu_partition"},
+	})
+}
@@ -0,0 +1,312 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv_vm
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Name = "hyperv_vm"
+
+type Config struct {
+	EnabledStates []string `yaml:"enabled-states"`
+}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{
+	EnabledStates: []string{
+		"running",
+	},
+}
+
+// A Collector is a Prometheus Collector for the virtual machines of a Hyper-V host.
+type Collector struct {
+	config    Config
+	miSession *mi.Session
+
+	miQueryVirtualMachines mi.Query
+	miQueryProcessors      mi.Query
+	miQueryMemory          mi.Query
+	miQuerySummary         mi.Query
+	miQueryNetworkAdapters mi.Query
+
+	cpuUsageRatio       *prometheus.Desc
+	memoryAssignedBytes *prometheus.Desc
+	memoryDemandBytes   *prometheus.Desc
+	networkAdapterInfo  *prometheus.Desc
+}
+
+// miVirtualMachine represents a virtual machine.
+// https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-computersystem
+type miVirtualMachine struct {
+	Name         string `mi:"Name"`
+	ElementName  string `mi:"ElementName"`
+	EnabledState uint16 `mi:"EnabledState"`
+}
+
+// miProcessor represents a virtual processor of a virtual machine.
+// https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-processor
+type miProcessor struct {
+	SystemName     string `mi:"SystemName"`
+	LoadPercentage uint16 `mi:"LoadPercentage"`
+}
+
+// miMemory represents the memory of a virtual machine.
+// https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-memory
+type miMemory struct {
+	SystemName     string `mi:"SystemName"`
+	BlockSize      uint64 `mi:"BlockSize"`
+	NumberOfBlocks uint64 `mi:"NumberOfBlocks"`
+}
+
+// miSummaryInformation represents the summary information of a virtual machine.
+// https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-summaryinformation
+type miSummaryInformation struct {
+	Name        string `mi:"Name"`
+	MemoryUsage uint64 `mi:"MemoryUsage"`
+}
+
+// miSyntheticEthernetPortSettingData represents a network adapter of a virtual machine.
+// https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-syntheticethernetportsettingdata
+type miSyntheticEthernetPortSettingData struct {
+	InstanceID  string `mi:"InstanceID"`
+	ElementName string `mi:"ElementName"`
+	Address     string `mi:"Address"`
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	if config.EnabledStates == nil {
+		config.EnabledStates = ConfigDefaults.EnabledStates
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+	c.config.EnabledStates = make([]string, 0)
+
+	var enabledStates string
+
+	app.Flag(
+		"collector.hyperv_vm.enabled-states",
+		"Comma-separated list of virtual machine states to report. Available states: "+strings.Join(slices.Sorted(maps.Values(vmStates)), ", ")+".",
+	).Default(strings.Join(ConfigDefaults.EnabledStates, ",")).StringVar(&enabledStates)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		c.config.EnabledStates = strings.Split(enabledStates, ",")
+
+		return nil
+	})
+
+	return c
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceWMI}
+}
+
+func (c *Collector) Close() error {
+	return nil
+}
+
+func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	for _, state := range c.config.EnabledStates {
+		if !slices.Contains(slices.Collect(maps.Values(vmStates)), state) {
+			return fmt.Errorf("unknown virtual machine state: %s", state)
+		}
+	}
+
+	c.miSession = miSession
+
+	var err error
+
+	c.miQueryVirtualMachines, err = mi.NewQuery("SELECT Name, ElementName, EnabledState FROM Msvm_ComputerSystem WHERE Caption = 'Virtual Machine'")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQueryProcessors, err = mi.NewQuery("SELECT SystemName, LoadPercentage FROM Msvm_Processor")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQueryMemory, err = mi.NewQuery("SELECT SystemName, BlockSize, NumberOfBlocks FROM Msvm_Memory")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQuerySummary, err = mi.NewQuery("SELECT Name, MemoryUsage FROM Msvm_SummaryInformation")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQueryNetworkAdapters, err = mi.NewQuery("SELECT InstanceID, ElementName, Address FROM Msvm_SyntheticEthernetPortSettingData")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.cpuUsageRatio = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "cpu_usage_ratio"),
+		"Average load of the virtual processors of the virtual machine. (Msvm_Processor.LoadPercentage)",
+		[]string{"vm_name", "vm_id", "state"},
+		nil,
+	)
+	c.memoryAssignedBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "memory_assigned_bytes"),
+		"Memory assigned to the virtual machine. (Msvm_Memory.BlockSize * Msvm_Memory.NumberOfBlocks)",
+		[]string{"vm_name", "vm_id", "state"},
+		nil,
+	)
+	c.memoryDemandBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "memory_demand_bytes"),
+		"Memory demanded by the virtual machine. (Msvm_SummaryInformation.MemoryUsage)",
+		[]string{"vm_name", "vm_id", "state"},
+		nil,
+	)
+	c.networkAdapterInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "network_adapter_info"),
+		"Network adapters of the virtual machine. (Msvm_SyntheticEthernetPortSettingData)",
+		[]string{"vm_name", "vm_id", "state", "adapter", "mac_address"},
+		nil,
+	)
+
+	return nil
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	var virtualMachines []miVirtualMachine
+	if err := c.miSession.Query(&virtualMachines, mi.NamespaceRootVirtualizationV2, c.miQueryVirtualMachines, maxScrapeDuration); err != nil {
+		return fmt.Errorf("failed to query Msvm_ComputerSystem: %w", err)
+	}
+
+	virtualMachines = slices.DeleteFunc(virtualMachines, func(vm miVirtualMachine) bool {
+		return !slices.Contains(c.config.EnabledStates, vmState(vm.EnabledState))
+	})
+
+	if len(virtualMachines) == 0 {
+		return nil
+	}
+
+	var processors []miProcessor
+	if err := c.miSession.Query(&processors, mi.NamespaceRootVirtualizationV2, c.miQueryProcessors, maxScrapeDuration); err != nil {
+		return fmt.Errorf("failed to query Msvm_Processor: %w", err)
+	}
+
+	var memories []miMemory
+	if err := c.miSession.Query(&memories, mi.NamespaceRootVirtualizationV2, c.miQueryMemory, maxScrapeDuration); err != nil {
+		return fmt.Errorf("failed to query Msvm_Memory: %w", err)
+	}
+
+	var summaries []miSummaryInformation
+	if err := c.miSession.Query(&summaries, mi.NamespaceRootVirtualizationV2, c.miQuerySummary, maxScrapeDuration); err != nil {
+		return fmt.Errorf("failed to query Msvm_SummaryInformation: %w", err)
+	}
+
+	var networkAdapters []miSyntheticEthernetPortSettingData
+	if err := c.miSession.Query(&networkAdapters, mi.NamespaceRootVirtualizationV2, c.miQueryNetworkAdapters, maxScrapeDuration); err != nil {
+		return fmt.Errorf("failed to query Msvm_SyntheticEthernetPortSettingData: %w", err)
+	}
+
+	load := averageLoad(processors)
+	assigned := assignedMemory(memories)
+
+	demand := make(map[string]uint64, len(summaries))
+	for _, summary := range summaries {
+		demand[strings.ToUpper(summary.Name)] = summary.MemoryUsage
+	}
+
+	for _, vm := range virtualMachines {
+		vmID := strings.ToUpper(vm.Name)
+		state := vmState(vm.EnabledState)
+
+		if value, ok := load[vmID]; ok {
+			ch <- prometheus.MustNewConstMetric(
+				c.cpuUsageRatio,
+				prometheus.GaugeValue,
+				value,
+				vm.ElementName, vm.Name, state,
+			)
+		}
+
+		if value, ok := assigned[vmID]; ok {
+			ch <- prometheus.MustNewConstMetric(
+				c.memoryAssignedBytes,
+				prometheus.GaugeValue,
+				float64(value),
+				vm.ElementName, vm.Name, state,
+			)
+		}
+
+		if value, ok := demand[vmID]; ok {
+			ch <- prometheus.MustNewConstMetric(
+				c.memoryDemandBytes,
+				prometheus.GaugeValue,
+				float64(value)*1024*1024, // MemoryUsage is in MiB.
+				vm.ElementName, vm.Name, state,
+			)
+		}
+
+		for _, adapter := range networkAdapters {
+			// Setting data of checkpoints does not belong to the virtual machine itself.
+			if adapterVMID, _ := parseSettingDataInstanceID(adapter.InstanceID); !strings.EqualFold(adapterVMID, vm.Name) {
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.networkAdapterInfo,
+				prometheus.GaugeValue,
+				1,
+				vm.ElementName, vm.Name, state, adapter.ElementName, adapter.Address,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv_vm
+
+import (
+	"strings"
+)
+
+// vmStates maps the EnabledState of Msvm_ComputerSystem to a readable name.
+//
+// https://learn.microsoft.com/en-us/windows/win32/hyperv_v2/msvm-computersystem
+//
+//nolint:gochecknoglobals
+var vmStates = map[uint16]string{
+	2:     "running",
+	3:     "off",
+	6:     "saved",
+	32768: "paused",
+	32769: "suspended",
+	32770: "starting",
+	32771: "snapshotting",
+	32773: "saving",
+	32774: "stopping",
+	32776: "pausing",
+	32777: "resuming",
+}
+
+// vmState returns the readable name of the given EnabledState.
+func vmState(enabledState uint16) string {
+	if state, ok := vmStates[enabledState]; ok {
+		return state
+	}
+
+	return "unknown"
+}
+
+// averageLoad returns the average load of all virtual processors per virtual machine ID, as ratio.
+func averageLoad(processors []miProcessor) map[string]float64 {
+	sums := make(map[string]float64)
+	counts := make(map[string]float64)
+
+	for _, processor := range processors {
+		vmID := strings.ToUpper(processor.SystemName)
+
+		sums[vmID] += float64(processor.LoadPercentage)
+		counts[vmID]++
+	}
+
+	for vmID, sum := range sums {
+		sums[vmID] = sum / counts[vmID] / 100
+	}
+
+	return sums
+}
+
+// assignedMemory returns the memory assigned per virtual machine ID, in bytes.
+// Virtual machines with virtual NUMA nodes have one Msvm_Memory instance per node
+// in addition to the aggregated instance, so the largest instance is used.
+func assignedMemory(memories []miMemory) map[string]uint64 {
+	assigned := make(map[string]uint64)
+
+	for _, memory := range memories {
+		vmID := strings.ToUpper(memory.SystemName)
+
+		assigned[vmID] = max(assigned[vmID], memory.BlockSize*memory.NumberOfBlocks)
+	}
+
+	return assigned
+}
+
+// parseSettingDataInstanceID splits an InstanceID like
+// Microsoft:<vm guid>\<device id> into the VM ID and the device ID.
+func parseSettingDataInstanceID(instanceID string) (string, string) {
+	instanceID = strings.TrimPrefix(instanceID, "Microsoft:")
+
+	vmID, device, _ := strings.Cut(instanceID, `\`)
+
+	return vmID, device
+}
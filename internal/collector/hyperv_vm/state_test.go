@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package hyperv_vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMState(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "running", vmState(2))
+	require.Equal(t, "off", vmState(3))
+	require.Equal(t, "paused", vmState(32768))
+	require.Equal(t, "unknown", vmState(42))
+}
+
+func TestAverageLoad(t *testing.T) {
+	t.Parallel()
+
+	load := averageLoad([]miProcessor{
+		{SystemName: "6a1b9c2d-0000-0000-0000-000000000001", LoadPercentage: 20},
+		{SystemName: "6A1B9C2D-0000-0000-0000-000000000001", LoadPercentage: 60},
+		{SystemName: "6A1B9C2D-0000-0000-0000-000000000002", LoadPercentage: 5},
+	})
+
+	require.Len(t, load, 2)
+	require.InDelta(t, 0.4, load["6A1B9C2D-0000-0000-0000-000000000001"], 1e-9)
+	require.InDelta(t, 0.05, load["6A1B9C2D-0000-0000-0000-000000000002"], 1e-9)
+}
+
+func TestAssignedMemory(t *testing.T) {
+	t.Parallel()
+
+	memory := assignedMemory([]miMemory{
+		{SystemName: "6A1B9C2D-0000-0000-0000-000000000001", BlockSize: 1048576, NumberOfBlocks: 2048},
+		{SystemName: "6A1B9C2D-0000-0000-0000-000000000001", BlockSize: 1048576, NumberOfBlocks: 4096},
+		{SystemName: "6A1B9C2D-0000-0000-0000-000000000001", BlockSize: 1048576, NumberOfBlocks: 2048},
+	})
+
+	require.Equal(t, map[string]uint64{"6A1B9C2D-0000-0000-0000-000000000001": 4096 * 1048576}, memory)
+}
+
+func TestParseSettingDataInstanceID(t *testing.T) {
+	t.Parallel()
+
+	vmID, device := parseSettingDataInstanceID(`Microsoft:6A1B9C2D-0000-0000-0000-000000000001\C3F1A2B4-0000-0000-0000-000000000002`)
+
+	require.Equal(t, "6A1B9C2D-0000-0000-0000-000000000001", vmID)
+	require.Equal(t, "C3F1A2B4-0000-0000-0000-000000000002", device)
+}
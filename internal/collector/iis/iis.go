@@ -33,9 +33,16 @@ import (
 	"golang.org/x/sys/windows/registry"
 )
 
-const Name = "iis"
+const (
+	Name                     = "iis"
+	subCollectorMetrics      = "metrics"
+	subCollectorSiteBindings = "site_bindings"
+	subCollectorSchannel     = "schannel"
+)
 
 type Config struct {
+	CollectorsEnabled []string `yaml:"enabled"`
+
 	SiteInclude *regexp.Regexp `yaml:"site-include"`
 	SiteExclude *regexp.Regexp `yaml:"site-exclude"`
 	AppInclude  *regexp.Regexp `yaml:"app-include"`
@@ -44,6 +51,9 @@ type Config struct {
 
 //nolint:gochecknoglobals
 var ConfigDefaults = Config{
+	CollectorsEnabled: []string{
+		subCollectorMetrics,
+	},
 	SiteInclude: types.RegExpAny,
 	SiteExclude: types.RegExpEmpty,
 	AppInclude:  types.RegExpAny,
@@ -53,9 +63,12 @@ var ConfigDefaults = Config{
 type Collector struct {
 	collectorWebService
 	collectorHttpServiceRequestQueues
+	collectorHttpService
 	collectorAppPoolWAS
 	collectorW3SVCW3WP
 	collectorWebServiceCache
+	collectorSiteBindings
+	collectorSchannel
 
 	config     Config
 	iisVersion simpleVersion
@@ -70,6 +83,10 @@ func New(config *Config) *Collector {
 		config = &ConfigDefaults
 	}
 
+	if config.CollectorsEnabled == nil {
+		config.CollectorsEnabled = ConfigDefaults.CollectorsEnabled
+	}
+
 	if config.AppExclude == nil {
 		config.AppExclude = ConfigDefaults.AppExclude
 	}
@@ -98,7 +115,18 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		config: ConfigDefaults,
 	}
 
-	var appExclude, appInclude, siteExclude, siteInclude string
+	c.config.CollectorsEnabled = make([]string, 0)
+
+	var collectorsEnabled, appExclude, appInclude, siteExclude, siteInclude string
+
+	app.Flag(
+		"collector.iis.enabled",
+		fmt.Sprintf("Comma-separated list of collectors to use. Available collectors: %s, %s, %s. Defaults to metrics, if not specified.",
+			subCollectorMetrics,
+			subCollectorSiteBindings,
+			subCollectorSchannel,
+		),
+	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
 
 	app.Flag(
 		"collector.iis.app-exclude",
@@ -121,6 +149,8 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 	).Default(".+").StringVar(&siteInclude)
 
 	app.Action(func(*kingpin.ParseContext) error {
+		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
+
 		var err error
 
 		c.config.AppExclude, err = regexp.Compile(fmt.Sprintf("^(?:%s)$", appExclude))
@@ -153,12 +183,19 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
-	c.perfDataCollectorWebService.Close()
-	c.perfDataCollectorHttpServiceRequestQueues.Close()
-	c.perfDataCollectorAppPoolWAS.Close()
-	c.w3SVCW3WPPerfDataCollector.Close()
-	c.serviceCachePerfDataCollector.Close()
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+		c.perfDataCollectorWebService.Close()
+		c.perfDataCollectorHttpServiceRequestQueues.Close()
+		c.perfDataCollectorHttpService.Close()
+		c.perfDataCollectorAppPoolWAS.Close()
+		c.w3SVCW3WPPerfDataCollector.Close()
+		c.serviceCachePerfDataCollector.Close()
+	}
 
 	return nil
 }
@@ -166,6 +203,14 @@ func (c *Collector) Close() error {
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	c.logger = logger.With(slog.String("collector", Name))
 
+	for _, collector := range c.config.CollectorsEnabled {
+		if !slices.Contains([]string{subCollectorMetrics, subCollectorSiteBindings, subCollectorSchannel}, collector) {
+			return fmt.Errorf("unknown sub collector: %s. Possible values: %s", collector,
+				strings.Join([]string{subCollectorMetrics, subCollectorSiteBindings, subCollectorSchannel}, ", "),
+			)
+		}
+	}
+
 	c.iisVersion = c.getIISVersion()
 
 	c.info = prometheus.NewDesc(
@@ -177,6 +222,25 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	errs := make([]error, 0)
 
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+		errs = append(errs, c.buildMetrics()...)
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorSiteBindings) {
+		c.buildSiteBindings()
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorSchannel) {
+		c.buildSchannel()
+	}
+
+	return errors.Join(errs...)
+}
+
+// buildMetrics builds the collectors of the IIS performance counter objects.
+func (c *Collector) buildMetrics() []error {
+	errs := make([]error, 0)
+
 	if err := c.buildWebService(); err != nil {
 		errs = append(errs, fmt.Errorf("failed to build Web Service collector: %w", err))
 	}
@@ -185,6 +249,10 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		errs = append(errs, fmt.Errorf("failed to build Http Service collector: %w", err))
 	}
 
+	if err := c.buildHttpService(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to build HTTP Service collector: %w", err))
+	}
+
 	if err := c.buildAppPoolWAS(); err != nil {
 		errs = append(errs, fmt.Errorf("failed to build APP_POOL_WAS collector: %w", err))
 	}
@@ -197,7 +265,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		errs = append(errs, fmt.Errorf("failed to build Web Service Cache collector: %w", err))
 	}
 
-	return errors.Join(errs...)
+	return errs
 }
 
 type simpleVersion struct {
@@ -261,6 +329,29 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 
 	errs := make([]error, 0)
 
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+		errs = append(errs, c.collectMetrics(ch)...)
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorSiteBindings) {
+		if err := c.collectSiteBindings(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed to collect site binding metrics: %w", err))
+		}
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorSchannel) {
+		if err := c.collectSchannel(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed to collect Schannel metrics: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// collectMetrics collects the IIS performance counter objects.
+func (c *Collector) collectMetrics(ch chan<- prometheus.Metric) []error {
+	errs := make([]error, 0)
+
 	if err := c.collectWebService(ch); err != nil {
 		errs = append(errs, fmt.Errorf("failed to collect Web Service metrics: %w", err))
 	}
@@ -269,6 +360,10 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		errs = append(errs, fmt.Errorf("failed to collect Http Service Request Queues metrics: %w", err))
 	}
 
+	if err := c.collectHttpService(ch); err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect HTTP Service metrics: %w", err))
+	}
+
 	if err := c.collectAppPoolWAS(ch); err != nil {
 		errs = append(errs, fmt.Errorf("failed to collect APP_POOL_WAS metrics: %w", err))
 	}
@@ -281,7 +376,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		errs = append(errs, fmt.Errorf("failed to collect Web Service Cache metrics: %w", err))
 	}
 
-	return errors.Join(errs...)
+	return errs
 }
 
 type collectorName interface {
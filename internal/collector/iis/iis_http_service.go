@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package iis
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const counterHttpServiceHTTP2Requests = "HTTP/2 Requests"
+
+type collectorHttpService struct {
+	// perfDataCollectorHttpService is nil, if the HTTP/2 counters are not available.
+	perfDataCollectorHttpService *pdh.Collector
+	perfDataObjectHttpService    []perfDataCounterValuesHttpService
+
+	httpServiceHTTP2Requests *prometheus.Desc
+}
+
+type perfDataCounterValuesHttpService struct {
+	HttpServiceHTTP2Requests float64 `perfdata:"HTTP/2 Requests" perfdata_optional:"true"`
+}
+
+// buildHttpService creates the collector for the HTTP/2 counters of the HTTP Service object.
+// The counters are not available on all Windows versions. In that case, no metrics are reported.
+func (c *Collector) buildHttpService() error {
+	c.httpServiceHTTP2Requests = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "http2_requests_total"),
+		"Number of HTTP/2 requests received by HTTP.sys (HttpService.HTTP2Requests)",
+		nil,
+		nil,
+	)
+
+	var err error
+
+	c.perfDataCollectorHttpService, err = pdh.NewCollector[perfDataCounterValuesHttpService](c.logger, pdh.CounterTypeRaw, "HTTP Service", nil)
+	if errors.Is(err, pdh.NewPdhError(pdh.CstatusNoObject)) {
+		c.logger.Debug("HTTP Service performance object not found, skipping HTTP/2 metrics")
+
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to create HTTP Service collector: %w", err)
+	}
+
+	if !c.perfDataCollectorHttpService.HasCounter(counterHttpServiceHTTP2Requests) {
+		c.logger.Debug("HTTP/2 counters not found, skipping HTTP/2 metrics")
+
+		c.perfDataCollectorHttpService.Close()
+		c.perfDataCollectorHttpService = nil
+	}
+
+	return nil
+}
+
+func (c *Collector) collectHttpService(ch chan<- prometheus.Metric) error {
+	if c.perfDataCollectorHttpService == nil {
+		return nil
+	}
+
+	err := c.perfDataCollectorHttpService.Collect(&c.perfDataObjectHttpService)
+	if err != nil {
+		return fmt.Errorf("failed to collect HTTP Service metrics: %w", err)
+	} else if len(c.perfDataObjectHttpService) == 0 {
+		return fmt.Errorf("failed to collect HTTP Service metrics: %w", types.ErrNoDataUnexpected)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.httpServiceHTTP2Requests,
+		prometheus.CounterValue,
+		c.perfDataObjectHttpService[0].HttpServiceHTTP2Requests,
+	)
+
+	return nil
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package iis
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/osversion"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows/registry"
+)
+
+const schannelProtocolsKey = `SYSTEM\CurrentControlSet\Control\SecurityProviders\SCHANNEL\Protocols`
+
+//nolint:gochecknoglobals
+var (
+	schannelProtocols = []string{"SSL 2.0", "SSL 3.0", "TLS 1.0", "TLS 1.1", "TLS 1.2", "TLS 1.3"}
+	schannelRoles     = []string{"Client", "Server"}
+)
+
+// registryDWORDReader reads a DWORD value from HKEY_LOCAL_MACHINE.
+// It returns [registry.ErrNotExist] if the key or the value does not exist.
+type registryDWORDReader func(path, name string) (uint64, error)
+
+type schannelProtocolState struct {
+	protocol string
+	role     string
+	enabled  bool
+}
+
+type collectorSchannel struct {
+	readRegistryDWORD registryDWORDReader
+
+	schannelProtocolEnabled *prometheus.Desc
+}
+
+func (c *Collector) buildSchannel() {
+	if c.readRegistryDWORD == nil {
+		c.readRegistryDWORD = readLocalMachineDWORD
+	}
+
+	c.schannelProtocolEnabled = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, "schannel", "protocol_enabled"),
+		"Whether the Schannel protocol is enabled. Falls back to the operating system default, if not configured in the registry",
+		[]string{"protocol", "role"},
+		nil,
+	)
+}
+
+func (c *Collector) collectSchannel(ch chan<- prometheus.Metric) error {
+	states, err := readSchannelProtocols(c.readRegistryDWORD, osversion.Build())
+	if err != nil {
+		return err
+	}
+
+	for _, state := range states {
+		ch <- prometheus.MustNewConstMetric(
+			c.schannelProtocolEnabled,
+			prometheus.GaugeValue,
+			utils.BoolToFloat(state.enabled),
+			state.protocol,
+			state.role,
+		)
+	}
+
+	return nil
+}
+
+// readSchannelProtocols returns the effective enablement of all known Schannel protocols.
+//
+// https://learn.microsoft.com/en-us/windows-server/security/tls/tls-registry-settings
+func readSchannelProtocols(readDWORD registryDWORDReader, build uint16) ([]schannelProtocolState, error) {
+	states := make([]schannelProtocolState, 0, len(schannelProtocols)*len(schannelRoles))
+
+	for _, protocol := range schannelProtocols {
+		for _, role := range schannelRoles {
+			enabled, err := readDWORD(schannelProtocolsKey+`\`+protocol+`\`+role, "Enabled")
+
+			switch {
+			case err == nil:
+				states = append(states, schannelProtocolState{protocol, role, enabled != 0})
+			case errors.Is(err, registry.ErrNotExist):
+				states = append(states, schannelProtocolState{protocol, role, schannelProtocolDefault(protocol, build)})
+			default:
+				return nil, fmt.Errorf("failed to read Schannel settings for %s %s: %w", protocol, role, err)
+			}
+		}
+	}
+
+	return states, nil
+}
+
+// schannelProtocolDefault returns whether the protocol is enabled by default on the given Windows build.
+//
+// https://learn.microsoft.com/en-us/windows/win32/secauthn/protocols-in-tls-ssl--schannel-ssp-
+func schannelProtocolDefault(protocol string, build uint16) bool {
+	switch protocol {
+	case "SSL 2.0", "SSL 3.0":
+		return false
+	case "TLS 1.3":
+		return build >= osversion.LTSC2022
+	default:
+		return true
+	}
+}
+
+func readLocalMachineDWORD(path, name string) (uint64, error) {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, path, registry.QUERY_VALUE)
+	if err != nil {
+		return 0, err
+	}
+
+	defer k.Close()
+
+	value, _, err := k.GetIntegerValue(name)
+
+	return value, err
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package iis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows/registry"
+)
+
+func TestReadSchannelProtocols(t *testing.T) {
+	t.Parallel()
+
+	values := map[string]uint64{
+		schannelProtocolsKey + `\TLS 1.0\Server`: 0,
+		schannelProtocolsKey + `\SSL 3.0\Client`: 1,
+	}
+
+	readDWORD := func(path, name string) (uint64, error) {
+		require.Equal(t, "Enabled", name)
+
+		value, ok := values[path]
+		if !ok {
+			return 0, registry.ErrNotExist
+		}
+
+		return value, nil
+	}
+
+	states, err := readSchannelProtocols(readDWORD, 17763)
+	require.NoError(t, err)
+	require.Len(t, states, len(schannelProtocols)*len(schannelRoles))
+
+	enabled := make(map[string]bool, len(states))
+	for _, state := range states {
+		enabled[state.protocol+" "+state.role] = state.enabled
+	}
+
+	require.False(t, enabled["TLS 1.0 Server"])
+	require.True(t, enabled["TLS 1.0 Client"])
+	require.True(t, enabled["SSL 3.0 Client"])
+	require.False(t, enabled["SSL 3.0 Server"])
+	require.True(t, enabled["TLS 1.2 Server"])
+	require.False(t, enabled["TLS 1.3 Server"])
+
+	states, err = readSchannelProtocols(readDWORD, 20348)
+	require.NoError(t, err)
+
+	for _, state := range states {
+		if state.protocol == "TLS 1.3" {
+			require.True(t, state.enabled)
+		}
+	}
+}
+
+func TestReadSchannelProtocolsError(t *testing.T) {
+	t.Parallel()
+
+	_, err := readSchannelProtocols(func(string, string) (uint64, error) {
+		return 0, registry.ErrUnexpectedType
+	}, 20348)
+	require.ErrorIs(t, err, registry.ErrUnexpectedType)
+}
@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package iis
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// sslFlagSNI is the SNI bit of the sslFlags attribute of an IIS binding.
+//
+// https://learn.microsoft.com/en-us/iis/configuration/system.applicationhost/sites/site/bindings/binding
+const sslFlagSNI = 1
+
+type collectorSiteBindings struct {
+	applicationHostConfigPath string
+
+	// applicationHostConfigMu guards the parsed applicationHost.config, which is parsed again
+	// only if the modification time of the file changed.
+	applicationHostConfigMu      sync.Mutex
+	applicationHostConfig        applicationHostConfig
+	applicationHostConfigModTime time.Time
+	applicationHostConfigMissing bool
+
+	siteBindingInfo *prometheus.Desc
+	siteHSTSEnabled *prometheus.Desc
+}
+
+// applicationHostConfig is the subset of applicationHost.config read by the site bindings collector.
+type applicationHostConfig struct {
+	SiteDefaults iisSiteDefaults `xml:"system.applicationHost>sites>siteDefaults"`
+	Sites        []iisSite       `xml:"system.applicationHost>sites>site"`
+}
+
+type iisSiteDefaults struct {
+	HSTS *iisHSTS `xml:"hsts"`
+}
+
+type iisSite struct {
+	Name     string       `xml:"name,attr"`
+	Bindings []iisBinding `xml:"bindings>binding"`
+	HSTS     *iisHSTS     `xml:"hsts"`
+}
+
+type iisBinding struct {
+	Protocol           string `xml:"protocol,attr"`
+	BindingInformation string `xml:"bindingInformation,attr"`
+	SSLFlags           uint32 `xml:"sslFlags,attr"`
+}
+
+type iisHSTS struct {
+	Enabled bool `xml:"enabled,attr"`
+}
+
+func (c *Collector) buildSiteBindings() {
+	c.applicationHostConfigPath = filepath.Join(os.Getenv("WINDIR"), "System32", "inetsrv", "config", "applicationHost.config")
+
+	c.siteBindingInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "site_binding_info"),
+		"IIS site bindings from applicationHost.config",
+		[]string{"site", "binding", "protocol", "sni_enabled"},
+		nil,
+	)
+	c.siteHSTSEnabled = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "site_hsts_enabled"),
+		"Whether HTTP Strict Transport Security is enabled for the IIS site",
+		[]string{"site"},
+		nil,
+	)
+}
+
+func (c *Collector) collectSiteBindings(ch chan<- prometheus.Metric) error {
+	config, ok, err := c.loadApplicationHostConfig()
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return nil
+	}
+
+	for _, site := range config.Sites {
+		if c.config.SiteExclude.MatchString(site.Name) || !c.config.SiteInclude.MatchString(site.Name) {
+			continue
+		}
+
+		for _, binding := range site.Bindings {
+			ch <- prometheus.MustNewConstMetric(
+				c.siteBindingInfo,
+				prometheus.GaugeValue,
+				1,
+				site.Name,
+				binding.BindingInformation,
+				binding.Protocol,
+				strconv.FormatBool(binding.SSLFlags&sslFlagSNI != 0),
+			)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.siteHSTSEnabled,
+			prometheus.GaugeValue,
+			utils.BoolToFloat(site.hstsEnabled(config.SiteDefaults)),
+			site.Name,
+		)
+	}
+
+	return nil
+}
+
+// loadApplicationHostConfig returns the parsed applicationHost.config. The file is parsed again,
+// once its modification time changed. ok is false, if the file does not exist, e.g. if IIS is not installed.
+func (c *Collector) loadApplicationHostConfig() (applicationHostConfig, bool, error) {
+	c.applicationHostConfigMu.Lock()
+	defer c.applicationHostConfigMu.Unlock()
+
+	info, err := os.Stat(c.applicationHostConfigPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		if !c.applicationHostConfigMissing {
+			c.logger.Warn("IIS configuration not found, skipping site binding metrics",
+				slog.String("path", c.applicationHostConfigPath),
+			)
+		}
+
+		c.applicationHostConfigMissing = true
+		c.applicationHostConfigModTime = time.Time{}
+
+		return applicationHostConfig{}, false, nil
+	}
+
+	if err != nil {
+		return applicationHostConfig{}, false, fmt.Errorf("failed to stat IIS configuration: %w", err)
+	}
+
+	c.applicationHostConfigMissing = false
+
+	if info.ModTime().Equal(c.applicationHostConfigModTime) {
+		return c.applicationHostConfig, true, nil
+	}
+
+	f, err := os.Open(c.applicationHostConfigPath)
+	if err != nil {
+		return applicationHostConfig{}, false, fmt.Errorf("failed to open IIS configuration: %w", err)
+	}
+
+	defer f.Close()
+
+	config, err := parseApplicationHostConfig(f)
+	if err != nil {
+		return applicationHostConfig{}, false, fmt.Errorf("failed to parse %s: %w", c.applicationHostConfigPath, err)
+	}
+
+	c.applicationHostConfig = config
+	c.applicationHostConfigModTime = info.ModTime()
+
+	return config, true, nil
+}
+
+func parseApplicationHostConfig(r io.Reader) (applicationHostConfig, error) {
+	var config applicationHostConfig
+
+	err := xml.NewDecoder(r).Decode(&config)
+
+	return config, err
+}
+
+// hstsEnabled returns the HSTS setting of the site, falling back to the site defaults.
+func (s iisSite) hstsEnabled(defaults iisSiteDefaults) bool {
+	if s.HSTS != nil {
+		return s.HSTS.Enabled
+	}
+
+	return defaults.HSTS != nil && defaults.HSTS.Enabled
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package iis
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testApplicationHostConfig = `<?xml version="1.0" encoding="UTF-8"?>
+<configuration>
+    <system.applicationHost>
+        <sites>
+            <site name="Default Web Site" id="1">
+                <bindings>
+                    <binding protocol="http" bindingInformation="*:80:" />
+                    <binding protocol="https" bindingInformation="*:443:www.example.com" sslFlags="1" />
+                </bindings>
+                <hsts enabled="true" max-age="31536000" />
+            </site>
+            <site name="Legacy" id="2">
+                <bindings>
+                    <binding protocol="https" bindingInformation="10.0.0.1:443:" sslFlags="0" />
+                </bindings>
+            </site>
+            <siteDefaults>
+                <hsts enabled="false" />
+            </siteDefaults>
+        </sites>
+    </system.applicationHost>
+</configuration>`
+
+func TestParseApplicationHostConfig(t *testing.T) {
+	t.Parallel()
+
+	config, err := parseApplicationHostConfig(strings.NewReader(testApplicationHostConfig))
+	require.NoError(t, err)
+	require.Len(t, config.Sites, 2)
+
+	site := config.Sites[0]
+	require.Equal(t, "Default Web Site", site.Name)
+	require.Equal(t, []iisBinding{
+		{Protocol: "http", BindingInformation: "*:80:"},
+		{Protocol: "https", BindingInformation: "*:443:www.example.com", SSLFlags: sslFlagSNI},
+	}, site.Bindings)
+	require.True(t, site.hstsEnabled(config.SiteDefaults))
+
+	site = config.Sites[1]
+	require.Equal(t, []iisBinding{{Protocol: "https", BindingInformation: "10.0.0.1:443:"}}, site.Bindings)
+	require.False(t, site.hstsEnabled(config.SiteDefaults))
+}
+
+func TestLoadApplicationHostConfig(t *testing.T) {
+	t.Parallel()
+
+	c := &Collector{logger: slog.New(slog.DiscardHandler)}
+	c.applicationHostConfigPath = filepath.Join(t.TempDir(), "applicationHost.config")
+
+	// A missing configuration is skipped.
+	_, ok, err := c.loadApplicationHostConfig()
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, os.WriteFile(c.applicationHostConfigPath, []byte(testApplicationHostConfig), 0o600))
+
+	config, ok, err := c.loadApplicationHostConfig()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, config.Sites, 2)
+
+	// The configuration is not parsed again, as long as the modification time is unchanged.
+	modTime := c.applicationHostConfigModTime
+
+	require.NoError(t, os.WriteFile(c.applicationHostConfigPath, []byte("<configuration />"), 0o600))
+	require.NoError(t, os.Chtimes(c.applicationHostConfigPath, modTime, modTime))
+
+	config, ok, err = c.loadApplicationHostConfig()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, config.Sites, 2)
+
+	modTime = modTime.Add(time.Second)
+	require.NoError(t, os.Chtimes(c.applicationHostConfigPath, modTime, modTime))
+
+	config, ok, err = c.loadApplicationHostConfig()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Empty(t, config.Sites)
+}
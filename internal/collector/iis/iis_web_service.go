@@ -51,6 +51,9 @@ type collectorWebService struct {
 	webServiceTotalNonAnonymousUsers              *prometheus.Desc
 	webServiceTotalNotFoundErrors                 *prometheus.Desc
 	webServiceTotalRejectedAsyncIORequests        *prometheus.Desc
+	webServiceCurrentWebSocketRequests            *prometheus.Desc
+	webServiceTotalWebSocketAttempts              *prometheus.Desc
+	webServiceTotalWebSocketFailedHandshakes      *prometheus.Desc
 }
 
 type perfDataCounterValuesWebService struct {
@@ -93,6 +96,11 @@ type perfDataCounterValuesWebService struct {
 	WebServiceTotalSearchRequests                 float64 `perfdata:"Total Search Requests"`
 	WebServiceTotalTraceRequests                  float64 `perfdata:"Total Trace Requests"`
 	WebServiceTotalUnlockRequests                 float64 `perfdata:"Total Unlock Requests"`
+
+	// WebSocket counters are only available, if the WebSocket Protocol feature is installed.
+	WebServiceCurrentWebSocketRequests       float64 `perfdata:"Current WebSocket Requests"      perfdata_optional:"true"`
+	WebServiceTotalWebSocketAttempts         float64 `perfdata:"WebSocket Attempts/sec"          perfdata_optional:"true"`
+	WebServiceTotalWebSocketFailedHandshakes float64 `perfdata:"WebSocket Failed Handshakes/sec" perfdata_optional:"true"`
 }
 
 func (p perfDataCounterValuesWebService) GetName() string {
@@ -239,6 +247,24 @@ func (c *Collector) buildWebService() error {
 		[]string{"site"},
 		nil,
 	)
+	c.webServiceCurrentWebSocketRequests = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "websocket_current_requests"),
+		"Current number of WebSocket requests being processed by the Web service (WebService.CurrentWebSocketRequests)",
+		[]string{"site"},
+		nil,
+	)
+	c.webServiceTotalWebSocketAttempts = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "websocket_connection_attempts_total"),
+		"Number of WebSocket connection attempts made to the Web service (WebService.WebSocketAttempts)",
+		[]string{"site"},
+		nil,
+	)
+	c.webServiceTotalWebSocketFailedHandshakes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "websocket_failed_handshakes_total"),
+		"Number of WebSocket upgrade handshakes that failed (WebService.WebSocketFailedHandshakes)",
+		[]string{"site"},
+		nil,
+	)
 
 	return nil
 }
@@ -530,7 +556,35 @@ func (c *Collector) collectWebService(ch chan<- prometheus.Metric) error {
 			data.Name,
 			"UNLOCK",
 		)
+
+		c.collectWebServiceWebSocket(ch, data)
 	}
 
 	return nil
 }
+
+// collectWebServiceWebSocket sends the WebSocket metrics of a site, if the counters are available.
+// Sites without WebSocket traffic report zero values.
+func (c *Collector) collectWebServiceWebSocket(ch chan<- prometheus.Metric, data perfDataCounterValuesWebService) {
+	for _, metric := range []struct {
+		counter   string
+		desc      *prometheus.Desc
+		valueType prometheus.ValueType
+		value     float64
+	}{
+		{"Current WebSocket Requests", c.webServiceCurrentWebSocketRequests, prometheus.GaugeValue, data.WebServiceCurrentWebSocketRequests},
+		{"WebSocket Attempts/sec", c.webServiceTotalWebSocketAttempts, prometheus.CounterValue, data.WebServiceTotalWebSocketAttempts},
+		{"WebSocket Failed Handshakes/sec", c.webServiceTotalWebSocketFailedHandshakes, prometheus.CounterValue, data.WebServiceTotalWebSocketFailedHandshakes},
+	} {
+		if !c.perfDataCollectorWebService.HasCounter(metric.counter) {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			metric.desc,
+			metric.valueType,
+			metric.value,
+			data.Name,
+		)
+	}
+}
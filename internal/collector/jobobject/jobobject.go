@@ -0,0 +1,339 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package jobobject
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/headers/kernel32"
+	"github.com/prometheus-community/windows_exporter/internal/headers/ntdll"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	Name = "jobobject"
+
+	// jobObjectMemoryUsageInformation is an undocumented information class of QueryInformationJobObject.
+	// https://github.com/microsoft/hcsshim/blob/bfb2a106798d3765666f6e39ec6cf0117275eab4/internal/jobobject/jobobject.go#L410
+	jobObjectMemoryUsageInformation = 28
+
+	// baseNamedObjects is the object manager directory of the global namespace.
+	baseNamedObjects = `\BaseNamedObjects`
+	globalPrefix     = `Global\`
+)
+
+type Config struct {
+	// Names are the names of the job objects to collect, as passed to OpenJobObject.
+	Names []string `yaml:"names"`
+	// NamePrefixes select job objects in the global namespace by name prefix.
+	NamePrefixes []string `yaml:"name-prefixes"`
+}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{
+	Names:        []string{},
+	NamePrefixes: []string{},
+}
+
+// A Collector is a Prometheus Collector for named Win32 job objects.
+type Collector struct {
+	config Config
+	logger *slog.Logger
+
+	activeProcesses    *prometheus.Desc
+	cpuTime            *prometheus.Desc
+	memoryLimit        *prometheus.Desc
+	memoryUsed         *prometheus.Desc
+	peakMemoryUsed     *prometheus.Desc
+	processMemoryLimit *prometheus.Desc
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	if config.Names == nil {
+		config.Names = ConfigDefaults.Names
+	}
+
+	if config.NamePrefixes == nil {
+		config.NamePrefixes = ConfigDefaults.NamePrefixes
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+
+	var names, namePrefixes string
+
+	app.Flag(
+		"collector.jobobject.names",
+		"Comma-separated list of job object names to collect, e.g. Global\\MyJob.",
+	).Default(strings.Join(ConfigDefaults.Names, ",")).StringVar(&names)
+
+	app.Flag(
+		"collector.jobobject.name-prefixes",
+		"Comma-separated list of name prefixes of job objects in the global namespace to collect. "+
+			"If neither names nor prefixes are configured, all named job objects in the global namespace are collected.",
+	).Default(strings.Join(ConfigDefaults.NamePrefixes, ",")).StringVar(&namePrefixes)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		if names != "" {
+			c.config.Names = strings.Split(names, ",")
+		}
+
+		if namePrefixes != "" {
+			c.config.NamePrefixes = strings.Split(namePrefixes, ",")
+		}
+
+		return nil
+	})
+
+	return c
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceAPI}
+}
+
+func (c *Collector) Close() error {
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+	c.logger = logger.With(slog.String("collector", Name))
+
+	c.activeProcesses = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "active_processes"),
+		"Number of processes currently associated with the job object",
+		[]string{"name"},
+		nil,
+	)
+	c.cpuTime = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "cpu_time_seconds_total"),
+		"CPU time used by all processes that ever belonged to the job object",
+		[]string{"name", "mode"},
+		nil,
+	)
+	c.memoryLimit = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "memory_limit_bytes"),
+		"Limit of the committed memory of all processes in the job object. Only present if a job memory limit is set",
+		[]string{"name"},
+		nil,
+	)
+	c.memoryUsed = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "memory_used_bytes"),
+		"Committed memory of all processes in the job object",
+		[]string{"name"},
+		nil,
+	)
+	c.peakMemoryUsed = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "peak_memory_used_bytes"),
+		"Peak committed memory of all processes in the job object",
+		[]string{"name"},
+		nil,
+	)
+	c.processMemoryLimit = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "process_memory_limit_bytes"),
+		"Limit of the committed memory of a single process in the job object. Only present if a process memory limit is set",
+		[]string{"name"},
+		nil,
+	)
+
+	return nil
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+	names, err := c.jobObjectNames()
+	if err != nil {
+		return fmt.Errorf("failed to enumerate job objects: %w", err)
+	}
+
+	errs := make([]error, 0)
+
+	for _, name := range names {
+		if err := c.collectJobObject(ch, name); err != nil {
+			errs = append(errs, fmt.Errorf("job object %s: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// jobObjectNames returns the configured job object names and the names
+// of all job objects in the global namespace matching a configured prefix.
+func (c *Collector) jobObjectNames() ([]string, error) {
+	names := slices.Clone(c.config.Names)
+
+	if len(c.config.Names) > 0 && len(c.config.NamePrefixes) == 0 {
+		return names, nil
+	}
+
+	globalNames, err := ntdll.QueryDirectoryObject(baseNamedObjects, "Job")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range globalNames {
+		if len(c.config.NamePrefixes) == 0 || slices.ContainsFunc(c.config.NamePrefixes, func(prefix string) bool {
+			return strings.HasPrefix(name, prefix)
+		}) {
+			names = append(names, globalPrefix+name)
+		}
+	}
+
+	slices.Sort(names)
+
+	return slices.Compact(names), nil
+}
+
+func (c *Collector) collectJobObject(ch chan<- prometheus.Metric, name string) error {
+	handle, err := kernel32.OpenJobObject(name)
+	if err != nil {
+		if errors.Is(err, windows.ERROR_FILE_NOT_FOUND) {
+			c.logger.Debug("job object does not exist",
+				slog.String("name", name),
+			)
+
+			return nil
+		}
+
+		return fmt.Errorf("failed to open job object: %w", err)
+	}
+
+	defer func(fd windows.Handle) {
+		_ = windows.Close(fd)
+	}(handle)
+
+	var accountingInfo kernel32.JobObjectBasicAccountingInformation
+
+	if err = windows.QueryInformationJobObject(
+		handle,
+		windows.JobObjectBasicAccountingInformation,
+		uintptr(unsafe.Pointer(&accountingInfo)),
+		uint32(unsafe.Sizeof(accountingInfo)),
+		nil,
+	); err != nil {
+		return fmt.Errorf("failed to query job object accounting information: %w", err)
+	}
+
+	var limitInfo windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION
+
+	if err = windows.QueryInformationJobObject(
+		handle,
+		windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&limitInfo)),
+		uint32(unsafe.Sizeof(limitInfo)),
+		nil,
+	); err != nil {
+		return fmt.Errorf("failed to query job object limit information: %w", err)
+	}
+
+	var memoryInfo kernel32.JobObjectMemoryUsageInformation
+
+	if err = windows.QueryInformationJobObject(
+		handle,
+		jobObjectMemoryUsageInformation,
+		uintptr(unsafe.Pointer(&memoryInfo)),
+		uint32(unsafe.Sizeof(memoryInfo)),
+		nil,
+	); err != nil {
+		return fmt.Errorf("failed to query job object memory usage information: %w", err)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.activeProcesses,
+		prometheus.GaugeValue,
+		float64(accountingInfo.ActiveProcesses),
+		name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.cpuTime,
+		prometheus.CounterValue,
+		float64(accountingInfo.TotalUserTime)*pdh.TicksToSecondScaleFactor,
+		name, "user",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.cpuTime,
+		prometheus.CounterValue,
+		float64(accountingInfo.TotalKernelTime)*pdh.TicksToSecondScaleFactor,
+		name, "kernel",
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.memoryUsed,
+		prometheus.GaugeValue,
+		float64(memoryInfo.JobMemory),
+		name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.peakMemoryUsed,
+		prometheus.GaugeValue,
+		float64(limitInfo.PeakJobMemoryUsed),
+		name,
+	)
+
+	if limitInfo.BasicLimitInformation.LimitFlags&windows.JOB_OBJECT_LIMIT_JOB_MEMORY != 0 {
+		ch <- prometheus.MustNewConstMetric(
+			c.memoryLimit,
+			prometheus.GaugeValue,
+			float64(limitInfo.JobMemoryLimit),
+			name,
+		)
+	}
+
+	if limitInfo.BasicLimitInformation.LimitFlags&windows.JOB_OBJECT_LIMIT_PROCESS_MEMORY != 0 {
+		ch <- prometheus.MustNewConstMetric(
+			c.processMemoryLimit,
+			prometheus.GaugeValue,
+			float64(limitInfo.ProcessMemoryLimit),
+			name,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package listener_certificate
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // certificate thumbprints are SHA-1 hashes
+	"crypto/x509"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// certificate is a certificate of the machine certificate store.
+type certificate struct {
+	thumbprint string
+	notAfter   time.Time
+	selfSigned bool
+}
+
+// parseCertificate parses a DER encoded certificate.
+// The thumbprint is the upper-case hex encoded SHA-1 hash of the certificate, as shown by certlm.msc.
+func parseCertificate(der []byte) (certificate, error) {
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return certificate{}, err
+	}
+
+	thumbprint := sha1.Sum(der) //nolint:gosec
+
+	return certificate{
+		thumbprint: strings.ToUpper(hex.EncodeToString(thumbprint[:])),
+		notAfter:   cert.NotAfter,
+		selfSigned: bytes.Equal(cert.RawIssuer, cert.RawSubject),
+	}, nil
+}
+
+// normalizeThumbprint removes all characters except hex digits and converts the thumbprint to upper case.
+// Thumbprints copied from the certificate dialog often contain spaces and invisible control characters.
+func normalizeThumbprint(thumbprint string) string {
+	return strings.ToUpper(strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9', r >= 'a' && r <= 'f', r >= 'A' && r <= 'F':
+			return r
+		default:
+			return -1
+		}
+	}, thumbprint))
+}
+
+// findCertificate returns the certificate with the given thumbprint.
+func findCertificate(certificates []certificate, thumbprint string) (certificate, bool) {
+	thumbprint = normalizeThumbprint(thumbprint)
+
+	for _, cert := range certificates {
+		if cert.thumbprint == thumbprint {
+			return cert, true
+		}
+	}
+
+	return certificate{}, false
+}
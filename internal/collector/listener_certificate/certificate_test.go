@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package listener_certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createCertificate(t *testing.T, subject string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) ([]byte, *x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: subject},
+		NotBefore:    time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return der, cert, key
+}
+
+func TestParseCertificate(t *testing.T) {
+	t.Parallel()
+
+	caDER, ca, caKey := createCertificate(t, "ca", nil, nil)
+	leafDER, _, _ := createCertificate(t, "host.example.com", ca, caKey)
+
+	selfSigned, err := parseCertificate(caDER)
+	require.NoError(t, err)
+	require.True(t, selfSigned.selfSigned)
+	require.Len(t, selfSigned.thumbprint, 40)
+	require.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), selfSigned.notAfter)
+
+	leaf, err := parseCertificate(leafDER)
+	require.NoError(t, err)
+	require.False(t, leaf.selfSigned)
+
+	_, err = parseCertificate([]byte("invalid"))
+	require.Error(t, err)
+}
+
+func TestFindCertificate(t *testing.T) {
+	t.Parallel()
+
+	certificates := []certificate{
+		{thumbprint: "0123456789ABCDEF0123456789ABCDEF01234567"},
+		{thumbprint: "FEDCBA9876543210FEDCBA9876543210FEDCBA98"},
+	}
+
+	// Thumbprints copied from the certificate dialog contain spaces and a leading left-to-right mark.
+	cert, ok := findCertificate(certificates, "‎fe dc ba 98 76 54 32 10 fe dc ba 98 76 54 32 10 fe dc ba 98")
+	require.True(t, ok)
+	require.Equal(t, certificates[1], cert)
+
+	_, ok = findCertificate(certificates, "00")
+	require.False(t, ok)
+}
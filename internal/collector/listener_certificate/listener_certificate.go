@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package listener_certificate
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Name = "listener_certificate"
+
+type Config struct{}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{}
+
+// A Collector is a Prometheus Collector for the certificates bound to the RDP and WinRM listeners.
+type Collector struct {
+	config    Config
+	logger    *slog.Logger
+	miSession *mi.Session
+	miQuery   mi.Query
+
+	rdpCertificateExpiry   *prometheus.Desc
+	winRMCertificateExpiry *prometheus.Desc
+}
+
+// win32TSGeneralSetting is the RDP listener configuration.
+// https://learn.microsoft.com/en-us/windows/win32/termserv/win32-tsgeneralsetting
+type win32TSGeneralSetting struct {
+	TerminalName           string `mi:"TerminalName"`
+	SSLCertificateSHA1Hash string `mi:"SSLCertificateSHA1Hash"`
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(_ *kingpin.Application) *Collector {
+	return &Collector{}
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceWMI, types.SourceAPI}
+}
+
+func (c *Collector) Close() error {
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
+	c.logger = logger.With(slog.String("collector", Name))
+
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	miQuery, err := mi.NewQuery("SELECT TerminalName, SSLCertificateSHA1Hash FROM Win32_TSGeneralSetting")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQuery = miQuery
+	c.miSession = miSession
+
+	c.rdpCertificateExpiry = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, "rdp", "certificate_expiry_timestamp_seconds"),
+		"Expiry of the certificate bound to the RDP listener as unix timestamp",
+		[]string{"terminal", "thumbprint", "self_signed"},
+		nil,
+	)
+	c.winRMCertificateExpiry = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, "winrm", "certificate_expiry_timestamp_seconds"),
+		"Expiry of the certificate bound to the WinRM HTTPS listener as unix timestamp",
+		[]string{"listener", "thumbprint"},
+		nil,
+	)
+
+	return nil
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	var (
+		wmiDuration, apiDuration time.Duration
+		errs                     []error
+	)
+
+	startTime := time.Now()
+
+	certificates, err := loadMachineStore(storeMy)
+	if err != nil {
+		return err
+	}
+
+	// Self-signed RDP certificates are generated into the Remote Desktop store,
+	// which does not exist on hosts that never accepted an RDP connection.
+	if remoteDesktopCertificates, err := loadMachineStore(storeRemoteDesktop); err == nil {
+		certificates = append(certificates, remoteDesktopCertificates...)
+	} else {
+		c.logger.Debug("failed to load Remote Desktop certificate store",
+			slog.Any("err", err),
+		)
+	}
+
+	apiDuration = time.Since(startTime)
+	startTime = time.Now()
+
+	if err = c.collectRDP(ch, certificates, maxScrapeDuration); err != nil {
+		errs = append(errs, err)
+	}
+
+	wmiDuration = time.Since(startTime)
+	startTime = time.Now()
+
+	if err = c.collectWinRM(ch, certificates); err != nil {
+		errs = append(errs, err)
+	}
+
+	apiDuration += time.Since(startTime)
+
+	ch <- types.NewSourceDurationMetric(Name, types.SourceWMI, wmiDuration)
+	ch <- types.NewSourceDurationMetric(Name, types.SourceAPI, apiDuration)
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) collectRDP(ch chan<- prometheus.Metric, certificates []certificate, maxScrapeDuration time.Duration) error {
+	var dst []win32TSGeneralSetting
+	if err := c.miSession.Query(&dst, mi.NamespaceRootTerminalServices, c.miQuery, maxScrapeDuration); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	for _, setting := range dst {
+		if setting.SSLCertificateSHA1Hash == "" {
+			continue
+		}
+
+		cert, ok := findCertificate(certificates, setting.SSLCertificateSHA1Hash)
+		if !ok {
+			c.logger.Debug("RDP certificate not found in the machine store",
+				slog.String("terminal", setting.TerminalName),
+				slog.String("thumbprint", setting.SSLCertificateSHA1Hash),
+			)
+
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.rdpCertificateExpiry,
+			prometheus.GaugeValue,
+			float64(cert.notAfter.Unix()),
+			setting.TerminalName,
+			cert.thumbprint,
+			strconv.FormatBool(cert.selfSigned),
+		)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectWinRM(ch chan<- prometheus.Metric, certificates []certificate) error {
+	listeners, err := getWinRMListeners()
+	if err != nil {
+		return err
+	}
+
+	for _, listener := range listeners {
+		cert, ok := findCertificate(certificates, listener.thumbprint)
+		if !ok {
+			c.logger.Debug("WinRM certificate not found in the machine store",
+				slog.String("listener", listener.name),
+				slog.String("thumbprint", listener.thumbprint),
+			)
+
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.winRMCertificateExpiry,
+			prometheus.GaugeValue,
+			float64(cert.notAfter.Unix()),
+			listener.name,
+			cert.thumbprint,
+		)
+	}
+
+	return nil
+}
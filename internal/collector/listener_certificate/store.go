@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package listener_certificate
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+const (
+	storeMy            = "My"
+	storeRemoteDesktop = "Remote Desktop"
+
+	// winRMListenerKey contains one subkey per WinRM listener, e.g. *+HTTPS.
+	winRMListenerKey = `SOFTWARE\Microsoft\Windows\CurrentVersion\WSMAN\Listener`
+)
+
+// winRMListener is a WinRM listener with a certificate.
+type winRMListener struct {
+	name       string
+	thumbprint string
+}
+
+// loadMachineStore returns all certificates of the given local machine certificate store.
+func loadMachineStore(name string) ([]certificate, error) {
+	storeName, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := windows.CertOpenStore(
+		windows.CERT_STORE_PROV_SYSTEM,
+		0,
+		0,
+		windows.CERT_SYSTEM_STORE_LOCAL_MACHINE|windows.CERT_STORE_READONLY_FLAG|windows.CERT_STORE_OPEN_EXISTING_FLAG,
+		uintptr(unsafe.Pointer(storeName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open certificate store %s: %w", name, err)
+	}
+
+	defer func(store windows.Handle) {
+		_ = windows.CertCloseStore(store, 0)
+	}(store)
+
+	var (
+		certificates []certificate
+		certContext  *windows.CertContext
+	)
+
+	for {
+		// CertEnumCertificatesInStore frees the previous context.
+		certContext, err = windows.CertEnumCertificatesInStore(store, certContext)
+		if err != nil {
+			if errors.Is(err, windows.Errno(windows.CRYPT_E_NOT_FOUND)) || errors.Is(err, windows.ERROR_NO_MORE_FILES) {
+				return certificates, nil
+			}
+
+			return nil, fmt.Errorf("failed to enumerate certificate store %s: %w", name, err)
+		}
+
+		cert, err := parseCertificate(unsafe.Slice(certContext.EncodedCert, certContext.Length))
+		if err != nil {
+			continue
+		}
+
+		certificates = append(certificates, cert)
+	}
+}
+
+// getWinRMListeners returns the HTTPS listeners of WinRM with their certificate thumbprint.
+func getWinRMListeners() ([]winRMListener, error) {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, winRMListenerKey, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to open WinRM listener key: %w", err)
+	}
+
+	defer key.Close()
+
+	names, err := key.ReadSubKeyNames(-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read WinRM listeners: %w", err)
+	}
+
+	listeners := make([]winRMListener, 0, len(names))
+
+	for _, name := range names {
+		listenerKey, err := registry.OpenKey(key, name, registry.QUERY_VALUE)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open WinRM listener %s: %w", name, err)
+		}
+
+		thumbprint, _, err := listenerKey.GetStringValue("certThumbprint")
+		_ = listenerKey.Close()
+
+		// HTTP listeners have no certificate.
+		if errors.Is(err, registry.ErrNotExist) || thumbprint == "" {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read certificate of WinRM listener %s: %w", name, err)
+		}
+
+		listeners = append(listeners, winRMListener{name: name, thumbprint: thumbprint})
+	}
+
+	return listeners, nil
+}
@@ -0,0 +1,554 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/go-ole/go-ole"
+	"github.com/go-ole/go-ole/oleutil"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// bitlockerRequest is a request to the BitLocker workers for a single volume.
+// resCh is buffered, so a worker never blocks on a request which already timed out.
+type bitlockerRequest struct {
+	path     string
+	deadline time.Time
+	resCh    chan bitlockerResult
+}
+
+// bitlockerResult is the response of the BitLocker worker for a single volume.
+type bitlockerResult struct {
+	err    error
+	status int
+	// encryptionPercent is NaN if the encryption percentage is unknown.
+	encryptionPercent float64
+	// encryptionMethod is the readable name of the encryption method, or empty if unknown.
+	// Only available if the exporter runs elevated.
+	encryptionMethod string
+	// keyProtectors are the distinct key protector types of the volume.
+	// Only available if the exporter runs elevated.
+	keyProtectors []string
+}
+
+// keyProtectorTypes maps the KeyProtectorType of Win32_EncryptableVolume to a readable name.
+//
+// https://learn.microsoft.com/en-us/windows/win32/secprov/getkeyprotectortype-win32-encryptablevolume
+//
+//nolint:gochecknoglobals
+var keyProtectorTypes = map[uint32]string{
+	0:  "unknown",
+	1:  "tpm",
+	2:  "external_key",
+	3:  "recovery_password",
+	4:  "tpm_pin",
+	5:  "tpm_startup_key",
+	6:  "tpm_pin_startup_key",
+	7:  "public_key",
+	8:  "password",
+	9:  "tpm_certificate",
+	10: "ad_account",
+}
+
+// encryptionMethods maps the EncryptionMethod of Win32_EncryptableVolume to a readable name.
+//
+// https://learn.microsoft.com/en-us/windows/win32/secprov/getencryptionmethod-win32-encryptablevolume
+//
+//nolint:gochecknoglobals
+var encryptionMethods = map[uint32]string{
+	0: "none",
+	1: "aes_128_diffuser",
+	2: "aes_256_diffuser",
+	3: "aes_128",
+	4: "aes_256",
+	5: "hardware_encryption",
+	6: "xts_aes_128",
+	7: "xts_aes_256",
+}
+
+var (
+	errEncryptableVolumeNotFound = errors.New("volume not found in Win32_EncryptableVolume")
+	errBitlockerTimeout          = errors.New("BitLocker status query timed out")
+)
+
+// queryBitlocker requests the BitLocker status of all volumes from the worker pool and waits
+// for the results until the BitLocker timeout is reached. The results are in the order of volumes.
+func (c *Collector) queryBitlocker(volumes []string) []bitlockerResult {
+	deadline := time.Now().Add(c.config.BitlockerTimeout)
+
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	requests := make([]bitlockerRequest, len(volumes))
+
+	for i, volume := range volumes {
+		requests[i] = bitlockerRequest{
+			path:     volume,
+			deadline: deadline,
+			resCh:    make(chan bitlockerResult, 1),
+		}
+	}
+
+	go func() {
+		for _, request := range requests {
+			select {
+			case c.bitlockerReqCh <- request:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make([]bitlockerResult, len(requests))
+
+	for i, request := range requests {
+		select {
+		case results[i] = <-request.resCh:
+		case <-ctx.Done():
+			// Prefer a result which arrived at the same time as the deadline.
+			select {
+			case results[i] = <-request.resCh:
+			default:
+				results[i] = bitlockerResult{
+					err:               fmt.Errorf("%w after %s", errBitlockerTimeout, c.config.BitlockerTimeout),
+					status:            -1,
+					encryptionPercent: math.NaN(),
+				}
+			}
+		}
+	}
+
+	return results
+}
+
+// bitlockerCacheEntry is the last successful BitLocker status query of a volume.
+type bitlockerCacheEntry struct {
+	result  bitlockerResult
+	updated time.Time
+}
+
+// cachedBitlocker returns the BitLocker status of the given volumes. Only volumes without a cached result
+// or with a cached result older than the BitLocker cache TTL are queried. Cached results of volumes,
+// which no longer exist, are dropped.
+func (c *Collector) cachedBitlocker(volumes []string) []bitlockerResult {
+	now := time.Now()
+	results := make([]bitlockerResult, len(volumes))
+	staleVolumes := make([]string, 0, len(volumes))
+	staleIndexes := make([]int, 0, len(volumes))
+
+	for i, volume := range volumes {
+		if entry, ok := c.bitlockerCache[volume]; ok && now.Sub(entry.updated) < c.config.BitlockerCacheTTL {
+			results[i] = entry.result
+
+			continue
+		}
+
+		staleVolumes = append(staleVolumes, volume)
+		staleIndexes = append(staleIndexes, i)
+	}
+
+	if len(staleVolumes) > 0 {
+		for i, result := range c.queryBitlocker(staleVolumes) {
+			results[staleIndexes[i]] = result
+
+			if result.err == nil && c.config.BitlockerCacheTTL > 0 {
+				c.bitlockerCache[staleVolumes[i]] = bitlockerCacheEntry{result: result, updated: now}
+			}
+		}
+	}
+
+	for volume := range c.bitlockerCache {
+		if !slices.Contains(volumes, volume) {
+			delete(c.bitlockerCache, volume)
+		}
+	}
+
+	return results
+}
+
+// collectBitlocker sends the BitLocker metrics of the given volumes.
+// If the status of a volume can't be queried, the last cached status is sent, so that the series has no gaps.
+func (c *Collector) collectBitlocker(ch chan<- prometheus.Metric, volumes []string) {
+	for i, result := range c.cachedBitlocker(volumes) {
+		volume := volumes[i]
+
+		if result.err != nil {
+			c.bitlockerQueryFailures++
+
+			c.logger.Warn("failed to get BitLocker status for "+volume,
+				slog.Any("err", result.err),
+			)
+
+			entry, ok := c.bitlockerCache[volume]
+			if !ok {
+				continue
+			}
+
+			result = entry.result
+		}
+
+		if result.status == -1 {
+			c.logger.Debug("BitLocker status for "+volume+" is unknown",
+				slog.Int("status", result.status),
+			)
+
+			continue
+		}
+
+		if !math.IsNaN(result.encryptionPercent) {
+			ch <- prometheus.MustNewConstMetric(
+				c.bitlockerEncryptionRatio,
+				prometheus.GaugeValue,
+				result.encryptionPercent/100,
+				volume,
+			)
+		}
+
+		for i, status := range []string{"disabled", "on", "off", "encrypting", "decrypting", "suspended", "locked", "unknown", "waiting_for_activation"} {
+			val := 0.0
+			if result.status == i {
+				val = 1.0
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.bitlockerStatus,
+				prometheus.GaugeValue,
+				val,
+				volume,
+				status,
+			)
+		}
+
+		if result.encryptionMethod != "" {
+			ch <- prometheus.MustNewConstMetric(
+				c.bitlockerEncryptionMethod,
+				prometheus.GaugeValue,
+				1,
+				volume,
+				result.encryptionMethod,
+			)
+		}
+
+		for _, protectorType := range result.keyProtectors {
+			ch <- prometheus.MustNewConstMetric(
+				c.bitlockerKeyProtector,
+				prometheus.GaugeValue,
+				1,
+				volume,
+				protectorType,
+			)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.bitlockerQueryFailuresTotal,
+		prometheus.CounterValue,
+		c.bitlockerQueryFailures,
+	)
+}
+
+// connectEncryptableVolumeWMI connects to the WMI namespace of Win32_EncryptableVolume.
+// The namespace is only accessible by elevated processes.
+// Must be called from a thread with an initialized COM apartment.
+func connectEncryptableVolumeWMI() (*ole.IDispatch, error) {
+	locator, err := oleutil.CreateObject("WbemScripting.SWbemLocator")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SWbemLocator: %w", err)
+	}
+
+	defer locator.Release()
+
+	locatorDispatch, err := locator.QueryInterface(ole.IID_IDispatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query SWbemLocator interface: %w", err)
+	}
+
+	defer locatorDispatch.Release()
+
+	serviceRaw, err := oleutil.CallMethod(locatorDispatch, "ConnectServer", ".", `root\CIMV2\Security\MicrosoftVolumeEncryption`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to WMI namespace: %w", err)
+	}
+
+	return serviceRaw.ToIDispatch(), nil
+}
+
+// getEncryptableVolume returns the Win32_EncryptableVolume instance of the volume with the given drive letter.
+// The caller must release the returned instance.
+func getEncryptableVolume(service *ole.IDispatch, driveLetter string) (*ole.IDispatch, error) {
+	resultRaw, err := oleutil.CallMethod(service, "ExecQuery",
+		fmt.Sprintf("SELECT * FROM Win32_EncryptableVolume WHERE DriveLetter = '%s'", driveLetter),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Win32_EncryptableVolume: %w", err)
+	}
+
+	result := resultRaw.ToIDispatch()
+	defer result.Release()
+
+	countRaw, err := oleutil.GetProperty(result, "Count")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get result count: %w", err)
+	}
+
+	if countRaw.Val == 0 {
+		return nil, errEncryptableVolumeNotFound
+	}
+
+	volumeRaw, err := oleutil.CallMethod(result, "ItemIndex", 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Win32_EncryptableVolume instance: %w", err)
+	}
+
+	return volumeRaw.ToIDispatch(), nil
+}
+
+// getEncryptionPercentage returns the encryption percentage of the volume
+// using Win32_EncryptableVolume.GetConversionStatus.
+//
+// https://learn.microsoft.com/en-us/windows/win32/secprov/getconversionstatus-win32-encryptablevolume
+func getEncryptionPercentage(volume *ole.IDispatch) (float64, error) {
+	outParamsRaw, err := oleutil.CallMethod(volume, "ExecMethod_", "GetConversionStatus")
+	if err != nil {
+		return 0, fmt.Errorf("failed to call GetConversionStatus: %w", err)
+	}
+
+	outParams := outParamsRaw.ToIDispatch()
+	defer outParams.Release()
+
+	returnValue, err := oleutil.GetProperty(outParams, "ReturnValue")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get GetConversionStatus return value: %w", err)
+	}
+
+	if returnValue.Val != 0 {
+		return 0, fmt.Errorf("GetConversionStatus failed with 0x%08X", uint32(returnValue.Val))
+	}
+
+	encryptionPercentage, err := oleutil.GetProperty(outParams, "EncryptionPercentage")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get EncryptionPercentage: %w", err)
+	}
+
+	return float64(encryptionPercentage.Val), nil
+}
+
+// getEncryptionMethod returns the readable name of the encryption method of the volume
+// using Win32_EncryptableVolume.GetEncryptionMethod.
+//
+// https://learn.microsoft.com/en-us/windows/win32/secprov/getencryptionmethod-win32-encryptablevolume
+func getEncryptionMethod(volume *ole.IDispatch) (string, error) {
+	outParamsRaw, err := oleutil.CallMethod(volume, "ExecMethod_", "GetEncryptionMethod")
+	if err != nil {
+		return "", fmt.Errorf("failed to call GetEncryptionMethod: %w", err)
+	}
+
+	outParams := outParamsRaw.ToIDispatch()
+	defer outParams.Release()
+
+	returnValue, err := oleutil.GetProperty(outParams, "ReturnValue")
+	if err != nil {
+		return "", fmt.Errorf("failed to get GetEncryptionMethod return value: %w", err)
+	}
+
+	if returnValue.Val != 0 {
+		return "", fmt.Errorf("GetEncryptionMethod failed with 0x%08X", uint32(returnValue.Val))
+	}
+
+	encryptionMethod, err := oleutil.GetProperty(outParams, "EncryptionMethod")
+	if err != nil {
+		return "", fmt.Errorf("failed to get EncryptionMethod: %w", err)
+	}
+
+	return encryptionMethodName(uint32(encryptionMethod.Val)), nil
+}
+
+// encryptionMethodName returns the readable name of the given EncryptionMethod.
+func encryptionMethodName(method uint32) string {
+	if name, ok := encryptionMethods[method]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// getKeyProtectors returns the distinct key protector types of the volume
+// using Win32_EncryptableVolume.GetKeyProtectors and GetKeyProtectorType.
+//
+// https://learn.microsoft.com/en-us/windows/win32/secprov/getkeyprotectors-win32-encryptablevolume
+func getKeyProtectors(volume *ole.IDispatch) ([]string, error) {
+	outParamsRaw, err := oleutil.CallMethod(volume, "ExecMethod_", "GetKeyProtectors")
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GetKeyProtectors: %w", err)
+	}
+
+	outParams := outParamsRaw.ToIDispatch()
+	defer outParams.Release()
+
+	returnValue, err := oleutil.GetProperty(outParams, "ReturnValue")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GetKeyProtectors return value: %w", err)
+	}
+
+	if returnValue.Val != 0 {
+		return nil, fmt.Errorf("GetKeyProtectors failed with 0x%08X", uint32(returnValue.Val))
+	}
+
+	protectorIDsRaw, err := oleutil.GetProperty(outParams, "VolumeKeyProtectorID")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VolumeKeyProtectorID: %w", err)
+	}
+
+	defer func() {
+		_ = protectorIDsRaw.Clear()
+	}()
+
+	// Volumes without key protectors return NULL instead of an empty array.
+	if protectorIDsRaw.VT&ole.VT_ARRAY == 0 {
+		return []string{}, nil
+	}
+
+	inParamsDefinition, err := getMethodInParameters(volume, "GetKeyProtectorType")
+	if err != nil {
+		return nil, err
+	}
+
+	defer inParamsDefinition.Release()
+
+	protectorTypes := make([]uint32, 0)
+
+	for _, protectorID := range protectorIDsRaw.ToArray().ToStringArray() {
+		protectorType, err := getKeyProtectorType(volume, inParamsDefinition, protectorID)
+		if err != nil {
+			return nil, err
+		}
+
+		protectorTypes = append(protectorTypes, protectorType)
+	}
+
+	return keyProtectorNames(protectorTypes), nil
+}
+
+// getMethodInParameters returns the input parameters definition of the given WMI method.
+// The caller must release the returned object.
+func getMethodInParameters(object *ole.IDispatch, method string) (*ole.IDispatch, error) {
+	methodsRaw, err := oleutil.GetProperty(object, "Methods_")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get methods: %w", err)
+	}
+
+	methods := methodsRaw.ToIDispatch()
+	defer methods.Release()
+
+	methodRaw, err := oleutil.CallMethod(methods, "Item", method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get method %s: %w", method, err)
+	}
+
+	methodDispatch := methodRaw.ToIDispatch()
+	defer methodDispatch.Release()
+
+	inParamsRaw, err := oleutil.GetProperty(methodDispatch, "InParameters")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get input parameters of %s: %w", method, err)
+	}
+
+	return inParamsRaw.ToIDispatch(), nil
+}
+
+// getKeyProtectorType returns the type of the given key protector
+// using Win32_EncryptableVolume.GetKeyProtectorType.
+//
+// https://learn.microsoft.com/en-us/windows/win32/secprov/getkeyprotectortype-win32-encryptablevolume
+func getKeyProtectorType(volume, inParamsDefinition *ole.IDispatch, protectorID string) (uint32, error) {
+	inParamsRaw, err := oleutil.CallMethod(inParamsDefinition, "SpawnInstance_")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create GetKeyProtectorType input parameters: %w", err)
+	}
+
+	inParams := inParamsRaw.ToIDispatch()
+	defer inParams.Release()
+
+	if _, err = oleutil.PutProperty(inParams, "VolumeKeyProtectorID", protectorID); err != nil {
+		return 0, fmt.Errorf("failed to set VolumeKeyProtectorID: %w", err)
+	}
+
+	outParamsRaw, err := oleutil.CallMethod(volume, "ExecMethod_", "GetKeyProtectorType", inParams)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call GetKeyProtectorType: %w", err)
+	}
+
+	outParams := outParamsRaw.ToIDispatch()
+	defer outParams.Release()
+
+	returnValue, err := oleutil.GetProperty(outParams, "ReturnValue")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get GetKeyProtectorType return value: %w", err)
+	}
+
+	if returnValue.Val != 0 {
+		return 0, fmt.Errorf("GetKeyProtectorType failed with 0x%08X", uint32(returnValue.Val))
+	}
+
+	protectorType, err := oleutil.GetProperty(outParams, "KeyProtectorType")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get KeyProtectorType: %w", err)
+	}
+
+	return uint32(protectorType.Val), nil
+}
+
+// keyProtectorNames returns the sorted, distinct names of the given key protector types.
+// A volume may have multiple key protectors of the same type, e.g. multiple recovery passwords.
+func keyProtectorNames(protectorTypes []uint32) []string {
+	names := make([]string, 0, len(protectorTypes))
+
+	for _, protectorType := range protectorTypes {
+		name, ok := keyProtectorTypes[protectorType]
+		if !ok {
+			name = "unknown"
+		}
+
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return slices.Compact(names)
+}
+
+// encryptionPercentFromStatus derives the encryption percentage from the
+// System.Volume.BitLockerProtection shell property, if the volume is either
+// fully encrypted or fully decrypted. NaN is returned for volumes in conversion.
+func encryptionPercentFromStatus(status int) float64 {
+	switch status {
+	case 1, 5, 8: // on, suspended, waiting for activation
+		return 100
+	case 2: // off
+		return 0
+	default:
+		return math.NaN()
+	}
+}
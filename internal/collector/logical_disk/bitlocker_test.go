@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptionPercentFromStatus(t *testing.T) {
+	t.Parallel()
+
+	require.InDelta(t, 100.0, encryptionPercentFromStatus(1), 0)
+	require.InDelta(t, 100.0, encryptionPercentFromStatus(5), 0)
+	require.InDelta(t, 0.0, encryptionPercentFromStatus(2), 0)
+	require.True(t, math.IsNaN(encryptionPercentFromStatus(3)))
+	require.True(t, math.IsNaN(encryptionPercentFromStatus(4)))
+	require.True(t, math.IsNaN(encryptionPercentFromStatus(6)))
+}
+
+func TestQueryBitlocker(t *testing.T) {
+	t.Parallel()
+
+	c := &Collector{
+		config:         Config{BitlockerTimeout: 200 * time.Millisecond},
+		bitlockerReqCh: make(chan bitlockerRequest),
+	}
+
+	// Two workers. The request for E: hangs and must not block the other volumes.
+	for range 2 {
+		go func() {
+			for request := range c.bitlockerReqCh {
+				switch request.path {
+				case "C:":
+					request.resCh <- bitlockerResult{status: 1, encryptionPercent: 100}
+				case "D:":
+					request.resCh <- bitlockerResult{status: 2, encryptionPercent: 0}
+				}
+			}
+		}()
+	}
+
+	results := c.queryBitlocker([]string{"E:", "C:", "D:"})
+
+	require.Len(t, results, 3)
+	require.ErrorIs(t, results[0].err, errBitlockerTimeout)
+	require.NoError(t, results[1].err)
+	require.Equal(t, 1, results[1].status)
+	require.NoError(t, results[2].err)
+	require.Equal(t, 2, results[2].status)
+}
+
+func TestCachedBitlocker(t *testing.T) {
+	t.Parallel()
+
+	c := &Collector{
+		config: Config{
+			BitlockerTimeout:  200 * time.Millisecond,
+			BitlockerCacheTTL: time.Hour,
+		},
+		bitlockerReqCh: make(chan bitlockerRequest),
+		bitlockerCache: make(map[string]bitlockerCacheEntry),
+	}
+
+	requested := make(chan string, 10)
+
+	go func() {
+		for request := range c.bitlockerReqCh {
+			requested <- request.path
+
+			request.resCh <- bitlockerResult{status: 1, encryptionPercent: 100}
+		}
+	}()
+
+	results := c.cachedBitlocker([]string{"C:", "D:"})
+	require.Len(t, results, 2)
+	require.Len(t, requested, 2)
+	require.Len(t, c.bitlockerCache, 2)
+
+	// Cached volumes are not queried again, new volumes are.
+	results = c.cachedBitlocker([]string{"C:", "E:"})
+	require.Len(t, results, 2)
+	require.Equal(t, 1, results[0].status)
+	require.Len(t, requested, 3)
+
+	// D: disappeared.
+	require.Contains(t, c.bitlockerCache, "C:")
+	require.Contains(t, c.bitlockerCache, "E:")
+	require.NotContains(t, c.bitlockerCache, "D:")
+}
+
+func TestKeyProtectorNames(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"recovery_password", "tpm"}, keyProtectorNames([]uint32{1, 3, 3}))
+	require.Equal(t, []string{"unknown"}, keyProtectorNames([]uint32{42}))
+	require.Empty(t, keyProtectorNames(nil))
+}
+
+func TestEncryptionMethodName(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "xts_aes_256", encryptionMethodName(7))
+	require.Equal(t, "none", encryptionMethodName(0))
+	require.Equal(t, "unknown", encryptionMethodName(42))
+}
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// fsctlIsVolumeDirty is FSCTL_IS_VOLUME_DIRTY.
+	// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ni-winioctl-fsctl_is_volume_dirty
+	fsctlIsVolumeDirty = 0x00090078
+
+	volumeIsDirty = 0x00000001
+)
+
+// collectDiskHealth sends whether the dirty bit of the given volume is set.
+// Volumes without a file system, e.g. CD-ROM or removable drives without media, are skipped.
+func (c *Collector) collectDiskHealth(ch chan<- prometheus.Metric, volumes mountedVolumes, volume, filesystem string, apiDuration *time.Duration) {
+	if filesystem == "" {
+		return
+	}
+
+	startTime := time.Now()
+	dirty, err := isVolumeDirty(volumes, volume)
+	*apiDuration += time.Since(startTime)
+
+	if err != nil {
+		if errors.Is(err, windows.ERROR_NOT_READY) || errors.Is(err, windows.ERROR_INVALID_FUNCTION) {
+			c.logger.Debug("skipping dirty bit for "+volume,
+				slog.Any("err", err),
+			)
+		} else {
+			c.logger.Warn("failed to get dirty bit for "+volume,
+				slog.Any("err", err),
+			)
+		}
+
+		return
+	}
+
+	val := 0.0
+	if dirty {
+		val = 1.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.needsCheck,
+		prometheus.GaugeValue,
+		val,
+		volume,
+	)
+}
+
+// isVolumeDirty reports whether the dirty bit of the given volume is set,
+// i.e. chkdsk runs on the next boot.
+func isVolumeDirty(volumes mountedVolumes, rootDrive string) (bool, error) {
+	volumeHandle, _, err := openVolume(volumes, rootDrive)
+	if err != nil {
+		return false, err
+	}
+
+	defer func(fd windows.Handle) {
+		_ = windows.Close(fd)
+	}(volumeHandle)
+
+	buf := make([]byte, 4)
+
+	var bytesReturned uint32
+
+	err = windows.DeviceIoControl(volumeHandle, fsctlIsVolumeDirty, nil, 0, &buf[0], uint32(len(buf)), &bytesReturned, nil)
+	if err != nil {
+		return false, fmt.Errorf("could not query dirty bit for %s: %w", rootDrive, err)
+	}
+
+	return binary.LittleEndian.Uint32(buf)&volumeIsDirty != 0, nil
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type ioSizeKey struct {
+	volume    string
+	operation string
+}
+
+// ioSizeHistogram approximates the I/O size distribution of a volume from the Avg. Disk Bytes/Read
+// or Avg. Disk Bytes/Write counters. The counters only provide the total bytes and operations,
+// so each scrape observes the average I/O size of the scrape interval once per operation.
+type ioSizeHistogram struct {
+	initialized bool
+	lastBytes   float64
+	lastOps     float64
+
+	count   uint64
+	sum     float64
+	buckets []uint64
+}
+
+func newIOSizeHistogram(bounds []float64) *ioSizeHistogram {
+	return &ioSizeHistogram{
+		buckets: make([]uint64, len(bounds)),
+	}
+}
+
+// observe adds the operations since the last call. bytes and ops are the raw counter values.
+func (h *ioSizeHistogram) observe(bounds []float64, bytes, ops float64) {
+	deltaBytes, deltaOps := bytes-h.lastBytes, ops-h.lastOps
+	initialized := h.initialized
+
+	h.initialized, h.lastBytes, h.lastOps = true, bytes, ops
+
+	// The first scrape and counter resets only establish a new baseline.
+	if !initialized || deltaOps <= 0 || deltaBytes < 0 {
+		return
+	}
+
+	size := deltaBytes / deltaOps
+
+	h.count += uint64(deltaOps)
+	h.sum += deltaBytes
+
+	for i, bound := range bounds {
+		if size <= bound {
+			h.buckets[i] += uint64(deltaOps)
+
+			break
+		}
+	}
+}
+
+// cumulativeBuckets returns the buckets in the format of [prometheus.MustNewConstHistogram].
+func (h *ioSizeHistogram) cumulativeBuckets(bounds []float64) map[float64]uint64 {
+	buckets := make(map[float64]uint64, len(bounds))
+
+	var cumulative uint64
+
+	for i, bound := range bounds {
+		cumulative += h.buckets[i]
+		buckets[bound] = cumulative
+	}
+
+	return buckets
+}
+
+// parseIOSizeBuckets parses a comma-separated list of strictly increasing, positive bucket boundaries in bytes.
+func parseIOSizeBuckets(value string) ([]float64, error) {
+	buckets := make([]float64, 0)
+
+	for bucket := range strings.SplitSeq(value, ",") {
+		bucket = strings.TrimSpace(bucket)
+		if bucket == "" {
+			continue
+		}
+
+		bound, err := strconv.ParseFloat(bucket, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bucket %q: %w", bucket, err)
+		}
+
+		buckets = append(buckets, bound)
+	}
+
+	if err := validateIOSizeBuckets(buckets); err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
+
+// formatIOSizeBuckets is the inverse of parseIOSizeBuckets.
+func formatIOSizeBuckets(buckets []float64) string {
+	values := make([]string, 0, len(buckets))
+
+	for _, bucket := range buckets {
+		values = append(values, strconv.FormatFloat(bucket, 'f', -1, 64))
+	}
+
+	return strings.Join(values, ",")
+}
+
+func validateIOSizeBuckets(buckets []float64) error {
+	if len(buckets) == 0 {
+		return errors.New("at least one bucket is required")
+	}
+
+	if buckets[0] <= 0 {
+		return errors.New("buckets must be positive")
+	}
+
+	if !slices.IsSorted(buckets) || len(slices.Compact(slices.Clone(buckets))) != len(buckets) {
+		return errors.New("buckets must be strictly increasing")
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIOSizeHistogram(t *testing.T) {
+	t.Parallel()
+
+	bounds := []float64{512, 4096, 65536}
+	histogram := newIOSizeHistogram(bounds)
+
+	// The first observation only establishes the baseline.
+	histogram.observe(bounds, 1<<20, 100)
+	require.Zero(t, histogram.count)
+
+	// 10 operations of 4 KiB.
+	histogram.observe(bounds, 1<<20+10*4096, 110)
+	// 2 operations of 1 MiB are larger than the largest bucket.
+	histogram.observe(bounds, 1<<20+10*4096+2<<20, 112)
+	// No operations.
+	histogram.observe(bounds, 1<<20+10*4096+2<<20, 112)
+
+	require.Equal(t, uint64(12), histogram.count)
+	require.InDelta(t, float64(10*4096+2<<20), histogram.sum, 0)
+	require.Equal(t, map[float64]uint64{512: 0, 4096: 10, 65536: 10}, histogram.cumulativeBuckets(bounds))
+
+	// A counter reset establishes a new baseline.
+	histogram.observe(bounds, 512, 1)
+	histogram.observe(bounds, 1024, 2)
+
+	require.Equal(t, map[float64]uint64{512: 1, 4096: 11, 65536: 11}, histogram.cumulativeBuckets(bounds))
+}
+
+func TestParseIOSizeBuckets(t *testing.T) {
+	t.Parallel()
+
+	buckets, err := parseIOSizeBuckets("512, 4096,65536")
+	require.NoError(t, err)
+	require.Equal(t, []float64{512, 4096, 65536}, buckets)
+	require.Equal(t, "512,4096,65536", formatIOSizeBuckets(buckets))
+
+	for _, value := range []string{"", "a", "0,512", "4096,512", "512,512"} {
+		_, err = parseIOSizeBuckets(value)
+		require.Error(t, err, value)
+	}
+}
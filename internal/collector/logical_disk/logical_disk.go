@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"regexp"
 	"runtime"
 	"runtime/debug"
@@ -35,6 +36,7 @@ import (
 	"github.com/go-ole/go-ole"
 	"github.com/prometheus-community/windows_exporter/internal/headers/propsys"
 	"github.com/prometheus-community/windows_exporter/internal/headers/shell32"
+	"github.com/prometheus-community/windows_exporter/internal/headers/win32"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
@@ -43,15 +45,29 @@ import (
 )
 
 const (
-	Name                  = "logical_disk"
-	subCollectorMetrics   = "metrics"
-	subCollectorBitlocker = "bitlocker_status"
+	Name                   = "logical_disk"
+	subCollectorMetrics    = "metrics"
+	subCollectorBitlocker  = "bitlocker_status"
+	subCollectorUSNJournal = "usn_journal"
+	subCollectorMountPoint = "mount_points"
+	subCollectorDiskHealth = "disk_health"
+	subCollectorSpace      = "space"
+	subCollectorQuota      = "quota"
+	subCollectorMFT        = "mft"
 )
 
 type Config struct {
 	CollectorsEnabled []string       `yaml:"enabled"`
 	VolumeInclude     *regexp.Regexp `yaml:"volume-include"`
 	VolumeExclude     *regexp.Regexp `yaml:"volume-exclude"`
+	IOSizeBuckets     []float64      `yaml:"io-size-buckets"`
+	DriveTypesExclude []string       `yaml:"drive-types-exclude"`
+	VolumeCacheTTL    time.Duration  `yaml:"volume-cache-ttl"`
+	BitlockerTimeout  time.Duration  `yaml:"bitlocker-timeout"`
+	BitlockerWorkers  int            `yaml:"bitlocker-workers"`
+	BitlockerCacheTTL time.Duration  `yaml:"bitlocker-cache-ttl"`
+	QuotaMaxUsers     int            `yaml:"quota-max-users"`
+	IncludeTotal      bool           `yaml:"include-total"`
 }
 
 //nolint:gochecknoglobals
@@ -59,8 +75,36 @@ var ConfigDefaults = Config{
 	CollectorsEnabled: []string{
 		subCollectorMetrics,
 	},
-	VolumeInclude: types.RegExpAny,
-	VolumeExclude: types.RegExpEmpty,
+	VolumeInclude:     types.RegExpAny,
+	VolumeExclude:     types.RegExpEmpty,
+	IOSizeBuckets:     []float64{512, 4096, 16384, 65536, 262144, 1048576},
+	DriveTypesExclude: []string{},
+	VolumeCacheTTL:    time.Minute,
+	BitlockerTimeout:  2 * time.Second,
+	BitlockerWorkers:  2,
+	BitlockerCacheTTL: 5 * time.Minute,
+	QuotaMaxUsers:     100,
+	IncludeTotal:      false,
+}
+
+// driveTypes are the values returned by getDriveType.
+//
+//nolint:gochecknoglobals
+var driveTypes = []string{"unknown", "norootdir", "removable", "fixed", "remote", "cdrom", "ramdisk"}
+
+// volumeFlags are the file system flags returned by GetVolumeInformation
+// that are reported by windows_logical_disk_volume_flags.
+//
+//nolint:gochecknoglobals
+var volumeFlags = []struct {
+	name string
+	mask uint32
+}{
+	{"compression_enabled", windows.FILE_FILE_COMPRESSION},
+	{"quotas_enabled", windows.FILE_VOLUME_QUOTAS},
+	{"encryption_supported", windows.FILE_SUPPORTS_ENCRYPTION},
+	{"usn_journal", windows.FILE_SUPPORTS_USN_JOURNAL},
+	{"case_sensitive", windows.FILE_CASE_SENSITIVE_SEARCH},
 }
 
 // A Collector is a Prometheus Collector for perflib logicalDisk metrics.
@@ -71,11 +115,20 @@ type Collector struct {
 	perfDataCollector *pdh.Collector
 	perfDataObject    []perfDataCounterValues
 
-	bitlockerReqCh chan string
-	bitlockerResCh chan struct {
-		err    error
-		status int
-	}
+	// ioSizeHistograms holds the I/O size histogram state per volume and operation.
+	ioSizeHistograms map[ioSizeKey]*ioSizeHistogram
+
+	volumeInfoCache *volumeInfoCache
+
+	bitlockerReqCh         chan bitlockerRequest
+	bitlockerCache         map[string]bitlockerCacheEntry
+	bitlockerQueryFailures float64
+
+	// infoErrors is the number of volume information lookups, which failed on drives with media.
+	infoErrors float64
+
+	// quotaAccountNames caches the account names of the quota entry SIDs.
+	quotaAccountNames map[string]string
 
 	ctxCancelFunc context.CancelFunc
 
@@ -87,27 +140,61 @@ type Collector struct {
 	readBytesTotal   *prometheus.Desc
 	readLatency      *prometheus.Desc
 	readOnly         *prometheus.Desc
+	volumeFlags      *prometheus.Desc
 	readsTotal       *prometheus.Desc
 	readTime         *prometheus.Desc
 	readWriteLatency *prometheus.Desc
 	requestsQueued   *prometheus.Desc
 	splitIOs         *prometheus.Desc
 	totalSpace       *prometheus.Desc
+	availableSpace   *prometheus.Desc
 	writeBytesTotal  *prometheus.Desc
 	writeLatency     *prometheus.Desc
 	writesTotal      *prometheus.Desc
 	writeTime        *prometheus.Desc
+	ioSize           *prometheus.Desc
+
+	bitlockerStatus             *prometheus.Desc
+	bitlockerEncryptionRatio    *prometheus.Desc
+	bitlockerKeyProtector       *prometheus.Desc
+	bitlockerEncryptionMethod   *prometheus.Desc
+	bitlockerQueryFailuresTotal *prometheus.Desc
+
+	quotaUsed      *prometheus.Desc
+	quotaLimit     *prometheus.Desc
+	quotaThreshold *prometheus.Desc
 
-	bitlockerStatus *prometheus.Desc
+	usnJournalSize    *prometheus.Desc
+	usnJournalMaxSize *prometheus.Desc
+	usnJournalNextUSN *prometheus.Desc
+
+	mountInfo      *prometheus.Desc
+	mountFreeBytes *prometheus.Desc
+	mountSizeBytes *prometheus.Desc
+
+	needsCheck *prometheus.Desc
+
+	infoErrorsTotal *prometheus.Desc
+
+	mftAllocated *prometheus.Desc
+	mftInUse     *prometheus.Desc
+	mftFragments *prometheus.Desc
+	mftRecords   *prometheus.Desc
+	clusterSize  *prometheus.Desc
+
+	volumeCacheHits *prometheus.Desc
 }
 
 type volumeInfo struct {
 	diskIDs      string
+	mountPoints  string
 	filesystem   string
 	serialNumber string
 	label        string
 	volumeType   string
 	readonly     float64
+	// fsFlags are the file system flags returned by GetVolumeInformation.
+	fsFlags uint32
 }
 
 func New(config *Config) *Collector {
@@ -123,6 +210,22 @@ func New(config *Config) *Collector {
 		config.VolumeInclude = ConfigDefaults.VolumeInclude
 	}
 
+	if config.IOSizeBuckets == nil {
+		config.IOSizeBuckets = ConfigDefaults.IOSizeBuckets
+	}
+
+	if config.DriveTypesExclude == nil {
+		config.DriveTypesExclude = ConfigDefaults.DriveTypesExclude
+	}
+
+	if config.BitlockerTimeout == 0 {
+		config.BitlockerTimeout = ConfigDefaults.BitlockerTimeout
+	}
+
+	if config.BitlockerWorkers == 0 {
+		config.BitlockerWorkers = ConfigDefaults.BitlockerWorkers
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -136,7 +239,7 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 	}
 	c.config.CollectorsEnabled = make([]string, 0)
 
-	var collectorsEnabled, volumeExclude, volumeInclude string
+	var collectorsEnabled, volumeExclude, volumeInclude, ioSizeBuckets, driveTypesExclude string
 
 	app.Flag(
 		"collector.logical_disk.volume-exclude",
@@ -148,11 +251,57 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		"Regexp of volumes to include. Volume name must both match include and not match exclude to be included.",
 	).Default(".+").StringVar(&volumeInclude)
 
+	app.Flag(
+		"collector.logical_disk.drive-types-exclude",
+		"Comma-separated list of drive types to exclude. Available drive types: "+strings.Join(driveTypes, ", ")+".",
+	).Default("").StringVar(&driveTypesExclude)
+
+	app.Flag(
+		"collector.logical_disk.volume-cache-ttl",
+		"Duration for which the volume information of windows_logical_disk_info is cached. Expired entries are refreshed in the background. 0 disables the cache.",
+	).Default(ConfigDefaults.VolumeCacheTTL.String()).DurationVar(&c.config.VolumeCacheTTL)
+
+	app.Flag(
+		"collector.logical_disk.bitlocker-timeout",
+		"Maximum duration of the BitLocker status queries of a scrape. Volumes which are not answered in time are skipped and counted in windows_logical_disk_bitlocker_query_failures_total.",
+	).Default(ConfigDefaults.BitlockerTimeout.String()).DurationVar(&c.config.BitlockerTimeout)
+
+	app.Flag(
+		"collector.logical_disk.bitlocker-workers",
+		"Number of worker threads querying the BitLocker status of volumes concurrently.",
+	).Default(strconv.Itoa(ConfigDefaults.BitlockerWorkers)).IntVar(&c.config.BitlockerWorkers)
+
+	app.Flag(
+		"collector.logical_disk.bitlocker-cache-ttl",
+		"Duration for which the BitLocker status of a volume is cached. 0 disables the cache.",
+	).Default(ConfigDefaults.BitlockerCacheTTL.String()).DurationVar(&c.config.BitlockerCacheTTL)
+
+	app.Flag(
+		"collector.logical_disk.quota-max-users",
+		"Maximum number of users per volume reported by the quota collector, ordered by used bytes. 0 means unlimited.",
+	).Default(strconv.Itoa(ConfigDefaults.QuotaMaxUsers)).IntVar(&c.config.QuotaMaxUsers)
+
+	app.Flag(
+		"collector.logical_disk.include-total",
+		"Emit the performance metrics of the _Total instance with volume=\"_Total\". The instance is subject to the volume include and exclude filters.",
+	).Default(strconv.FormatBool(ConfigDefaults.IncludeTotal)).BoolVar(&c.config.IncludeTotal)
+
+	app.Flag(
+		"collector.logical_disk.io-size-buckets",
+		"Comma-separated list of bucket boundaries in bytes for the windows_logical_disk_io_size_bytes histogram.",
+	).Default(formatIOSizeBuckets(ConfigDefaults.IOSizeBuckets)).StringVar(&ioSizeBuckets)
+
 	app.Flag(
 		"collector.logical_disk.enabled",
-		fmt.Sprintf("Comma-separated list of collectors to use. Available collectors: %s, %s. Defaults to metrics, if not specified.",
+		fmt.Sprintf("Comma-separated list of collectors to use. Available collectors: %s, %s, %s, %s, %s, %s, %s, %s. Defaults to metrics, if not specified.",
 			subCollectorMetrics,
 			subCollectorBitlocker,
+			subCollectorUSNJournal,
+			subCollectorMountPoint,
+			subCollectorDiskHealth,
+			subCollectorSpace,
+			subCollectorQuota,
+			subCollectorMFT,
 		),
 	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
 
@@ -171,6 +320,21 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 			return fmt.Errorf("collector.logical_disk.volume-include: %w", err)
 		}
 
+		c.config.IOSizeBuckets, err = parseIOSizeBuckets(ioSizeBuckets)
+		if err != nil {
+			return fmt.Errorf("collector.logical_disk.io-size-buckets: %w", err)
+		}
+
+		c.config.DriveTypesExclude = make([]string, 0)
+
+		if driveTypesExclude != "" {
+			c.config.DriveTypesExclude = strings.Split(driveTypesExclude, ",")
+		}
+
+		if err = validateDriveTypes(c.config.DriveTypesExclude); err != nil {
+			return fmt.Errorf("collector.logical_disk.drive-types-exclude: %w", err)
+		}
+
 		return nil
 	})
 
@@ -181,11 +345,19 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceAPI, types.SourceCOM}
+}
+
 func (c *Collector) Close() error {
 	if slices.Contains(c.config.CollectorsEnabled, subCollectorBitlocker) {
 		c.ctxCancelFunc()
 	}
 
+	if c.volumeInfoCache != nil {
+		c.volumeInfoCache.wait()
+	}
+
 	return nil
 }
 
@@ -193,17 +365,41 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	c.logger = logger.With(slog.String("collector", Name))
 
 	for _, collector := range c.config.CollectorsEnabled {
-		if !slices.Contains([]string{subCollectorMetrics, subCollectorBitlocker}, collector) {
+		if !slices.Contains([]string{subCollectorMetrics, subCollectorBitlocker, subCollectorUSNJournal, subCollectorMountPoint, subCollectorDiskHealth, subCollectorSpace, subCollectorQuota, subCollectorMFT}, collector) {
 			return fmt.Errorf("unknown sub collector: %s. Possible values: %s", collector,
-				strings.Join([]string{subCollectorMetrics, subCollectorBitlocker}, ", "),
+				strings.Join([]string{subCollectorMetrics, subCollectorBitlocker, subCollectorUSNJournal, subCollectorMountPoint, subCollectorDiskHealth, subCollectorSpace, subCollectorQuota, subCollectorMFT}, ", "),
 			)
 		}
 	}
 
+	if err := validateIOSizeBuckets(c.config.IOSizeBuckets); err != nil {
+		return fmt.Errorf("invalid io-size-buckets: %w", err)
+	}
+
+	if err := validateDriveTypes(c.config.DriveTypesExclude); err != nil {
+		return fmt.Errorf("invalid drive-types-exclude: %w", err)
+	}
+
+	if c.config.BitlockerTimeout <= 0 {
+		return fmt.Errorf("invalid bitlocker-timeout: must be positive, got %s", c.config.BitlockerTimeout)
+	}
+
+	if c.config.BitlockerWorkers < 1 {
+		return fmt.Errorf("invalid bitlocker-workers: must be at least 1, got %d", c.config.BitlockerWorkers)
+	}
+
+	if c.config.QuotaMaxUsers < 0 {
+		return fmt.Errorf("invalid quota-max-users: must not be negative, got %d", c.config.QuotaMaxUsers)
+	}
+
+	c.ioSizeHistograms = make(map[ioSizeKey]*ioSizeHistogram)
+	c.quotaAccountNames = make(map[string]string)
+	c.volumeInfoCache = newVolumeInfoCache(c.config.VolumeCacheTTL)
+
 	c.information = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "info"),
 		"A metric with a constant '1' value labeled with logical disk information",
-		[]string{"disk", "type", "volume", "volume_name", "filesystem", "serial_number"},
+		[]string{"disk", "type", "volume", "volume_name", "filesystem", "serial_number", "mount_point", "volume_guid"},
 		nil,
 	)
 	c.readOnly = prometheus.NewDesc(
@@ -212,6 +408,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		[]string{"volume"},
 		nil,
 	)
+	c.volumeFlags = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "volume_flags"),
+		"Whether the file system flag is set on the logical disk (GetVolumeInformation)",
+		[]string{"volume", "flag"},
+		nil,
+	)
 	c.requestsQueued = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "requests_queued"),
 		"The number of requests queued to the disk (LogicalDisk.CurrentDiskQueueLength)",
@@ -277,14 +479,21 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	c.freeSpace = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "free_bytes"),
-		"Free space in bytes, updates every 10-15 min (LogicalDisk.PercentFreeSpace)",
+		"Free space in bytes, updates every 10-15 min unless the space collector is enabled (LogicalDisk.PercentFreeSpace)",
 		[]string{"volume"},
 		nil,
 	)
 
 	c.totalSpace = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "size_bytes"),
-		"Total space in bytes, updates every 10-15 min (LogicalDisk.PercentFreeSpace_Base)",
+		"Total space in bytes, updates every 10-15 min unless the space collector is enabled (LogicalDisk.PercentFreeSpace_Base)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.availableSpace = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "available_bytes"),
+		"Free space in bytes available to the user running the exporter, taking disk quotas into account (GetDiskFreeSpaceEx)",
 		[]string{"volume"},
 		nil,
 	)
@@ -324,6 +533,13 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 	)
 
+	c.ioSize = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "io_size_bytes"),
+		"Approximated distribution of the I/O size, based on the average I/O size between two scrapes (LogicalDisk.AvgDiskBytesPerRead, LogicalDisk.AvgDiskBytesPerWrite)",
+		[]string{"volume", "operation"},
+		nil,
+	)
+
 	c.bitlockerStatus = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bitlocker_status"),
 		"BitLocker status for the logical disk",
@@ -331,29 +547,187 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 	)
 
+	c.bitlockerEncryptionRatio = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "bitlocker_encryption_ratio"),
+		"Ratio of the logical disk encrypted by BitLocker",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.bitlockerKeyProtector = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "bitlocker_key_protector"),
+		"BitLocker key protectors configured for the logical disk. Only available if windows_exporter is running elevated",
+		[]string{"volume", "protector_type"},
+		nil,
+	)
+
+	c.bitlockerEncryptionMethod = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "bitlocker_encryption_method"),
+		"BitLocker encryption method of the logical disk. Value is always 1. Only available if windows_exporter is running elevated",
+		[]string{"volume", "method"},
+		nil,
+	)
+
+	c.bitlockerQueryFailuresTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "bitlocker_query_failures_total"),
+		"Number of BitLocker status queries which failed or timed out",
+		nil,
+		nil,
+	)
+
+	c.quotaUsed = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "quota_used_bytes"),
+		"Disk space charged to the user by the NTFS disk quota of the volume",
+		[]string{"volume", "user"},
+		nil,
+	)
+
+	c.quotaLimit = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "quota_limit_bytes"),
+		"NTFS disk quota limit of the user on the volume. Not reported, if no limit is set",
+		[]string{"volume", "user"},
+		nil,
+	)
+
+	c.quotaThreshold = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "quota_threshold_bytes"),
+		"NTFS disk quota warning threshold of the user on the volume. Not reported, if no threshold is set",
+		[]string{"volume", "user"},
+		nil,
+	)
+
+	c.usnJournalSize = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "usn_journal_size_bytes"),
+		"Size of the valid records in the USN change journal of the volume (NextUsn - FirstUsn)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.usnJournalMaxSize = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "usn_journal_max_size_bytes"),
+		"Configured maximum size of the USN change journal of the volume",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.usnJournalNextUSN = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "usn_journal_next_usn_total"),
+		"Next update sequence number of the USN change journal. USNs are byte offsets, so the rate is the journal growth in bytes per second",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.mountInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "mount_info"),
+		"A metric with a constant '1' value labeled with the mount points of each mounted volume",
+		[]string{"guid", "mount_point", "filesystem", "label"},
+		nil,
+	)
+
+	c.mountFreeBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "mount_free_bytes"),
+		"Free space of the mounted volume in bytes (GetDiskFreeSpaceEx)",
+		[]string{"guid"},
+		nil,
+	)
+
+	c.mountSizeBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "mount_size_bytes"),
+		"Total size of the mounted volume in bytes (GetDiskFreeSpaceEx)",
+		[]string{"guid"},
+		nil,
+	)
+
+	c.needsCheck = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "needs_check"),
+		"Whether the dirty bit of the volume is set and chkdsk runs on the next boot (FSCTL_IS_VOLUME_DIRTY)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.mftAllocated = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "mft_allocated_bytes"),
+		"Disk space allocated to the NTFS master file table ($MFT) of the volume",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.mftInUse = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "mft_in_use_bytes"),
+		"Valid data length of the NTFS master file table of the volume (FSCTL_GET_NTFS_VOLUME_DATA)",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.mftFragments = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "mft_fragment_count"),
+		"Number of fragments of the NTFS master file table of the volume",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.mftRecords = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "mft_record_count"),
+		"Number of file records in the NTFS master file table of the volume, derived from the in-use size",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.clusterSize = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "cluster_size_bytes"),
+		"Cluster size of the NTFS volume",
+		[]string{"volume"},
+		nil,
+	)
+
+	c.infoErrorsTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "info_errors_total"),
+		"Number of failed volume information lookups on fixed, remote and RAM disks",
+		nil,
+		nil,
+	)
+
+	c.volumeCacheHits = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "volume_cache_hits_total"),
+		"Number of volume information lookups served from the volume information cache",
+		nil,
+		nil,
+	)
+
+	instances := pdh.InstancesAll
+	if c.config.IncludeTotal {
+		instances = pdh.InstancesAllWithTotal
+	}
+
 	var err error
 
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "LogicalDisk", pdh.InstancesAll)
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "LogicalDisk", instances)
 	if err != nil {
 		return fmt.Errorf("failed to create LogicalDisk collector: %w", err)
 	}
 
 	if slices.Contains(c.config.CollectorsEnabled, subCollectorBitlocker) {
-		initErrCh := make(chan error)
-		c.bitlockerReqCh = make(chan string, 1)
-		c.bitlockerResCh = make(chan struct {
-			err    error
-			status int
-		}, 1)
+		c.bitlockerReqCh = make(chan bitlockerRequest)
+		c.bitlockerCache = make(map[string]bitlockerCacheEntry)
 
 		ctx, cancel := context.WithCancel(context.Background())
 
 		c.ctxCancelFunc = cancel
 
-		go c.workerBitlocker(ctx, initErrCh)
+		if !windows.GetCurrentProcessToken().IsElevated() {
+			c.logger.Info("windows_exporter is not running elevated, skipping BitLocker key protector and encryption method metrics. " +
+				"The BitLocker encryption percentage is only reported for fully encrypted or decrypted volumes")
+		}
 
-		if err = <-initErrCh; err != nil {
-			return fmt.Errorf("failed to initialize BitLocker worker: %w", err)
+		// Each worker runs on its own COM thread, so a volume which hangs only blocks a single worker.
+		for range c.config.BitlockerWorkers {
+			initErrCh := make(chan error)
+
+			go c.workerBitlocker(ctx, initErrCh)
+
+			if err = <-initErrCh; err != nil {
+				return fmt.Errorf("failed to initialize BitLocker worker: %w", err)
+			}
 		}
 	}
 
@@ -363,28 +737,73 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
 func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
-	var info volumeInfo
+	var (
+		info                                  volumeInfo
+		pdhDuration, apiDuration, comDuration time.Duration
+	)
+
+	startTime := time.Now()
 
 	err := c.perfDataCollector.Collect(&c.perfDataObject)
 	if err != nil {
 		return fmt.Errorf("failed to collect LogicalDisk metrics: %w", err)
 	}
 
+	pdhDuration = time.Since(startTime)
+	startTime = time.Now()
+
 	volumes, err := getAllMountedVolumes()
 	if err != nil {
 		return fmt.Errorf("failed to get volumes: %w", err)
 	}
 
+	apiDuration = time.Since(startTime)
+
+	cachedVolumes := make(map[string]struct{}, len(c.perfDataObject))
+	bitlockerVolumes := make([]string, 0, len(c.perfDataObject))
+	quotaVolumes := make([]string, 0, len(c.perfDataObject))
+
 	for _, data := range c.perfDataObject {
 		if c.config.VolumeExclude.MatchString(data.Name) || !c.config.VolumeInclude.MatchString(data.Name) {
 			continue
 		}
 
-		info, err = getVolumeInfo(volumes, data.Name)
+		// The _Total instance is only present if IncludeTotal is set. It is not a volume,
+		// so only the performance metrics apply.
+		if data.Name == pdh.InstanceTotal {
+			if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+				c.collectMetrics(ch, data)
+			}
+
+			continue
+		}
+
+		// Volumes, whose GUID can't be resolved, are reported with an empty volume_guid label.
+		var guid string
+
+		volumeGUID, ok := volumes.guidOf(data.Name)
+		if ok {
+			guid = bareGUID(volumeGUID)
+		} else {
+			volumeGUID = data.Name
+		}
+
+		cachedVolumes[volumeGUID] = struct{}{}
+
+		startTime = time.Now()
+		info, err = c.volumeInfoCache.get(volumeGUID, func() (volumeInfo, error) {
+			return getVolumeInfo(volumes, data.Name)
+		})
+		apiDuration += time.Since(startTime)
+
+		info.mountPoints = strings.Join(volumes.mountPointsOf(data.Name), ";")
+
 		if err != nil {
-			c.logger.Warn("failed to get volume information for "+data.Name,
-				slog.Any("err", err),
-			)
+			c.logVolumeInfoError(data.Name, info.volumeType, err)
+		}
+
+		if slices.Contains(c.config.DriveTypesExclude, info.volumeType) {
+			continue
 		}
 
 		ch <- prometheus.MustNewConstMetric(
@@ -397,161 +816,328 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 			info.label,
 			info.filesystem,
 			info.serialNumber,
+			info.mountPoints,
+			guid,
 		)
 
+		// The file system flags are unknown, if GetVolumeInformation failed, e.g. for an empty CD-ROM drive.
+		if info.filesystem != "" {
+			for _, flag := range volumeFlags {
+				val := 0.0
+				if info.fsFlags&flag.mask != 0 {
+					val = 1.0
+				}
+
+				ch <- prometheus.MustNewConstMetric(
+					c.volumeFlags,
+					prometheus.GaugeValue,
+					val,
+					data.Name,
+					flag.name,
+				)
+			}
+		}
+
 		if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
-			ch <- prometheus.MustNewConstMetric(
-				c.requestsQueued,
-				prometheus.GaugeValue,
-				data.CurrentDiskQueueLength,
-				data.Name,
-			)
+			c.collectMetrics(ch, data)
+		}
 
-			ch <- prometheus.MustNewConstMetric(
-				c.avgReadQueue,
-				prometheus.GaugeValue,
-				data.AvgDiskReadQueueLength*pdh.TicksToSecondScaleFactor,
-				data.Name,
-			)
+		if slices.Contains(c.config.CollectorsEnabled, subCollectorUSNJournal) {
+			c.collectUSNJournal(ch, volumes, data.Name, info.filesystem, &apiDuration)
+		}
 
-			ch <- prometheus.MustNewConstMetric(
-				c.avgWriteQueue,
-				prometheus.GaugeValue,
-				data.AvgDiskWriteQueueLength*pdh.TicksToSecondScaleFactor,
-				data.Name,
-			)
+		if slices.Contains(c.config.CollectorsEnabled, subCollectorSpace) {
+			startTime = time.Now()
+			c.collectSpace(ch, volumes, data.Name)
+			apiDuration += time.Since(startTime)
+		}
 
-			ch <- prometheus.MustNewConstMetric(
-				c.readBytesTotal,
-				prometheus.CounterValue,
-				data.DiskReadBytesPerSec,
-				data.Name,
-			)
+		if slices.Contains(c.config.CollectorsEnabled, subCollectorDiskHealth) {
+			c.collectDiskHealth(ch, volumes, data.Name, info.filesystem, &apiDuration)
+		}
 
-			ch <- prometheus.MustNewConstMetric(
-				c.readsTotal,
-				prometheus.CounterValue,
-				data.DiskReadsPerSec,
-				data.Name,
-			)
+		if slices.Contains(c.config.CollectorsEnabled, subCollectorMFT) {
+			c.collectMFT(ch, volumes, data.Name, info.filesystem, &apiDuration)
+		}
 
-			ch <- prometheus.MustNewConstMetric(
-				c.writeBytesTotal,
-				prometheus.CounterValue,
-				data.DiskWriteBytesPerSec,
-				data.Name,
-			)
+		if slices.Contains(c.config.CollectorsEnabled, subCollectorBitlocker) {
+			bitlockerVolumes = append(bitlockerVolumes, data.Name)
+		}
 
-			ch <- prometheus.MustNewConstMetric(
-				c.writesTotal,
-				prometheus.CounterValue,
-				data.DiskWritesPerSec,
-				data.Name,
-			)
+		// Only NTFS supports disk quotas.
+		if slices.Contains(c.config.CollectorsEnabled, subCollectorQuota) && info.filesystem == "NTFS" {
+			quotaVolumes = append(quotaVolumes, data.Name)
+		}
+	}
 
-			ch <- prometheus.MustNewConstMetric(
-				c.readTime,
-				prometheus.CounterValue,
-				data.PercentDiskReadTime,
-				data.Name,
-			)
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorQuota) {
+		startTime = time.Now()
+		c.collectQuota(ch, volumes, quotaVolumes)
+		comDuration += time.Since(startTime)
+	}
 
-			ch <- prometheus.MustNewConstMetric(
-				c.writeTime,
-				prometheus.CounterValue,
-				data.PercentDiskWriteTime,
-				data.Name,
-			)
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorBitlocker) {
+		startTime = time.Now()
+		c.collectBitlocker(ch, bitlockerVolumes)
+		comDuration += time.Since(startTime)
+	}
 
-			ch <- prometheus.MustNewConstMetric(
-				c.freeSpace,
-				prometheus.GaugeValue,
-				data.FreeSpace*1024*1024,
-				data.Name,
-			)
+	c.volumeInfoCache.retain(cachedVolumes)
 
-			ch <- prometheus.MustNewConstMetric(
-				c.totalSpace,
-				prometheus.GaugeValue,
-				data.PercentFreeSpace*1024*1024,
-				data.Name,
-			)
+	ch <- prometheus.MustNewConstMetric(
+		c.volumeCacheHits,
+		prometheus.CounterValue,
+		c.volumeInfoCache.hitsTotal(),
+	)
 
-			ch <- prometheus.MustNewConstMetric(
-				c.idleTime,
-				prometheus.CounterValue,
-				data.PercentIdleTime,
-				data.Name,
-			)
+	ch <- prometheus.MustNewConstMetric(
+		c.infoErrorsTotal,
+		prometheus.CounterValue,
+		c.infoErrors,
+	)
 
-			ch <- prometheus.MustNewConstMetric(
-				c.splitIOs,
-				prometheus.CounterValue,
-				data.SplitIOPerSec,
-				data.Name,
-			)
+	// Drop the histogram state of volumes that no longer exist.
+	for key := range c.ioSizeHistograms {
+		if !slices.ContainsFunc(c.perfDataObject, func(data perfDataCounterValues) bool { return data.Name == key.volume }) {
+			delete(c.ioSizeHistograms, key)
+		}
+	}
 
-			ch <- prometheus.MustNewConstMetric(
-				c.readLatency,
-				prometheus.CounterValue,
-				data.AvgDiskSecPerRead*pdh.TicksToSecondScaleFactor,
-				data.Name,
-			)
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMountPoint) {
+		startTime = time.Now()
+		c.collectMountPoints(ch, volumes)
+		apiDuration += time.Since(startTime)
+	}
 
-			ch <- prometheus.MustNewConstMetric(
-				c.writeLatency,
-				prometheus.CounterValue,
-				data.AvgDiskSecPerWrite*pdh.TicksToSecondScaleFactor,
-				data.Name,
-			)
+	ch <- types.NewSourceDurationMetric(Name, types.SourcePDH, pdhDuration)
+	ch <- types.NewSourceDurationMetric(Name, types.SourceAPI, apiDuration)
 
-			ch <- prometheus.MustNewConstMetric(
-				c.readWriteLatency,
-				prometheus.CounterValue,
-				data.AvgDiskSecPerTransfer*pdh.TicksToSecondScaleFactor,
-				data.Name,
-			)
-		}
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorBitlocker) || slices.Contains(c.config.CollectorsEnabled, subCollectorQuota) {
+		ch <- types.NewSourceDurationMetric(Name, types.SourceCOM, comDuration)
+	}
 
-		if slices.Contains(c.config.CollectorsEnabled, subCollectorBitlocker) {
-			c.bitlockerReqCh <- data.Name
+	return nil
+}
 
-			bitlockerStatus := <-c.bitlockerResCh
+// collectMetrics sends the performance counter metrics of a single volume.
+func (c *Collector) collectMetrics(ch chan<- prometheus.Metric, data perfDataCounterValues) {
+	ch <- prometheus.MustNewConstMetric(
+		c.requestsQueued,
+		prometheus.GaugeValue,
+		data.CurrentDiskQueueLength,
+		data.Name,
+	)
 
-			if bitlockerStatus.err != nil {
-				c.logger.Warn("failed to get BitLocker status for "+data.Name,
-					slog.Any("err", bitlockerStatus.err),
-				)
+	ch <- prometheus.MustNewConstMetric(
+		c.avgReadQueue,
+		prometheus.GaugeValue,
+		data.AvgDiskReadQueueLength*pdh.TicksToSecondScaleFactor,
+		data.Name,
+	)
 
-				continue
-			}
+	ch <- prometheus.MustNewConstMetric(
+		c.avgWriteQueue,
+		prometheus.GaugeValue,
+		data.AvgDiskWriteQueueLength*pdh.TicksToSecondScaleFactor,
+		data.Name,
+	)
 
-			if bitlockerStatus.status == -1 {
-				c.logger.Debug("BitLocker status for "+data.Name+" is unknown",
-					slog.Int("status", bitlockerStatus.status),
-				)
+	ch <- prometheus.MustNewConstMetric(
+		c.readBytesTotal,
+		prometheus.CounterValue,
+		data.DiskReadBytesPerSec,
+		data.Name,
+	)
 
-				continue
-			}
+	ch <- prometheus.MustNewConstMetric(
+		c.readsTotal,
+		prometheus.CounterValue,
+		data.DiskReadsPerSec,
+		data.Name,
+	)
 
-			for i, status := range []string{"disabled", "on", "off", "encrypting", "decrypting", "suspended", "locked", "unknown", "waiting_for_activation"} {
-				val := 0.0
-				if bitlockerStatus.status == i {
-					val = 1.0
-				}
+	ch <- prometheus.MustNewConstMetric(
+		c.writeBytesTotal,
+		prometheus.CounterValue,
+		data.DiskWriteBytesPerSec,
+		data.Name,
+	)
 
-				ch <- prometheus.MustNewConstMetric(
-					c.bitlockerStatus,
-					prometheus.GaugeValue,
-					val,
-					data.Name,
-					status,
-				)
-			}
+	ch <- prometheus.MustNewConstMetric(
+		c.writesTotal,
+		prometheus.CounterValue,
+		data.DiskWritesPerSec,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.readTime,
+		prometheus.CounterValue,
+		data.PercentDiskReadTime,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.writeTime,
+		prometheus.CounterValue,
+		data.PercentDiskWriteTime,
+		data.Name,
+	)
+
+	// The space collector sends the current values instead.
+	if !slices.Contains(c.config.CollectorsEnabled, subCollectorSpace) {
+		ch <- prometheus.MustNewConstMetric(
+			c.freeSpace,
+			prometheus.GaugeValue,
+			data.FreeSpace*1024*1024,
+			data.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.totalSpace,
+			prometheus.GaugeValue,
+			data.PercentFreeSpace*1024*1024,
+			data.Name,
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.idleTime,
+		prometheus.CounterValue,
+		data.PercentIdleTime,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.splitIOs,
+		prometheus.CounterValue,
+		data.SplitIOPerSec,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.readLatency,
+		prometheus.CounterValue,
+		data.AvgDiskSecPerRead*pdh.TicksToSecondScaleFactor,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.writeLatency,
+		prometheus.CounterValue,
+		data.AvgDiskSecPerWrite*pdh.TicksToSecondScaleFactor,
+		data.Name,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.readWriteLatency,
+		prometheus.CounterValue,
+		data.AvgDiskSecPerTransfer*pdh.TicksToSecondScaleFactor,
+		data.Name,
+	)
+
+	c.collectIOSize(ch, data.Name, "read", data.AvgDiskBytesPerRead, data.AvgDiskBytesPerReadBase)
+	c.collectIOSize(ch, data.Name, "write", data.AvgDiskBytesPerWrite, data.AvgDiskBytesPerWriteBase)
+}
+
+// collectIOSize updates and sends the I/O size histogram of the given volume and operation.
+// bytes and ops are the raw values of the Avg. Disk Bytes/Read or Avg. Disk Bytes/Write counter.
+func (c *Collector) collectIOSize(ch chan<- prometheus.Metric, volume, operation string, bytes, ops float64) {
+	key := ioSizeKey{volume: volume, operation: operation}
+
+	histogram, ok := c.ioSizeHistograms[key]
+	if !ok {
+		histogram = newIOSizeHistogram(c.config.IOSizeBuckets)
+		c.ioSizeHistograms[key] = histogram
+	}
+
+	histogram.observe(c.config.IOSizeBuckets, bytes, ops)
+
+	ch <- prometheus.MustNewConstHistogram(
+		c.ioSize,
+		histogram.count,
+		histogram.sum,
+		histogram.cumulativeBuckets(c.config.IOSizeBuckets),
+		volume,
+		operation,
+	)
+}
+
+// collectUSNJournal sends the USN change journal metrics of the given volume.
+// Volumes without an active journal and file systems without journal support are skipped.
+func (c *Collector) collectUSNJournal(ch chan<- prometheus.Metric, volumes mountedVolumes, volume, filesystem string, apiDuration *time.Duration) {
+	if filesystem != "NTFS" && filesystem != "ReFS" {
+		return
+	}
+
+	startTime := time.Now()
+	journal, err := getUSNJournal(volumes, volume)
+	*apiDuration += time.Since(startTime)
+
+	if err != nil {
+		if errors.Is(err, errNoUSNJournal) {
+			c.logger.Debug("skipping USN journal for "+volume,
+				slog.Any("err", err),
+			)
+		} else {
+			c.logger.Warn("failed to get USN journal for "+volume,
+				slog.Any("err", err),
+			)
 		}
+
+		return
 	}
 
-	return nil
+	ch <- prometheus.MustNewConstMetric(
+		c.usnJournalSize,
+		prometheus.GaugeValue,
+		journal.sizeBytes(),
+		volume,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.usnJournalMaxSize,
+		prometheus.GaugeValue,
+		float64(journal.maximumSize),
+		volume,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.usnJournalNextUSN,
+		prometheus.CounterValue,
+		float64(journal.nextUSN),
+		volume,
+	)
+}
+
+// logVolumeInfoError logs a failed volume information lookup. Failures on drives,
+// which may not contain media, e.g. an empty DVD drive, are expected and logged at debug level.
+// Other failures are logged as warning and counted in windows_logical_disk_info_errors_total.
+func (c *Collector) logVolumeInfoError(volume, volumeType string, err error) {
+	if isRemovableDriveType(volumeType) {
+		c.logger.Debug("failed to get volume information for "+volume,
+			slog.String("type", volumeType),
+			slog.Any("err", err),
+		)
+
+		return
+	}
+
+	c.infoErrors++
+
+	c.logger.Warn("failed to get volume information for "+volume,
+		slog.Any("err", err),
+	)
+}
+
+// isRemovableDriveType reports whether a drive of the given type may not contain media.
+func isRemovableDriveType(volumeType string) bool {
+	switch volumeType {
+	case "cdrom", "removable", "norootdir":
+		return true
+	default:
+		return false
+	}
 }
 
 func getDriveType(driveType uint32) string {
@@ -575,21 +1161,24 @@ func getDriveType(driveType uint32) string {
 	}
 }
 
-// diskExtentSize Size of the DiskExtent structure in bytes.
-const diskExtentSize = 24
+// validateDriveTypes returns an error if any of the given drive types is unknown.
+func validateDriveTypes(values []string) error {
+	for _, driveType := range values {
+		if !slices.Contains(driveTypes, driveType) {
+			return fmt.Errorf("unknown drive type %q. Possible values: %s", driveType, strings.Join(driveTypes, ", "))
+		}
+	}
 
-// getDiskIDByVolume returns the disk ID for a given volume.
-func getVolumeInfo(volumes map[string]string, rootDrive string) (volumeInfo, error) {
-	volumePath := rootDrive
+	return nil
+}
 
-	// If rootDrive is a NTFS directory, convert it to a volume GUID.
-	if volumeGUID, ok := volumes[rootDrive]; ok {
-		// GetVolumeNameForVolumeMountPoint returns the volume GUID path as \\?\Volume{GUID}\
-		// According https://learn.microsoft.com/en-us/windows/win32/api/ioapiset/nf-ioapiset-deviceiocontrol#remarks
-		// Win32 Drive Namespace is prefixed with \\.\, so we need to remove the \\?\ prefix.
-		volumePath, _ = strings.CutPrefix(volumeGUID, `\\?\`)
-	}
+// diskExtentSize Size of the DiskExtent structure in bytes.
+const diskExtentSize = 24
 
+// openVolume opens a handle to the given volume without requesting any access rights.
+// It returns the handle and the volume path in the Win32 drive namespace, without the \\.\ prefix.
+func openVolume(volumes mountedVolumes, rootDrive string) (windows.Handle, string, error) {
+	volumePath := volumeDrivePath(volumes, rootDrive)
 	volumePathPtr := windows.StringToUTF16Ptr(`\\.\` + volumePath)
 
 	// mode has to include FILE_SHARE permission to allow concurrent access to the disk.
@@ -599,7 +1188,45 @@ func getVolumeInfo(volumes map[string]string, rootDrive string) (volumeInfo, err
 
 	volumeHandle, err := windows.CreateFile(volumePathPtr, 0, mode, nil, windows.OPEN_EXISTING, attr, 0)
 	if err != nil {
-		return volumeInfo{}, fmt.Errorf("could not open volume for %s: %w", rootDrive, err)
+		return windows.InvalidHandle, "", fmt.Errorf("could not open volume for %s: %w", rootDrive, err)
+	}
+
+	return volumeHandle, volumePath, nil
+}
+
+// volumeDrivePath returns the path of the given volume in the Win32 drive namespace, without the \\.\ prefix.
+func volumeDrivePath(volumes mountedVolumes, rootDrive string) string {
+	// If rootDrive is a NTFS directory or a device name, convert it to a volume GUID.
+	if volumeGUID, ok := volumes.guidOf(rootDrive); ok {
+		// GetVolumeNameForVolumeMountPoint returns the volume GUID path as \\?\Volume{GUID}\
+		// According https://learn.microsoft.com/en-us/windows/win32/api/ioapiset/nf-ioapiset-deviceiocontrol#remarks
+		// Win32 Drive Namespace is prefixed with \\.\, so we need to remove the \\?\ prefix.
+		volumePath, _ := strings.CutPrefix(volumeGUID, `\\?\`)
+
+		return volumePath
+	}
+
+	return rootDrive
+}
+
+// getVolumeInfo returns the disk IDs and volume information for a given volume.
+// The drive type is determined first and returned together with any error,
+// so the caller can tell expected failures on drives without media from genuine ones.
+func getVolumeInfo(volumes mountedVolumes, rootDrive string) (volumeInfo, error) {
+	volumePath := volumeDrivePath(volumes, rootDrive)
+	volumeInformationRootDrive := volumePath + `\`
+
+	if strings.Contains(volumePath, `Volume`) {
+		volumeInformationRootDrive = `\\?\` + volumeInformationRootDrive
+	}
+
+	volumeInformationRootDrivePtr := windows.StringToUTF16Ptr(volumeInformationRootDrive)
+	driveType := windows.GetDriveType(volumeInformationRootDrivePtr)
+	info := volumeInfo{volumeType: getDriveType(driveType)}
+
+	volumeHandle, _, err := openVolume(volumes, rootDrive)
+	if err != nil {
+		return info, err
 	}
 
 	defer func(fd windows.Handle) {
@@ -613,12 +1240,12 @@ func getVolumeInfo(volumes map[string]string, rootDrive string) (volumeInfo, err
 
 	err = windows.DeviceIoControl(volumeHandle, controlCode, nil, 0, &volumeDiskExtents[0], uint32(len(volumeDiskExtents)), &bytesReturned, nil)
 	if err != nil {
-		return volumeInfo{}, fmt.Errorf("could not identify physical drive for %s: %w", rootDrive, err)
+		return info, fmt.Errorf("could not identify physical drive for %s: %w", rootDrive, err)
 	}
 
 	numDiskIDs := uint(binary.LittleEndian.Uint32(volumeDiskExtents))
 	if numDiskIDs < 1 {
-		return volumeInfo{}, fmt.Errorf("could not identify physical drive for %s: no disk IDs returned", rootDrive)
+		return info, fmt.Errorf("could not identify physical drive for %s: no disk IDs returned", rootDrive)
 	}
 
 	diskIDs := make([]string, numDiskIDs)
@@ -630,14 +1257,6 @@ func getVolumeInfo(volumes map[string]string, rootDrive string) (volumeInfo, err
 	slices.Sort(diskIDs)
 	diskIDs = slices.Compact(diskIDs)
 
-	volumeInformationRootDrive := volumePath + `\`
-
-	if strings.Contains(volumePath, `Volume`) {
-		volumeInformationRootDrive = `\\?\` + volumeInformationRootDrive
-	}
-
-	volumeInformationRootDrivePtr := windows.StringToUTF16Ptr(volumeInformationRootDrive)
-	driveType := windows.GetDriveType(volumeInformationRootDrivePtr)
 	volBufLabel := make([]uint16, windows.MAX_PATH+1)
 	volSerialNum := uint32(0)
 	fsFlags := uint32(0)
@@ -651,36 +1270,90 @@ func getVolumeInfo(volumes map[string]string, rootDrive string) (volumeInfo, err
 	)
 	if err != nil {
 		if driveType == windows.DRIVE_CDROM || driveType == windows.DRIVE_REMOVABLE {
-			return volumeInfo{}, nil
+			return info, nil
 		}
 
-		return volumeInfo{}, fmt.Errorf("could not get volume information for %s: %w", volumeInformationRootDrive, err)
+		return info, fmt.Errorf("could not get volume information for %s: %w", volumeInformationRootDrive, err)
 	}
 
 	return volumeInfo{
 		diskIDs:      strings.Join(diskIDs, ";"),
-		volumeType:   getDriveType(driveType),
+		volumeType:   info.volumeType,
 		label:        windows.UTF16PtrToString(&volBufLabel[0]),
 		filesystem:   windows.UTF16PtrToString(&volBufType[0]),
 		serialNumber: fmt.Sprintf("%X", volSerialNum),
 		readonly:     float64(fsFlags & windows.FILE_READ_ONLY_VOLUME),
+		fsFlags:      fsFlags,
 	}, nil
 }
 
-func getAllMountedVolumes() (map[string]string, error) {
+// mountedVolumes contains the mount points of all mounted volumes.
+type mountedVolumes struct {
+	// guids maps each mount point without trailing backslash to the volume GUID path.
+	guids map[string]string
+	// devices maps the device name (e.g. HarddiskVolume12) to the volume GUID path.
+	devices map[string]string
+	// mountPoints maps the volume GUID path to its sorted mount points.
+	mountPoints map[string][]string
+	// resolveMountPoint returns the volume GUID path of a mount point, which is not enumerated by FindFirstVolume.
+	resolveMountPoint func(mountPoint string) (string, error)
+}
+
+// guidOf returns the volume GUID path of a mount point or device name, as used by the LogicalDisk instances.
+func (v mountedVolumes) guidOf(name string) (string, bool) {
+	if volumeGUID, ok := v.guids[name]; ok {
+		return volumeGUID, true
+	}
+
+	if volumeGUID, ok := v.devices[name]; ok {
+		return volumeGUID, true
+	}
+
+	// Cluster Shared Volumes are not enumerated by FindFirstVolume, but their
+	// mount points like C:\ClusterStorage\Volume1 are reported as LogicalDisk instances.
+	if strings.Contains(name, `\`) && v.resolveMountPoint != nil {
+		if volumeGUID, err := v.resolveMountPoint(name); err == nil {
+			return volumeGUID, true
+		}
+	}
+
+	return "", false
+}
+
+// bareGUID returns the GUID of a volume GUID path without the decoration, e.g. \\?\Volume{GUID} -> GUID.
+func bareGUID(volumeGUID string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(volumeGUID, `\\?\Volume{`), "}")
+}
+
+// mountPointsOf returns all mount points of the volume, e.g. drive letters and NTFS folders.
+func (v mountedVolumes) mountPointsOf(name string) []string {
+	volumeGUID, ok := v.guidOf(name)
+	if !ok {
+		return nil
+	}
+
+	return v.mountPoints[volumeGUID]
+}
+
+func getAllMountedVolumes() (mountedVolumes, error) {
 	guidBuf := make([]uint16, windows.MAX_PATH+1)
 	guidBufLen := uint32(len(guidBuf) * 2)
 
 	hFindVolume, err := windows.FindFirstVolume(&guidBuf[0], guidBufLen)
 	if err != nil {
-		return nil, fmt.Errorf("FindFirstVolume: %w", err)
+		return mountedVolumes{}, fmt.Errorf("FindFirstVolume: %w", err)
 	}
 
 	defer func() {
 		_ = windows.FindVolumeClose(hFindVolume)
 	}()
 
-	volumes := map[string]string{}
+	volumes := mountedVolumes{
+		guids:             map[string]string{},
+		devices:           map[string]string{},
+		mountPoints:       map[string][]string{},
+		resolveMountPoint: getVolumeNameForMountPoint,
+	}
 
 	for ; ; err = windows.FindNextVolume(hFindVolume, &guidBuf[0], guidBufLen) {
 		if err != nil {
@@ -688,14 +1361,14 @@ func getAllMountedVolumes() (map[string]string, error) {
 			case errors.Is(err, windows.ERROR_NO_MORE_FILES):
 				return volumes, nil
 			default:
-				return nil, fmt.Errorf("FindNextVolume: %w", err)
+				return mountedVolumes{}, fmt.Errorf("FindNextVolume: %w", err)
 			}
 		}
 
 		var rootPathLen uint32
 
 		rootPathBuf := make([]uint16, windows.MAX_PATH+1)
-		rootPathBufLen := uint32(len(rootPathBuf) * 2)
+		rootPathBufLen := uint32(len(rootPathBuf))
 
 		for {
 			err = windows.GetVolumePathNamesForVolumeName(&guidBuf[0], &rootPathBuf[0], rootPathBufLen, &rootPathLen)
@@ -708,26 +1381,60 @@ func getAllMountedVolumes() (map[string]string, error) {
 				break
 			}
 
-			if errors.Is(err, windows.ERROR_NO_MORE_FILES) {
-				rootPathBuf = make([]uint16, (rootPathLen+1)/2)
+			if errors.Is(err, windows.ERROR_NO_MORE_FILES) || errors.Is(err, windows.ERROR_MORE_DATA) {
+				rootPathBuf = make([]uint16, rootPathLen+1)
+				rootPathBufLen = uint32(len(rootPathBuf))
 
 				continue
 			}
 
-			return nil, fmt.Errorf("GetVolumePathNamesForVolumeName: %w", err)
+			return mountedVolumes{}, fmt.Errorf("GetVolumePathNamesForVolumeName: %w", err)
 		}
 
-		mountPoint := windows.UTF16ToString(rootPathBuf)
+		// The volume path names are a MULTI_SZ list, since a volume can be mounted on multiple paths.
+		mountPoints := make([]string, 0, 1)
+
+		for _, mountPoint := range win32.ParseMultiSz(rootPathBuf) {
+			mountPoints = append(mountPoints, strings.TrimSuffix(windows.UTF16ToString(mountPoint), `\`))
+		}
 
 		// Skip unmounted volumes
-		if len(mountPoint) == 0 {
+		if len(mountPoints) == 0 {
 			continue
 		}
 
-		volumes[strings.TrimSuffix(mountPoint, `\`)] = strings.TrimSuffix(windows.UTF16ToString(guidBuf), `\`)
+		volumeGUID := strings.TrimSuffix(windows.UTF16ToString(guidBuf), `\`)
+
+		slices.Sort(mountPoints)
+		volumes.mountPoints[volumeGUID] = mountPoints
+
+		for _, mountPoint := range mountPoints {
+			volumes.guids[mountPoint] = volumeGUID
+		}
+
+		if deviceName, err := getVolumeDeviceName(volumeGUID); err == nil {
+			volumes.devices[deviceName] = volumeGUID
+		}
 	}
 }
 
+// getVolumeDeviceName returns the device name of a volume, e.g. HarddiskVolume12 for \\?\Volume{GUID}.
+// LogicalDisk instances of volumes without a drive letter are named after the device.
+func getVolumeDeviceName(volumeGUID string) (string, error) {
+	volumeName, err := windows.UTF16PtrFromString(strings.TrimPrefix(volumeGUID, `\\?\`))
+	if err != nil {
+		return "", err
+	}
+
+	targetBuf := make([]uint16, windows.MAX_PATH+1)
+
+	if _, err = windows.QueryDosDevice(volumeName, &targetBuf[0], uint32(len(targetBuf))); err != nil {
+		return "", fmt.Errorf("QueryDosDevice: %w", err)
+	}
+
+	return strings.TrimPrefix(windows.UTF16ToString(targetBuf), `\Device\`), nil
+}
+
 /*
 ++ References
 
@@ -814,22 +1521,44 @@ func (c *Collector) workerBitlocker(ctx context.Context, initErrCh chan<- error)
 		return
 	}
 
+	// The encryption percentage is only available via WMI, which requires elevated privileges.
+	// Otherwise, it is derived from the shell property for fully encrypted or decrypted volumes.
+	wmiService, err := connectEncryptableVolumeWMI()
+	if err != nil {
+		level := slog.LevelDebug
+		if windows.GetCurrentProcessToken().IsElevated() {
+			// Non-elevated processes are already reported once in Build.
+			level = slog.LevelWarn
+		}
+
+		c.logger.Log(ctx, level, "Win32_EncryptableVolume is not accessible, BitLocker encryption percentage is derived from the status and key protectors and encryption method are not collected",
+			slog.Any("err", err),
+		)
+	} else {
+		defer wmiService.Release()
+	}
+
 	close(initErrCh)
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case path, ok := <-c.bitlockerReqCh:
+		case request, ok := <-c.bitlockerReqCh:
 			if !ok {
 				return
 			}
 
+			path := request.path
+
+			if time.Now().After(request.deadline) {
+				request.resCh <- bitlockerResult{err: errBitlockerTimeout, status: -1, encryptionPercent: math.NaN()}
+
+				continue
+			}
+
 			if !strings.Contains(path, `:`) {
-				c.bitlockerResCh <- struct {
-					err    error
-					status int
-				}{err: nil, status: -1}
+				request.resCh <- bitlockerResult{err: nil, status: -1, encryptionPercent: math.NaN()}
 
 				continue
 			}
@@ -851,10 +1580,54 @@ func (c *Collector) workerBitlocker(ctx context.Context, initErrCh chan<- error)
 				return int(v.Val), v.Clear()
 			}(path)
 
-			c.bitlockerResCh <- struct {
-				err    error
-				status int
-			}{err: err, status: status}
+			result := bitlockerResult{err: err, status: status, encryptionPercent: encryptionPercentFromStatus(status)}
+
+			if wmiService != nil {
+				c.queryEncryptableVolume(ctx, wmiService, path, &result)
+			}
+
+			request.resCh <- result
+		}
+	}
+}
+
+// queryEncryptableVolume sets the encryption percentage, the encryption method and the key protector types
+// of the result from Win32_EncryptableVolume. If the encryption percentage can't be queried, the value derived
+// from the status is kept.
+func (c *Collector) queryEncryptableVolume(ctx context.Context, wmiService *ole.IDispatch, path string, result *bitlockerResult) {
+	volume, err := getEncryptableVolume(wmiService, path)
+	if err != nil {
+		if !errors.Is(err, errEncryptableVolumeNotFound) {
+			c.logger.DebugContext(ctx, "failed to get Win32_EncryptableVolume for "+path,
+				slog.Any("err", err),
+			)
 		}
+
+		return
+	}
+
+	defer volume.Release()
+
+	if percent, err := getEncryptionPercentage(volume); err == nil {
+		result.encryptionPercent = percent
+	} else {
+		c.logger.DebugContext(ctx, "failed to get BitLocker encryption percentage for "+path,
+			slog.Any("err", err),
+		)
+	}
+
+	if method, err := getEncryptionMethod(volume); err == nil {
+		result.encryptionMethod = method
+	} else {
+		c.logger.DebugContext(ctx, "failed to get BitLocker encryption method for "+path,
+			slog.Any("err", err),
+		)
+	}
+
+	result.keyProtectors, err = getKeyProtectors(volume)
+	if err != nil {
+		c.logger.DebugContext(ctx, "failed to get BitLocker key protectors for "+path,
+			slog.Any("err", err),
+		)
 	}
 }
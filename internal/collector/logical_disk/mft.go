@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// fsctlGetNTFSVolumeData is FSCTL_GET_NTFS_VOLUME_DATA.
+	// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ni-winioctl-fsctl_get_ntfs_volume_data
+	fsctlGetNTFSVolumeData = 0x00090064
+	// fsctlGetRetrievalPointers is FSCTL_GET_RETRIEVAL_POINTERS.
+	// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ni-winioctl-fsctl_get_retrieval_pointers
+	fsctlGetRetrievalPointers = 0x00090073
+
+	// ntfsVolumeDataSize is the size of NTFS_VOLUME_DATA_BUFFER.
+	ntfsVolumeDataSize = 96
+	// ntfsVolumeDataBufferSize leaves room for the NTFS_EXTENDED_VOLUME_DATA that follows
+	// NTFS_VOLUME_DATA_BUFFER, if the output buffer is large enough. The extended structure
+	// grew over time, e.g. Server 2022 adds the trim limits.
+	ntfsVolumeDataBufferSize = 256
+
+	// retrievalPointersHeaderSize is the offset of the Extents array in RETRIEVAL_POINTERS_BUFFER.
+	retrievalPointersHeaderSize = 16
+	// retrievalPointersExtentSize is the size of a single extent in RETRIEVAL_POINTERS_BUFFER.
+	retrievalPointersExtentSize = 16
+	// retrievalPointersMaxExtents limits the number of extents returned by a single DeviceIoControl call.
+	retrievalPointersMaxExtents = 512
+)
+
+// errNotNTFS is returned if the volume is not formatted with NTFS.
+var errNotNTFS = errors.New("not an NTFS volume")
+
+type ntfsVolumeData struct {
+	bytesPerCluster           uint32
+	bytesPerFileRecordSegment uint32
+	mftValidDataLength        uint64
+}
+
+type mftExtents struct {
+	allocatedClusters uint64
+	fragments         int
+}
+
+// collectMFT sends the MFT metrics of the given NTFS volume.
+// The record count is derived from the in-use size and the size of a file record segment.
+// The allocated size and the fragment count are read from the extents of the $MFT file,
+// which can only be opened with administrative privileges. If this fails, only the
+// in-use size is reported.
+func (c *Collector) collectMFT(ch chan<- prometheus.Metric, volumes mountedVolumes, volume, filesystem string, apiDuration *time.Duration) {
+	if filesystem != "NTFS" {
+		return
+	}
+
+	startTime := time.Now()
+	volumeData, extents, err := getMFTInfo(volumes, volume)
+	*apiDuration += time.Since(startTime)
+
+	if err != nil && volumeData.bytesPerCluster == 0 {
+		if errors.Is(err, errNotNTFS) || errors.Is(err, windows.ERROR_NOT_READY) {
+			c.logger.Debug("skipping MFT metrics for "+volume,
+				slog.Any("err", err),
+			)
+		} else {
+			c.logger.Warn("failed to get NTFS volume data for "+volume,
+				slog.Any("err", err),
+			)
+		}
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.mftInUse,
+		prometheus.GaugeValue,
+		float64(volumeData.mftValidDataLength),
+		volume,
+	)
+
+	if volumeData.bytesPerFileRecordSegment != 0 {
+		ch <- prometheus.MustNewConstMetric(
+			c.mftRecords,
+			prometheus.GaugeValue,
+			float64(volumeData.mftValidDataLength/uint64(volumeData.bytesPerFileRecordSegment)),
+			volume,
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.clusterSize,
+		prometheus.GaugeValue,
+		float64(volumeData.bytesPerCluster),
+		volume,
+	)
+
+	if err != nil {
+		c.logger.Debug("skipping MFT extents for "+volume,
+			slog.Any("err", err),
+		)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.mftAllocated,
+		prometheus.GaugeValue,
+		float64(extents.allocatedClusters)*float64(volumeData.bytesPerCluster),
+		volume,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.mftFragments,
+		prometheus.GaugeValue,
+		float64(extents.fragments),
+		volume,
+	)
+}
+
+// getMFTInfo returns the NTFS volume data and the extents of the $MFT file of the given volume.
+// If only the extents could not be read, the volume data is returned together with the error.
+func getMFTInfo(volumes mountedVolumes, rootDrive string) (ntfsVolumeData, mftExtents, error) {
+	volumeHandle, volumePath, err := openVolume(volumes, rootDrive)
+	if err != nil {
+		return ntfsVolumeData{}, mftExtents{}, err
+	}
+
+	defer func(fd windows.Handle) {
+		_ = windows.Close(fd)
+	}(volumeHandle)
+
+	volumeData, err := getNTFSVolumeData(volumeHandle)
+	if err != nil {
+		return ntfsVolumeData{}, mftExtents{}, fmt.Errorf("could not get NTFS volume data for %s: %w", rootDrive, err)
+	}
+
+	extents, err := getMFTExtents(volumePath)
+	if err != nil {
+		return volumeData, mftExtents{}, fmt.Errorf("could not get MFT extents for %s: %w", rootDrive, err)
+	}
+
+	return volumeData, extents, nil
+}
+
+// getNTFSVolumeData queries FSCTL_GET_NTFS_VOLUME_DATA on the given volume handle.
+func getNTFSVolumeData(volumeHandle windows.Handle) (ntfsVolumeData, error) {
+	buf := make([]byte, ntfsVolumeDataBufferSize)
+
+	var bytesReturned uint32
+
+	err := windows.DeviceIoControl(volumeHandle, fsctlGetNTFSVolumeData, nil, 0, &buf[0], uint32(len(buf)), &bytesReturned, nil)
+	if err != nil {
+		switch {
+		// The extended volume data did not fit into the buffer, but NTFS_VOLUME_DATA_BUFFER is complete.
+		case errors.Is(err, windows.ERROR_MORE_DATA) && bytesReturned >= ntfsVolumeDataSize:
+		case errors.Is(err, windows.ERROR_INVALID_FUNCTION), errors.Is(err, windows.ERROR_INVALID_PARAMETER):
+			return ntfsVolumeData{}, fmt.Errorf("%w: %w", errNotNTFS, err)
+		default:
+			return ntfsVolumeData{}, err
+		}
+	}
+
+	return parseNTFSVolumeData(buf[:bytesReturned])
+}
+
+// parseNTFSVolumeData parses the NTFS_VOLUME_DATA_BUFFER part of the output of FSCTL_GET_NTFS_VOLUME_DATA.
+// The optional NTFS_EXTENDED_VOLUME_DATA is ignored.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ns-winioctl-ntfs_volume_data_buffer
+func parseNTFSVolumeData(buf []byte) (ntfsVolumeData, error) {
+	if len(buf) < ntfsVolumeDataSize {
+		return ntfsVolumeData{}, fmt.Errorf("NTFS volume data too short: %d bytes", len(buf))
+	}
+
+	return ntfsVolumeData{
+		bytesPerCluster:           binary.LittleEndian.Uint32(buf[44:]),
+		bytesPerFileRecordSegment: binary.LittleEndian.Uint32(buf[48:]),
+		mftValidDataLength:        binary.LittleEndian.Uint64(buf[56:]),
+	}, nil
+}
+
+// getMFTExtents reads the extents of the $MFT file of the given volume.
+// volumePath is the volume path in the Win32 drive namespace, as returned by openVolume.
+func getMFTExtents(volumePath string) (mftExtents, error) {
+	mftPath, err := windows.UTF16PtrFromString(`\\?\` + strings.TrimSuffix(volumePath, `\`) + `\$MFT`)
+	if err != nil {
+		return mftExtents{}, err
+	}
+
+	mode := uint32(windows.FILE_SHARE_READ | windows.FILE_SHARE_WRITE | windows.FILE_SHARE_DELETE)
+
+	mftHandle, err := windows.CreateFile(mftPath, windows.FILE_READ_ATTRIBUTES, mode, nil, windows.OPEN_EXISTING, windows.FILE_FLAG_BACKUP_SEMANTICS, 0)
+	if err != nil {
+		return mftExtents{}, err
+	}
+
+	defer func(fd windows.Handle) {
+		_ = windows.Close(fd)
+	}(mftHandle)
+
+	var (
+		extents       mftExtents
+		startingVCN   int64
+		bytesReturned uint32
+	)
+
+	in := make([]byte, 8)
+	buf := make([]byte, retrievalPointersHeaderSize+retrievalPointersMaxExtents*retrievalPointersExtentSize)
+
+	for {
+		binary.LittleEndian.PutUint64(in, uint64(startingVCN))
+
+		err = windows.DeviceIoControl(mftHandle, fsctlGetRetrievalPointers, &in[0], uint32(len(in)), &buf[0], uint32(len(buf)), &bytesReturned, nil)
+		if err != nil && !errors.Is(err, windows.ERROR_MORE_DATA) {
+			return mftExtents{}, err
+		}
+
+		nextVCN, parseErr := parseRetrievalPointers(buf[:bytesReturned], &extents)
+		if parseErr != nil {
+			return mftExtents{}, parseErr
+		}
+
+		// ERROR_MORE_DATA is returned, if the extents did not fit into the buffer.
+		if err == nil || nextVCN <= startingVCN {
+			return extents, nil
+		}
+
+		startingVCN = nextVCN
+	}
+}
+
+// parseRetrievalPointers adds the extents of a RETRIEVAL_POINTERS_BUFFER to the given mftExtents
+// and returns the next VCN after the last extent.
+// Extents without an LCN, i.e. sparse or compressed ranges, are not counted as fragments.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ns-winioctl-retrieval_pointers_buffer
+func parseRetrievalPointers(buf []byte, extents *mftExtents) (int64, error) {
+	if len(buf) < retrievalPointersHeaderSize {
+		return 0, fmt.Errorf("retrieval pointers too short: %d bytes", len(buf))
+	}
+
+	extentCount := int(binary.LittleEndian.Uint32(buf))
+	if retrievalPointersHeaderSize+extentCount*retrievalPointersExtentSize > len(buf) {
+		return 0, fmt.Errorf("retrieval pointers report %d extents, but only %d bytes were returned", extentCount, len(buf))
+	}
+
+	vcn := int64(binary.LittleEndian.Uint64(buf[8:]))
+
+	for i := range extentCount {
+		extent := buf[retrievalPointersHeaderSize+i*retrievalPointersExtentSize:][:retrievalPointersExtentSize]
+
+		nextVCN := int64(binary.LittleEndian.Uint64(extent[0:]))
+		lcn := int64(binary.LittleEndian.Uint64(extent[8:]))
+
+		if lcn != -1 {
+			extents.allocatedClusters += uint64(nextVCN - vcn)
+			extents.fragments++
+		}
+
+		vcn = nextVCN
+	}
+
+	return vcn, nil
+}
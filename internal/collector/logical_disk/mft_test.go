@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNTFSVolumeData(t *testing.T) {
+	t.Parallel()
+
+	buf := make([]byte, ntfsVolumeDataBufferSize)
+	binary.LittleEndian.PutUint32(buf[40:], 512)     // BytesPerSector
+	binary.LittleEndian.PutUint32(buf[44:], 4096)    // BytesPerCluster
+	binary.LittleEndian.PutUint32(buf[48:], 1024)    // BytesPerFileRecordSegment
+	binary.LittleEndian.PutUint64(buf[56:], 256<<20) // MftValidDataLength
+	binary.LittleEndian.PutUint32(buf[96:], 32)      // NTFS_EXTENDED_VOLUME_DATA.ByteCount
+	binary.LittleEndian.PutUint16(buf[100:], 3)      // NTFS_EXTENDED_VOLUME_DATA.MajorVersion
+
+	volumeData, err := parseNTFSVolumeData(buf)
+	require.NoError(t, err)
+	require.Equal(t, ntfsVolumeData{bytesPerCluster: 4096, bytesPerFileRecordSegment: 1024, mftValidDataLength: 256 << 20}, volumeData)
+
+	volumeData, err = parseNTFSVolumeData(buf[:ntfsVolumeDataSize])
+	require.NoError(t, err)
+	require.Equal(t, ntfsVolumeData{bytesPerCluster: 4096, bytesPerFileRecordSegment: 1024, mftValidDataLength: 256 << 20}, volumeData)
+
+	_, err = parseNTFSVolumeData(buf[:ntfsVolumeDataSize-1])
+	require.Error(t, err)
+}
+
+func TestParseRetrievalPointers(t *testing.T) {
+	t.Parallel()
+
+	buf := make([]byte, retrievalPointersHeaderSize+3*retrievalPointersExtentSize)
+	binary.LittleEndian.PutUint32(buf[0:], 3)  // ExtentCount
+	binary.LittleEndian.PutUint64(buf[8:], 16) // StartingVcn
+
+	for i, extent := range [][2]int64{{48, 786432}, {64, -1}, {128, 1048576}} {
+		binary.LittleEndian.PutUint64(buf[retrievalPointersHeaderSize+i*retrievalPointersExtentSize:], uint64(extent[0]))
+		binary.LittleEndian.PutUint64(buf[retrievalPointersHeaderSize+i*retrievalPointersExtentSize+8:], uint64(extent[1]))
+	}
+
+	extents := mftExtents{allocatedClusters: 16, fragments: 1}
+
+	nextVCN, err := parseRetrievalPointers(buf, &extents)
+	require.NoError(t, err)
+	require.Equal(t, int64(128), nextVCN)
+	require.Equal(t, mftExtents{allocatedClusters: 16 + 32 + 64, fragments: 3}, extents)
+
+	binary.LittleEndian.PutUint32(buf[0:], 4)
+
+	_, err = parseRetrievalPointers(buf, &extents)
+	require.Error(t, err)
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+// collectMountPoints sends the information and space of every mounted volume, read from the volumes directly.
+// In contrast to the LogicalDisk performance counters, each mount point of a volume is included.
+func (c *Collector) collectMountPoints(ch chan<- prometheus.Metric, volumes mountedVolumes) {
+	for volumeGUID, mountPoints := range volumes.mountPoints {
+		included := make([]string, 0, len(mountPoints))
+
+		for _, mountPoint := range mountPoints {
+			if c.config.VolumeExclude.MatchString(mountPoint) || !c.config.VolumeInclude.MatchString(mountPoint) {
+				continue
+			}
+
+			included = append(included, mountPoint)
+		}
+
+		if len(included) == 0 {
+			continue
+		}
+
+		guid := bareGUID(volumeGUID)
+
+		info, err := getVolumeInfo(volumes, included[0])
+		if err != nil {
+			c.logger.Warn("failed to get volume information for "+volumeGUID,
+				slog.Any("err", err),
+			)
+		}
+
+		for _, mountPoint := range included {
+			ch <- prometheus.MustNewConstMetric(
+				c.mountInfo,
+				prometheus.GaugeValue,
+				1,
+				guid,
+				mountPoint,
+				info.filesystem,
+				info.label,
+			)
+		}
+
+		space, err := getDiskFreeSpace(volumeGUID)
+		if err != nil {
+			// Volumes without media, e.g. empty card readers, have no free space information.
+			c.logger.Debug("failed to get free space for "+volumeGUID,
+				slog.Any("err", err),
+			)
+
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.mountFreeBytes,
+			prometheus.GaugeValue,
+			float64(space.free),
+			guid,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.mountSizeBytes,
+			prometheus.GaugeValue,
+			float64(space.total),
+			guid,
+		)
+	}
+}
+
+type diskSpace struct {
+	free  uint64
+	total uint64
+	// available is the free space available to the calling user, which is limited by disk quotas.
+	available uint64
+}
+
+// getDiskFreeSpace returns the space of the volume with the given volume GUID path.
+func getDiskFreeSpace(volumeGUID string) (diskSpace, error) {
+	rootPath, err := windows.UTF16PtrFromString(volumeGUID + `\`)
+	if err != nil {
+		return diskSpace{}, err
+	}
+
+	var space diskSpace
+
+	if err = windows.GetDiskFreeSpaceEx(rootPath, &space.available, &space.total, &space.free); err != nil {
+		return diskSpace{}, err
+	}
+
+	return space, nil
+}
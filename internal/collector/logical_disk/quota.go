@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"slices"
+
+	"github.com/go-ole/go-ole"
+	"github.com/prometheus-community/windows_exporter/internal/headers/dskquota"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+// errQuotaDisabled is returned if quotas are neither tracked nor enforced on the volume.
+var errQuotaDisabled = errors.New("disk quotas are disabled")
+
+// quotaUser is a single NTFS quota entry of a volume.
+type quotaUser struct {
+	sid  *windows.SID
+	info dskquota.UserInformation
+}
+
+// collectQuota sends the NTFS quota entries of the given volumes.
+// Volumes without quota support or with disabled quotas are skipped.
+func (c *Collector) collectQuota(ch chan<- prometheus.Metric, volumes mountedVolumes, quotaVolumes []string) {
+	// The quota control objects are bound to the COM apartment of the current OS thread.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := ole.CoInitializeEx(0, ole.COINIT_MULTITHREADED); err != nil {
+		var oleCode *ole.OleError
+		if errors.As(err, &oleCode) && oleCode.Code() != ole.S_OK && oleCode.Code() != 0x00000001 {
+			c.logger.Warn("failed to initialize COM for quota collection",
+				slog.Any("err", err),
+			)
+
+			return
+		}
+	}
+
+	defer ole.CoUninitialize()
+
+	for _, volume := range quotaVolumes {
+		rootPath := volume + `\`
+		if volumeGUID, ok := volumes.guidOf(volume); ok {
+			rootPath = volumeGUID + `\`
+		}
+
+		users, err := getQuotaUsers(rootPath)
+		if err != nil {
+			c.logger.Debug("skipping quota collection for "+volume,
+				slog.Any("err", err),
+			)
+
+			continue
+		}
+
+		for _, user := range topQuotaUsers(users, c.config.QuotaMaxUsers) {
+			userName := c.quotaAccountName(user.sid)
+
+			ch <- prometheus.MustNewConstMetric(
+				c.quotaUsed,
+				prometheus.GaugeValue,
+				float64(user.info.QuotaUsed),
+				volume, userName,
+			)
+
+			if user.info.QuotaLimit != dskquota.NoLimit {
+				ch <- prometheus.MustNewConstMetric(
+					c.quotaLimit,
+					prometheus.GaugeValue,
+					float64(user.info.QuotaLimit),
+					volume, userName,
+				)
+			}
+
+			if user.info.QuotaThreshold != dskquota.NoLimit {
+				ch <- prometheus.MustNewConstMetric(
+					c.quotaThreshold,
+					prometheus.GaugeValue,
+					float64(user.info.QuotaThreshold),
+					volume, userName,
+				)
+			}
+		}
+	}
+}
+
+// getQuotaUsers returns all quota entries of the volume with the given root path.
+// Must be called from a thread with an initialized COM apartment.
+func getQuotaUsers(rootPath string) ([]quotaUser, error) {
+	control, err := dskquota.NewDiskQuotaControl()
+	if err != nil {
+		return nil, err
+	}
+
+	defer control.Release()
+
+	// Fails on file systems without quota support, e.g. FAT32 and ReFS.
+	if err = control.Initialize(rootPath); err != nil {
+		return nil, err
+	}
+
+	state, err := control.GetQuotaState()
+	if err != nil {
+		return nil, err
+	}
+
+	if state&dskquota.StateMask == dskquota.StateDisabled {
+		return nil, errQuotaDisabled
+	}
+
+	enum, err := control.CreateEnumUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	defer enum.Release()
+
+	var users []quotaUser
+
+	for {
+		user, err := enum.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		if user == nil {
+			return users, nil
+		}
+
+		entry, err := readQuotaUser(user)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, entry)
+	}
+}
+
+func readQuotaUser(user *dskquota.IDiskQuotaUser) (quotaUser, error) {
+	defer user.Release()
+
+	sid, err := user.GetSid()
+	if err != nil {
+		return quotaUser{}, err
+	}
+
+	info, err := user.GetQuotaInformation()
+	if err != nil {
+		return quotaUser{}, err
+	}
+
+	return quotaUser{sid: sid, info: info}, nil
+}
+
+// topQuotaUsers returns up to maxUsers quota entries with the most used bytes. 0 means unlimited.
+func topQuotaUsers(users []quotaUser, maxUsers int) []quotaUser {
+	if maxUsers <= 0 || len(users) <= maxUsers {
+		return users
+	}
+
+	users = slices.Clone(users)
+
+	slices.SortStableFunc(users, func(a, b quotaUser) int {
+		return cmp.Compare(b.info.QuotaUsed, a.info.QuotaUsed)
+	})
+
+	return users[:maxUsers]
+}
+
+// quotaAccountName resolves the SID to DOMAIN\user. The raw SID is returned, if the SID can't be resolved,
+// e.g. for deleted accounts. Resolved names are cached for the lifetime of the collector.
+func (c *Collector) quotaAccountName(sid *windows.SID) string {
+	sidString := sid.String()
+
+	if name, ok := c.quotaAccountNames[sidString]; ok {
+		return name
+	}
+
+	account, domain, _, err := sid.LookupAccount("")
+	if err != nil {
+		return sidString
+	}
+
+	name := account
+	if domain != "" {
+		name = fmt.Sprintf(`%s\%s`, domain, account)
+	}
+
+	c.quotaAccountNames[sidString] = name
+
+	return name
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/dskquota"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopQuotaUsers(t *testing.T) {
+	t.Parallel()
+
+	users := []quotaUser{
+		{info: dskquota.UserInformation{QuotaUsed: 10}},
+		{info: dskquota.UserInformation{QuotaUsed: 30}},
+		{info: dskquota.UserInformation{QuotaUsed: 20}},
+		{info: dskquota.UserInformation{QuotaUsed: 30, QuotaLimit: dskquota.NoLimit}},
+	}
+
+	used := func(users []quotaUser) []int64 {
+		values := make([]int64, 0, len(users))
+		for _, user := range users {
+			values = append(values, user.info.QuotaUsed)
+		}
+
+		return values
+	}
+
+	require.Equal(t, []int64{10, 30, 20, 30}, used(topQuotaUsers(users, 0)))
+	require.Equal(t, []int64{10, 30, 20, 30}, used(topQuotaUsers(users, 4)))
+	require.Equal(t, []int64{30, 30}, used(topQuotaUsers(users, 2)))
+	require.Equal(t, dskquota.NoLimit, int(topQuotaUsers(users, 2)[1].info.QuotaLimit))
+
+	// The input is not reordered.
+	require.Equal(t, []int64{10, 30, 20, 30}, used(users))
+}
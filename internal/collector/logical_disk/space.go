@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectSpace sends the current free, total and available space of the given volume.
+// In contrast to the LogicalDisk performance counters, the values are read on every scrape.
+func (c *Collector) collectSpace(ch chan<- prometheus.Metric, volumes mountedVolumes, volume string) {
+	volumeGUID, ok := volumes.guidOf(volume)
+	if !ok {
+		c.logger.Debug("no volume GUID found for " + volume)
+
+		return
+	}
+
+	space, err := getDiskFreeSpace(volumeGUID)
+	if err != nil {
+		// Volumes without media, e.g. empty card readers, have no free space information.
+		c.logger.Debug("failed to get free space for "+volume,
+			slog.Any("err", err),
+		)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.freeSpace,
+		prometheus.GaugeValue,
+		float64(space.free),
+		volume,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.totalSpace,
+		prometheus.GaugeValue,
+		float64(space.total),
+		volume,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.availableSpace,
+		prometheus.GaugeValue,
+		float64(space.available),
+		volume,
+	)
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// fsctlQueryUSNJournal is FSCTL_QUERY_USN_JOURNAL.
+	// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ni-winioctl-fsctl_query_usn_journal
+	fsctlQueryUSNJournal = 0x000900f4
+
+	// usnJournalDataV0Size is the size of USN_JOURNAL_DATA_V0.
+	usnJournalDataV0Size = 56
+	// usnJournalDataBufferSize is large enough for USN_JOURNAL_DATA_V2, which newer systems may return.
+	usnJournalDataBufferSize = 80
+)
+
+// errNoUSNJournal is returned if the volume does not have an active change journal.
+var errNoUSNJournal = errors.New("no active USN journal")
+
+type usnJournal struct {
+	firstUSN    int64
+	nextUSN     int64
+	maximumSize uint64
+}
+
+// sizeBytes returns the number of bytes between the first and the next USN.
+func (j usnJournal) sizeBytes() float64 {
+	return float64(j.nextUSN - j.firstUSN)
+}
+
+// getUSNJournal queries the change journal of the given volume.
+// errNoUSNJournal is returned if the volume has no active journal or the file system does not support one.
+func getUSNJournal(volumes mountedVolumes, rootDrive string) (usnJournal, error) {
+	volumeHandle, _, err := openVolume(volumes, rootDrive)
+	if err != nil {
+		return usnJournal{}, err
+	}
+
+	defer func(fd windows.Handle) {
+		_ = windows.Close(fd)
+	}(volumeHandle)
+
+	buf := make([]byte, usnJournalDataBufferSize)
+
+	var bytesReturned uint32
+
+	err = windows.DeviceIoControl(volumeHandle, fsctlQueryUSNJournal, nil, 0, &buf[0], uint32(len(buf)), &bytesReturned, nil)
+	if err != nil {
+		switch {
+		case errors.Is(err, windows.ERROR_JOURNAL_NOT_ACTIVE),
+			errors.Is(err, windows.ERROR_JOURNAL_DELETE_IN_PROGRESS),
+			errors.Is(err, windows.ERROR_INVALID_FUNCTION):
+			return usnJournal{}, fmt.Errorf("%w: %w", errNoUSNJournal, err)
+		default:
+			return usnJournal{}, fmt.Errorf("could not query USN journal for %s: %w", rootDrive, err)
+		}
+	}
+
+	return parseUSNJournalData(buf[:bytesReturned])
+}
+
+// parseUSNJournalData parses the USN_JOURNAL_DATA_V0 part of a USN_JOURNAL_DATA structure.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ns-winioctl-usn_journal_data_v0
+func parseUSNJournalData(buf []byte) (usnJournal, error) {
+	if len(buf) < usnJournalDataV0Size {
+		return usnJournal{}, fmt.Errorf("USN journal data too short: %d bytes", len(buf))
+	}
+
+	return usnJournal{
+		firstUSN:    int64(binary.LittleEndian.Uint64(buf[8:])),
+		nextUSN:     int64(binary.LittleEndian.Uint64(buf[16:])),
+		maximumSize: binary.LittleEndian.Uint64(buf[40:]),
+	}, nil
+}
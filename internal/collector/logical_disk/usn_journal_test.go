@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUSNJournalData(t *testing.T) {
+	t.Parallel()
+
+	buf := make([]byte, usnJournalDataBufferSize)
+	binary.LittleEndian.PutUint64(buf[0:], 0x01D9566400000000) // UsnJournalID
+	binary.LittleEndian.PutUint64(buf[8:], 4096)               // FirstUsn
+	binary.LittleEndian.PutUint64(buf[16:], 1<<20)             // NextUsn
+	binary.LittleEndian.PutUint64(buf[24:], 0)                 // LowestValidUsn
+	binary.LittleEndian.PutUint64(buf[32:], 1<<62)             // MaxUsn
+	binary.LittleEndian.PutUint64(buf[40:], 32<<20)            // MaximumSize
+	binary.LittleEndian.PutUint64(buf[48:], 8<<20)             // AllocationDelta
+
+	journal, err := parseUSNJournalData(buf)
+	require.NoError(t, err)
+	require.Equal(t, usnJournal{firstUSN: 4096, nextUSN: 1 << 20, maximumSize: 32 << 20}, journal)
+	require.InDelta(t, float64(1<<20-4096), journal.sizeBytes(), 0)
+
+	_, err = parseUSNJournalData(buf[:usnJournalDataV0Size-1])
+	require.Error(t, err)
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+)
+
+// volumeInfoCache caches the result of getVolumeInfo per volume GUID, since opening the volume and
+// querying the disk extents and volume information on every scrape is expensive on hosts with many volumes.
+// Expired entries are still returned and refreshed in the background, so a scrape is only blocked
+// by volumes which are not cached yet.
+type volumeInfoCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]*utils.TTLCache[volumeInfo]
+	hits    float64
+
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+func newVolumeInfoCache(ttl time.Duration) *volumeInfoCache {
+	return &volumeInfoCache{
+		ttl:     ttl,
+		entries: make(map[string]*utils.TTLCache[volumeInfo]),
+		now:     time.Now,
+	}
+}
+
+// get returns the cached volume information of the volume, or calls load, if the volume is not cached.
+// If the cached entry is older than the TTL, it is returned and load is called in the background.
+// Errors of the first load are not cached, failed background refreshes keep the previous information
+// until the next refresh. A TTL of 0 disables the cache.
+func (c *volumeInfoCache) get(volumeGUID string, load func() (volumeInfo, error)) (volumeInfo, error) {
+	if c.ttl <= 0 {
+		return load()
+	}
+
+	c.mu.RLock()
+	entry, ok := c.entries[volumeGUID]
+	c.mu.RUnlock()
+
+	if !ok {
+		info, err := load()
+		if err != nil {
+			return info, err
+		}
+
+		entry = utils.NewTTLCache[volumeInfo](c.ttl).WithClock(c.now)
+		entry.Set(info)
+
+		c.mu.Lock()
+		c.entries[volumeGUID] = entry
+		c.mu.Unlock()
+
+		return info, nil
+	}
+
+	info, _, _ := entry.Get(load)
+
+	c.mu.Lock()
+	c.hits++
+	c.mu.Unlock()
+
+	return info, nil
+}
+
+// retain removes the entries of all volumes, which are not contained in volumeGUIDs.
+func (c *volumeInfoCache) retain(volumeGUIDs map[string]struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for volumeGUID := range c.entries {
+		if _, ok := volumeGUIDs[volumeGUID]; !ok {
+			delete(c.entries, volumeGUID)
+		}
+	}
+}
+
+// wait blocks until all background refreshes finished.
+func (c *volumeInfoCache) wait() {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, entry := range c.entries {
+		entry.Wait()
+	}
+}
+
+// hitsTotal returns the number of lookups served from the cache.
+func (c *volumeInfoCache) hitsTotal() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.hits
+}
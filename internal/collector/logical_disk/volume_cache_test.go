@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeInfoCache(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newVolumeInfoCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	loads := 0
+	load := func() (volumeInfo, error) {
+		loads++
+
+		return volumeInfo{label: "label" + string(rune('0'+loads))}, nil
+	}
+
+	info, err := cache.get(`\\?\Volume{1}`, load)
+	require.NoError(t, err)
+	require.Equal(t, "label1", info.label)
+
+	// Fresh entries are served from the cache.
+	info, err = cache.get(`\\?\Volume{1}`, load)
+	require.NoError(t, err)
+	require.Equal(t, "label1", info.label)
+	require.Equal(t, 1, loads)
+	require.InDelta(t, 1, cache.hitsTotal(), 0)
+
+	// Expired entries are returned and refreshed in the background.
+	now = now.Add(2 * time.Minute)
+
+	info, err = cache.get(`\\?\Volume{1}`, load)
+	require.NoError(t, err)
+	require.Equal(t, "label1", info.label)
+
+	cache.wait()
+	require.Equal(t, 2, loads)
+
+	info, err = cache.get(`\\?\Volume{1}`, load)
+	require.NoError(t, err)
+	require.Equal(t, "label2", info.label)
+	require.InDelta(t, 3, cache.hitsTotal(), 0)
+
+	cache.retain(map[string]struct{}{})
+
+	info, err = cache.get(`\\?\Volume{1}`, load)
+	require.NoError(t, err)
+	require.Equal(t, "label3", info.label)
+}
+
+func TestVolumeInfoCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	cache := newVolumeInfoCache(time.Minute)
+	errLoad := errors.New("access denied")
+
+	_, err := cache.get("C:", func() (volumeInfo, error) {
+		return volumeInfo{}, errLoad
+	})
+	require.ErrorIs(t, err, errLoad)
+
+	// Errors are not cached.
+	info, err := cache.get("C:", func() (volumeInfo, error) {
+		return volumeInfo{label: "system"}, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "system", info.label)
+}
+
+func TestVolumeInfoCacheDisabled(t *testing.T) {
+	t.Parallel()
+
+	cache := newVolumeInfoCache(0)
+	loads := 0
+
+	for range 3 {
+		_, err := cache.get("C:", func() (volumeInfo, error) {
+			loads++
+
+			return volumeInfo{}, nil
+		})
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 3, loads)
+	require.Zero(t, cache.hitsTotal())
+}
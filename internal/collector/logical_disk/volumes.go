@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+// getVolumeNameForMountPoint returns the volume GUID path, e.g. \\?\Volume{GUID}, of the volume mounted at the given path.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/fileapi/nf-fileapi-getvolumenameforvolumemountpointw
+func getVolumeNameForMountPoint(mountPoint string) (string, error) {
+	// The mount point must end with a trailing backslash.
+	mountPointPtr, err := windows.UTF16PtrFromString(strings.TrimSuffix(mountPoint, `\`) + `\`)
+	if err != nil {
+		return "", err
+	}
+
+	volumeNameBuf := make([]uint16, windows.MAX_PATH+1)
+
+	if err = windows.GetVolumeNameForVolumeMountPoint(mountPointPtr, &volumeNameBuf[0], uint32(len(volumeNameBuf))); err != nil {
+		return "", fmt.Errorf("GetVolumeNameForVolumeMountPoint %s: %w", mountPoint, err)
+	}
+
+	return strings.TrimSuffix(windows.UTF16ToString(volumeNameBuf), `\`), nil
+}
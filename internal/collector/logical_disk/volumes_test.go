@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package logical_disk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMountedVolumesGUIDOf(t *testing.T) {
+	t.Parallel()
+
+	const (
+		systemVolume = `\\?\Volume{11111111-1111-1111-1111-111111111111}`
+		dataVolume   = `\\?\Volume{22222222-2222-2222-2222-222222222222}`
+		csvVolume    = `\\?\Volume{33333333-3333-3333-3333-333333333333}`
+	)
+
+	var resolved []string
+
+	volumes := mountedVolumes{
+		guids: map[string]string{
+			"C:":          systemVolume,
+			`C:\mnt\data`: dataVolume,
+		},
+		devices: map[string]string{
+			"HarddiskVolume12": dataVolume,
+		},
+		mountPoints: map[string][]string{
+			systemVolume: {"C:"},
+			dataVolume:   {`C:\mnt\data`},
+		},
+		resolveMountPoint: func(mountPoint string) (string, error) {
+			resolved = append(resolved, mountPoint)
+
+			if mountPoint == `C:\ClusterStorage\Volume1` {
+				return csvVolume, nil
+			}
+
+			return "", errors.New("not a mount point")
+		},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		expected string
+		ok       bool
+	}{
+		{name: "C:", expected: systemVolume, ok: true},
+		{name: `C:\mnt\data`, expected: dataVolume, ok: true},
+		{name: "HarddiskVolume12", expected: dataVolume, ok: true},
+		{name: `C:\ClusterStorage\Volume1`, expected: csvVolume, ok: true},
+		{name: `C:\ClusterStorage\Volume2`, ok: false},
+		{name: "D:", ok: false},
+	} {
+		volumeGUID, ok := volumes.guidOf(tc.name)
+
+		require.Equal(t, tc.ok, ok, tc.name)
+		require.Equal(t, tc.expected, volumeGUID, tc.name)
+	}
+
+	// Only paths, which are not in the volume map, are resolved.
+	require.Equal(t, []string{`C:\ClusterStorage\Volume1`, `C:\ClusterStorage\Volume2`}, resolved)
+	require.Equal(t, "33333333-3333-3333-3333-333333333333", bareGUID(csvVolume))
+}
@@ -21,9 +21,15 @@
 package memory
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
@@ -34,20 +40,41 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-const Name = "memory"
+const (
+	Name = "memory"
 
-type Config struct{}
+	subCollectorMetrics  = "metrics"
+	subCollectorPoolTags = "pool_tags"
+)
+
+type Config struct {
+	CollectorsEnabled []string `yaml:"enabled"`
+	// DisablePageFaultsTotal suppresses the deprecated windows_memory_page_faults_total metric.
+	DisablePageFaultsTotal bool `yaml:"disable-page-faults-total"`
+	// PoolTagsTopN is the number of pool tags per pool type exposed by the pool_tags sub collector.
+	PoolTagsTopN int `yaml:"pool-tags-top-n"`
+}
 
 //nolint:gochecknoglobals
-var ConfigDefaults = Config{}
+var ConfigDefaults = Config{
+	CollectorsEnabled: []string{
+		subCollectorMetrics,
+	},
+	DisablePageFaultsTotal: false,
+	PoolTagsTopN:           20,
+}
 
 // A Collector is a Prometheus Collector for perflib Memory metrics.
 type Collector struct {
 	config Config
 
+	softFaults softFaultsCounter
+
 	perfDataCollector *pdh.Collector
 	perfDataObject    []perfDataCounterValues
 
+	poolTagQuerier poolTagQuerier
+
 	// Performance metrics
 	availableBytes                  *prometheus.Desc
 	cacheBytes                      *prometheus.Desc
@@ -58,6 +85,7 @@ type Collector struct {
 	demandZeroFaultsTotal           *prometheus.Desc
 	freeAndZeroPageListBytes        *prometheus.Desc
 	freeSystemPageTableEntries      *prometheus.Desc
+	hardFaultsTotal                 *prometheus.Desc
 	modifiedPageListBytes           *prometheus.Desc
 	pageFaultsTotal                 *prometheus.Desc
 	swapPageReadsTotal              *prometheus.Desc
@@ -70,6 +98,7 @@ type Collector struct {
 	poolPagedAllocationsTotal       *prometheus.Desc
 	poolPagedBytes                  *prometheus.Desc
 	poolPagedResidentBytes          *prometheus.Desc
+	softFaultsTotal                 *prometheus.Desc
 	standbyCacheCoreBytes           *prometheus.Desc
 	standbyCacheNormalPriorityBytes *prometheus.Desc
 	standbyCacheReserveBytes        *prometheus.Desc
@@ -86,6 +115,9 @@ type Collector struct {
 	processMemoryLimitBytes  *prometheus.Desc
 	physicalMemoryTotalBytes *prometheus.Desc
 	physicalMemoryFreeBytes  *prometheus.Desc
+
+	// Pool tags
+	poolTagBytes *prometheus.Desc
 }
 
 func New(config *Config) *Collector {
@@ -93,6 +125,14 @@ func New(config *Config) *Collector {
 		config = &ConfigDefaults
 	}
 
+	if config.CollectorsEnabled == nil {
+		config.CollectorsEnabled = ConfigDefaults.CollectorsEnabled
+	}
+
+	if config.PoolTagsTopN == 0 {
+		config.PoolTagsTopN = ConfigDefaults.PoolTagsTopN
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -100,19 +140,77 @@ func New(config *Config) *Collector {
 	return c
 }
 
-func NewWithFlags(_ *kingpin.Application) *Collector {
-	return &Collector{}
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+	c.config.CollectorsEnabled = make([]string, 0)
+
+	var collectorsEnabled string
+
+	app.Flag(
+		"collector.memory.enabled",
+		"Comma-separated list of collectors to use. Available collectors: metrics, pool_tags.",
+	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
+
+	app.Flag(
+		"collector.memory.disable-page-faults-total",
+		"If enabled, the deprecated windows_memory_page_faults_total metric is not exposed. Use windows_memory_hard_faults_total and windows_memory_soft_faults_total instead.",
+	).Default(strconv.FormatBool(c.config.DisablePageFaultsTotal)).BoolVar(&c.config.DisablePageFaultsTotal)
+
+	app.Flag(
+		"collector.memory.pool-tags-top-n",
+		"Number of kernel pool tags per pool type exposed by the pool_tags sub collector. All other pool tags are summed up as \"other\".",
+	).Default(strconv.Itoa(ConfigDefaults.PoolTagsTopN)).IntVar(&c.config.PoolTagsTopN)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
+
+		return nil
+	})
+
+	return c
 }
 
 func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
 	return nil
 }
 
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+	for _, collector := range c.config.CollectorsEnabled {
+		if !slices.Contains([]string{subCollectorMetrics, subCollectorPoolTags}, collector) {
+			return fmt.Errorf("unknown sub collector: %s. Possible values: %s", collector,
+				strings.Join([]string{subCollectorMetrics, subCollectorPoolTags}, ", "),
+			)
+		}
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+		if err := c.buildMetrics(logger); err != nil {
+			return err
+		}
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorPoolTags) {
+		if c.config.PoolTagsTopN <= 0 {
+			return fmt.Errorf("pool-tags-top-n must be greater than 0, got %d", c.config.PoolTagsTopN)
+		}
+
+		c.buildPoolTags()
+	}
+
+	return nil
+}
+
+func (c *Collector) buildMetrics(logger *slog.Logger) error {
 	c.availableBytes = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "available_bytes"),
 		"The amount of physical memory immediately available for allocation to a process or for system use. It is equal to the sum of memory assigned to"+
@@ -171,6 +269,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 		nil,
 	)
+	c.hardFaultsTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "hard_faults_total"),
+		"Number of pages read from disk to resolve hard page faults. Hard faults occur when a page is not in physical memory and must be retrieved from disk (Pages Input/sec)",
+		nil,
+		nil,
+	)
 	c.modifiedPageListBytes = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "modified_page_list_bytes"),
 		"The amount of physical memory, in bytes, that is assigned to the modified page list. This memory contains cached data and code that is not actively in "+
@@ -180,7 +284,8 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	)
 	c.pageFaultsTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "page_faults_total"),
-		"Overall rate at which faulted pages are handled by the processor (Page Faults/sec)",
+		"DEPRECATED: Use windows_memory_hard_faults_total and windows_memory_soft_faults_total instead. Overall rate at which faulted pages are handled by the processor, "+
+			"including soft faults (Page Faults/sec)",
 		nil,
 		nil,
 	)
@@ -247,6 +352,13 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 		nil,
 	)
+	c.softFaultsTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "soft_faults_total"),
+		"Approximate number of page faults resolved without disk access, derived from Page Faults/sec minus Page Reads/sec. Since Page Reads/sec counts "+
+			"read operations rather than faults, the value is an estimate and may undercount when a single read resolves multiple faults",
+		nil,
+		nil,
+	)
 	c.standbyCacheCoreBytes = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "standby_cache_core_bytes"),
 		"The amount of physical memory, in bytes, that is assigned to the core standby cache page lists. This memory contains cached data and code that is "+
@@ -351,12 +463,20 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
 	errs := make([]error, 0)
 
-	if err := c.collectPDH(ch); err != nil {
-		errs = append(errs, fmt.Errorf("failed collecting memory metrics: %w", err))
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+		if err := c.collectPDH(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed collecting memory metrics: %w", err))
+		}
+
+		if err := c.collectGlobalMemoryStatus(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed collecting global memory metrics: %w", err))
+		}
 	}
 
-	if err := c.collectGlobalMemoryStatus(ch); err != nil {
-		errs = append(errs, fmt.Errorf("failed collecting global memory metrics: %w", err))
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorPoolTags) {
+		if err := c.collectPoolTags(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed collecting pool tag metrics: %w", err))
+		}
 	}
 
 	return errors.Join(errs...)
@@ -457,10 +577,24 @@ func (c *Collector) collectPDH(ch chan<- prometheus.Metric) error {
 		c.perfDataObject[0].ModifiedPageListBytes,
 	)
 
+	if !c.config.DisablePageFaultsTotal {
+		ch <- prometheus.MustNewConstMetric(
+			c.pageFaultsTotal,
+			prometheus.CounterValue,
+			c.perfDataObject[0].PageFaultsPerSec,
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.hardFaultsTotal,
+		prometheus.CounterValue,
+		c.perfDataObject[0].PagesInputPerSec,
+	)
+
 	ch <- prometheus.MustNewConstMetric(
-		c.pageFaultsTotal,
+		c.softFaultsTotal,
 		prometheus.CounterValue,
-		c.perfDataObject[0].PageFaultsPerSec,
+		c.softFaults.update(c.perfDataObject[0]),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
@@ -591,3 +725,86 @@ func (c *Collector) collectPDH(ch chan<- prometheus.Metric) error {
 
 	return nil
 }
+
+// softFaultsCounter derives the number of soft page faults from the raw counters.
+// There is no dedicated counter for soft faults, so the number of page read operations
+// is subtracted from the total number of page faults as a surrogate for hard faults.
+// The counters are sampled independently, so the difference of the raw counters is not monotonic.
+// Therefore, the difference is accumulated per collection and negative increments are dropped.
+type softFaultsCounter struct {
+	mu          sync.Mutex
+	initialized bool
+	pageFaults  float64
+	pageReads   float64
+	total       float64
+}
+
+// update returns the number of soft page faults after adding the increment since the last update.
+func (c *softFaultsCounter) update(values perfDataCounterValues) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case !c.initialized:
+		c.total = max(values.PageFaultsPerSec-values.PageReadsPerSec, 0)
+		c.initialized = true
+	case values.PageFaultsPerSec >= c.pageFaults && values.PageReadsPerSec >= c.pageReads:
+		c.total += max((values.PageFaultsPerSec-c.pageFaults)-(values.PageReadsPerSec-c.pageReads), 0)
+	}
+
+	c.pageFaults = values.PageFaultsPerSec
+	c.pageReads = values.PageReadsPerSec
+
+	return c.total
+}
+
+// softFaultsState is the persisted state of the soft page fault counter.
+type softFaultsState struct {
+	PageFaults float64 `json:"page_faults"`
+	PageReads  float64 `json:"page_reads"`
+	Total      float64 `json:"total"`
+}
+
+// SaveState returns the soft page fault count and the raw counters of the last collection.
+func (c *Collector) SaveState() (json.RawMessage, error) {
+	c.softFaults.mu.Lock()
+	defer c.softFaults.mu.Unlock()
+
+	if !c.softFaults.initialized {
+		return json.RawMessage("null"), nil
+	}
+
+	return json.Marshal(softFaultsState{
+		PageFaults: c.softFaults.pageFaults,
+		PageReads:  c.softFaults.pageReads,
+		Total:      c.softFaults.total,
+	})
+}
+
+// RestoreState restores the soft page fault count. If the raw counters were reset in between,
+// e.g. by a reboot, the next collection keeps the restored count and continues from the new raw counters.
+func (c *Collector) RestoreState(state json.RawMessage) error {
+	var faultsState *softFaultsState
+
+	if err := json.Unmarshal(state, &faultsState); err != nil {
+		return fmt.Errorf("failed to parse soft faults state: %w", err)
+	}
+
+	if faultsState == nil {
+		return nil
+	}
+
+	if faultsState.Total < 0 || math.IsNaN(faultsState.Total) {
+		return fmt.Errorf("invalid soft fault count %f", faultsState.Total)
+	}
+
+	c.softFaults.mu.Lock()
+	defer c.softFaults.mu.Unlock()
+
+	c.softFaults.initialized = true
+	c.softFaults.pageFaults = faultsState.PageFaults
+	c.softFaults.pageReads = faultsState.PageReads
+	c.softFaults.total = faultsState.Total
+
+	return nil
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package memory
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftFaults(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		values   []perfDataCounterValues
+		expected []float64
+	}{
+		{
+			name: "idle workstation",
+			values: []perfDataCounterValues{
+				{PageFaultsPerSec: 1843211977, PageReadsPerSec: 4211394, PagesInputPerSec: 15092785},
+				{PageFaultsPerSec: 1843215977, PageReadsPerSec: 4211494, PagesInputPerSec: 15093185},
+			},
+			expected: []float64{1839000583, 1839004483},
+		},
+		{
+			name: "paging heavy server",
+			values: []perfDataCounterValues{
+				{PageFaultsPerSec: 98734112, PageReadsPerSec: 53129870, PagesInputPerSec: 312876541},
+				{PageFaultsPerSec: 98744112, PageReadsPerSec: 53139870, PagesInputPerSec: 312916541},
+			},
+			expected: []float64{45604242, 45604242},
+		},
+		{
+			name: "freshly booted",
+			values: []perfDataCounterValues{
+				{},
+				{PageFaultsPerSec: 10, PageReadsPerSec: 2, PagesInputPerSec: 8},
+			},
+			expected: []float64{0, 8},
+		},
+		{
+			name: "page reads sampled after page faults",
+			values: []perfDataCounterValues{
+				{PageFaultsPerSec: 1000, PageReadsPerSec: 998, PagesInputPerSec: 3992},
+				{PageFaultsPerSec: 1000, PageReadsPerSec: 1002, PagesInputPerSec: 4008},
+				{PageFaultsPerSec: 1010, PageReadsPerSec: 1003, PagesInputPerSec: 4012},
+			},
+			expected: []float64{2, 2, 11},
+		},
+		{
+			name: "counter reset",
+			values: []perfDataCounterValues{
+				{PageFaultsPerSec: 1000, PageReadsPerSec: 100},
+				{PageFaultsPerSec: 50, PageReadsPerSec: 10},
+				{PageFaultsPerSec: 60, PageReadsPerSec: 10},
+			},
+			expected: []float64{900, 900, 910},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var counter softFaultsCounter
+
+			for i, values := range tc.values {
+				require.InDelta(t, tc.expected[i], counter.update(values), 0, "collection %d", i)
+			}
+		})
+	}
+}
+
+func TestSoftFaultsState(t *testing.T) {
+	t.Parallel()
+
+	previous := &Collector{}
+	previous.softFaults.update(perfDataCounterValues{PageFaultsPerSec: 1000, PageReadsPerSec: 100})
+	previous.softFaults.update(perfDataCounterValues{PageFaultsPerSec: 50, PageReadsPerSec: 10})
+
+	state, err := previous.SaveState()
+	require.NoError(t, err)
+
+	c := &Collector{}
+	require.NoError(t, c.RestoreState(state))
+
+	// The raw counters kept growing while the exporter was stopped.
+	require.InDelta(t, 905, c.softFaults.update(perfDataCounterValues{PageFaultsPerSec: 60, PageReadsPerSec: 15}), 0)
+
+	// The raw counters were reset by a reboot.
+	c = &Collector{}
+	require.NoError(t, c.RestoreState(state))
+	require.InDelta(t, 900, c.softFaults.update(perfDataCounterValues{PageFaultsPerSec: 5, PageReadsPerSec: 1}), 0)
+	require.InDelta(t, 910, c.softFaults.update(perfDataCounterValues{PageFaultsPerSec: 16, PageReadsPerSec: 2}), 0)
+}
+
+func TestSoftFaultsStateEmpty(t *testing.T) {
+	t.Parallel()
+
+	c := &Collector{}
+
+	state, err := c.SaveState()
+	require.NoError(t, err)
+	require.JSONEq(t, "null", string(state))
+
+	require.NoError(t, c.RestoreState(state))
+	require.False(t, c.softFaults.initialized)
+
+	require.Error(t, c.RestoreState(json.RawMessage(`{"total":-1}`)))
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/ntdll"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolTagOther is the tag label for all pool tags outside the top-N.
+const poolTagOther = "other"
+
+// poolTagQuerier returns the kernel pool usage per pool tag.
+type poolTagQuerier interface {
+	QueryPoolTags() ([]ntdll.SystemPoolTag, error)
+}
+
+// ntdllPoolTagQuerier queries the pool tags via NtQuerySystemInformation.
+type ntdllPoolTagQuerier struct{}
+
+func (ntdllPoolTagQuerier) QueryPoolTags() ([]ntdll.SystemPoolTag, error) {
+	return ntdll.QuerySystemPoolTagInformation()
+}
+
+type poolTagUsage struct {
+	tag   string
+	bytes float64
+}
+
+func (c *Collector) buildPoolTags() {
+	if c.poolTagQuerier == nil {
+		c.poolTagQuerier = ntdllPoolTagQuerier{}
+	}
+
+	c.poolTagBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "pool_tag_bytes"),
+		"Number of bytes allocated in the kernel pool by pool tag. Only the top-N tags per pool type are exposed, all others are summed up in the tag \"other\"",
+		[]string{"tag", "type"},
+		nil,
+	)
+}
+
+func (c *Collector) collectPoolTags(ch chan<- prometheus.Metric) error {
+	poolTags, err := c.poolTagQuerier.QueryPoolTags()
+	if err != nil {
+		return fmt.Errorf("failed to query pool tags: %w", err)
+	}
+
+	paged := make([]poolTagUsage, 0, len(poolTags))
+	nonPaged := make([]poolTagUsage, 0, len(poolTags))
+
+	for _, poolTag := range poolTags {
+		tag := sanitizePoolTag(poolTag.Tag)
+
+		if poolTag.PagedUsed > 0 {
+			paged = append(paged, poolTagUsage{tag, float64(poolTag.PagedUsed)})
+		}
+
+		if poolTag.NonPagedUsed > 0 {
+			nonPaged = append(nonPaged, poolTagUsage{tag, float64(poolTag.NonPagedUsed)})
+		}
+	}
+
+	for poolType, usage := range map[string][]poolTagUsage{"paged": paged, "nonpaged": nonPaged} {
+		for _, poolTag := range topPoolTags(usage, c.config.PoolTagsTopN) {
+			ch <- prometheus.MustNewConstMetric(
+				c.poolTagBytes,
+				prometheus.GaugeValue,
+				poolTag.bytes,
+				poolTag.tag,
+				poolType,
+			)
+		}
+	}
+
+	return nil
+}
+
+// topPoolTags returns the n largest pool tags, followed by the sum of all other pool tags.
+// Tags that sanitize to the same string are merged.
+func topPoolTags(usage []poolTagUsage, n int) []poolTagUsage {
+	merged := make(map[string]float64, len(usage))
+	for _, poolTag := range usage {
+		merged[poolTag.tag] += poolTag.bytes
+	}
+
+	sorted := make([]poolTagUsage, 0, len(merged))
+	for tag, bytes := range merged {
+		sorted = append(sorted, poolTagUsage{tag, bytes})
+	}
+
+	slices.SortFunc(sorted, func(a, b poolTagUsage) int {
+		return cmp.Or(cmp.Compare(b.bytes, a.bytes), strings.Compare(a.tag, b.tag))
+	})
+
+	if len(sorted) <= n {
+		return sorted
+	}
+
+	other := poolTagUsage{tag: poolTagOther}
+	for _, poolTag := range sorted[n:] {
+		other.bytes += poolTag.bytes
+	}
+
+	return append(sorted[:n], other)
+}
+
+// sanitizePoolTag converts a pool tag to printable ASCII.
+// Non-printable bytes are replaced by '?' and trailing spaces and NUL bytes are removed.
+func sanitizePoolTag(tag [4]byte) string {
+	end := len(tag)
+	for end > 0 && (tag[end-1] == 0 || tag[end-1] == ' ') {
+		end--
+	}
+
+	sanitized := make([]byte, 0, end)
+
+	for _, b := range tag[:end] {
+		if b < 0x20 || b > 0x7E {
+			b = '?'
+		}
+
+		sanitized = append(sanitized, b)
+	}
+
+	return string(sanitized)
+}
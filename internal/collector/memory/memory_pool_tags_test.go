@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package memory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/ntdll"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePoolTagQuerier struct {
+	poolTags []ntdll.SystemPoolTag
+	err      error
+}
+
+func (f fakePoolTagQuerier) QueryPoolTags() ([]ntdll.SystemPoolTag, error) {
+	return f.poolTags, f.err
+}
+
+func TestSanitizePoolTag(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		tag      [4]byte
+		expected string
+	}{
+		{[4]byte{'N', 't', 'f', 's'}, "Ntfs"},
+		{[4]byte{'F', 'M', 'f', ' '}, "FMf"},
+		{[4]byte{'I', 'o', 0, 0}, "Io"},
+		{[4]byte{'A', 0x01, 0xFF, 'B'}, "A??B"},
+		{[4]byte{}, ""},
+	} {
+		require.Equal(t, tc.expected, sanitizePoolTag(tc.tag))
+	}
+}
+
+func TestCollectPoolTags(t *testing.T) {
+	t.Parallel()
+
+	c := New(&Config{
+		CollectorsEnabled: []string{subCollectorPoolTags},
+		PoolTagsTopN:      2,
+	})
+	c.poolTagQuerier = fakePoolTagQuerier{
+		poolTags: []ntdll.SystemPoolTag{
+			{Tag: [4]byte{'N', 't', 'f', 's'}, PagedUsed: 100, NonPagedUsed: 4000},
+			{Tag: [4]byte{'L', 'e', 'a', 'k'}, NonPagedUsed: 9000},
+			{Tag: [4]byte{'F', 'M', 'f', ' '}, PagedUsed: 50, NonPagedUsed: 300},
+			{Tag: [4]byte{'T', 'h', 'r', 'e'}, NonPagedUsed: 200},
+		},
+	}
+	c.buildPoolTags()
+
+	ch := make(chan prometheus.Metric, 10)
+	require.NoError(t, c.collectPoolTags(ch))
+	close(ch)
+
+	got := make(map[string]float64)
+
+	for metric := range ch {
+		var m dto.Metric
+
+		require.NoError(t, metric.Write(&m))
+
+		labels := make(map[string]string)
+		for _, label := range m.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+
+		got[labels["type"]+"/"+labels["tag"]] = m.GetGauge().GetValue()
+	}
+
+	require.Equal(t, map[string]float64{
+		"nonpaged/Leak":  9000,
+		"nonpaged/Ntfs":  4000,
+		"nonpaged/other": 500,
+		"paged/Ntfs":     100,
+		"paged/FMf":      50,
+	}, got)
+}
+
+func TestCollectPoolTagsError(t *testing.T) {
+	t.Parallel()
+
+	errAccessDenied := errors.New("access denied")
+
+	c := New(&Config{
+		CollectorsEnabled: []string{subCollectorPoolTags},
+		PoolTagsTopN:      2,
+	})
+	c.poolTagQuerier = fakePoolTagQuerier{err: errAccessDenied}
+	c.buildPoolTags()
+
+	require.ErrorIs(t, c.collectPoolTags(make(chan prometheus.Metric, 1)), errAccessDenied)
+}
@@ -22,12 +22,14 @@ import (
 	"fmt"
 	"log/slog"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -45,6 +47,8 @@ const (
 
 type Config struct {
 	CollectorsEnabled []string `yaml:"enabled"`
+	// DisableDeprecatedMetrics suppresses the deprecated metrics that were renamed to use base units.
+	DisableDeprecatedMetrics bool `yaml:"disable-deprecated-metrics"`
 }
 
 //nolint:gochecknoglobals
@@ -58,6 +62,7 @@ var ConfigDefaults = Config{
 		subCollectorSharedVolumes,
 		subCollectorVirtualDisk,
 	},
+	DisableDeprecatedMetrics: false,
 }
 
 // A Collector is a Prometheus Collector for WMI MSCluster_Cluster metrics.
@@ -103,6 +108,11 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		"Comma-separated list of collectors to use.",
 	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
 
+	app.Flag(
+		"collector.mscluster.disable-deprecated-metrics",
+		"Do not emit the deprecated metrics with non-base units, e.g. windows_mscluster_cluster_clus_svc_regroup_tick_in_milliseconds.",
+	).Default(strconv.FormatBool(c.config.DisableDeprecatedMetrics)).BoolVar(&c.config.DisableDeprecatedMetrics)
+
 	app.Action(func(*kingpin.ParseContext) error {
 		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
 
@@ -116,6 +126,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceWMI}
+}
+
 func (c *Collector) Close() error {
 	return nil
 }
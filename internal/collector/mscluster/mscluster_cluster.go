@@ -24,6 +24,7 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/osversion"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -44,6 +45,7 @@ type collectorCluster struct {
 	clusterClusSvcRegroupPruningTimeout            *prometheus.Desc
 	clusterClusSvcRegroupStageTimeout              *prometheus.Desc
 	clusterClusSvcRegroupTickInMilliseconds        *prometheus.Desc
+	clusterClusSvcRegroupTickSeconds               *prometheus.Desc
 	clusterClusterEnforcedAntiAffinity             *prometheus.Desc
 	clusterClusterFunctionalLevel                  *prometheus.Desc
 	clusterClusterGroupWaitDelay                   *prometheus.Desc
@@ -278,7 +280,13 @@ func (c *Collector) buildCluster() error {
 	)
 	c.clusterClusSvcRegroupTickInMilliseconds = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, nameCluster, "clus_svc_regroup_tick_in_milliseconds"),
-		"Controls how frequently the membership algorithm is sending periodic membership messages.",
+		"Controls how frequently the membership algorithm is sending periodic membership messages. (deprecated, use windows_mscluster_cluster_clus_svc_regroup_tick_seconds)",
+		[]string{"name"},
+		nil,
+	)
+	c.clusterClusSvcRegroupTickSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, nameCluster, "clus_svc_regroup_tick_seconds"),
+		"Interval in seconds, in which the membership algorithm is sending periodic membership messages.",
 		[]string{"name"},
 		nil,
 	)
@@ -765,10 +773,19 @@ func (c *Collector) collectCluster(ch chan<- prometheus.Metric, maxScrapeDuratio
 			v.Name,
 		)
 
+		if !c.config.DisableDeprecatedMetrics {
+			ch <- prometheus.MustNewConstMetric(
+				c.clusterClusSvcRegroupTickInMilliseconds,
+				prometheus.GaugeValue,
+				float64(v.ClusSvcRegroupTickInMilliseconds),
+				v.Name,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
-			c.clusterClusSvcRegroupTickInMilliseconds,
+			c.clusterClusSvcRegroupTickSeconds,
 			prometheus.GaugeValue,
-			float64(v.ClusSvcRegroupTickInMilliseconds),
+			utils.MilliSecToSec(float64(v.ClusSvcRegroupTickInMilliseconds)),
 			v.Name,
 		)
 
@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/impersonation"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
@@ -46,6 +47,7 @@ const (
 	subCollectorGeneralStatistics   = "genstats"
 	subCollectorInfo                = "info"
 	subCollectorLocks               = "locks"
+	subCollectorMemoryClerks        = "memclerks"
 	subCollectorMemoryManager       = "memmgr"
 	subCollectorSQLErrors           = "sqlerrors"
 	subCollectorSQLStats            = "sqlstats"
@@ -55,6 +57,8 @@ const (
 
 type Config struct {
 	CollectorsEnabled []string `yaml:"enabled"`
+	// Impersonation is the account the sub-collectors run as. Optional.
+	Impersonation impersonation.Config `yaml:"impersonation"`
 }
 
 //nolint:gochecknoglobals
@@ -86,6 +90,7 @@ type Collector struct {
 	collectorGeneralStatistics
 	collectorInstance
 	collectorLocks
+	collectorMemoryClerks
 	collectorMemoryManager
 	collectorSQLErrors
 	collectorSQLStats
@@ -96,6 +101,8 @@ type Collector struct {
 
 	logger *slog.Logger
 
+	impersonator *impersonation.Impersonator
+
 	mssqlInstances []mssqlInstance
 	collectorFns   []func(ch chan<- prometheus.Metric) error
 	closeFns       []func()
@@ -133,6 +140,16 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		"Comma-separated list of collectors to use.",
 	).Default(strings.Join(c.config.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
 
+	app.Flag(
+		"collector.mssql.impersonation-user",
+		"Account to run the collection as, in DOMAIN\\user or user@domain format. Group managed service accounts end with a $.",
+	).Default("").StringVar(&c.config.Impersonation.User)
+
+	app.Flag(
+		"collector.mssql.impersonation-secret",
+		"Name of the LSA secret holding the password of the impersonation account. Leave empty for group managed service accounts.",
+	).Default("").StringVar(&c.config.Impersonation.Secret)
+
 	app.Action(func(*kingpin.ParseContext) error {
 		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
 
@@ -146,11 +163,19 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
 	for _, fn := range c.closeFns {
 		fn()
 	}
 
+	if c.impersonator != nil {
+		return c.impersonator.Close()
+	}
+
 	return nil
 }
 
@@ -164,6 +189,10 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	c.mssqlInstances = instances
 
+	if c.config.Impersonation.Enabled() {
+		c.impersonator = impersonation.New(c.config.Impersonation)
+	}
+
 	subCollectors := map[string]struct {
 		build   func() error
 		collect func(ch chan<- prometheus.Metric) error
@@ -209,6 +238,11 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 			collect: c.collectLocks,
 			close:   c.closeLocks,
 		},
+		subCollectorMemoryClerks: {
+			build:   c.buildMemoryClerks,
+			collect: c.collectMemoryClerks,
+			close:   c.closeMemoryClerks,
+		},
 		subCollectorMemoryManager: {
 			build:   c.buildMemoryManager,
 			collect: c.collectMemoryManager,
@@ -416,3 +450,13 @@ func (c *Collector) collect(
 
 	return errors.Join(errs...)
 }
+
+// newPerfDataCollector returns a performance counter collector for the given SQL Server object.
+// If impersonation is configured, the performance data is read under the configured account.
+func newPerfDataCollector[T any](c *Collector, object string, instances []string) (*pdh.Collector, error) {
+	if c.impersonator == nil {
+		return pdh.NewCollector[T](c.logger, pdh.CounterTypeRaw, object, instances)
+	}
+
+	return pdh.NewImpersonatedCollector[T](c.logger, c.impersonator, pdh.CounterTypeRaw, object, instances)
+}
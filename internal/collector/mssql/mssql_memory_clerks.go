@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package mssql
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// pageSizeBytes is the size of a SQL Server page.
+const pageSizeBytes = 8192
+
+type collectorMemoryClerks struct {
+	memClerksPerfDataCollectors map[mssqlInstance]*pdh.Collector
+	memClerksPerfDataObject     []perfDataCounterValuesMemClerks
+
+	memClerksSize *prometheus.Desc
+}
+
+type perfDataCounterValuesMemClerks struct {
+	Name string
+
+	MemClerksSize float64 `perfdata:"Memory broker clerk size"`
+}
+
+func (c *Collector) buildMemoryClerks() error {
+	var err error
+
+	c.memClerksPerfDataCollectors = make(map[mssqlInstance]*pdh.Collector, len(c.mssqlInstances))
+	errs := make([]error, 0, len(c.mssqlInstances))
+
+	for _, sqlInstance := range c.mssqlInstances {
+		c.memClerksPerfDataCollectors[sqlInstance], err = newPerfDataCollector[perfDataCounterValuesMemClerks](c, c.mssqlGetPerfObjectName(sqlInstance, "Memory Broker Clerks"), pdh.InstancesAll)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to create Memory Broker Clerks collector for instance %s: %w", sqlInstance.name, err))
+		}
+	}
+
+	c.memClerksSize = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "memory_clerk_bytes"),
+		"(MemoryBrokerClerks.MemoryBrokerClerkSize)",
+		[]string{"mssql_instance", "clerk"},
+		nil,
+	)
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) collectMemoryClerks(ch chan<- prometheus.Metric) error {
+	return c.collect(ch, subCollectorMemoryClerks, c.memClerksPerfDataCollectors, c.collectMemoryClerksInstance)
+}
+
+func (c *Collector) collectMemoryClerksInstance(ch chan<- prometheus.Metric, sqlInstance mssqlInstance, perfDataCollector *pdh.Collector) error {
+	err := perfDataCollector.Collect(&c.memClerksPerfDataObject)
+	if err != nil {
+		return fmt.Errorf("failed to collect %s metrics: %w", c.mssqlGetPerfObjectName(sqlInstance, "Memory Broker Clerks"), err)
+	}
+
+	for _, data := range c.memClerksPerfDataObject {
+		ch <- prometheus.MustNewConstMetric(
+			c.memClerksSize,
+			prometheus.GaugeValue,
+			data.MemClerksSize*pageSizeBytes,
+			sqlInstance.name, data.Name,
+		)
+	}
+
+	return nil
+}
+
+func (c *Collector) closeMemoryClerks() {
+	for _, perfDataCollector := range c.memClerksPerfDataCollectors {
+		perfDataCollector.Close()
+	}
+}
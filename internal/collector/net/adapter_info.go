@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package net
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// adapterInfoTTL is the duration for which the Win32_NetworkAdapter query result is cached.
+// The hardware details of an adapter rarely change.
+const adapterInfoTTL = 5 * time.Minute
+
+// win32NetworkAdapter is the subset of Win32_NetworkAdapter exposed by windows_net_adapter_info.
+//
+// https://learn.microsoft.com/en-us/windows/win32/cimwin32prov/win32-networkadapter
+type win32NetworkAdapter struct {
+	Name           string `mi:"Name"`
+	MACAddress     string `mi:"MACAddress"`
+	Manufacturer   string `mi:"Manufacturer"`
+	AdapterType    string `mi:"AdapterType"`
+	GUID           string `mi:"GUID"`
+	Speed          uint64 `mi:"Speed"`
+	InterfaceIndex uint32 `mi:"InterfaceIndex"`
+}
+
+type adapterInfo struct {
+	name         string
+	macAddress   string
+	manufacturer string
+	adapterType  string
+	guid         string
+	speedMbps    string
+}
+
+// collectAdapterInfo sends windows_net_adapter_info for each network adapter.
+// The Win32_NetworkAdapter query result is cached for adapterInfoTTL.
+func (c *Collector) collectAdapterInfo(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	if c.adapterInfoCache == nil || time.Since(c.adapterInfoUpdated) >= adapterInfoTTL {
+		var dst []win32NetworkAdapter
+		if err := c.miSession.Query(&dst, mi.NamespaceRootCIMv2, c.miQueryAdapterInfo, maxScrapeDuration); err != nil {
+			return fmt.Errorf("WMI query failed: %w", err)
+		}
+
+		nicAdapterAddresses, err := adapterAddresses()
+		if err != nil {
+			return err
+		}
+
+		identities := make(map[uint32]adapterIdentity, len(nicAdapterAddresses))
+
+		for _, nicAdapter := range nicAdapterAddresses {
+			identity := newAdapterIdentity(nicAdapter)
+			identities[identity.ifIndex] = identity
+		}
+
+		c.adapterInfoCache = newAdapterInfos(dst, identities)
+		c.adapterInfoUpdated = time.Now()
+	}
+
+	for _, adapter := range c.adapterInfoCache {
+		if c.config.NicExclude.MatchString(adapter.name) || !c.config.NicInclude.MatchString(adapter.name) {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.adapterInfo,
+			prometheus.GaugeValue,
+			1,
+			adapter.name,
+			adapter.macAddress,
+			adapter.manufacturer,
+			adapter.adapterType,
+			adapter.guid,
+			adapter.speedMbps,
+		)
+	}
+
+	return nil
+}
+
+// newAdapterInfos joins the Win32_NetworkAdapter instances with the adapters returned by
+// GetAdaptersAddresses on the interface index. The name is the Network Interface instance name
+// of the matching adapter, so it matches the nic label of the performance counter metrics.
+// Adapters without a match, e.g. disabled adapters, keep the name from WMI.
+func newAdapterInfos(adapters []win32NetworkAdapter, identities map[uint32]adapterIdentity) []adapterInfo {
+	infos := make([]adapterInfo, 0, len(adapters))
+
+	for _, adapter := range adapters {
+		info := adapterInfo{
+			name:         perfInstanceName(adapter.Name),
+			macAddress:   adapter.MACAddress,
+			manufacturer: adapter.Manufacturer,
+			adapterType:  adapter.AdapterType,
+			guid:         strings.ToLower(strings.Trim(adapter.GUID, "{}")),
+			speedMbps:    formatSpeedMbps(adapter.Speed),
+		}
+
+		if identity, ok := identities[adapter.InterfaceIndex]; ok && adapter.InterfaceIndex != 0 {
+			info.name = perfInstanceName(identity.description)
+
+			if info.guid == "" {
+				info.guid = identity.guid
+			}
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+// formatSpeedMbps formats the speed of an adapter in bits per second as megabits per second.
+// Disconnected adapters report either no speed or math.MaxInt64, which is reported as empty string.
+func formatSpeedMbps(speed uint64) string {
+	if speed == 0 || speed >= math.MaxInt64 {
+		return ""
+	}
+
+	return strconv.FormatUint(speed/1_000_000, 10)
+}
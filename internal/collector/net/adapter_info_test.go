@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package net
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatSpeedMbps(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "1000", formatSpeedMbps(1_000_000_000))
+	require.Equal(t, "2500", formatSpeedMbps(2_500_000_000))
+	require.Empty(t, formatSpeedMbps(0))
+	require.Empty(t, formatSpeedMbps(math.MaxInt64))
+}
+
+func TestNewAdapterInfos(t *testing.T) {
+	t.Parallel()
+
+	identities := map[uint32]adapterIdentity{
+		12: {description: "Intel(R) Ethernet Connection #2", ifIndex: 12, guid: "4d36e972-e325-11ce-bfc1-08002be10318"},
+	}
+
+	infos := newAdapterInfos([]win32NetworkAdapter{
+		{
+			Name:           "Intel(R) Ethernet Connection",
+			MACAddress:     "00:15:5D:01:02:03",
+			Manufacturer:   "Intel Corporation",
+			AdapterType:    "Ethernet 802.3",
+			Speed:          1_000_000_000,
+			InterfaceIndex: 12,
+		},
+		{
+			Name:         "WAN Miniport (IP)",
+			Manufacturer: "Microsoft",
+			GUID:         "{A1B2C3D4-0000-0000-0000-000000000000}",
+		},
+	}, identities)
+
+	require.Equal(t, []adapterInfo{
+		{
+			name:         "Intel[R] Ethernet Connection _2",
+			macAddress:   "00:15:5D:01:02:03",
+			manufacturer: "Intel Corporation",
+			adapterType:  "Ethernet 802.3",
+			guid:         "4d36e972-e325-11ce-bfc1-08002be10318",
+			speedMbps:    "1000",
+		},
+		{
+			name:         "WAN Miniport [IP]",
+			manufacturer: "Microsoft",
+			guid:         "a1b2c3d4-0000-0000-0000-000000000000",
+		},
+	}, infos)
+}
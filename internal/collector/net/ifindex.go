@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package net
+
+import (
+	"strconv"
+	"strings"
+)
+
+// perfInstanceNameReplacer replicates the character substitutions PDH performs
+// when it derives the Network Interface instance name from the adapter description.
+//
+//nolint:gochecknoglobals
+var perfInstanceNameReplacer = strings.NewReplacer(
+	"(", "[",
+	")", "]",
+	"#", "_",
+	"/", "_",
+	`\`, "_",
+)
+
+// adapterIdentity is the stable identity of a network adapter, as returned by GetAdaptersAddresses.
+type adapterIdentity struct {
+	description string
+	ifIndex     uint32
+	guid        string
+}
+
+// perfInstanceName returns the Network Interface instance name of the adapter description.
+func perfInstanceName(description string) string {
+	return perfInstanceNameReplacer.Replace(description)
+}
+
+// newIdentityTable maps Network Interface instance names to the identity of the adapter.
+func newIdentityTable(adapters []adapterIdentity) map[string]adapterIdentity {
+	table := make(map[string]adapterIdentity, len(adapters))
+
+	for _, adapter := range adapters {
+		table[perfInstanceName(adapter.description)] = adapter
+	}
+
+	return table
+}
+
+// nicLabelValues returns the values of the nic label and, if enabled, the if_index label
+// for the given Network Interface instance name. ok reports whether a matching adapter was found.
+// Instances without a matching adapter keep their name and get an empty if_index.
+func (c *Collector) nicLabelValues(nicName string, identity adapterIdentity, ok bool) []string {
+	if c.config.UseGUIDNicLabel && ok && identity.guid != "" {
+		nicName = identity.guid
+	}
+
+	if !c.config.UseIfIndexLabel {
+		return []string{nicName}
+	}
+
+	var ifIndex string
+	if ok {
+		ifIndex = strconv.FormatUint(uint64(identity.ifIndex), 10)
+	}
+
+	return []string{nicName, ifIndex}
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package net
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerfInstanceName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		description string
+		expected    string
+	}{
+		{"Intel(R) Ethernet Connection (7) I219-LM", "Intel[R] Ethernet Connection [7] I219-LM"},
+		{"Microsoft Hyper-V Network Adapter #2", "Microsoft Hyper-V Network Adapter _2"},
+		{"Realtek PCIe GbE/2.5GbE Family Controller", "Realtek PCIe GbE_2.5GbE Family Controller"},
+		{`Virtual Adapter\Team`, "Virtual Adapter_Team"},
+		{"vmxnet3 Ethernet Adapter", "vmxnet3 Ethernet Adapter"},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, perfInstanceName(tc.description))
+		})
+	}
+}
+
+func TestNicLabelValues(t *testing.T) {
+	t.Parallel()
+
+	identities := newIdentityTable([]adapterIdentity{
+		{description: "Intel(R) Ethernet Connection #2", ifIndex: 12, guid: "4d36e972-e325-11ce-bfc1-08002be10318"},
+	})
+
+	identity, ok := identities["Intel[R] Ethernet Connection _2"]
+	require.True(t, ok)
+
+	c := &Collector{config: Config{}}
+	require.Equal(t, []string{"Intel[R] Ethernet Connection _2"}, c.nicLabelValues("Intel[R] Ethernet Connection _2", identity, ok))
+
+	c = &Collector{config: Config{UseIfIndexLabel: true}}
+	require.Equal(t, []string{"Intel[R] Ethernet Connection _2", "12"}, c.nicLabelValues("Intel[R] Ethernet Connection _2", identity, ok))
+	require.Equal(t, []string{"isatap", ""}, c.nicLabelValues("isatap", adapterIdentity{}, false))
+
+	c = &Collector{config: Config{UseIfIndexLabel: true, UseGUIDNicLabel: true}}
+	require.Equal(t, []string{"4d36e972-e325-11ce-bfc1-08002be10318", "12"}, c.nicLabelValues("Intel[R] Ethernet Connection _2", identity, ok))
+}
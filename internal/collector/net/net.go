@@ -24,6 +24,7 @@ import (
 	"os"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 	"unsafe"
@@ -39,14 +40,22 @@ import (
 const (
 	Name = "net"
 
-	subCollectorMetrics = "metrics"
-	subCollectorNicInfo = "nic_info"
+	subCollectorMetrics        = "metrics"
+	subCollectorNicInfo        = "nic_info"
+	subCollectorTCPConnections = "tcp_connections"
+	subCollectorAdapterInfo    = "adapter_info"
 )
 
 type Config struct {
 	NicExclude        *regexp.Regexp `yaml:"nic-exclude"`
 	NicInclude        *regexp.Regexp `yaml:"nic-include"`
 	CollectorsEnabled []string       `yaml:"enabled"`
+	// UseIfIndexLabel adds the if_index label with the interface index to all series.
+	UseIfIndexLabel bool `yaml:"use-ifindex-label"`
+	// UseGUIDNicLabel replaces the value of the nic label with the interface GUID.
+	UseGUIDNicLabel bool `yaml:"use-guid-nic-label"`
+	// TCPPortsEnabled adds windows_net_tcp_connections_by_port to the tcp_connections collector.
+	TCPPortsEnabled bool `yaml:"tcp-ports-enable"`
 }
 
 //nolint:gochecknoglobals
@@ -57,6 +66,9 @@ var ConfigDefaults = Config{
 		subCollectorMetrics,
 		subCollectorNicInfo,
 	},
+	UseIfIndexLabel: false,
+	UseGUIDNicLabel: false,
+	TCPPortsEnabled: false,
 }
 
 // A Collector is a Prometheus Collector for Perflib Network Interface metrics.
@@ -66,6 +78,11 @@ type Collector struct {
 	perfDataCollector *pdh.Collector
 	perfDataObject    []perfDataCounterValues
 
+	miSession          *mi.Session
+	miQueryAdapterInfo mi.Query
+	adapterInfoCache   []adapterInfo
+	adapterInfoUpdated time.Time
+
 	bytesReceivedTotal       *prometheus.Desc
 	bytesSentTotal           *prometheus.Desc
 	bytesTotal               *prometheus.Desc
@@ -84,6 +101,11 @@ type Collector struct {
 	nicOperStatus    *prometheus.Desc
 	nicInfo          *prometheus.Desc
 	routeInfo        *prometheus.Desc
+
+	tcpConnections       *prometheus.Desc
+	tcpConnectionsByPort *prometheus.Desc
+
+	adapterInfo *prometheus.Desc
 }
 
 func New(config *Config) *Collector {
@@ -132,9 +154,29 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 
 	app.Flag(
 		"collector.net.enabled",
-		"Comma-separated list of collectors to use. Defaults to all, if not specified.",
+		fmt.Sprintf("Comma-separated list of collectors to use. Available collectors: %s, %s, %s, %s. Defaults to metrics and nic_info, if not specified.",
+			subCollectorMetrics,
+			subCollectorNicInfo,
+			subCollectorTCPConnections,
+			subCollectorAdapterInfo,
+		),
 	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
 
+	app.Flag(
+		"collector.net.use-ifindex-label",
+		"Add the if_index label with the interface index to all series. The index is stable across renames of the NIC description.",
+	).Default(strconv.FormatBool(ConfigDefaults.UseIfIndexLabel)).BoolVar(&c.config.UseIfIndexLabel)
+
+	app.Flag(
+		"collector.net.use-guid-nic-label",
+		"Use the interface GUID instead of the NIC description as value of the nic label.",
+	).Default(strconv.FormatBool(ConfigDefaults.UseGUIDNicLabel)).BoolVar(&c.config.UseGUIDNicLabel)
+
+	app.Flag(
+		"collector.net.tcp-ports-enable",
+		"Expose windows_net_tcp_connections_by_port with the number of TCP connections per state and local port. Beware of the cardinality on hosts with many outgoing connections.",
+	).Default(strconv.FormatBool(ConfigDefaults.TCPPortsEnabled)).BoolVar(&c.config.TCPPortsEnabled)
+
 	app.Action(func(*kingpin.ParseContext) error {
 		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
 
@@ -160,121 +202,148 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
 	c.perfDataCollector.Close()
 
 	return nil
 }
 
-func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 	for _, collector := range c.config.CollectorsEnabled {
-		if !slices.Contains([]string{subCollectorMetrics, subCollectorNicInfo}, collector) {
+		if !slices.Contains([]string{subCollectorMetrics, subCollectorNicInfo, subCollectorTCPConnections, subCollectorAdapterInfo}, collector) {
 			return fmt.Errorf("unknown sub collector: %s. Possible values: %s", collector,
-				strings.Join([]string{subCollectorMetrics, subCollectorNicInfo}, ", "),
+				strings.Join([]string{subCollectorMetrics, subCollectorNicInfo, subCollectorTCPConnections, subCollectorAdapterInfo}, ", "),
 			)
 		}
 	}
 
+	nicLabels := []string{"nic"}
+	if c.config.UseIfIndexLabel {
+		nicLabels = append(nicLabels, "if_index")
+	}
+
 	c.bytesReceivedTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bytes_received_total"),
 		"(Network.BytesReceivedPerSec)",
-		[]string{"nic"},
+		nicLabels,
 		nil,
 	)
 	c.bytesSentTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bytes_sent_total"),
 		"(Network.BytesSentPerSec)",
-		[]string{"nic"},
+		nicLabels,
 		nil,
 	)
 	c.bytesTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "bytes_total"),
 		"(Network.BytesTotalPerSec)",
-		[]string{"nic"},
+		nicLabels,
 		nil,
 	)
 	c.outputQueueLength = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "output_queue_length_packets"),
 		"(Network.OutputQueueLength)",
-		[]string{"nic"},
+		nicLabels,
 		nil,
 	)
 	c.packetsOutboundDiscarded = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_outbound_discarded_total"),
 		"(Network.PacketsOutboundDiscarded)",
-		[]string{"nic"},
+		nicLabels,
 		nil,
 	)
 	c.packetsOutboundErrors = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_outbound_errors_total"),
 		"(Network.PacketsOutboundErrors)",
-		[]string{"nic"},
+		nicLabels,
 		nil,
 	)
 	c.packetsReceivedDiscarded = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_received_discarded_total"),
 		"(Network.PacketsReceivedDiscarded)",
-		[]string{"nic"},
+		nicLabels,
 		nil,
 	)
 	c.packetsReceivedErrors = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_received_errors_total"),
 		"(Network.PacketsReceivedErrors)",
-		[]string{"nic"},
+		nicLabels,
 		nil,
 	)
 	c.packetsReceivedTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_received_total"),
 		"(Network.PacketsReceivedPerSec)",
-		[]string{"nic"},
+		nicLabels,
 		nil,
 	)
 	c.packetsReceivedUnknown = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_received_unknown_total"),
 		"(Network.PacketsReceivedUnknown)",
-		[]string{"nic"},
+		nicLabels,
 		nil,
 	)
 	c.packetsTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_total"),
 		"(Network.PacketsPerSec)",
-		[]string{"nic"},
+		nicLabels,
 		nil,
 	)
 	c.packetsSentTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "packets_sent_total"),
 		"(Network.PacketsSentPerSec)",
-		[]string{"nic"},
+		nicLabels,
 		nil,
 	)
 	c.currentBandwidth = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "current_bandwidth_bytes"),
 		"(Network.CurrentBandwidth)",
-		[]string{"nic"},
+		nicLabels,
 		nil,
 	)
 	c.nicIPAddressInfo = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "nic_address_info"),
 		"A metric with a constant '1' value labeled with the network interface's address information.",
-		[]string{"nic", "address", "family"},
+		slices.Concat(nicLabels, []string{"address", "family"}),
 		nil,
 	)
 	c.nicOperStatus = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "nic_operation_status"),
 		"The operational status for the interface as defined in RFC 2863 as IfOperStatus.",
-		[]string{"nic", "status"},
+		slices.Concat(nicLabels, []string{"status"}),
 		nil,
 	)
 	c.nicInfo = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "nic_info"),
 		"A metric with a constant '1' value labeled with the network interface's general information.",
-		[]string{"nic", "friendly_name", "mac"},
+		slices.Concat(nicLabels, []string{"friendly_name", "mac"}),
 		nil,
 	)
 	c.routeInfo = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "route_info"),
 		"A metric with a constant '1' value labeled with the network interface's route information.",
-		[]string{"nic", "src", "dest", "metric"},
+		slices.Concat(nicLabels, []string{"src", "dest", "metric"}),
+		nil,
+	)
+	c.tcpConnections = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "tcp_connections_total"),
+		"Number of IPv4 and IPv6 TCP connections by state.",
+		[]string{"state"},
+		nil,
+	)
+	c.tcpConnectionsByPort = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "tcp_connections_by_port"),
+		"Number of IPv4 and IPv6 TCP connections by state and local port.",
+		[]string{"state", "local_port"},
+		nil,
+	)
+	c.adapterInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "adapter_info"),
+		"A metric with a constant '1' value labeled with the hardware details of the network adapter from Win32_NetworkAdapter.",
+		[]string{"name", "mac_address", "manufacturer", "adapter_type", "guid", "speed_mbps"},
 		nil,
 	)
 
@@ -285,6 +354,19 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		return fmt.Errorf("failed to create Network Interface collector: %w", err)
 	}
 
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorAdapterInfo) {
+		if miSession == nil {
+			return errors.New("miSession is nil")
+		}
+
+		c.miQueryAdapterInfo, err = mi.NewQuery("SELECT Name, MACAddress, Manufacturer, AdapterType, GUID, Speed, InterfaceIndex FROM Win32_NetworkAdapter")
+		if err != nil {
+			return fmt.Errorf("failed to create WMI query: %w", err)
+		}
+
+		c.miSession = miSession
+	}
+
 	if slices.Contains(c.config.CollectorsEnabled, subCollectorNicInfo) {
 		logger.Info("nic/addresses collector is in an experimental state! The configuration and metrics may change in future. Please report any issues.",
 			slog.String("collector", Name),
@@ -296,7 +378,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
-func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
 	errs := make([]error, 0)
 
 	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
@@ -311,6 +393,18 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		}
 	}
 
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorTCPConnections) {
+		if err := c.collectTCPConnections(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed collecting TCP connections: %w", err))
+		}
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorAdapterInfo) {
+		if err := c.collectAdapterInfo(ch, maxScrapeDuration); err != nil {
+			errs = append(errs, fmt.Errorf("failed collecting adapter info: %w", err))
+		}
+	}
+
 	return errors.Join(errs...)
 }
 
@@ -320,101 +414,113 @@ func (c *Collector) collect(ch chan<- prometheus.Metric) error {
 		return fmt.Errorf("failed to collect Network Information metrics: %w", err)
 	}
 
+	var identities map[string]adapterIdentity
+
+	if c.config.UseIfIndexLabel || c.config.UseGUIDNicLabel {
+		identities, err = adapterIdentities()
+		if err != nil {
+			return fmt.Errorf("failed to get network adapters: %w", err)
+		}
+	}
+
 	for _, data := range c.perfDataObject {
 		if c.config.NicExclude.MatchString(data.Name) || !c.config.NicInclude.MatchString(data.Name) {
 			continue
 		}
 
+		identity, ok := identities[data.Name]
+		nicLabelValues := c.nicLabelValues(data.Name, identity, ok)
+
 		// Counters
 		ch <- prometheus.MustNewConstMetric(
 			c.bytesReceivedTotal,
 			prometheus.CounterValue,
 			data.BytesReceivedPerSec,
-			data.Name,
+			nicLabelValues...,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.bytesSentTotal,
 			prometheus.CounterValue,
 			data.BytesSentPerSec,
-			data.Name,
+			nicLabelValues...,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.bytesTotal,
 			prometheus.CounterValue,
 			data.BytesTotalPerSec,
-			data.Name,
+			nicLabelValues...,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.outputQueueLength,
 			prometheus.GaugeValue,
 			data.OutputQueueLength,
-			data.Name,
+			nicLabelValues...,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.packetsOutboundDiscarded,
 			prometheus.CounterValue,
 			data.PacketsOutboundDiscarded,
-			data.Name,
+			nicLabelValues...,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.packetsOutboundErrors,
 			prometheus.CounterValue,
 			data.PacketsOutboundErrors,
-			data.Name,
+			nicLabelValues...,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.packetsTotal,
 			prometheus.CounterValue,
 			data.PacketsPerSec,
-			data.Name,
+			nicLabelValues...,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.packetsReceivedDiscarded,
 			prometheus.CounterValue,
 			data.PacketsReceivedDiscarded,
-			data.Name,
+			nicLabelValues...,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.packetsReceivedErrors,
 			prometheus.CounterValue,
 			data.PacketsReceivedErrors,
-			data.Name,
+			nicLabelValues...,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.packetsReceivedTotal,
 			prometheus.CounterValue,
 			data.PacketsReceivedPerSec,
-			data.Name,
+			nicLabelValues...,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.packetsReceivedUnknown,
 			prometheus.CounterValue,
 			data.PacketsReceivedUnknown,
-			data.Name,
+			nicLabelValues...,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.packetsSentTotal,
 			prometheus.CounterValue,
 			data.PacketsSentPerSec,
-			data.Name,
+			nicLabelValues...,
 		)
 
 		ch <- prometheus.MustNewConstMetric(
 			c.currentBandwidth,
 			prometheus.GaugeValue,
 			data.CurrentBandwidth/8,
-			data.Name,
+			nicLabelValues...,
 		)
 	}
 
@@ -427,17 +533,17 @@ func (c *Collector) collectNICInfo(ch chan<- prometheus.Metric) error {
 		return err
 	}
 
-	convertNicName := strings.NewReplacer("(", "[", ")", "]", "#", "_")
-
 	for _, nicAdapter := range nicAdapterAddresses {
 		friendlyName := windows.UTF16PtrToString(nicAdapter.FriendlyName)
-		nicName := convertNicName.Replace(windows.UTF16PtrToString(nicAdapter.Description))
+		nicName := perfInstanceName(windows.UTF16PtrToString(nicAdapter.Description))
 
 		if c.config.NicExclude.MatchString(nicName) ||
 			!c.config.NicInclude.MatchString(nicName) {
 			continue
 		}
 
+		nicLabelValues := c.nicLabelValues(nicName, newAdapterIdentity(nicAdapter), true)
+
 		macAddress := fmt.Sprintf("%02X:%02X:%02X:%02X:%02X:%02X",
 			nicAdapter.PhysicalAddress[0],
 			nicAdapter.PhysicalAddress[1],
@@ -451,9 +557,7 @@ func (c *Collector) collectNICInfo(ch chan<- prometheus.Metric) error {
 			c.nicInfo,
 			prometheus.GaugeValue,
 			1,
-			nicName,
-			friendlyName,
-			macAddress,
+			slices.Concat(nicLabelValues, []string{friendlyName, macAddress})...,
 		)
 
 		for operState, labelValue := range operStatus {
@@ -466,8 +570,7 @@ func (c *Collector) collectNICInfo(ch chan<- prometheus.Metric) error {
 				c.nicOperStatus,
 				prometheus.GaugeValue,
 				metricStatus,
-				nicName,
-				labelValue,
+				slices.Concat(nicLabelValues, []string{labelValue})...,
 			)
 		}
 
@@ -486,9 +589,7 @@ func (c *Collector) collectNICInfo(ch chan<- prometheus.Metric) error {
 				c.nicIPAddressInfo,
 				prometheus.GaugeValue,
 				1,
-				nicName,
-				ipAddr.String(),
-				addressFamily[address.Address.Sockaddr.Addr.Family],
+				slices.Concat(nicLabelValues, []string{ipAddr.String(), addressFamily[address.Address.Sockaddr.Addr.Family]})...,
 			)
 		}
 
@@ -503,9 +604,7 @@ func (c *Collector) collectNICInfo(ch chan<- prometheus.Metric) error {
 				c.nicIPAddressInfo,
 				prometheus.GaugeValue,
 				1,
-				nicName,
-				ipAddr.String(),
-				addressFamily[address.Address.Sockaddr.Addr.Family],
+				slices.Concat(nicLabelValues, []string{ipAddr.String(), addressFamily[address.Address.Sockaddr.Addr.Family]})...,
 			)
 		}
 	}
@@ -553,3 +652,32 @@ func adapterAddresses() ([]*windows.IpAdapterAddresses, error) {
 
 	return addresses, nil
 }
+
+// adapterIdentities returns the identity of all network adapters, keyed by the Network Interface instance name.
+func adapterIdentities() (map[string]adapterIdentity, error) {
+	nicAdapterAddresses, err := adapterAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	adapters := make([]adapterIdentity, 0, len(nicAdapterAddresses))
+	for _, nicAdapter := range nicAdapterAddresses {
+		adapters = append(adapters, newAdapterIdentity(nicAdapter))
+	}
+
+	return newIdentityTable(adapters), nil
+}
+
+func newAdapterIdentity(nicAdapter *windows.IpAdapterAddresses) adapterIdentity {
+	// IfIndex is 0, if IPv4 is disabled on the interface.
+	ifIndex := nicAdapter.IfIndex
+	if ifIndex == 0 {
+		ifIndex = nicAdapter.Ipv6IfIndex
+	}
+
+	return adapterIdentity{
+		description: windows.UTF16PtrToString(nicAdapter.Description),
+		ifIndex:     ifIndex,
+		guid:        strings.ToLower(strings.Trim(windows.BytePtrToString(nicAdapter.AdapterName), "{}")),
+	}
+}
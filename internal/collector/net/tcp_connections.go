@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package net
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/iphlpapi"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+)
+
+// collectTCPConnections sends the number of IPv4 and IPv6 TCP connections per state and,
+// if enabled, per state and local port.
+func (c *Collector) collectTCPConnections(ch chan<- prometheus.Metric) error {
+	errs := make([]error, 0, 2)
+	counts := make(map[iphlpapi.TCPStatePort]uint32)
+
+	for _, family := range []uint32{windows.AF_INET, windows.AF_INET6} {
+		familyCounts, err := iphlpapi.GetTCPConnectionStatesByPort(family)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get TCP connections of address family %d: %w", family, err))
+
+			continue
+		}
+
+		for key, count := range familyCounts {
+			counts[key] += count
+		}
+	}
+
+	stateCounts := make(map[iphlpapi.MIB_TCP_STATE]uint32)
+
+	for key, count := range counts {
+		stateCounts[key.State] += count
+
+		if c.config.TCPPortsEnabled {
+			ch <- prometheus.MustNewConstMetric(
+				c.tcpConnectionsByPort,
+				prometheus.GaugeValue,
+				float64(count),
+				key.State.String(),
+				strconv.FormatUint(uint64(key.LocalPort), 10),
+			)
+		}
+	}
+
+	for state, count := range stateCounts {
+		ch <- prometheus.MustNewConstMetric(
+			c.tcpConnections,
+			prometheus.GaugeValue,
+			float64(count),
+			state.String(),
+		)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,281 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package os
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"runtime"
+	"time"
+	"unicode/utf16"
+
+	"github.com/go-ole/go-ole"
+	"github.com/go-ole/go-ole/oleutil"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// componentStoreRefreshInterval is the minimum interval between two walks of the component store,
+	// since the walk reads the metadata of several hundred thousand files.
+	componentStoreRefreshInterval = time.Hour
+
+	// componentCleanupTaskPath is the scheduled task, which removes superseded components from the component store.
+	componentCleanupTaskPath = `\Microsoft\Windows\Servicing\StartComponentCleanup`
+
+	// fileIDBothDirInfoSize is the offset of the FileName field in FILE_ID_BOTH_DIR_INFO.
+	fileIDBothDirInfoSize = 104
+)
+
+// directoryEntry is an entry of a FILE_ID_BOTH_DIR_INFO list.
+type directoryEntry struct {
+	name           string
+	fileID         uint64
+	allocationSize int64
+	attributes     uint32
+}
+
+// getComponentStoreSize returns the disk space allocated by the files below %windir%\WinSxS.
+// Files with multiple hard links inside the component store are counted once by their file ID.
+// Reparse points are not followed and directories, which can't be read, are skipped.
+func getComponentStoreSize() (int64, error) {
+	windowsDir, err := windows.GetSystemWindowsDirectory()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get Windows directory: %w", err)
+	}
+
+	root := filepath.Join(windowsDir, "WinSxS")
+	seen := make(map[uint64]struct{})
+	dirs := []string{root}
+
+	var size int64
+
+	for len(dirs) > 0 {
+		dir := dirs[len(dirs)-1]
+		dirs = dirs[:len(dirs)-1]
+
+		entries, err := readDirectoryEntries(dir)
+		if err != nil {
+			if dir == root {
+				return 0, err
+			}
+
+			continue
+		}
+
+		for _, entry := range entries {
+			if entry.name == "." || entry.name == ".." {
+				continue
+			}
+
+			if entry.attributes&windows.FILE_ATTRIBUTE_DIRECTORY != 0 {
+				if entry.attributes&windows.FILE_ATTRIBUTE_REPARSE_POINT == 0 {
+					dirs = append(dirs, filepath.Join(dir, entry.name))
+				}
+
+				continue
+			}
+
+			if _, ok := seen[entry.fileID]; ok {
+				continue
+			}
+
+			seen[entry.fileID] = struct{}{}
+			size += entry.allocationSize
+		}
+	}
+
+	return size, nil
+}
+
+// readDirectoryEntries returns the entries of the given directory including their file IDs
+// using GetFileInformationByHandleEx with FileIdBothDirectoryInfo.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winbase/nf-winbase-getfileinformationbyhandleex
+func readDirectoryEntries(dir string) ([]directoryEntry, error) {
+	dirPath, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := uint32(windows.FILE_SHARE_READ | windows.FILE_SHARE_WRITE | windows.FILE_SHARE_DELETE)
+	flags := uint32(windows.FILE_FLAG_BACKUP_SEMANTICS | windows.FILE_FLAG_OPEN_REPARSE_POINT)
+
+	handle, err := windows.CreateFile(dirPath, windows.FILE_LIST_DIRECTORY, mode, nil, windows.OPEN_EXISTING, flags, 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not open directory %s: %w", dir, err)
+	}
+
+	defer func(fd windows.Handle) {
+		_ = windows.Close(fd)
+	}(handle)
+
+	buf := make([]byte, 64*1024)
+	entries := make([]directoryEntry, 0)
+	class := uint32(windows.FileIdBothDirectoryRestartInfo)
+
+	for {
+		err = windows.GetFileInformationByHandleEx(handle, class, &buf[0], uint32(len(buf)))
+		if errors.Is(err, windows.ERROR_NO_MORE_FILES) {
+			return entries, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("could not read directory %s: %w", dir, err)
+		}
+
+		batch, err := parseFileIDBothDirInfo(buf)
+		if err != nil {
+			return nil, fmt.Errorf("could not read directory %s: %w", dir, err)
+		}
+
+		entries = append(entries, batch...)
+		class = windows.FileIdBothDirectoryInfo
+	}
+}
+
+// parseFileIDBothDirInfo parses a list of FILE_ID_BOTH_DIR_INFO structures.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winbase/ns-winbase-file_id_both_dir_info
+func parseFileIDBothDirInfo(buf []byte) ([]directoryEntry, error) {
+	entries := make([]directoryEntry, 0)
+
+	for offset := 0; ; {
+		if offset+fileIDBothDirInfoSize > len(buf) {
+			return nil, fmt.Errorf("directory entry at offset %d exceeds buffer of %d bytes", offset, len(buf))
+		}
+
+		entry := buf[offset:]
+		nameLength := int(binary.LittleEndian.Uint32(entry[60:]))
+
+		if fileIDBothDirInfoSize+nameLength > len(entry) {
+			return nil, fmt.Errorf("file name of directory entry at offset %d exceeds buffer of %d bytes", offset, len(buf))
+		}
+
+		name := make([]uint16, nameLength/2)
+		for i := range name {
+			name[i] = binary.LittleEndian.Uint16(entry[fileIDBothDirInfoSize+i*2:])
+		}
+
+		entries = append(entries, directoryEntry{
+			name:           string(utf16.Decode(name)),
+			fileID:         binary.LittleEndian.Uint64(entry[96:]),
+			allocationSize: int64(binary.LittleEndian.Uint64(entry[48:])),
+			attributes:     binary.LittleEndian.Uint32(entry[56:]),
+		})
+
+		nextEntryOffset := int(binary.LittleEndian.Uint32(entry))
+		if nextEntryOffset == 0 {
+			return entries, nil
+		}
+
+		offset += nextEntryOffset
+	}
+}
+
+// S_FALSE is returned by CoInitialize if it was already called on this thread.
+const S_FALSE = 0x00000001
+
+// getLastComponentCleanup returns the last run time of the StartComponentCleanup scheduled task.
+// The zero time is returned if the task has never run.
+func getLastComponentCleanup() (time.Time, error) {
+	// The COM apartment is bound to the current OS thread.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := ole.CoInitializeEx(0, ole.COINIT_APARTMENTTHREADED|ole.COINIT_DISABLE_OLE1DDE); err != nil {
+		var oleCode *ole.OleError
+		if errors.As(err, &oleCode) && oleCode.Code() != ole.S_OK && oleCode.Code() != S_FALSE {
+			return time.Time{}, err
+		}
+	}
+
+	defer ole.CoUninitialize()
+
+	schedClassID, err := ole.ClassIDFrom("Schedule.Service.1")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	taskSchedulerObj, err := ole.CreateInstance(schedClassID, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	defer taskSchedulerObj.Release()
+
+	taskServiceObj, err := taskSchedulerObj.QueryInterface(ole.IID_IDispatch)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	defer taskServiceObj.Release()
+
+	if _, err = oleutil.CallMethod(taskServiceObj, "Connect"); err != nil {
+		return time.Time{}, fmt.Errorf("failed to connect to the Task Scheduler: %w", err)
+	}
+
+	res, err := oleutil.CallMethod(taskServiceObj, "GetFolder", `\`)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	rootFolderObj := res.ToIDispatch()
+	defer rootFolderObj.Release()
+
+	res, err = oleutil.CallMethod(rootFolderObj, "GetTask", componentCleanupTaskPath)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	taskObj := res.ToIDispatch()
+	defer taskObj.Release()
+
+	lastRunTime, err := oleutil.GetProperty(taskObj, "LastRunTime")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	defer func() {
+		_ = lastRunTime.Clear()
+	}()
+
+	if lastRunTime.VT != ole.VT_DATE {
+		return time.Time{}, nil
+	}
+
+	return oleDateToTime(math.Float64frombits(uint64(lastRunTime.Val)), time.Local), nil
+}
+
+// oleDateToTime converts an OLE automation date, as returned by the LastRunTime property
+// of IRegisteredTask, into a time in the given location. The Task Scheduler uses 0 if
+// the task has never run, in which case the zero time is returned.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/oleauto/nf-oleauto-varianttimetosystemtime
+func oleDateToTime(date float64, loc *time.Location) time.Time {
+	if date <= 0 || math.IsNaN(date) || math.IsInf(date, 0) {
+		return time.Time{}
+	}
+
+	days := math.Floor(date)
+	milliseconds := int(math.Round((date - days) * 24 * 60 * 60 * 1000))
+
+	return time.Date(1899, time.December, 30+int(days), 0, 0, milliseconds/1000, (milliseconds%1000)*int(time.Millisecond), loc)
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package os
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows"
+)
+
+func buildFileIDBothDirInfo(t *testing.T, entries []directoryEntry) []byte {
+	t.Helper()
+
+	buf := make([]byte, 0)
+
+	for i, entry := range entries {
+		name := utf16.Encode([]rune(entry.name))
+		size := fileIDBothDirInfoSize + len(name)*2
+		// Entries are aligned to 8 bytes.
+		size = (size + 7) &^ 7
+
+		record := make([]byte, size)
+		if i < len(entries)-1 {
+			binary.LittleEndian.PutUint32(record[0:], uint32(size))
+		}
+
+		binary.LittleEndian.PutUint64(record[48:], uint64(entry.allocationSize))
+		binary.LittleEndian.PutUint32(record[56:], entry.attributes)
+		binary.LittleEndian.PutUint32(record[60:], uint32(len(name)*2))
+		binary.LittleEndian.PutUint64(record[96:], entry.fileID)
+
+		for j, c := range name {
+			binary.LittleEndian.PutUint16(record[fileIDBothDirInfoSize+j*2:], c)
+		}
+
+		buf = append(buf, record...)
+	}
+
+	return buf
+}
+
+func TestParseFileIDBothDirInfo(t *testing.T) {
+	t.Parallel()
+
+	expected := []directoryEntry{
+		{name: ".", fileID: 1, attributes: windows.FILE_ATTRIBUTE_DIRECTORY},
+		{name: "amd64_microsoft-windows-kernel32_31bf3856ad364e35", fileID: 2, attributes: windows.FILE_ATTRIBUTE_DIRECTORY},
+		{name: "pending.xml", fileID: 3, allocationSize: 8192, attributes: windows.FILE_ATTRIBUTE_ARCHIVE},
+	}
+
+	buf := buildFileIDBothDirInfo(t, expected)
+
+	entries, err := parseFileIDBothDirInfo(buf)
+	require.NoError(t, err)
+	require.Equal(t, expected, entries)
+
+	_, err = parseFileIDBothDirInfo(buf[:len(buf)-fileIDBothDirInfoSize])
+	require.Error(t, err)
+}
+
+func TestOLEDateToTime(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, oleDateToTime(0, time.UTC).IsZero())
+	require.Equal(t, time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC), oleDateToTime(45352.5, time.UTC))
+}
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -30,26 +31,47 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/osversion"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sys/windows/registry"
 )
 
-const Name = "os"
+const (
+	Name                       = "os"
+	subCollectorMetrics        = "metrics"
+	subCollectorComponentStore = "component_store"
+)
 
-type Config struct{}
+type Config struct {
+	CollectorsEnabled []string `yaml:"enabled"`
+}
 
 //nolint:gochecknoglobals
-var ConfigDefaults = Config{}
+var ConfigDefaults = Config{
+	CollectorsEnabled: []string{
+		subCollectorMetrics,
+	},
+}
 
 // A Collector is a Prometheus Collector for WMI metrics.
 type Collector struct {
 	config Config
+	logger *slog.Logger
 
 	installTimeTimestamp float64
 
-	hostname      *prometheus.Desc
-	osInformation *prometheus.Desc
-	installTime   *prometheus.Desc
+	componentStoreSizeCache    *utils.TTLCache[int64]
+	componentStoreCleanupCache *utils.TTLCache[time.Time]
+
+	hostname           *prometheus.Desc
+	osInformation      *prometheus.Desc
+	installTime        *prometheus.Desc
+	powerPlanInfo      *prometheus.Desc
+	onBattery          *prometheus.Desc
+	batteryChargeRatio *prometheus.Desc
+
+	componentStoreSize        *prometheus.Desc
+	componentStoreLastCleanup *prometheus.Desc
 }
 
 func New(config *Config) *Collector {
@@ -57,6 +79,10 @@ func New(config *Config) *Collector {
 		config = &ConfigDefaults
 	}
 
+	if config.CollectorsEnabled == nil {
+		config.CollectorsEnabled = ConfigDefaults.CollectorsEnabled
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -64,19 +90,60 @@ func New(config *Config) *Collector {
 	return c
 }
 
-func NewWithFlags(_ *kingpin.Application) *Collector {
-	return &Collector{}
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+
+	c.config.CollectorsEnabled = make([]string, 0)
+
+	var collectorsEnabled string
+
+	app.Flag(
+		"collector.os.enabled",
+		fmt.Sprintf("Comma-separated list of collectors to use. Available collectors: %s, %s. Defaults to metrics, if not specified.",
+			subCollectorMetrics,
+			subCollectorComponentStore,
+		),
+	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
+
+		return nil
+	})
+
+	return c
 }
 
 func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
 	return nil
 }
 
-func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
+func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+	c.logger = logger.With(slog.String("collector", Name))
+
+	for _, collector := range c.config.CollectorsEnabled {
+		if !slices.Contains([]string{subCollectorMetrics, subCollectorComponentStore}, collector) {
+			return fmt.Errorf("unknown sub collector: %s. Possible values: %s", collector,
+				strings.Join([]string{subCollectorMetrics, subCollectorComponentStore}, ", "),
+			)
+		}
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorComponentStore) {
+		c.componentStoreSizeCache = utils.NewTTLCache[int64](componentStoreRefreshInterval)
+		c.componentStoreCleanupCache = utils.NewTTLCache[time.Time](componentStoreRefreshInterval)
+	}
+
 	productName, revision, installationType, err := c.getWindowsVersion()
 	if err != nil {
 		return fmt.Errorf("failed to get Windows version: %w", err)
@@ -129,6 +196,41 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 		nil,
 	)
 
+	c.powerPlanInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "power_plan_info"),
+		"Active power plan. The plan name is localized",
+		[]string{"plan", "guid"},
+		nil,
+	)
+
+	c.onBattery = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "on_battery"),
+		"Whether the system is running on battery power. Omitted on systems without battery",
+		nil,
+		nil,
+	)
+
+	c.batteryChargeRatio = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "battery_charge_ratio"),
+		"Remaining battery charge. Omitted on systems without battery",
+		nil,
+		nil,
+	)
+
+	c.componentStoreSize = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "component_store_size_bytes"),
+		"Disk space allocated by the files of the component store (%windir%\\WinSxS). Hard-linked files are counted once",
+		nil,
+		nil,
+	)
+
+	c.componentStoreLastCleanup = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "component_store_last_cleanup_timestamp_seconds"),
+		"Unix timestamp of the last run of the StartComponentCleanup scheduled task. Omitted if the task has never run",
+		nil,
+		nil,
+	)
+
 	return nil
 }
 
@@ -137,20 +239,67 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
 	errs := make([]error, 0)
 
-	ch <- prometheus.MustNewConstMetric(
-		c.osInformation,
-		prometheus.GaugeValue,
-		1.0,
-	)
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+		ch <- prometheus.MustNewConstMetric(
+			c.osInformation,
+			prometheus.GaugeValue,
+			1.0,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.installTime,
+			prometheus.GaugeValue,
+			c.installTimeTimestamp,
+		)
+
+		if err := c.collectHostname(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed to collect hostname metrics: %w", err))
+		}
+
+		if err := c.collectPower(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed to collect power metrics: %w", err))
+		}
+	}
 
-	ch <- prometheus.MustNewConstMetric(
-		c.installTime,
-		prometheus.GaugeValue,
-		c.installTimeTimestamp,
-	)
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorComponentStore) {
+		if err := c.collectComponentStore(ch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// collectComponentStore sends the size of the component store and the last run of the component cleanup.
+// Both are refreshed in the background at most once per hour, so nothing is sent until the first refresh completed.
+// The error of the last refresh is returned until the next refresh.
+func (c *Collector) collectComponentStore(ch chan<- prometheus.Metric) error {
+	errs := make([]error, 0)
+
+	sizeBytes, ok, err := c.componentStoreSizeCache.Get(getComponentStoreSize)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to get component store size: %w", err))
+	}
+
+	if ok && sizeBytes > 0 {
+		ch <- prometheus.MustNewConstMetric(
+			c.componentStoreSize,
+			prometheus.GaugeValue,
+			float64(sizeBytes),
+		)
+	}
+
+	lastCleanup, ok, err := c.componentStoreCleanupCache.Get(getLastComponentCleanup)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to get last run of %s: %w", componentCleanupTaskPath, err))
+	}
 
-	if err := c.collectHostname(ch); err != nil {
-		errs = append(errs, fmt.Errorf("failed to collect hostname metrics: %w", err))
+	if ok && !lastCleanup.IsZero() {
+		ch <- prometheus.MustNewConstMetric(
+			c.componentStoreLastCleanup,
+			prometheus.GaugeValue,
+			float64(lastCleanup.UnixMilli())/1e3,
+		)
 	}
 
 	return errors.Join(errs...)
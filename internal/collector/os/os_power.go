@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package os
+
+import (
+	"fmt"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/kernel32"
+	"github.com/prometheus-community/windows_exporter/internal/headers/powrprof"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectPower sends the active power plan and the battery status.
+// The power plan is read on every scrape, so plan changes are reflected immediately.
+func (c *Collector) collectPower(ch chan<- prometheus.Metric) error {
+	scheme, err := powrprof.GetActiveScheme()
+	if err != nil {
+		return fmt.Errorf("failed to get active power scheme: %w", err)
+	}
+
+	plan, err := powrprof.ReadFriendlyName(scheme)
+	if err != nil {
+		return fmt.Errorf("failed to read name of power scheme %s: %w", scheme, err)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.powerPlanInfo,
+		prometheus.GaugeValue,
+		1.0,
+		plan,
+		scheme.String(),
+	)
+
+	status, err := kernel32.GetSystemPowerStatus()
+	if err != nil {
+		return fmt.Errorf("failed to get system power status: %w", err)
+	}
+
+	onBattery, chargeRatio, ok := batteryStatus(status)
+	if !ok {
+		return nil
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.onBattery,
+		prometheus.GaugeValue,
+		utils.BoolToFloat(onBattery),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.batteryChargeRatio,
+		prometheus.GaugeValue,
+		chargeRatio,
+	)
+
+	return nil
+}
+
+// batteryStatus returns whether the system runs on battery and the remaining battery charge.
+// ok is false if the system has no battery or the battery status is unknown.
+func batteryStatus(status kernel32.SystemPowerStatus) (bool, float64, bool) {
+	if status.BatteryFlag == kernel32.BatteryStatusUnknown ||
+		status.BatteryFlag&kernel32.BatteryFlagNoSystemBattery != 0 ||
+		status.BatteryLifePercent == kernel32.BatteryStatusUnknown {
+		return false, 0, false
+	}
+
+	return status.ACLineStatus == kernel32.ACLineStatusOffline, utils.PercentageToRatio(float64(status.BatteryLifePercent)), true
+}
@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package os
+
+import (
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/kernel32"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatteryStatus(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name        string
+		status      kernel32.SystemPowerStatus
+		onBattery   bool
+		chargeRatio float64
+		ok          bool
+	}{
+		{
+			name:   "no system battery",
+			status: kernel32.SystemPowerStatus{ACLineStatus: 1, BatteryFlag: 128, BatteryLifePercent: 255},
+		},
+		{
+			name:   "unknown",
+			status: kernel32.SystemPowerStatus{ACLineStatus: 255, BatteryFlag: 255, BatteryLifePercent: 255},
+		},
+		{
+			name:        "charging",
+			status:      kernel32.SystemPowerStatus{ACLineStatus: 1, BatteryFlag: 8, BatteryLifePercent: 80},
+			chargeRatio: 0.8,
+			ok:          true,
+		},
+		{
+			name:        "on battery",
+			status:      kernel32.SystemPowerStatus{ACLineStatus: 0, BatteryFlag: 2, BatteryLifePercent: 25},
+			onBattery:   true,
+			chargeRatio: 0.25,
+			ok:          true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			onBattery, chargeRatio, ok := batteryStatus(tc.status)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.onBattery, onBattery)
+			require.InDelta(t, tc.chargeRatio, chargeRatio, 1e-9)
+		})
+	}
+}
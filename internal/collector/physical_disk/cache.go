@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package physical_disk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// ioctlDiskGetCacheInformation is IOCTL_DISK_GET_CACHE_INFORMATION.
+	// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ni-winioctl-ioctl_disk_get_cache_information
+	ioctlDiskGetCacheInformation = 0x000740D4
+
+	storageDeviceWriteCacheProperty = 4
+
+	// diskCacheInformationSize is the size of DISK_CACHE_INFORMATION.
+	diskCacheInformationSize = 24
+	// storageWriteCachePropertySize is the size of STORAGE_WRITE_CACHE_PROPERTY.
+	storageWriteCachePropertySize = 28
+)
+
+// writeCacheInfo contains the write cache state of a disk.
+// Fields the disk did not report are nil.
+type writeCacheInfo struct {
+	enabled        *bool
+	powerProtected *bool
+}
+
+// getWriteCache returns the write cache state of the given physical disk number.
+// Failing ioctls are not treated as error, since some disks (e.g. SAN LUNs) reject them.
+func getWriteCache(diskNumber string) (writeCacheInfo, error) {
+	diskPath, err := windows.UTF16PtrFromString(`\\.\PhysicalDrive` + diskNumber)
+	if err != nil {
+		return writeCacheInfo{}, err
+	}
+
+	mode := uint32(windows.FILE_SHARE_READ | windows.FILE_SHARE_WRITE | windows.FILE_SHARE_DELETE)
+
+	// IOCTL_DISK_GET_CACHE_INFORMATION requires read access, IOCTL_STORAGE_QUERY_PROPERTY does not.
+	handle, err := windows.CreateFile(diskPath, windows.GENERIC_READ, mode, nil, windows.OPEN_EXISTING, 0, 0)
+	if errors.Is(err, windows.ERROR_ACCESS_DENIED) {
+		handle, err = windows.CreateFile(diskPath, 0, mode, nil, windows.OPEN_EXISTING, 0, 0)
+	}
+
+	if err != nil {
+		return writeCacheInfo{}, fmt.Errorf("could not open physical drive %s: %w", diskNumber, err)
+	}
+
+	defer func(fd windows.Handle) {
+		_ = windows.Close(fd)
+	}(handle)
+
+	var (
+		info          writeCacheInfo
+		bytesReturned uint32
+	)
+
+	buf := make([]byte, diskCacheInformationSize)
+
+	err = windows.DeviceIoControl(handle, ioctlDiskGetCacheInformation, nil, 0, &buf[0], uint32(len(buf)), &bytesReturned, nil)
+	if err == nil {
+		if enabled, err := parseDiskCacheInformation(buf[:bytesReturned]); err == nil {
+			info.enabled = &enabled
+		}
+	}
+
+	query := make([]byte, 12)
+	binary.LittleEndian.PutUint32(query[0:], storageDeviceWriteCacheProperty)
+	binary.LittleEndian.PutUint32(query[4:], propertyStandardQuery)
+
+	buf = make([]byte, storageWriteCachePropertySize)
+
+	err = windows.DeviceIoControl(handle, ioctlStorageQueryProperty, &query[0], uint32(len(query)), &buf[0], uint32(len(buf)), &bytesReturned, nil)
+	if err == nil {
+		if powerProtected, err := parseWriteCacheProperty(buf[:bytesReturned]); err == nil {
+			info.powerProtected = &powerProtected
+		}
+	}
+
+	return info, nil
+}
+
+// parseDiskCacheInformation returns the WriteCacheEnabled field of a DISK_CACHE_INFORMATION structure.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ns-winioctl-disk_cache_information
+func parseDiskCacheInformation(buf []byte) (bool, error) {
+	if len(buf) < diskCacheInformationSize {
+		return false, fmt.Errorf("disk cache information too short: %d bytes", len(buf))
+	}
+
+	return buf[2] != 0, nil
+}
+
+// parseWriteCacheProperty parses a STORAGE_WRITE_CACHE_PROPERTY structure.
+// The cache is considered power protected, if it is non-volatile or the user marked it as power protected.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ns-winioctl-storage_write_cache_property
+func parseWriteCacheProperty(buf []byte) (bool, error) {
+	if len(buf) < storageWriteCachePropertySize {
+		return false, fmt.Errorf("write cache property too short: %d bytes", len(buf))
+	}
+
+	userDefinedPowerProtection := buf[25] != 0
+	nvCacheEnabled := buf[26] != 0
+
+	return userDefinedPowerProtection || nvCacheEnabled, nil
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package physical_disk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiskCacheInformation(t *testing.T) {
+	t.Parallel()
+
+	buf := make([]byte, diskCacheInformationSize)
+
+	enabled, err := parseDiskCacheInformation(buf)
+	require.NoError(t, err)
+	require.False(t, enabled)
+
+	buf[2] = 1
+
+	enabled, err = parseDiskCacheInformation(buf)
+	require.NoError(t, err)
+	require.True(t, enabled)
+
+	_, err = parseDiskCacheInformation(buf[:diskCacheInformationSize-1])
+	require.Error(t, err)
+}
+
+func TestParseWriteCacheProperty(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name                       string
+		userDefinedPowerProtection byte
+		nvCacheEnabled             byte
+		expected                   bool
+	}{
+		{name: "volatile", expected: false},
+		{name: "user defined", userDefinedPowerProtection: 1, expected: true},
+		{name: "non-volatile", nvCacheEnabled: 1, expected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := make([]byte, storageWriteCachePropertySize)
+			buf[25] = tc.userDefinedPowerProtection
+			buf[26] = tc.nvCacheEnabled
+
+			powerProtected, err := parseWriteCacheProperty(buf)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, powerProtected)
+		})
+	}
+
+	_, err := parseWriteCacheProperty(make([]byte, storageWriteCachePropertySize-1))
+	require.Error(t, err)
+}
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package physical_disk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+// storageBusTypes maps the STORAGE_BUS_TYPE enumeration to a readable name.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ne-winioctl-storage_bus_type
+//
+//nolint:gochecknoglobals
+var storageBusTypes = map[uint32]string{
+	0x00:        "unknown",
+	0x01:        "scsi",
+	0x02:        "atapi",
+	busTypeATA:  "ata",
+	0x04:        "1394",
+	0x05:        "ssa",
+	0x06:        "fibre",
+	busTypeUSB:  "usb",
+	0x08:        "raid",
+	0x09:        "iscsi",
+	0x0A:        "sas",
+	busTypeSATA: "sata",
+	0x0C:        "sd",
+	0x0D:        "mmc",
+	0x0E:        "virtual",
+	0x0F:        "file_backed_virtual",
+	0x10:        "spaces",
+	busTypeNVMe: "nvme",
+	0x12:        "scm",
+	0x13:        "ufs",
+	0x14:        "nvmeof",
+}
+
+// validateBusTypes returns an error if any of the given bus types is not a known STORAGE_BUS_TYPE name.
+func validateBusTypes(busTypes []string) error {
+	knownBusTypes := slices.Sorted(maps.Values(storageBusTypes))
+
+	for _, busType := range busTypes {
+		if !slices.Contains(knownBusTypes, busType) {
+			return fmt.Errorf("unknown bus type %q. Possible values: %s", busType, strings.Join(knownBusTypes, ", "))
+		}
+	}
+
+	return nil
+}
+
+// deviceInfo contains the identification of a physical disk.
+type deviceInfo struct {
+	model           string
+	serialNumber    string
+	busType         string
+	firmwareVersion string
+}
+
+// getDeviceInfo returns the model, serial number, bus type and firmware version of the given physical disk number.
+func getDeviceInfo(diskNumber string) (deviceInfo, error) {
+	diskPath, err := windows.UTF16PtrFromString(`\\.\PhysicalDrive` + diskNumber)
+	if err != nil {
+		return deviceInfo{}, err
+	}
+
+	// IOCTL_STORAGE_QUERY_PROPERTY uses FILE_ANY_ACCESS, so no access rights are required.
+	mode := uint32(windows.FILE_SHARE_READ | windows.FILE_SHARE_WRITE | windows.FILE_SHARE_DELETE)
+
+	handle, err := windows.CreateFile(diskPath, 0, mode, nil, windows.OPEN_EXISTING, 0, 0)
+	if err != nil {
+		return deviceInfo{}, fmt.Errorf("could not open physical drive %s: %w", diskNumber, err)
+	}
+
+	defer func(fd windows.Handle) {
+		_ = windows.Close(fd)
+	}(handle)
+
+	query := make([]byte, 12)
+	binary.LittleEndian.PutUint32(query[0:], storageDeviceProperty)
+	binary.LittleEndian.PutUint32(query[4:], propertyStandardQuery)
+
+	buf := make([]byte, 1024)
+
+	var bytesReturned uint32
+
+	err = windows.DeviceIoControl(handle, ioctlStorageQueryProperty, &query[0], uint32(len(query)), &buf[0], uint32(len(buf)), &bytesReturned, nil)
+	if err != nil {
+		return deviceInfo{}, fmt.Errorf("could not get storage device descriptor of physical drive %s: %w", diskNumber, err)
+	}
+
+	return parseStorageDeviceDescriptor(buf[:bytesReturned])
+}
+
+// parseStorageDeviceDescriptor parses a STORAGE_DEVICE_DESCRIPTOR structure.
+// The vendor ID is prepended to the product ID, if the disk reports one.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ns-winioctl-storage_device_descriptor
+func parseStorageDeviceDescriptor(buf []byte) (deviceInfo, error) {
+	if len(buf) < storageDeviceDescriptorSize {
+		return deviceInfo{}, fmt.Errorf("storage device descriptor too short: %d bytes", len(buf))
+	}
+
+	vendor := descriptorString(buf, binary.LittleEndian.Uint32(buf[12:]))
+	product := descriptorString(buf, binary.LittleEndian.Uint32(buf[16:]))
+
+	model := product
+	if vendor != "" && !strings.HasPrefix(product, vendor) {
+		model = strings.TrimSpace(vendor + " " + product)
+	}
+
+	busType := binary.LittleEndian.Uint32(buf[28:])
+
+	busTypeName, ok := storageBusTypes[busType]
+	if !ok {
+		busTypeName = strconv.FormatUint(uint64(busType), 10)
+	}
+
+	return deviceInfo{
+		model:           model,
+		serialNumber:    descriptorString(buf, binary.LittleEndian.Uint32(buf[24:])),
+		busType:         busTypeName,
+		firmwareVersion: descriptorString(buf, binary.LittleEndian.Uint32(buf[20:])),
+	}, nil
+}
+
+// descriptorString returns the null-terminated string at the given offset of the descriptor.
+// An offset of zero means that the device did not report the value.
+func descriptorString(buf []byte, offset uint32) string {
+	if offset == 0 || int(offset) >= len(buf) {
+		return ""
+	}
+
+	value := buf[offset:]
+	if end := bytes.IndexByte(value, 0); end >= 0 {
+		value = value[:end]
+	}
+
+	return strings.TrimSpace(string(value))
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package physical_disk
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func buildStorageDeviceDescriptor(t *testing.T, busType uint32, vendor, product, revision, serial string) []byte {
+	t.Helper()
+
+	buf := make([]byte, storageDeviceDescriptorSize)
+	binary.LittleEndian.PutUint32(buf[28:], busType)
+
+	for i, value := range []string{vendor, product, revision, serial} {
+		if value == "" {
+			continue
+		}
+
+		binary.LittleEndian.PutUint32(buf[12+i*4:], uint32(len(buf)))
+		buf = append(buf, value...)
+		buf = append(buf, 0)
+	}
+
+	return buf
+}
+
+func TestParseStorageDeviceDescriptor(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		buf      []byte
+		expected deviceInfo
+	}{
+		{
+			name: "nvme",
+			buf:  buildStorageDeviceDescriptor(t, busTypeNVMe, "", "Samsung SSD 980 PRO 1TB", "5B2QGXA7", "0025_3852_1190_41E5."),
+			expected: deviceInfo{
+				model:           "Samsung SSD 980 PRO 1TB",
+				serialNumber:    "0025_3852_1190_41E5.",
+				busType:         "nvme",
+				firmwareVersion: "5B2QGXA7",
+			},
+		},
+		{
+			name: "sas with padded vendor",
+			buf:  buildStorageDeviceDescriptor(t, 0x0A, "SEAGATE ", "ST4000NM0023    ", "0004", "  Z1Z0ABCD"),
+			expected: deviceInfo{
+				model:           "SEAGATE ST4000NM0023",
+				serialNumber:    "Z1Z0ABCD",
+				busType:         "sas",
+				firmwareVersion: "0004",
+			},
+		},
+		{
+			name: "unknown bus type without strings",
+			buf:  buildStorageDeviceDescriptor(t, 0x7F, "", "", "", ""),
+			expected: deviceInfo{
+				busType: "127",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			info, err := parseStorageDeviceDescriptor(tc.buf)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, info)
+		})
+	}
+
+	_, err := parseStorageDeviceDescriptor(make([]byte, storageDeviceDescriptorSize-1))
+	require.Error(t, err)
+}
+
+func TestValidateBusTypes(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validateBusTypes(nil))
+	require.NoError(t, validateBusTypes([]string{"usb", "iscsi"}))
+	require.ErrorContains(t, validateBusTypes([]string{"usb", "firewire"}), `unknown bus type "firewire"`)
+}
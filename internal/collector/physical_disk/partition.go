@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package physical_disk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// ioctlDiskGetDriveLayoutEx is IOCTL_DISK_GET_DRIVE_LAYOUT_EX.
+	// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ni-winioctl-ioctl_disk_get_drive_layout_ex
+	ioctlDiskGetDriveLayoutEx = 0x00070050
+
+	// driveLayoutHeaderSize is the offset of the PartitionEntry array in DRIVE_LAYOUT_INFORMATION_EX.
+	driveLayoutHeaderSize = 48
+	// partitionEntrySize is the size of PARTITION_INFORMATION_EX.
+	partitionEntrySize = 144
+	// initialPartitionEntries is the number of partition entries the first DeviceIoControl call has room for.
+	// GPT disks have 128 partition entries by default.
+	initialPartitionEntries = 128
+	// maxPartitionEntries limits the growth of the buffer passed to DeviceIoControl.
+	maxPartitionEntries = 16384
+
+	partitionStyleMBR = 0
+	partitionStyleGPT = 1
+	partitionStyleRAW = 2
+)
+
+// gptPartitionTypes maps well-known GPT partition type GUIDs to a readable name.
+//
+//nolint:gochecknoglobals
+var gptPartitionTypes = map[string]string{
+	"{EBD0A0A2-B9E5-4433-87C0-68B6B72699C7}": "basic_data",
+	"{C12A7328-F81F-11D2-BA4B-00A0C93EC93B}": "efi_system",
+	"{E3C9E316-0B5C-4DB8-817D-F92DF00215AE}": "microsoft_reserved",
+	"{DE94BBA4-06D1-4D40-A16A-BFD50179D6AC}": "recovery",
+	"{5808C8AA-7E8F-42E0-85D2-E1E90434CFB3}": "ldm_metadata",
+	"{AF9B60A0-1431-4F62-BC68-3311714A69AD}": "ldm_data",
+	"{E75CAF8F-F680-4CEE-AFA3-B001E56EFC2D}": "storage_spaces",
+	"{DB97DBA9-0840-4BAE-97F0-FFB9A327C7E1}": "cluster_metadata",
+}
+
+// mbrPartitionTypes maps well-known MBR partition type bytes to a readable name.
+//
+//nolint:gochecknoglobals
+var mbrPartitionTypes = map[uint8]string{
+	0x01: "fat12",
+	0x04: "fat16",
+	0x05: "extended",
+	0x06: "fat16",
+	0x07: "ifs",
+	0x0B: "fat32",
+	0x0C: "fat32",
+	0x0E: "fat16",
+	0x0F: "extended",
+	0x27: "recovery",
+	0x42: "ldm",
+	0xEE: "gpt_protective",
+	0xEF: "efi_system",
+}
+
+type partitionInfo struct {
+	number        string
+	style         string
+	partitionType string
+	offset        float64
+	size          float64
+}
+
+// getPartitions returns the partition layout of the given physical disk number.
+func getPartitions(diskNumber string) ([]partitionInfo, error) {
+	diskPath, err := windows.UTF16PtrFromString(`\\.\PhysicalDrive` + diskNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// IOCTL_DISK_GET_DRIVE_LAYOUT_EX uses FILE_ANY_ACCESS, so no access rights are required.
+	mode := uint32(windows.FILE_SHARE_READ | windows.FILE_SHARE_WRITE | windows.FILE_SHARE_DELETE)
+
+	handle, err := windows.CreateFile(diskPath, 0, mode, nil, windows.OPEN_EXISTING, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not open physical drive %s: %w", diskNumber, err)
+	}
+
+	defer func(fd windows.Handle) {
+		_ = windows.Close(fd)
+	}(handle)
+
+	var bytesReturned uint32
+
+	// The buffer is doubled until all partition entries fit, e.g. for dynamic disks with many partitions.
+	for entries := initialPartitionEntries; ; entries *= 2 {
+		buf := make([]byte, driveLayoutHeaderSize+entries*partitionEntrySize)
+
+		err = windows.DeviceIoControl(handle, ioctlDiskGetDriveLayoutEx, nil, 0, &buf[0], uint32(len(buf)), &bytesReturned, nil)
+		if err == nil {
+			return parseDriveLayout(buf[:bytesReturned])
+		}
+
+		if !errors.Is(err, windows.ERROR_INSUFFICIENT_BUFFER) || entries >= maxPartitionEntries {
+			return nil, fmt.Errorf("could not get drive layout of physical drive %s: %w", diskNumber, err)
+		}
+	}
+}
+
+// parseDriveLayout parses a DRIVE_LAYOUT_INFORMATION_EX structure.
+// Unused MBR entries and extended partition containers are skipped.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ns-winioctl-drive_layout_information_ex
+func parseDriveLayout(buf []byte) ([]partitionInfo, error) {
+	if len(buf) < driveLayoutHeaderSize {
+		return nil, fmt.Errorf("drive layout too short: %d bytes", len(buf))
+	}
+
+	partitionCount := int(binary.LittleEndian.Uint32(buf[4:]))
+	if driveLayoutHeaderSize+partitionCount*partitionEntrySize > len(buf) {
+		return nil, fmt.Errorf("drive layout reports %d partitions, but only %d bytes were returned", partitionCount, len(buf))
+	}
+
+	partitions := make([]partitionInfo, 0, partitionCount)
+
+	for i := range partitionCount {
+		entry := buf[driveLayoutHeaderSize+i*partitionEntrySize:][:partitionEntrySize]
+
+		partition, err := parsePartitionEntry(entry)
+		if err != nil {
+			return nil, fmt.Errorf("partition entry %d: %w", i, err)
+		}
+
+		if partition.number == "0" || partition.size == 0 {
+			continue
+		}
+
+		partitions = append(partitions, partition)
+	}
+
+	return partitions, nil
+}
+
+// parsePartitionEntry parses a PARTITION_INFORMATION_EX structure.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ns-winioctl-partition_information_ex
+func parsePartitionEntry(entry []byte) (partitionInfo, error) {
+	partition := partitionInfo{
+		offset: float64(binary.LittleEndian.Uint64(entry[8:])),
+		size:   float64(binary.LittleEndian.Uint64(entry[16:])),
+		number: strconv.FormatUint(uint64(binary.LittleEndian.Uint32(entry[24:])), 10),
+	}
+
+	switch style := binary.LittleEndian.Uint32(entry); style {
+	case partitionStyleMBR:
+		partition.style = "mbr"
+
+		mbrType := entry[32]
+		if name, ok := mbrPartitionTypes[mbrType]; ok {
+			partition.partitionType = name
+		} else {
+			partition.partitionType = fmt.Sprintf("0x%02X", mbrType)
+		}
+	case partitionStyleGPT:
+		partition.style = "gpt"
+
+		gptType := formatGUID(entry[32:48])
+		if name, ok := gptPartitionTypes[gptType]; ok {
+			partition.partitionType = name
+		} else {
+			partition.partitionType = strings.ToLower(strings.Trim(gptType, "{}"))
+		}
+	case partitionStyleRAW:
+		partition.style = "raw"
+		partition.partitionType = "raw"
+	default:
+		return partitionInfo{}, errors.New("unknown partition style " + strconv.FormatUint(uint64(style), 10))
+	}
+
+	return partition, nil
+}
+
+// formatGUID formats a GUID in its mixed-endian binary representation.
+func formatGUID(b []byte) string {
+	return windows.GUID{
+		Data1: binary.LittleEndian.Uint32(b[0:]),
+		Data2: binary.LittleEndian.Uint16(b[4:]),
+		Data3: binary.LittleEndian.Uint16(b[6:]),
+		Data4: [8]byte(b[8:16]),
+	}.String()
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package physical_disk
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows"
+)
+
+type testPartition struct {
+	style  uint32
+	offset uint64
+	size   uint64
+	number uint32
+	mbr    uint8
+	gpt    string
+}
+
+func buildDriveLayout(t *testing.T, style uint32, partitions []testPartition) []byte {
+	t.Helper()
+
+	buf := make([]byte, driveLayoutHeaderSize+len(partitions)*partitionEntrySize)
+	binary.LittleEndian.PutUint32(buf[0:], style)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(len(partitions)))
+
+	for i, partition := range partitions {
+		entry := buf[driveLayoutHeaderSize+i*partitionEntrySize:]
+		binary.LittleEndian.PutUint32(entry[0:], partition.style)
+		binary.LittleEndian.PutUint64(entry[8:], partition.offset)
+		binary.LittleEndian.PutUint64(entry[16:], partition.size)
+		binary.LittleEndian.PutUint32(entry[24:], partition.number)
+
+		switch partition.style {
+		case partitionStyleMBR:
+			entry[32] = partition.mbr
+		case partitionStyleGPT:
+			guid, err := windows.GUIDFromString(partition.gpt)
+			require.NoError(t, err)
+
+			binary.LittleEndian.PutUint32(entry[32:], guid.Data1)
+			binary.LittleEndian.PutUint16(entry[36:], guid.Data2)
+			binary.LittleEndian.PutUint16(entry[38:], guid.Data3)
+			copy(entry[40:48], guid.Data4[:])
+		}
+	}
+
+	return buf
+}
+
+func TestParseDriveLayout(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		style      uint32
+		partitions []testPartition
+		expected   []partitionInfo
+	}{
+		{
+			name:  "gpt",
+			style: partitionStyleGPT,
+			partitions: []testPartition{
+				{style: partitionStyleGPT, offset: 1048576, size: 104857600, number: 1, gpt: "{C12A7328-F81F-11D2-BA4B-00A0C93EC93B}"},
+				{style: partitionStyleGPT, offset: 105906176, size: 16777216, number: 2, gpt: "{E3C9E316-0B5C-4DB8-817D-F92DF00215AE}"},
+				{style: partitionStyleGPT, offset: 122683392, size: 255936724992, number: 3, gpt: "{EBD0A0A2-B9E5-4433-87C0-68B6B72699C7}"},
+				{style: partitionStyleGPT, offset: 256059408384, size: 1048576, number: 4, gpt: "{0FC63DAF-8483-4772-8E79-3D69D8477DE4}"},
+			},
+			expected: []partitionInfo{
+				{number: "1", style: "gpt", partitionType: "efi_system", offset: 1048576, size: 104857600},
+				{number: "2", style: "gpt", partitionType: "microsoft_reserved", offset: 105906176, size: 16777216},
+				{number: "3", style: "gpt", partitionType: "basic_data", offset: 122683392, size: 255936724992},
+				{number: "4", style: "gpt", partitionType: "0fc63daf-8483-4772-8e79-3d69d8477de4", offset: 256059408384, size: 1048576},
+			},
+		},
+		{
+			name:  "mbr with unused entries",
+			style: partitionStyleMBR,
+			partitions: []testPartition{
+				{style: partitionStyleMBR, offset: 32256, size: 53686402560, number: 1, mbr: 0x07},
+				{style: partitionStyleMBR},
+				{style: partitionStyleMBR},
+				{style: partitionStyleMBR},
+			},
+			expected: []partitionInfo{
+				{number: "1", style: "mbr", partitionType: "ifs", offset: 32256, size: 53686402560},
+			},
+		},
+		{
+			name:  "mbr dynamic disk",
+			style: partitionStyleMBR,
+			partitions: []testPartition{
+				{style: partitionStyleMBR, offset: 32256, size: 1000202241024, number: 1, mbr: 0x42},
+				{style: partitionStyleMBR, offset: 0, size: 0, number: 0, mbr: 0x00},
+			},
+			expected: []partitionInfo{
+				{number: "1", style: "mbr", partitionType: "ldm", offset: 32256, size: 1000202241024},
+			},
+		},
+		{
+			name:  "gpt dynamic disk",
+			style: partitionStyleGPT,
+			partitions: []testPartition{
+				{style: partitionStyleGPT, offset: 17408, size: 1048576, number: 1, gpt: "{5808C8AA-7E8F-42E0-85D2-E1E90434CFB3}"},
+				{style: partitionStyleGPT, offset: 1065984, size: 2000396746752, number: 2, gpt: "{AF9B60A0-1431-4F62-BC68-3311714A69AD}"},
+			},
+			expected: []partitionInfo{
+				{number: "1", style: "gpt", partitionType: "ldm_metadata", offset: 17408, size: 1048576},
+				{number: "2", style: "gpt", partitionType: "ldm_data", offset: 1065984, size: 2000396746752},
+			},
+		},
+		{
+			name:       "raw disk",
+			style:      partitionStyleRAW,
+			partitions: []testPartition{},
+			expected:   []partitionInfo{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			partitions, err := parseDriveLayout(buildDriveLayout(t, tc.style, tc.partitions))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, partitions)
+		})
+	}
+}
+
+func TestParseDriveLayoutTruncated(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseDriveLayout(make([]byte, 8))
+	require.Error(t, err)
+
+	buf := buildDriveLayout(t, partitionStyleGPT, []testPartition{
+		{style: partitionStyleGPT, offset: 1048576, size: 104857600, number: 1, gpt: "{C12A7328-F81F-11D2-BA4B-00A0C93EC93B}"},
+	})
+	binary.LittleEndian.PutUint32(buf[4:], 128)
+
+	_, err = parseDriveLayout(buf)
+	require.Error(t, err)
+}
@@ -18,9 +18,11 @@
 package physical_disk
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -28,29 +30,56 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-const Name = "physical_disk"
+const (
+	Name                    = "physical_disk"
+	subCollectorMetrics     = "metrics"
+	subCollectorSMART       = "smart"
+	subCollectorReliability = "reliability"
+)
 
 type Config struct {
-	DiskInclude *regexp.Regexp `yaml:"disk-include"`
-	DiskExclude *regexp.Regexp `yaml:"disk-exclude"`
+	CollectorsEnabled []string       `yaml:"enabled"`
+	DiskInclude       *regexp.Regexp `yaml:"disk-include"`
+	DiskExclude       *regexp.Regexp `yaml:"disk-exclude"`
+	// BusTypeExclude are the bus types of disks to exclude, e.g. usb.
+	BusTypeExclude []string `yaml:"bus-type-exclude"`
 }
 
 //nolint:gochecknoglobals
 var ConfigDefaults = Config{
-	DiskInclude: types.RegExpAny,
-	DiskExclude: types.RegExpEmpty,
+	CollectorsEnabled: []string{
+		subCollectorMetrics,
+	},
+	DiskInclude:    types.RegExpAny,
+	DiskExclude:    types.RegExpEmpty,
+	BusTypeExclude: []string{},
 }
 
 // A Collector is a Prometheus Collector for perflib PhysicalDisk metrics.
 type Collector struct {
-	config Config
+	config    Config
+	logger    *slog.Logger
+	miSession *mi.Session
+
+	miQueryPhysicalDisks       mi.Query
+	miQueryReliabilityCounters mi.Query
 
 	perfDataCollector *pdh.Collector
 	perfDataObject    []perfDataCounterValues
 
+	// partitionDisks is the set of disks the partitions were read for.
+	// The partition layout is only refreshed if the set of disks changes. Failed reads are retried on every scrape.
+	partitionDisks []string
+	partitions     map[string][]partitionInfo
+
+	// deviceDisks is the set of disks the device information was read for.
+	deviceDisks []string
+	devices     map[string]deviceInfo
+
 	idleTime         *prometheus.Desc
 	readBytesTotal   *prometheus.Desc
 	readLatency      *prometheus.Desc
@@ -63,6 +92,32 @@ type Collector struct {
 	writeLatency     *prometheus.Desc
 	writeTime        *prometheus.Desc
 	writesTotal      *prometheus.Desc
+
+	info                 *prometheus.Desc
+	writeCacheEnabled    *prometheus.Desc
+	powerProtected       *prometheus.Desc
+	partitionInfo        *prometheus.Desc
+	partitionOffsetBytes *prometheus.Desc
+	partitionSizeBytes   *prometheus.Desc
+
+	smartHealthStatus         *prometheus.Desc
+	smartTemperature          *prometheus.Desc
+	smartReallocatedSectors   *prometheus.Desc
+	smartPendingSectors       *prometheus.Desc
+	smartUncorrectableSectors *prometheus.Desc
+
+	smartAttributeValue      *prometheus.Desc
+	smartAttributeWorst      *prometheus.Desc
+	smartAttributeRaw        *prometheus.Desc
+	smartNVMeCriticalWarning *prometheus.Desc
+	smartNVMePercentageUsed  *prometheus.Desc
+	smartNVMeMediaErrors     *prometheus.Desc
+
+	reliabilityTemperature  *prometheus.Desc
+	reliabilityWear         *prometheus.Desc
+	reliabilityPowerOnHours *prometheus.Desc
+	reliabilityReadErrors   *prometheus.Desc
+	reliabilityWriteErrors  *prometheus.Desc
 }
 
 func New(config *Config) *Collector {
@@ -70,6 +125,10 @@ func New(config *Config) *Collector {
 		config = &ConfigDefaults
 	}
 
+	if config.CollectorsEnabled == nil {
+		config.CollectorsEnabled = ConfigDefaults.CollectorsEnabled
+	}
+
 	if config.DiskExclude == nil {
 		config.DiskExclude = ConfigDefaults.DiskExclude
 	}
@@ -78,6 +137,10 @@ func New(config *Config) *Collector {
 		config.DiskInclude = ConfigDefaults.DiskInclude
 	}
 
+	if config.BusTypeExclude == nil {
+		config.BusTypeExclude = ConfigDefaults.BusTypeExclude
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -90,7 +153,9 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		config: ConfigDefaults,
 	}
 
-	var diskExclude, diskInclude string
+	c.config.CollectorsEnabled = make([]string, 0)
+
+	var collectorsEnabled, diskExclude, diskInclude, busTypeExclude string
 
 	app.Flag(
 		"collector.physical_disk.disk-exclude",
@@ -102,7 +167,28 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		"Regexp of disks to include. Disk number must both match include and not match exclude to be included.",
 	).Default(".+").StringVar(&diskInclude)
 
+	app.Flag(
+		"collector.physical_disk.bus-type-exclude",
+		"Comma-separated list of bus types of disks to exclude, e.g. usb. Applies in addition to the disk include and exclude regexps.",
+	).Default(strings.Join(ConfigDefaults.BusTypeExclude, ",")).StringVar(&busTypeExclude)
+
+	app.Flag(
+		"collector.physical_disk.enabled",
+		fmt.Sprintf("Comma-separated list of collectors to use. Available collectors: %s, %s, %s. Defaults to metrics, if not specified.",
+			subCollectorMetrics,
+			subCollectorSMART,
+			subCollectorReliability,
+		),
+	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
+
 	app.Action(func(*kingpin.ParseContext) error {
+		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
+
+		c.config.BusTypeExclude = make([]string, 0)
+		if busTypeExclude != "" {
+			c.config.BusTypeExclude = strings.Split(busTypeExclude, ",")
+		}
+
 		var err error
 
 		c.config.DiskExclude, err = regexp.Compile(fmt.Sprintf("^(?:%s)$", diskExclude))
@@ -125,11 +211,41 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceAPI, types.SourceWMI}
+}
+
 func (c *Collector) Close() error {
 	return nil
 }
 
-func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
+	c.logger = logger.With(slog.String("collector", Name))
+
+	for _, collector := range c.config.CollectorsEnabled {
+		if !slices.Contains([]string{subCollectorMetrics, subCollectorSMART, subCollectorReliability}, collector) {
+			return fmt.Errorf("unknown sub collector: %s. Possible values: %s", collector,
+				strings.Join([]string{subCollectorMetrics, subCollectorSMART, subCollectorReliability}, ", "),
+			)
+		}
+	}
+
+	if err := validateBusTypes(c.config.BusTypeExclude); err != nil {
+		return fmt.Errorf("invalid bus-type-exclude: %w", err)
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorReliability) {
+		if miSession == nil {
+			return errors.New("miSession is nil")
+		}
+
+		c.miSession = miSession
+
+		if err := c.buildReliability(); err != nil {
+			return err
+		}
+	}
+
 	c.requestsQueued = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "requests_queued"),
 		"The number of requests queued to the disk (PhysicalDisk.CurrentDiskQueueLength)",
@@ -214,9 +330,128 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 	)
 
+	c.info = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "info"),
+		"Identification of the disk. Value is always 1 (IOCTL_STORAGE_QUERY_PROPERTY)",
+		[]string{"disk", "model", "serial_number", "bus_type", "firmware_version"},
+		nil,
+	)
+
+	c.writeCacheEnabled = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "write_cache_enabled"),
+		"Whether the write cache of the disk is enabled (IOCTL_DISK_GET_CACHE_INFORMATION)",
+		[]string{"disk"},
+		nil,
+	)
+
+	c.powerProtected = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "power_protected"),
+		"Whether the write cache of the disk is protected against power loss, e.g. by a battery or a non-volatile cache (StorageDeviceWriteCacheProperty)",
+		[]string{"disk"},
+		nil,
+	)
+
+	c.partitionInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "partition_info"),
+		"Partition layout of the disk. Value is always 1 (IOCTL_DISK_GET_DRIVE_LAYOUT_EX)",
+		[]string{"disk", "partition", "style", "type"},
+		nil,
+	)
+
+	c.partitionOffsetBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "partition_offset_bytes"),
+		"The starting offset of the partition, in bytes. Offsets which are not a multiple of the physical sector size cause write amplification",
+		[]string{"disk", "partition"},
+		nil,
+	)
+
+	c.partitionSizeBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "partition_size_bytes"),
+		"The size of the partition, in bytes",
+		[]string{"disk", "partition"},
+		nil,
+	)
+
+	c.smartHealthStatus = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "smart_health_status"),
+		"Overall SMART health status of the disk. For NVMe disks, failed means the critical warning field is set",
+		[]string{"disk", "status"},
+		nil,
+	)
+
+	c.smartTemperature = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "smart_temperature_celsius"),
+		"Current temperature of the disk in degrees Celsius (SMART attribute 194, NVMe composite temperature)",
+		[]string{"disk"},
+		nil,
+	)
+
+	c.smartReallocatedSectors = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "smart_reallocated_sectors"),
+		"Number of reallocated sectors (SMART attribute 5)",
+		[]string{"disk"},
+		nil,
+	)
+
+	c.smartPendingSectors = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "smart_pending_sectors"),
+		"Number of sectors waiting to be remapped (SMART attribute 197)",
+		[]string{"disk"},
+		nil,
+	)
+
+	c.smartUncorrectableSectors = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "smart_uncorrectable_sectors"),
+		"Number of uncorrectable sectors (SMART attribute 198, NVMe media and data integrity errors)",
+		[]string{"disk"},
+		nil,
+	)
+
+	c.smartAttributeValue = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "smart_attribute_value"),
+		"Normalized current value of the SMART attribute of ATA and SATA disks",
+		[]string{"disk", "attribute_id", "attribute_name"},
+		nil,
+	)
+
+	c.smartAttributeWorst = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "smart_attribute_worst"),
+		"Worst normalized value of the SMART attribute of ATA and SATA disks",
+		[]string{"disk", "attribute_id", "attribute_name"},
+		nil,
+	)
+
+	c.smartAttributeRaw = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "smart_attribute_raw"),
+		"Raw value of the SMART attribute of ATA and SATA disks. The meaning is vendor specific for some attributes",
+		[]string{"disk", "attribute_id", "attribute_name"},
+		nil,
+	)
+
+	c.smartNVMeCriticalWarning = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "smart_nvme_critical_warning"),
+		"Whether the bit of the critical warning field of the NVMe health information log is set",
+		[]string{"disk", "warning"},
+		nil,
+	)
+
+	c.smartNVMePercentageUsed = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "smart_nvme_percentage_used"),
+		"Vendor specific estimate of the percentage of the NVMe disk life used. May exceed 100",
+		[]string{"disk"},
+		nil,
+	)
+
+	c.smartNVMeMediaErrors = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "smart_nvme_media_errors_total"),
+		"Number of unrecovered data integrity errors of the NVMe disk",
+		[]string{"disk"},
+		nil,
+	)
+
 	var err error
 
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "PhysicalDisk", pdh.InstancesAll)
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](c.logger, pdh.CounterTypeRaw, "PhysicalDisk", pdh.InstancesAll)
 	if err != nil {
 		return fmt.Errorf("failed to create PhysicalDisk collector: %w", err)
 	}
@@ -226,12 +461,14 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
-func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
 	err := c.perfDataCollector.Collect(&c.perfDataObject)
 	if err != nil {
 		return fmt.Errorf("failed to collect PhysicalDisk metrics: %w", err)
 	}
 
+	diskNumbers := make([]string, 0, len(c.perfDataObject))
+
 	for _, data := range c.perfDataObject {
 		if c.config.DiskExclude.MatchString(data.Name) ||
 			!c.config.DiskInclude.MatchString(data.Name) {
@@ -242,6 +479,27 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		// sometimes included, e.g. "1 C:".
 		disk_number, _, _ := strings.Cut(data.Name, " ")
 
+		diskNumbers = append(diskNumbers, disk_number)
+	}
+
+	// The device information is required for the info metric and to resolve the bus type of the disks.
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) || len(c.config.BusTypeExclude) > 0 {
+		c.refreshDeviceInfo(diskNumbers)
+	}
+
+	diskNumbers = slices.DeleteFunc(diskNumbers, c.isBusTypeExcluded)
+
+	for _, data := range c.perfDataObject {
+		disk_number, _, _ := strings.Cut(data.Name, " ")
+
+		if !slices.Contains(diskNumbers, disk_number) {
+			continue
+		}
+
+		if !slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+			continue
+		}
+
 		ch <- prometheus.MustNewConstMetric(
 			c.requestsQueued,
 			prometheus.GaugeValue,
@@ -327,5 +585,285 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 		)
 	}
 
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+		c.collectDeviceInfo(ch, diskNumbers)
+		c.collectWriteCache(ch, diskNumbers)
+		c.collectPartitions(ch, diskNumbers)
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorSMART) {
+		c.collectSMART(ch, diskNumbers)
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorReliability) {
+		if err := c.collectReliability(ch, diskNumbers, maxScrapeDuration); err != nil {
+			return fmt.Errorf("failed to collect storage reliability counters: %w", err)
+		}
+	}
+
 	return nil
 }
+
+// collectSMART exposes the SMART health information of the given disks.
+// Disks which do not support SMART queries, e.g. USB-attached disks, are skipped.
+func (c *Collector) collectSMART(ch chan<- prometheus.Metric, diskNumbers []string) {
+	for _, diskNumber := range diskNumbers {
+		info, err := getSMART(diskNumber)
+		if err != nil {
+			if errors.Is(err, errSMARTUnsupported) {
+				c.logger.Debug("skipping SMART data",
+					slog.String("disk", diskNumber),
+					slog.Any("err", err),
+				)
+			} else {
+				c.logger.Warn("failed to read SMART data",
+					slog.String("disk", diskNumber),
+					slog.Any("err", err),
+				)
+			}
+
+			continue
+		}
+
+		for _, status := range []string{"passed", "failed"} {
+			val := 0.0
+			if (status == "failed") == info.failed {
+				val = 1.0
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.smartHealthStatus,
+				prometheus.GaugeValue,
+				val,
+				diskNumber,
+				status,
+			)
+		}
+
+		for desc, value := range map[*prometheus.Desc]*float64{
+			c.smartTemperature:          info.temperature,
+			c.smartReallocatedSectors:   info.reallocatedSectors,
+			c.smartPendingSectors:       info.pendingSectors,
+			c.smartUncorrectableSectors: info.uncorrectableSectors,
+		} {
+			if value == nil {
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				desc,
+				prometheus.GaugeValue,
+				*value,
+				diskNumber,
+			)
+		}
+
+		for _, attribute := range info.attributes {
+			ch <- prometheus.MustNewConstMetric(
+				c.smartAttributeValue,
+				prometheus.GaugeValue,
+				float64(attribute.value),
+				diskNumber, attribute.idString(), attribute.name(),
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				c.smartAttributeWorst,
+				prometheus.GaugeValue,
+				float64(attribute.worst),
+				diskNumber, attribute.idString(), attribute.name(),
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				c.smartAttributeRaw,
+				prometheus.GaugeValue,
+				float64(attribute.raw),
+				diskNumber, attribute.idString(), attribute.name(),
+			)
+		}
+
+		if info.nvme != nil {
+			c.collectNVMeHealth(ch, diskNumber, info.nvme)
+		}
+	}
+}
+
+// collectNVMeHealth exposes the NVMe specific fields of the health information log.
+func (c *Collector) collectNVMeHealth(ch chan<- prometheus.Metric, diskNumber string, health *nvmeHealth) {
+	for _, warning := range nvmeCriticalWarnings {
+		val := 0.0
+		if health.criticalWarning&warning.mask != 0 {
+			val = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.smartNVMeCriticalWarning,
+			prometheus.GaugeValue,
+			val,
+			diskNumber,
+			warning.name,
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.smartNVMePercentageUsed,
+		prometheus.GaugeValue,
+		float64(health.percentageUsed),
+		diskNumber,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.smartNVMeMediaErrors,
+		prometheus.CounterValue,
+		health.mediaErrors,
+		diskNumber,
+	)
+}
+
+// refreshDeviceInfo reads the device information of the given disks.
+// The device information is read again only if the set of disks has changed since the last scrape.
+func (c *Collector) refreshDeviceInfo(diskNumbers []string) {
+	slices.Sort(diskNumbers)
+
+	if !slices.Equal(diskNumbers, c.deviceDisks) {
+		c.devices = make(map[string]deviceInfo, len(diskNumbers))
+
+		for _, diskNumber := range diskNumbers {
+			device, err := getDeviceInfo(diskNumber)
+			if err != nil {
+				c.logger.Debug("failed to read device information",
+					slog.String("disk", diskNumber),
+					slog.Any("err", err),
+				)
+
+				continue
+			}
+
+			c.devices[diskNumber] = device
+		}
+
+		c.deviceDisks = slices.Clone(diskNumbers)
+	}
+}
+
+// isBusTypeExcluded reports whether the bus type of the disk is excluded.
+// Disks without device information are never excluded.
+func (c *Collector) isBusTypeExcluded(diskNumber string) bool {
+	device, ok := c.devices[diskNumber]
+
+	return ok && slices.Contains(c.config.BusTypeExclude, device.busType)
+}
+
+// collectDeviceInfo exposes the model, serial number, bus type and firmware version of the given disks.
+func (c *Collector) collectDeviceInfo(ch chan<- prometheus.Metric, diskNumbers []string) {
+	for _, diskNumber := range diskNumbers {
+		device, ok := c.devices[diskNumber]
+		if !ok {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.info,
+			prometheus.GaugeValue,
+			1,
+			diskNumber,
+			device.model,
+			device.serialNumber,
+			device.busType,
+			device.firmwareVersion,
+		)
+	}
+}
+
+// collectWriteCache exposes the write cache state of the given disks.
+// Metrics the disk does not report are omitted.
+func (c *Collector) collectWriteCache(ch chan<- prometheus.Metric, diskNumbers []string) {
+	for _, diskNumber := range diskNumbers {
+		info, err := getWriteCache(diskNumber)
+		if err != nil {
+			c.logger.Debug("failed to read write cache state",
+				slog.String("disk", diskNumber),
+				slog.Any("err", err),
+			)
+
+			continue
+		}
+
+		if info.enabled != nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.writeCacheEnabled,
+				prometheus.GaugeValue,
+				utils.BoolToFloat(*info.enabled),
+				diskNumber,
+			)
+		}
+
+		if info.powerProtected != nil {
+			ch <- prometheus.MustNewConstMetric(
+				c.powerProtected,
+				prometheus.GaugeValue,
+				utils.BoolToFloat(*info.powerProtected),
+				diskNumber,
+			)
+		}
+	}
+}
+
+// collectPartitions exposes the partition layout of the given disks.
+// The layout is read again only if the set of disks has changed since the last scrape.
+// The layout of disks, which could not be read, is not cached and read again on the next scrape.
+func (c *Collector) collectPartitions(ch chan<- prometheus.Metric, diskNumbers []string) {
+	slices.Sort(diskNumbers)
+
+	if !slices.Equal(diskNumbers, c.partitionDisks) {
+		c.partitions = make(map[string][]partitionInfo, len(diskNumbers))
+		c.partitionDisks = diskNumbers
+	}
+
+	for _, diskNumber := range diskNumbers {
+		if _, ok := c.partitions[diskNumber]; ok {
+			continue
+		}
+
+		partitions, err := getPartitions(diskNumber)
+		if err != nil {
+			c.logger.Debug("failed to read partition layout",
+				slog.String("disk", diskNumber),
+				slog.Any("err", err),
+			)
+
+			continue
+		}
+
+		c.partitions[diskNumber] = partitions
+	}
+
+	for diskNumber, partitions := range c.partitions {
+		for _, partition := range partitions {
+			ch <- prometheus.MustNewConstMetric(
+				c.partitionInfo,
+				prometheus.GaugeValue,
+				1,
+				diskNumber,
+				partition.number,
+				partition.style,
+				partition.partitionType,
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				c.partitionOffsetBytes,
+				prometheus.GaugeValue,
+				partition.offset,
+				diskNumber,
+				partition.number,
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				c.partitionSizeBytes,
+				prometheus.GaugeValue,
+				partition.size,
+				diskNumber,
+				partition.number,
+			)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package physical_disk
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// msftPhysicalDisk is the subset of MSFT_PhysicalDisk used to label the reliability counters.
+// DeviceId is the disk number.
+//
+// https://learn.microsoft.com/en-us/windows-hardware/drivers/storage/msft-physicaldisk
+type msftPhysicalDisk struct {
+	DeviceID     string `mi:"DeviceId"`
+	SerialNumber string `mi:"SerialNumber"`
+}
+
+// msftStorageReliabilityCounter is MSFT_StorageReliabilityCounter.
+// Counters which are not reported by the disk are null.
+//
+// https://learn.microsoft.com/en-us/windows-hardware/drivers/storage/msft-storagereliabilitycounter
+type msftStorageReliabilityCounter struct {
+	DeviceID         string  `mi:"DeviceId"`
+	Temperature      *uint8  `mi:"Temperature"`
+	Wear             *uint8  `mi:"Wear"`
+	PowerOnHours     *uint32 `mi:"PowerOnHours"`
+	ReadErrorsTotal  *uint64 `mi:"ReadErrorsTotal"`
+	WriteErrorsTotal *uint64 `mi:"WriteErrorsTotal"`
+}
+
+func (c *Collector) buildReliability() error {
+	miQueryPhysicalDisks, err := mi.NewQuery("SELECT DeviceId, SerialNumber FROM MSFT_PhysicalDisk")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	miQueryReliabilityCounters, err := mi.NewQuery("SELECT DeviceId, Temperature, Wear, PowerOnHours, ReadErrorsTotal, WriteErrorsTotal FROM MSFT_StorageReliabilityCounter")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQueryPhysicalDisks = miQueryPhysicalDisks
+	c.miQueryReliabilityCounters = miQueryReliabilityCounters
+
+	c.reliabilityTemperature = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "temperature_celsius"),
+		"Current temperature of the disk in degrees Celsius (MSFT_StorageReliabilityCounter.Temperature)",
+		[]string{"disk", "serial_number"},
+		nil,
+	)
+
+	c.reliabilityWear = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "wear_ratio"),
+		"Ratio of the rated lifetime of the disk that is used up (MSFT_StorageReliabilityCounter.Wear)",
+		[]string{"disk", "serial_number"},
+		nil,
+	)
+
+	c.reliabilityPowerOnHours = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "power_on_hours_total"),
+		"Number of hours the disk was powered on (MSFT_StorageReliabilityCounter.PowerOnHours)",
+		[]string{"disk", "serial_number"},
+		nil,
+	)
+
+	c.reliabilityReadErrors = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "read_errors_total"),
+		"Number of read errors of the disk (MSFT_StorageReliabilityCounter.ReadErrorsTotal)",
+		[]string{"disk", "serial_number"},
+		nil,
+	)
+
+	c.reliabilityWriteErrors = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "write_errors_total"),
+		"Number of write errors of the disk (MSFT_StorageReliabilityCounter.WriteErrorsTotal)",
+		[]string{"disk", "serial_number"},
+		nil,
+	)
+
+	return nil
+}
+
+// collectReliability exposes the storage reliability counters of the given disks.
+// Counters which are not reported by a disk are omitted.
+func (c *Collector) collectReliability(ch chan<- prometheus.Metric, diskNumbers []string, maxScrapeDuration time.Duration) error {
+	var physicalDisks []msftPhysicalDisk
+	if err := c.miSession.Query(&physicalDisks, mi.NamespaceRootStorage, c.miQueryPhysicalDisks, maxScrapeDuration); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var reliabilityCounters []msftStorageReliabilityCounter
+	if err := c.miSession.Query(&reliabilityCounters, mi.NamespaceRootStorage, c.miQueryReliabilityCounters, maxScrapeDuration); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	serialNumbers := make(map[string]string, len(physicalDisks))
+	for _, disk := range physicalDisks {
+		serialNumbers[disk.DeviceID] = strings.TrimSpace(disk.SerialNumber)
+	}
+
+	for _, counters := range reliabilityCounters {
+		if !slices.Contains(diskNumbers, counters.DeviceID) {
+			continue
+		}
+
+		serialNumber := serialNumbers[counters.DeviceID]
+
+		// Wear is reported in percent.
+		wear := toFloat(counters.Wear)
+		if wear != nil {
+			*wear /= 100
+		}
+
+		for desc, value := range map[*prometheus.Desc]*float64{
+			c.reliabilityTemperature: toFloat(counters.Temperature),
+			c.reliabilityWear:        wear,
+		} {
+			if value == nil {
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				desc,
+				prometheus.GaugeValue,
+				*value,
+				counters.DeviceID,
+				serialNumber,
+			)
+		}
+
+		for desc, value := range map[*prometheus.Desc]*float64{
+			c.reliabilityPowerOnHours: toFloat(counters.PowerOnHours),
+			c.reliabilityReadErrors:   toFloat(counters.ReadErrorsTotal),
+			c.reliabilityWriteErrors:  toFloat(counters.WriteErrorsTotal),
+		} {
+			if value == nil {
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				desc,
+				prometheus.CounterValue,
+				*value,
+				counters.DeviceID,
+				serialNumber,
+			)
+		}
+	}
+
+	return nil
+}
+
+// toFloat converts an optional WMI value to an optional float64.
+func toFloat[T uint8 | uint32 | uint64](value *T) *float64 {
+	if value == nil {
+		return nil
+	}
+
+	return new(float64(*value))
+}
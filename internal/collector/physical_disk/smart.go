@@ -0,0 +1,436 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package physical_disk
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// ioctlStorageQueryProperty is IOCTL_STORAGE_QUERY_PROPERTY.
+	// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ni-winioctl-ioctl_storage_query_property
+	ioctlStorageQueryProperty = 0x002D1400
+	// ioctlATAPassThrough is IOCTL_ATA_PASS_THROUGH.
+	// https://learn.microsoft.com/en-us/windows-hardware/drivers/ddi/ntddscsi/ni-ntddscsi-ioctl_ata_pass_through
+	ioctlATAPassThrough = 0x0004D02C
+
+	storageDeviceProperty                  = 0
+	storageAdapterProtocolSpecificProperty = 49
+	propertyStandardQuery                  = 0
+
+	// storageDeviceDescriptorSize is the size of STORAGE_DEVICE_DESCRIPTOR without the raw device properties.
+	storageDeviceDescriptorSize = 40
+
+	busTypeATA  = 0x03
+	busTypeUSB  = 0x07
+	busTypeSATA = 0x0B
+	busTypeNVMe = 0x11
+
+	protocolTypeNVMe         = 3
+	nvmeDataTypeLogPage      = 2
+	nvmeLogPageHealthInfo    = 2
+	nvmeHealthInfoLogSize    = 512
+	storagePropertyQueryHead = 8
+	// storageProtocolSpecificDataSize is the size of STORAGE_PROTOCOL_SPECIFIC_DATA.
+	storageProtocolSpecificDataSize = 40
+
+	ataFlagsDRDYRequired = 0x01
+	ataFlagsDataIn       = 0x02
+
+	ataCommandSMART         = 0xB0
+	ataSMARTReadData        = 0xD0
+	ataSMARTReturnStatus    = 0xDA
+	ataSMARTCylLow          = 0x4F
+	ataSMARTCylHigh         = 0xC2
+	ataSMARTCylLowExceeded  = 0xF4
+	ataSMARTCylHighExceeded = 0x2C
+	ataSMARTDataSize        = 512
+	ataSMARTAttributeCount  = 30
+	ataSMARTAttributeSize   = 12
+
+	ataAttributeReallocatedSectors   = 5
+	ataAttributeAirflowTemperature   = 190
+	ataAttributeTemperature          = 194
+	ataAttributePendingSectors       = 197
+	ataAttributeUncorrectableSectors = 198
+)
+
+// ataAttributeNames maps well-known SMART attribute IDs to a readable name.
+// The meaning of the raw value of some attributes is vendor specific.
+//
+//nolint:gochecknoglobals
+var ataAttributeNames = map[uint8]string{
+	1:   "raw_read_error_rate",
+	3:   "spin_up_time",
+	4:   "start_stop_count",
+	5:   "reallocated_sector_count",
+	7:   "seek_error_rate",
+	9:   "power_on_hours",
+	10:  "spin_retry_count",
+	12:  "power_cycle_count",
+	177: "wear_leveling_count",
+	181: "program_fail_count",
+	182: "erase_fail_count",
+	183: "runtime_bad_block",
+	184: "end_to_end_error",
+	187: "reported_uncorrectable_errors",
+	188: "command_timeout",
+	190: "airflow_temperature",
+	191: "g_sense_error_rate",
+	192: "power_off_retract_count",
+	193: "load_cycle_count",
+	194: "temperature",
+	195: "hardware_ecc_recovered",
+	196: "reallocation_event_count",
+	197: "current_pending_sector_count",
+	198: "offline_uncorrectable",
+	199: "udma_crc_error_count",
+	231: "ssd_life_left",
+	233: "media_wearout_indicator",
+	241: "total_lbas_written",
+	242: "total_lbas_read",
+}
+
+// nvmeCriticalWarnings are the bits of the critical warning field of the NVMe health information log.
+//
+//nolint:gochecknoglobals
+var nvmeCriticalWarnings = []struct {
+	name string
+	mask uint8
+}{
+	{"available_spare", 0x01},
+	{"temperature", 0x02},
+	{"reliability_degraded", 0x04},
+	{"read_only", 0x08},
+	{"volatile_memory_backup_failed", 0x10},
+	{"persistent_memory_region_read_only", 0x20},
+}
+
+// errSMARTUnsupported is returned if the disk does not support SMART queries from user mode,
+// e.g. USB-attached disks or missing privileges.
+var errSMARTUnsupported = errors.New("SMART not supported")
+
+// smartInfo contains the health information of a disk.
+// Attributes which are not reported by the disk are nil.
+type smartInfo struct {
+	failed               bool
+	temperature          *float64
+	reallocatedSectors   *float64
+	pendingSectors       *float64
+	uncorrectableSectors *float64
+
+	// attributes is the SMART attribute table of ATA disks.
+	attributes []smartAttribute
+	// nvme is the health information log of NVMe disks.
+	nvme *nvmeHealth
+}
+
+// smartAttribute is an entry of the SMART attribute table.
+type smartAttribute struct {
+	id    uint8
+	value uint8
+	worst uint8
+	raw   uint64
+}
+
+// name returns the readable name of the attribute, or an empty string, if the attribute is not known.
+func (a smartAttribute) name() string {
+	return ataAttributeNames[a.id]
+}
+
+// idString returns the attribute ID as decimal string.
+func (a smartAttribute) idString() string {
+	return strconv.FormatUint(uint64(a.id), 10)
+}
+
+// nvmeHealth contains the fields of the NVMe SMART / health information log that are not
+// covered by the common smartInfo fields.
+type nvmeHealth struct {
+	criticalWarning uint8
+	percentageUsed  uint8
+	mediaErrors     float64
+}
+
+// ataPassThroughEx is ATA_PASS_THROUGH_EX.
+// https://learn.microsoft.com/en-us/windows-hardware/drivers/ddi/ntddscsi/ns-ntddscsi-_ata_pass_through_ex
+type ataPassThroughEx struct {
+	Length             uint16
+	AtaFlags           uint16
+	PathId             uint8
+	TargetId           uint8
+	Lun                uint8
+	ReservedAsUchar    uint8
+	DataTransferLength uint32
+	TimeOutValue       uint32
+	ReservedAsUlong    uint32
+	DataBufferOffset   uintptr
+	PreviousTaskFile   [8]uint8
+	CurrentTaskFile    [8]uint8
+}
+
+type ataPassThroughWithBuffer struct {
+	ataPassThroughEx
+
+	data [ataSMARTDataSize]byte
+}
+
+// getSMART returns the SMART health information of the given physical disk number.
+// ATA and SATA disks are queried via ATA pass-through, NVMe disks via the health information log page.
+func getSMART(diskNumber string) (smartInfo, error) {
+	diskPath, err := windows.UTF16PtrFromString(`\\.\PhysicalDrive` + diskNumber)
+	if err != nil {
+		return smartInfo{}, err
+	}
+
+	// ATA pass-through requires read and write access, which in turn requires administrator privileges.
+	access := uint32(windows.GENERIC_READ | windows.GENERIC_WRITE)
+	mode := uint32(windows.FILE_SHARE_READ | windows.FILE_SHARE_WRITE)
+
+	handle, err := windows.CreateFile(diskPath, access, mode, nil, windows.OPEN_EXISTING, 0, 0)
+	if err != nil {
+		if errors.Is(err, windows.ERROR_ACCESS_DENIED) {
+			return smartInfo{}, fmt.Errorf("%w: could not open physical drive %s: %w", errSMARTUnsupported, diskNumber, err)
+		}
+
+		return smartInfo{}, fmt.Errorf("could not open physical drive %s: %w", diskNumber, err)
+	}
+
+	defer func(fd windows.Handle) {
+		_ = windows.Close(fd)
+	}(handle)
+
+	busType, err := getBusType(handle)
+	if err != nil {
+		return smartInfo{}, fmt.Errorf("could not get bus type of physical drive %s: %w", diskNumber, err)
+	}
+
+	var info smartInfo
+
+	switch busType {
+	case busTypeATA, busTypeSATA:
+		info, err = getATASMART(handle)
+	case busTypeNVMe:
+		info, err = getNVMeHealth(handle)
+	default:
+		return smartInfo{}, fmt.Errorf("%w: unsupported bus type %d", errSMARTUnsupported, busType)
+	}
+
+	if err != nil {
+		if errors.Is(err, windows.ERROR_ACCESS_DENIED) ||
+			errors.Is(err, windows.ERROR_INVALID_FUNCTION) ||
+			errors.Is(err, windows.ERROR_INVALID_PARAMETER) ||
+			errors.Is(err, windows.ERROR_NOT_SUPPORTED) {
+			return smartInfo{}, fmt.Errorf("%w: physical drive %s: %w", errSMARTUnsupported, diskNumber, err)
+		}
+
+		return smartInfo{}, fmt.Errorf("could not get SMART data of physical drive %s: %w", diskNumber, err)
+	}
+
+	return info, nil
+}
+
+// getBusType returns the bus type from the STORAGE_DEVICE_DESCRIPTOR of the disk.
+func getBusType(handle windows.Handle) (uint32, error) {
+	query := make([]byte, 12)
+	binary.LittleEndian.PutUint32(query[0:], storageDeviceProperty)
+	binary.LittleEndian.PutUint32(query[4:], propertyStandardQuery)
+
+	buf := make([]byte, 1024)
+
+	var bytesReturned uint32
+
+	err := windows.DeviceIoControl(handle, ioctlStorageQueryProperty, &query[0], uint32(len(query)), &buf[0], uint32(len(buf)), &bytesReturned, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseBusType(buf[:bytesReturned])
+}
+
+// parseBusType returns the BusType field of a STORAGE_DEVICE_DESCRIPTOR structure.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ns-winioctl-storage_device_descriptor
+func parseBusType(buf []byte) (uint32, error) {
+	if len(buf) < storageDeviceDescriptorSize {
+		return 0, fmt.Errorf("storage device descriptor too short: %d bytes", len(buf))
+	}
+
+	return binary.LittleEndian.Uint32(buf[28:]), nil
+}
+
+// getATASMART reads the SMART status and attributes of an ATA disk.
+func getATASMART(handle windows.Handle) (smartInfo, error) {
+	status, err := ataSMARTCommand(handle, ataSMARTReturnStatus, 0)
+	if err != nil {
+		return smartInfo{}, fmt.Errorf("SMART RETURN STATUS: %w", err)
+	}
+
+	data, err := ataSMARTCommand(handle, ataSMARTReadData, ataSMARTDataSize)
+	if err != nil {
+		return smartInfo{}, fmt.Errorf("SMART READ DATA: %w", err)
+	}
+
+	info := parseATASMARTData(data.data[:])
+	info.failed = status.CurrentTaskFile[3] == ataSMARTCylLowExceeded && status.CurrentTaskFile[4] == ataSMARTCylHighExceeded
+
+	return info, nil
+}
+
+// ataSMARTCommand issues a SMART command with the given feature via IOCTL_ATA_PASS_THROUGH.
+// The returned task file contains the registers after the command completed.
+func ataSMARTCommand(handle windows.Handle, feature uint8, dataLength uint32) (*ataPassThroughWithBuffer, error) {
+	req := &ataPassThroughWithBuffer{}
+	req.Length = uint16(unsafe.Sizeof(req.ataPassThroughEx))
+	req.AtaFlags = ataFlagsDRDYRequired
+	req.DataTransferLength = dataLength
+	req.TimeOutValue = 5
+	req.DataBufferOffset = unsafe.Offsetof(req.data)
+	req.CurrentTaskFile = [8]uint8{feature, 1, 1, ataSMARTCylLow, ataSMARTCylHigh, 0xA0, ataCommandSMART, 0}
+
+	if dataLength > 0 {
+		req.AtaFlags |= ataFlagsDataIn
+	}
+
+	size := uint32(unsafe.Sizeof(*req))
+
+	var bytesReturned uint32
+
+	err := windows.DeviceIoControl(handle, ioctlATAPassThrough, (*byte)(unsafe.Pointer(req)), size, (*byte)(unsafe.Pointer(req)), size, &bytesReturned, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// parseATASMARTData parses the attribute table of the SMART READ DATA response.
+// Each entry is 12 bytes long: ID, flags (2 bytes), current, worst, raw value (6 bytes) and a reserved byte.
+func parseATASMARTData(data []byte) smartInfo {
+	var info smartInfo
+
+	for i := range ataSMARTAttributeCount {
+		offset := 2 + i*ataSMARTAttributeSize
+		if offset+ataSMARTAttributeSize > len(data) {
+			break
+		}
+
+		attribute := data[offset : offset+ataSMARTAttributeSize]
+
+		id := attribute[0]
+		if id == 0 {
+			continue
+		}
+
+		raw := make([]byte, 8)
+		copy(raw, attribute[5:11])
+		rawValue := float64(binary.LittleEndian.Uint64(raw))
+
+		info.attributes = append(info.attributes, smartAttribute{
+			id:    id,
+			value: attribute[3],
+			worst: attribute[4],
+			raw:   binary.LittleEndian.Uint64(raw),
+		})
+
+		switch id {
+		case ataAttributeReallocatedSectors:
+			info.reallocatedSectors = new(rawValue)
+		case ataAttributePendingSectors:
+			info.pendingSectors = new(rawValue)
+		case ataAttributeUncorrectableSectors:
+			info.uncorrectableSectors = new(rawValue)
+		case ataAttributeTemperature:
+			// The lowest byte is the current temperature. The other bytes are vendor specific, e.g. min and max.
+			info.temperature = new(float64(attribute[5]))
+		case ataAttributeAirflowTemperature:
+			if info.temperature == nil {
+				info.temperature = new(float64(attribute[5]))
+			}
+		}
+	}
+
+	return info
+}
+
+// getNVMeHealth reads the SMART / health information log page of a NVMe disk.
+func getNVMeHealth(handle windows.Handle) (smartInfo, error) {
+	buf := make([]byte, storagePropertyQueryHead+storageProtocolSpecificDataSize+nvmeHealthInfoLogSize)
+
+	// STORAGE_PROPERTY_QUERY followed by STORAGE_PROTOCOL_SPECIFIC_DATA in place of AdditionalParameters.
+	binary.LittleEndian.PutUint32(buf[0:], storageAdapterProtocolSpecificProperty)
+	binary.LittleEndian.PutUint32(buf[4:], propertyStandardQuery)
+
+	protocolData := buf[storagePropertyQueryHead:]
+	binary.LittleEndian.PutUint32(protocolData[0:], protocolTypeNVMe)
+	binary.LittleEndian.PutUint32(protocolData[4:], nvmeDataTypeLogPage)
+	binary.LittleEndian.PutUint32(protocolData[8:], nvmeLogPageHealthInfo)
+	binary.LittleEndian.PutUint32(protocolData[16:], storageProtocolSpecificDataSize)
+	binary.LittleEndian.PutUint32(protocolData[20:], nvmeHealthInfoLogSize)
+
+	var bytesReturned uint32
+
+	err := windows.DeviceIoControl(handle, ioctlStorageQueryProperty, &buf[0], uint32(len(buf)), &buf[0], uint32(len(buf)), &bytesReturned, nil)
+	if err != nil {
+		return smartInfo{}, err
+	}
+
+	return parseNVMeHealthDescriptor(buf[:bytesReturned])
+}
+
+// parseNVMeHealthDescriptor parses a STORAGE_PROTOCOL_DATA_DESCRIPTOR containing the
+// SMART / health information log page (log identifier 02h).
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winioctl/ns-winioctl-storage_protocol_data_descriptor
+func parseNVMeHealthDescriptor(buf []byte) (smartInfo, error) {
+	if len(buf) < storagePropertyQueryHead+storageProtocolSpecificDataSize {
+		return smartInfo{}, fmt.Errorf("protocol data descriptor too short: %d bytes", len(buf))
+	}
+
+	protocolData := buf[storagePropertyQueryHead:]
+	dataOffset := storagePropertyQueryHead + int(binary.LittleEndian.Uint32(protocolData[16:]))
+	dataLength := int(binary.LittleEndian.Uint32(protocolData[20:]))
+
+	if dataLength < nvmeHealthInfoLogSize || dataOffset+nvmeHealthInfoLogSize > len(buf) {
+		return smartInfo{}, fmt.Errorf("invalid health information log: offset %d, length %d, %d bytes returned", dataOffset, dataLength, len(buf))
+	}
+
+	log := buf[dataOffset : dataOffset+nvmeHealthInfoLogSize]
+
+	// Composite temperature is reported in Kelvin.
+	temperature := float64(binary.LittleEndian.Uint16(log[1:])) - 273
+	// Media and data integrity errors is a 128-bit counter, the upper half is ignored.
+	mediaErrors := float64(binary.LittleEndian.Uint64(log[160:]))
+
+	return smartInfo{
+		// Any bit set in the critical warning field indicates a degraded device.
+		failed:               log[0] != 0,
+		temperature:          new(temperature),
+		uncorrectableSectors: new(mediaErrors),
+		nvme: &nvmeHealth{
+			criticalWarning: log[0],
+			percentageUsed:  log[5],
+			mediaErrors:     mediaErrors,
+		},
+	}, nil
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package physical_disk
+
+import (
+	"encoding/binary"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestATAPassThroughExSize(t *testing.T) {
+	t.Parallel()
+
+	// sizeof(ATA_PASS_THROUGH_EX) is 48 bytes on 64-bit and 40 bytes on 32-bit Windows.
+	require.Equal(t, uintptr(32+2*unsafe.Sizeof(uintptr(0))), unsafe.Sizeof(ataPassThroughEx{}))
+}
+
+func TestParseATASMARTData(t *testing.T) {
+	t.Parallel()
+
+	data := make([]byte, ataSMARTDataSize)
+
+	setAttribute := func(slot int, id uint8, raw ...byte) {
+		attribute := data[2+slot*ataSMARTAttributeSize:]
+		attribute[0] = id
+		attribute[3] = 100
+		attribute[4] = 90
+		copy(attribute[5:11], raw)
+	}
+
+	setAttribute(0, ataAttributeReallocatedSectors, 8)
+	setAttribute(1, 9, 0xFF, 0xFF)                      // power-on hours, ignored
+	setAttribute(2, ataAttributeAirflowTemperature, 40) // ignored, attribute 194 takes precedence
+	setAttribute(3, ataAttributeTemperature, 35, 0, 20, 0, 50, 0)
+	setAttribute(4, ataAttributePendingSectors, 0x01, 0x01)
+
+	info := parseATASMARTData(data)
+
+	require.False(t, info.failed)
+	require.Equal(t, new(8.0), info.reallocatedSectors)
+	require.Equal(t, new(35.0), info.temperature)
+	require.Equal(t, new(257.0), info.pendingSectors)
+	require.Nil(t, info.uncorrectableSectors)
+	require.Nil(t, info.nvme)
+
+	require.Len(t, info.attributes, 5)
+	require.Equal(t, smartAttribute{id: ataAttributePendingSectors, value: 100, worst: 90, raw: 257}, info.attributes[4])
+	require.Equal(t, "current_pending_sector_count", info.attributes[4].name())
+	require.Equal(t, "197", info.attributes[4].idString())
+}
+
+func TestParseNVMeHealthDescriptor(t *testing.T) {
+	t.Parallel()
+
+	buf := make([]byte, storagePropertyQueryHead+storageProtocolSpecificDataSize+nvmeHealthInfoLogSize)
+	protocolData := buf[storagePropertyQueryHead:]
+	binary.LittleEndian.PutUint32(protocolData[16:], storageProtocolSpecificDataSize)
+	binary.LittleEndian.PutUint32(protocolData[20:], nvmeHealthInfoLogSize)
+
+	log := buf[storagePropertyQueryHead+storageProtocolSpecificDataSize:]
+	log[0] = 0x04 // NVM subsystem reliability degraded
+	binary.LittleEndian.PutUint16(log[1:], 318)
+	log[5] = 12 // percentage used
+	binary.LittleEndian.PutUint64(log[160:], 3)
+
+	info, err := parseNVMeHealthDescriptor(buf)
+	require.NoError(t, err)
+	require.Equal(t, smartInfo{
+		failed:               true,
+		temperature:          new(45.0),
+		uncorrectableSectors: new(3.0),
+		nvme: &nvmeHealth{
+			criticalWarning: 0x04,
+			percentageUsed:  12,
+			mediaErrors:     3,
+		},
+	}, info)
+
+	_, err = parseNVMeHealthDescriptor(buf[:len(buf)-1])
+	require.Error(t, err)
+}
+
+func TestParseBusType(t *testing.T) {
+	t.Parallel()
+
+	buf := make([]byte, storageDeviceDescriptorSize)
+	binary.LittleEndian.PutUint32(buf[28:], busTypeNVMe)
+
+	busType, err := parseBusType(buf)
+	require.NoError(t, err)
+	require.Equal(t, uint32(busTypeNVMe), busType)
+
+	_, err = parseBusType(buf[:27])
+	require.Error(t, err)
+}
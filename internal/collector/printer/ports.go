@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package printer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// tcpipPrinterPortProtocols maps Win32_TCPIPPrinterPort.Protocol to a label value.
+//
+//nolint:gochecknoglobals
+var tcpipPrinterPortProtocols = map[uint32]string{
+	1: "raw",
+	2: "lpr",
+}
+
+type wmiPrinterPort struct {
+	Name        string `mi:"Name"`
+	HostAddress string `mi:"HostAddress"`
+	PortNumber  uint32 `mi:"PortNumber"`
+	Protocol    uint32 `mi:"Protocol"`
+}
+
+type wmiPrinterPortName struct {
+	Name     string `mi:"Name"`
+	PortName string `mi:"PortName"`
+}
+
+// printerPort is a printer port, which is either a Standard TCP/IP port or referenced by a printer.
+type printerPort struct {
+	host     string
+	port     uint32
+	protocol string
+	printers int
+}
+
+func (c *Collector) collectPorts(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	var tcpipPorts []wmiPrinterPort
+	if err := c.miSession.Query(&tcpipPorts, mi.NamespaceRootCIMv2, c.miQueryPrinterPorts, maxScrapeDuration); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var printers []wmiPrinterPortName
+	if err := c.miSession.Query(&printers, mi.NamespaceRootCIMv2, c.miQueryPrinterPortNames, maxScrapeDuration); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	ports := c.groupPorts(tcpipPorts, printers)
+
+	for name, port := range ports {
+		ch <- prometheus.MustNewConstMetric(
+			c.portInfo,
+			prometheus.GaugeValue,
+			1,
+			name,
+			port.host,
+			port.protocol,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.portPrinters,
+			prometheus.GaugeValue,
+			float64(port.printers),
+			name,
+		)
+	}
+
+	for name, reachable := range c.probePorts(ports) {
+		value := 0.0
+		if reachable {
+			value = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.portReachable,
+			prometheus.GaugeValue,
+			value,
+			name,
+		)
+	}
+
+	return nil
+}
+
+// groupPorts merges the Standard TCP/IP ports with the ports referenced by printers and counts
+// the printers per port. Printers with printer pooling reference multiple comma-separated ports.
+func (c *Collector) groupPorts(tcpipPorts []wmiPrinterPort, printers []wmiPrinterPortName) map[string]*printerPort {
+	ports := make(map[string]*printerPort, len(tcpipPorts))
+
+	for _, tcpipPort := range tcpipPorts {
+		ports[tcpipPort.Name] = &printerPort{
+			host:     tcpipPort.HostAddress,
+			port:     tcpipPort.PortNumber,
+			protocol: tcpipPrinterPortProtocols[tcpipPort.Protocol],
+		}
+	}
+
+	for _, printer := range printers {
+		if c.config.PrinterExclude.MatchString(printer.Name) ||
+			!c.config.PrinterInclude.MatchString(printer.Name) {
+			continue
+		}
+
+		for portName := range strings.SplitSeq(printer.PortName, ",") {
+			portName = strings.TrimSpace(portName)
+			if portName == "" {
+				continue
+			}
+
+			port, ok := ports[portName]
+			if !ok {
+				port = &printerPort{protocol: portProtocol(portName)}
+				ports[portName] = port
+			}
+
+			port.printers++
+		}
+	}
+
+	return ports
+}
+
+// portProtocol guesses the protocol of ports, which are not Standard TCP/IP ports, from the name.
+func portProtocol(portName string) string {
+	switch {
+	case strings.HasPrefix(portName, "WSD-"):
+		return "wsd"
+	case strings.HasPrefix(portName, "USB"):
+		return "usb"
+	case strings.HasPrefix(portName, "LPT"), strings.HasPrefix(portName, "COM"):
+		return "local"
+	case strings.HasPrefix(portName, `\\`):
+		return "smb"
+	default:
+		return "other"
+	}
+}
+
+// probePorts opens a TCP connection to the Standard TCP/IP ports matching the probe include regexp.
+// The ports are probed concurrently and the probe is aborted after the probe timeout.
+func (c *Collector) probePorts(ports map[string]*printerPort) map[string]bool {
+	probes := make(map[string]string)
+
+	for name, port := range ports {
+		if port.host == "" || port.port == 0 || !c.config.PortProbeInclude.MatchString(name) {
+			continue
+		}
+
+		probes[name] = net.JoinHostPort(port.host, strconv.FormatUint(uint64(port.port), 10))
+	}
+
+	if len(probes) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.PortProbeTimeout)
+	defer cancel()
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		reachable = make(map[string]bool, len(probes))
+		dialer    net.Dialer
+	)
+
+	for name, address := range probes {
+		wg.Go(func() {
+			conn, err := dialer.DialContext(ctx, "tcp", address)
+			if err == nil {
+				_ = conn.Close()
+			}
+
+			mu.Lock()
+			reachable[name] = err == nil
+			mu.Unlock()
+		})
+	}
+
+	wg.Wait()
+
+	return reachable
+}
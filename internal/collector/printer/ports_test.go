@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package printer
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupPorts(t *testing.T) {
+	t.Parallel()
+
+	c := &Collector{config: Config{
+		PrinterInclude: types.RegExpAny,
+		PrinterExclude: regexp.MustCompile("^(?:Excluded)$"),
+	}}
+
+	ports := c.groupPorts(
+		[]wmiPrinterPort{
+			{Name: "IP_10.0.0.10", HostAddress: "10.0.0.10", PortNumber: 9100, Protocol: 1},
+			{Name: "IP_10.0.0.11", HostAddress: "10.0.0.11", PortNumber: 515, Protocol: 2},
+			{Name: "IP_10.0.0.12", HostAddress: "10.0.0.12", PortNumber: 9100, Protocol: 1},
+		},
+		[]wmiPrinterPortName{
+			{Name: "Floor 1", PortName: "IP_10.0.0.10"},
+			{Name: "Floor 1 Pool", PortName: "IP_10.0.0.10, IP_10.0.0.11"},
+			{Name: "Office", PortName: "WSD-3b5e2a1c-7d1f-4e0b-9c4e-1f2a3b4c5d6e"},
+			{Name: "Excluded", PortName: "IP_10.0.0.12"},
+		},
+	)
+
+	require.Len(t, ports, 4)
+	require.Equal(t, &printerPort{host: "10.0.0.10", port: 9100, protocol: "raw", printers: 2}, ports["IP_10.0.0.10"])
+	require.Equal(t, &printerPort{host: "10.0.0.11", port: 515, protocol: "lpr", printers: 1}, ports["IP_10.0.0.11"])
+	require.Equal(t, &printerPort{host: "10.0.0.12", port: 9100, protocol: "raw"}, ports["IP_10.0.0.12"])
+	require.Equal(t, &printerPort{protocol: "wsd", printers: 1}, ports["WSD-3b5e2a1c-7d1f-4e0b-9c4e-1f2a3b4c5d6e"])
+}
+
+func TestProbePorts(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, closed.Close())
+
+	portOf := func(l net.Listener) uint32 {
+		_, port, err := net.SplitHostPort(l.Addr().String())
+		require.NoError(t, err)
+
+		p, err := strconv.ParseUint(port, 10, 16)
+		require.NoError(t, err)
+
+		return uint32(p)
+	}
+
+	c := &Collector{config: Config{
+		PortProbeInclude: regexp.MustCompile("^(?:IP_.+)$"),
+		PortProbeTimeout: 5 * time.Second,
+	}}
+
+	reachable := c.probePorts(map[string]*printerPort{
+		"IP_open":   {host: "127.0.0.1", port: portOf(listener)},
+		"IP_closed": {host: "127.0.0.1", port: portOf(closed)},
+		"WSD-1":     {protocol: "wsd"},
+		"OTHER":     {host: "127.0.0.1", port: portOf(listener)},
+	})
+
+	require.Equal(t, map[string]bool{"IP_open": true, "IP_closed": false}, reachable)
+}
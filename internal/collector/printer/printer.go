@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"log/slog"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -31,7 +32,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-const Name = "printer"
+const (
+	Name                 = "printer"
+	subCollectorPrinters = "printers"
+	subCollectorPorts    = "ports"
+)
 
 // printerStatusMap source: https://learn.microsoft.com/en-us/windows/win32/cimwin32prov/win32-printer#:~:text=Power%20Save-,PrinterStatus,Offline%20(7),-PrintJobDataType
 //
@@ -47,14 +52,22 @@ var printerStatusMap = map[uint16]string{
 }
 
 type Config struct {
-	PrinterInclude *regexp.Regexp `yaml:"include"`
-	PrinterExclude *regexp.Regexp `yaml:"exclude"`
+	CollectorsEnabled []string       `yaml:"enabled"`
+	PrinterInclude    *regexp.Regexp `yaml:"include"`
+	PrinterExclude    *regexp.Regexp `yaml:"exclude"`
+	PortProbeInclude  *regexp.Regexp `yaml:"port-probe-include"`
+	PortProbeTimeout  time.Duration  `yaml:"port-probe-timeout"`
 }
 
 //nolint:gochecknoglobals
 var ConfigDefaults = Config{
-	PrinterInclude: types.RegExpAny,
-	PrinterExclude: types.RegExpEmpty,
+	CollectorsEnabled: []string{
+		subCollectorPrinters,
+	},
+	PrinterInclude:   types.RegExpAny,
+	PrinterExclude:   types.RegExpEmpty,
+	PortProbeInclude: types.RegExpEmpty,
+	PortProbeTimeout: time.Second,
 }
 
 type Collector struct {
@@ -63,9 +76,16 @@ type Collector struct {
 	miQueryPrinterJobs mi.Query
 	miQueryPrinter     mi.Query
 
+	miQueryPrinterPorts     mi.Query
+	miQueryPrinterPortNames mi.Query
+
 	printerStatus    *prometheus.Desc
 	printerJobStatus *prometheus.Desc
 	printerJobCount  *prometheus.Desc
+
+	portInfo      *prometheus.Desc
+	portPrinters  *prometheus.Desc
+	portReachable *prometheus.Desc
 }
 
 func New(config *Config) *Collector {
@@ -81,6 +101,18 @@ func New(config *Config) *Collector {
 		config.PrinterInclude = ConfigDefaults.PrinterInclude
 	}
 
+	if config.CollectorsEnabled == nil {
+		config.CollectorsEnabled = ConfigDefaults.CollectorsEnabled
+	}
+
+	if config.PortProbeInclude == nil {
+		config.PortProbeInclude = ConfigDefaults.PortProbeInclude
+	}
+
+	if config.PortProbeTimeout == 0 {
+		config.PortProbeTimeout = ConfigDefaults.PortProbeTimeout
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -93,7 +125,7 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		config: ConfigDefaults,
 	}
 
-	var printerInclude, printerExclude string
+	var collectorsEnabled, printerInclude, printerExclude, portProbeInclude string
 
 	app.Flag(
 		"collector.printer.include",
@@ -105,7 +137,27 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		"Regular expression to match printers to exclude",
 	).Default("").StringVar(&printerExclude)
 
+	app.Flag(
+		"collector.printer.enabled",
+		fmt.Sprintf("Comma-separated list of collectors to use. Available collectors: %s, %s. Defaults to printers, if not specified.",
+			subCollectorPrinters,
+			subCollectorPorts,
+		),
+	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
+
+	app.Flag(
+		"collector.printer.port-probe-include",
+		"Regular expression to match Standard TCP/IP printer ports, which are probed by opening a TCP connection to the printer. No port is probed by default.",
+	).Default("").StringVar(&portProbeInclude)
+
+	app.Flag(
+		"collector.printer.port-probe-timeout",
+		"Maximum duration of the printer port probes of a scrape.",
+	).Default(ConfigDefaults.PortProbeTimeout.String()).DurationVar(&c.config.PortProbeTimeout)
+
 	app.Action(func(*kingpin.ParseContext) error {
+		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
+
 		var err error
 
 		c.config.PrinterInclude, err = regexp.Compile(fmt.Sprintf("^(?:%s)$", printerInclude))
@@ -118,6 +170,11 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 			return fmt.Errorf("collector.printer.exclude: %w", err)
 		}
 
+		c.config.PortProbeInclude, err = regexp.Compile(fmt.Sprintf("^(?:%s)$", portProbeInclude))
+		if err != nil {
+			return fmt.Errorf("collector.printer.port-probe-include: %w", err)
+		}
+
 		return nil
 	})
 
@@ -129,6 +186,14 @@ func (c *Collector) Close() error {
 }
 
 func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
+	for _, collector := range c.config.CollectorsEnabled {
+		if !slices.Contains([]string{subCollectorPrinters, subCollectorPorts}, collector) {
+			return fmt.Errorf("unknown sub collector: %s. Possible values: %s", collector,
+				strings.Join([]string{subCollectorPrinters, subCollectorPorts}, ", "),
+			)
+		}
+	}
+
 	c.printerJobStatus = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "job_status"),
 		"A counter of printer jobs by status",
@@ -147,6 +212,24 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 		[]string{"printer"},
 		nil,
 	)
+	c.portInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "port_info"),
+		"A metric with a constant '1' value labeled with the host and protocol of the printer port",
+		[]string{"port", "host", "protocol"},
+		nil,
+	)
+	c.portPrinters = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "port_printers"),
+		"Number of printers using the printer port",
+		[]string{"port"},
+		nil,
+	)
+	c.portReachable = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "port_reachable"),
+		"Whether a TCP connection to the host and port of the printer port could be opened. Only reported for ports matching --collector.printer.port-probe-include",
+		[]string{"port"},
+		nil,
+	)
 
 	if miSession == nil {
 		return errors.New("miSession is nil")
@@ -165,6 +248,20 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 	}
 
 	c.miQueryPrinterJobs = miQuery
+
+	miQuery, err = mi.NewQuery("SELECT Name, HostAddress, PortNumber, Protocol FROM Win32_TCPIPPrinterPort")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQueryPrinterPorts = miQuery
+
+	miQuery, err = mi.NewQuery("SELECT Name, PortName FROM win32_Printer")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQueryPrinterPortNames = miQuery
 	c.miSession = miSession
 
 	return nil
@@ -172,6 +269,8 @@ func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
 
 func (c *Collector) GetName() string { return Name }
 
+func (c *Collector) GetSources() []string { return []string{types.SourceWMI} }
+
 type wmiPrinter struct {
 	Name                   string `mi:"Name"`
 	Default                bool   `mi:"Default"`
@@ -187,12 +286,20 @@ type wmiPrintJob struct {
 func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
 	var errs []error
 
-	if err := c.collectPrinterStatus(ch); err != nil {
-		errs = append(errs, fmt.Errorf("failed to collect printer status metrics: %w", err))
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorPrinters) {
+		if err := c.collectPrinterStatus(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed to collect printer status metrics: %w", err))
+		}
+
+		if err := c.collectPrinterJobStatus(ch, maxScrapeDuration); err != nil {
+			errs = append(errs, fmt.Errorf("failed to collect printer job status metrics: %w", err))
+		}
 	}
 
-	if err := c.collectPrinterJobStatus(ch, maxScrapeDuration); err != nil {
-		errs = append(errs, fmt.Errorf("failed to collect printer job status metrics: %w", err))
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorPorts) {
+		if err := c.collectPorts(ch, maxScrapeDuration); err != nil {
+			errs = append(errs, fmt.Errorf("failed to collect printer port metrics: %w", err))
+		}
 	}
 
 	return errors.Join(errs...)
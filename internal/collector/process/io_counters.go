@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package process
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/ntdll"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectIOCounters sends the I/O transfer counters of all processes matching the process filters.
+// The counters are read from SystemProcessInformation, which doesn't require a process handle,
+// so the I/O of protected processes is reported as well.
+func (c *Collector) collectIOCounters(ch chan<- prometheus.Metric) error {
+	processes, err := ntdll.QuerySystemProcessIOCounters()
+	if err != nil {
+		return fmt.Errorf("failed to query process I/O counters: %w", err)
+	}
+
+	for _, process := range c.filterIOCounters(processes) {
+		name := ioCountersProcessName(process)
+		pidString := strconv.FormatUint(uint64(process.ProcessID), 10)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.readBytesTotal,
+			prometheus.CounterValue,
+			float64(process.ReadTransferCount),
+			name, pidString,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.writeBytesTotal,
+			prometheus.CounterValue,
+			float64(process.WriteTransferCount),
+			name, pidString,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.otherBytesTotal,
+			prometheus.CounterValue,
+			float64(process.OtherTransferCount),
+			name, pidString,
+		)
+	}
+
+	return nil
+}
+
+// filterIOCounters returns the processes matching the include and exclude filters.
+// The _Total instance of the performance counters is never reported.
+func (c *Collector) filterIOCounters(processes []ntdll.ProcessIOCounters) []ntdll.ProcessIOCounters {
+	filtered := make([]ntdll.ProcessIOCounters, 0, len(processes))
+
+	for _, process := range processes {
+		name := ioCountersProcessName(process)
+
+		if name == pdh.InstanceTotal {
+			continue
+		}
+
+		if c.config.ProcessExclude.MatchString(name) || !c.config.ProcessInclude.MatchString(name) {
+			continue
+		}
+
+		filtered = append(filtered, process)
+	}
+
+	return filtered
+}
+
+// ioCountersProcessName returns the process name the same way as the Process performance counter
+// object, e.g. svchost instead of svchost.exe. The System Idle Process has no image name.
+func ioCountersProcessName(process ntdll.ProcessIOCounters) string {
+	if process.ProcessID == 0 && process.ImageName == "" {
+		return "Idle"
+	}
+
+	name := process.ImageName
+	if len(name) > 4 && strings.EqualFold(name[len(name)-4:], ".exe") {
+		name = name[:len(name)-4]
+	}
+
+	return name
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package process
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/ntdll"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterIOCounters(t *testing.T) {
+	t.Parallel()
+
+	processes := []ntdll.ProcessIOCounters{
+		{ProcessID: 0},
+		{ProcessID: 4, ImageName: "System"},
+		{ProcessID: 0, ImageName: "_Total"},
+		{ProcessID: 812, ImageName: "svchost.exe"},
+		{ProcessID: 1024, ImageName: "LSASS.EXE"},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		include *regexp.Regexp
+		exclude *regexp.Regexp
+		want    []string
+	}{
+		{
+			name:    "all",
+			include: types.RegExpAny,
+			exclude: types.RegExpEmpty,
+			want:    []string{"Idle", "System", "svchost", "LSASS"},
+		},
+		{
+			name:    "total",
+			include: regexp.MustCompile("^(?:_Total|svchost)$"),
+			exclude: types.RegExpEmpty,
+			want:    []string{"svchost"},
+		},
+		{
+			name:    "exclude",
+			include: types.RegExpAny,
+			exclude: regexp.MustCompile("^(?:svchost|Idle)$"),
+			want:    []string{"System", "LSASS"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := New(&Config{
+				ProcessInclude: tc.include,
+				ProcessExclude: tc.exclude,
+			})
+
+			names := make([]string, 0, len(processes))
+			for _, process := range c.filterIOCounters(processes) {
+				names = append(names, ioCountersProcessName(process))
+			}
+
+			require.Equal(t, tc.want, names)
+		})
+	}
+}
@@ -84,6 +84,9 @@ type Collector struct {
 	poolBytes         *prometheus.Desc
 	priorityBase      *prometheus.Desc
 	privateBytes      *prometheus.Desc
+	readBytesTotal    *prometheus.Desc
+	writeBytesTotal   *prometheus.Desc
+	otherBytesTotal   *prometheus.Desc
 	startTime         *prometheus.Desc
 	threadCount       *prometheus.Desc
 	virtualBytes      *prometheus.Desc
@@ -167,6 +170,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceWMI, types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -188,11 +195,11 @@ func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 
 	switch c.config.CounterVersion {
 	case 2:
-		c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](c.logger, pdh.CounterTypeRaw, "Process V2", pdh.InstancesAll)
+		c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](c.logger, pdh.CounterTypeRaw, "Process V2", c.processV2Instances())
 	case 1:
 		c.perfDataCollector, err = registry.NewCollector[perfDataCounterValues]("Process", pdh.InstancesAll)
 	default:
-		c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](c.logger, pdh.CounterTypeRaw, "Process V2", pdh.InstancesAll)
+		c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](c.logger, pdh.CounterTypeRaw, "Process V2", c.processV2Instances())
 		c.config.CounterVersion = 2
 
 		if errors.Is(err, pdh.NewPdhError(pdh.CstatusNoObject)) {
@@ -257,6 +264,24 @@ func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 		[]string{"process", "process_id", "mode"},
 		nil,
 	)
+	c.readBytesTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "read_bytes_total"),
+		"Bytes read by the process, read from SystemProcessInformation. Includes protected processes.",
+		[]string{"process", "process_id"},
+		nil,
+	)
+	c.writeBytesTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "write_bytes_total"),
+		"Bytes written by the process, read from SystemProcessInformation. Includes protected processes.",
+		[]string{"process", "process_id"},
+		nil,
+	)
+	c.otherBytesTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "other_bytes_total"),
+		"Bytes transferred by the process in operations other than read and write, e.g. metadata operations, read from SystemProcessInformation.",
+		[]string{"process", "process_id"},
+		nil,
+	)
 	c.pageFaultsTotal = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "page_faults_total"),
 		"Page faults by the threads executing in this process.",
@@ -343,6 +368,24 @@ func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 	return nil
 }
 
+// processV2Instances returns the instances of the Process V2 object matching the include filter.
+// Pushing the filter into the counter path avoids expanding all processes in PDH.
+// Process V2 instances are named <name>:<pid>.
+func (c *Collector) processV2Instances() []string {
+	patterns, ok := pdh.InstancesFromRegexp(c.config.ProcessInclude)
+	if !ok {
+		return pdh.InstancesAll
+	}
+
+	instances := make([]string, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		instances = append(instances, pattern+":*")
+	}
+
+	return instances
+}
+
 func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
 	return c.collect(ch, maxScrapeDuration)
 }
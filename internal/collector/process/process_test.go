@@ -18,6 +18,7 @@
 package process_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/alecthomas/kingpin/v2"
@@ -34,6 +35,16 @@ func BenchmarkProcessCollector(b *testing.B) {
 	})
 }
 
+func BenchmarkProcessCollectorFiltered(b *testing.B) {
+	// The include filter is pushed into the PDH counter path, so only matching processes are expanded.
+	testutils.FuncBenchmarkCollector(b, process.Name, func(*kingpin.Application) *process.Collector {
+		config := process.ConfigDefaults
+		config.ProcessInclude = regexp.MustCompile("^(?:svchost|lsass)$")
+
+		return process.New(&config)
+	})
+}
+
 func TestCollector(t *testing.T) {
 	testutils.TestCollector(t, process.New, nil)
 }
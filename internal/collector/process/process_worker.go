@@ -19,6 +19,7 @@ package process
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"runtime/debug"
@@ -90,6 +91,10 @@ func (c *Collector) collect(ch chan<- prometheus.Metric, maxScrapeDuration time.
 
 	wg.Wait()
 
+	if ioErr := c.collectIOCounters(ch); ioErr != nil {
+		err = errors.Join(err, ioErr)
+	}
+
 	return err
 }
 
@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package rdgateway
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Name = "rdgateway"
+
+type Config struct{}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{}
+
+// A Collector is a Prometheus Collector for the Remote Desktop Gateway role.
+type Collector struct {
+	config    Config
+	miSession *mi.Session
+	miQuery   mi.Query
+
+	// disabled is set on hosts without the Remote Desktop Gateway role.
+	disabled bool
+
+	perfDataCollector *pdh.Collector
+	perfDataObject    []perfDataCounterValues
+
+	currentConnections  *prometheus.Desc
+	connectionsTotal    *prometheus.Desc
+	connectionFailures  *prometheus.Desc
+	resourceConnections *prometheus.Desc
+	receivedBytes       *prometheus.Desc
+	sentBytes           *prometheus.Desc
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(_ *kingpin.Application) *Collector {
+	return &Collector{}
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceWMI}
+}
+
+func (c *Collector) Close() error {
+	c.perfDataCollector.Close()
+
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
+	logger = logger.With(slog.String("collector", Name))
+
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	c.currentConnections = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "current_connections"),
+		"Number of active connections through the gateway",
+		nil,
+		nil,
+	)
+	c.connectionsTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "connections_total"),
+		"Number of connection requests that passed connection authorization",
+		nil,
+		nil,
+	)
+	c.connectionFailures = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "connection_failures_total"),
+		"Number of failed connection requests by reason",
+		[]string{"reason"},
+		nil,
+	)
+	c.resourceConnections = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "resource_connections"),
+		"Number of active connections by connected resource",
+		[]string{"resource"},
+		nil,
+	)
+	c.receivedBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "active_connections_received_bytes"),
+		"Bytes received by the gateway on the active connections by transport",
+		[]string{"transport"},
+		nil,
+	)
+	c.sentBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "active_connections_sent_bytes"),
+		"Bytes sent by the gateway on the active connections by transport",
+		[]string{"transport"},
+		nil,
+	)
+
+	var err error
+
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger, pdh.CounterTypeRaw, "Terminal Service Gateway", nil)
+	if errors.Is(err, pdh.NewPdhError(pdh.CstatusNoObject)) {
+		logger.Warn("Terminal Service Gateway performance object not found. The Remote Desktop Gateway role is not installed, the rdgateway collector is disabled")
+
+		c.disabled = true
+
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to create Terminal Service Gateway collector: %w", err)
+	}
+
+	miQuery, err := mi.NewQuery("SELECT ConnectedResource, ConnectionProtocol, NumberOfKilobytesReceived, NumberOfKilobytesSent FROM Win32_TSGatewayConnection")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQuery = miQuery
+	c.miSession = miSession
+
+	return nil
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	if c.disabled {
+		return nil
+	}
+
+	errs := make([]error, 0)
+
+	if err := c.collectPDH(ch); err != nil {
+		errs = append(errs, fmt.Errorf("failed collecting Terminal Service Gateway metrics: %w", err))
+	}
+
+	if err := c.collectConnections(ch, maxScrapeDuration); err != nil {
+		errs = append(errs, fmt.Errorf("failed collecting Win32_TSGatewayConnection metrics: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) collectPDH(ch chan<- prometheus.Metric) error {
+	err := c.perfDataCollector.Collect(&c.perfDataObject)
+	if err != nil {
+		return fmt.Errorf("failed to collect Terminal Service Gateway metrics: %w", err)
+	}
+
+	data := c.perfDataObject[0]
+
+	ch <- prometheus.MustNewConstMetric(
+		c.currentConnections,
+		prometheus.GaugeValue,
+		data.CurrentConnections,
+	)
+
+	if c.perfDataCollector.HasCounter(counterSuccessfulConnectionAuthorization) {
+		ch <- prometheus.MustNewConstMetric(
+			c.connectionsTotal,
+			prometheus.CounterValue,
+			data.SuccessfulConnectionAuthorization,
+		)
+	}
+
+	for _, failure := range []struct {
+		counter string
+		reason  string
+		value   float64
+	}{
+		{counterFailedConnectionAuthentication, "authentication", data.FailedConnectionAuthentication},
+		{counterFailedConnectionAuthorization, "connection_authorization", data.FailedConnectionAuthorization},
+		{counterFailedResourceAuthorization, "resource_authorization", data.FailedResourceAuthorization},
+	} {
+		if !c.perfDataCollector.HasCounter(failure.counter) {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.connectionFailures,
+			prometheus.CounterValue,
+			failure.value,
+			failure.reason,
+		)
+	}
+
+	return nil
+}
+
+func (c *Collector) collectConnections(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	var dst []win32TSGatewayConnection
+	if err := c.miSession.Query(&dst, mi.NamespaceRootTerminalServices, c.miQuery, maxScrapeDuration); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	resourceConnections := make(map[string]float64)
+	receivedBytes := make(map[string]float64)
+	sentBytes := make(map[string]float64)
+
+	for _, connection := range dst {
+		resourceConnections[strings.ToLower(connection.ConnectedResource)]++
+
+		transport := strings.ToLower(connection.ConnectionProtocol)
+		receivedBytes[transport] += float64(connection.NumberOfKilobytesReceived) * 1024
+		sentBytes[transport] += float64(connection.NumberOfKilobytesSent) * 1024
+	}
+
+	for resource, count := range resourceConnections {
+		ch <- prometheus.MustNewConstMetric(
+			c.resourceConnections,
+			prometheus.GaugeValue,
+			count,
+			resource,
+		)
+	}
+
+	for transport, value := range receivedBytes {
+		ch <- prometheus.MustNewConstMetric(
+			c.receivedBytes,
+			prometheus.GaugeValue,
+			value,
+			transport,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.sentBytes,
+			prometheus.GaugeValue,
+			sentBytes[transport],
+			transport,
+		)
+	}
+
+	return nil
+}
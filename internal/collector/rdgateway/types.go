@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package rdgateway
+
+const (
+	counterFailedConnectionAuthentication    = "Failed Connection Authentication"
+	counterFailedConnectionAuthorization     = "Failed Connection Authorization"
+	counterFailedResourceAuthorization       = "Failed Resource Authorization"
+	counterSuccessfulConnectionAuthorization = "Successful Connection Authorization"
+)
+
+// Terminal Service Gateway performance counters. Only "Current connections" is required,
+// since the set of counters differs between Windows Server versions.
+type perfDataCounterValues struct {
+	CurrentConnections                float64 `perfdata:"Current connections"`
+	FailedConnectionAuthentication    float64 `perfdata:"Failed Connection Authentication" perfdata_optional:"true"`
+	FailedConnectionAuthorization     float64 `perfdata:"Failed Connection Authorization" perfdata_optional:"true"`
+	FailedResourceAuthorization       float64 `perfdata:"Failed Resource Authorization" perfdata_optional:"true"`
+	SuccessfulConnectionAuthorization float64 `perfdata:"Successful Connection Authorization" perfdata_optional:"true"`
+}
+
+// win32TSGatewayConnection represents the Win32_TSGatewayConnection WMI class.
+// https://learn.microsoft.com/en-us/windows/win32/termserv/win32-tsgatewayconnection
+type win32TSGatewayConnection struct {
+	ConnectedResource         string `mi:"ConnectedResource"`
+	ConnectionProtocol        string `mi:"ConnectionProtocol"`
+	NumberOfKilobytesReceived uint32 `mi:"NumberOfKilobytesReceived"`
+	NumberOfKilobytesSent     uint32 `mi:"NumberOfKilobytesSent"`
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package reliability
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// dmtfLayout is the layout of a CIM datetime value without the UTC offset.
+// The offset is appended as a sign and three digits of minutes, e.g. 20250101120000.000000+060.
+//
+// https://learn.microsoft.com/en-us/windows/win32/wmisdk/cim-datetime
+const dmtfLayout = "20060102150405.000000"
+
+// parseDMTF parses a CIM datetime value.
+func parseDMTF(value string) (time.Time, error) {
+	if len(value) != len(dmtfLayout)+4 {
+		return time.Time{}, fmt.Errorf("invalid CIM datetime %q", value)
+	}
+
+	t, err := time.Parse(dmtfLayout, value[:len(dmtfLayout)])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid CIM datetime %q: %w", value, err)
+	}
+
+	offset, err := strconv.Atoi(value[len(dmtfLayout):])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid CIM datetime offset %q: %w", value, err)
+	}
+
+	return t.Add(-time.Duration(offset) * time.Minute), nil
+}
+
+// formatDMTF formats the given time as a CIM datetime value in UTC.
+func formatDMTF(t time.Time) string {
+	return t.UTC().Format(dmtfLayout) + "+000"
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package reliability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDMTF(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		value    string
+		expected time.Time
+		err      bool
+	}{
+		{value: "20250101120000.000000+000", expected: time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)},
+		{value: "20250101120000.500000+060", expected: time.Date(2025, time.January, 1, 11, 0, 0, 500_000_000, time.UTC)},
+		{value: "20250101120000.000000-300", expected: time.Date(2025, time.January, 1, 17, 0, 0, 0, time.UTC)},
+		{value: "20250101120000", err: true},
+		{value: "2025010112000x.000000+000", err: true},
+		{value: "20250101120000.000000+0x0", err: true},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := parseDMTF(tc.value)
+			if tc.err {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, tc.expected.Equal(actual), "expected %s, got %s", tc.expected, actual)
+		})
+	}
+}
+
+func TestFormatDMTF(t *testing.T) {
+	t.Parallel()
+
+	value := formatDMTF(time.Date(2025, time.January, 1, 13, 0, 0, 0, time.FixedZone("CET", 3600)))
+	require.Equal(t, "20250101120000.000000+000", value)
+
+	parsed, err := parseDMTF(value)
+	require.NoError(t, err)
+	require.True(t, time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC).Equal(parsed))
+}
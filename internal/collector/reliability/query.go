@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package reliability
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/go-ole/go-ole"
+	"github.com/go-ole/go-ole/oleutil"
+)
+
+const (
+	// eventLogSource is the source of the records of the System event log written by the event log service.
+	eventLogSource = "EventLog"
+	// eventIDUnexpectedShutdown is the ID of the "The previous system shutdown was unexpected" event.
+	eventIDUnexpectedShutdown = 6008
+)
+
+type reliabilityData struct {
+	stabilityIndex float64
+	// stabilityIndexTime is the zero time, if no stability index was calculated yet.
+	stabilityIndexTime time.Time
+
+	// records is the number of reliability records by source name.
+	records             map[string]float64
+	unexpectedShutdowns float64
+}
+
+type reliabilityRecord struct {
+	sourceName      string
+	eventIdentifier uint32
+}
+
+// queryReliability queries the latest stability index and the reliability records generated since the given time.
+// The classes are queried through the scripting API, because it reports datetime values as DMTF strings.
+func queryReliability(since time.Time) (reliabilityData, error) {
+	// The only way to run WMI queries in parallel while being thread-safe is to
+	// ensure the CoInitialize[Ex]() call is bound to its current OS thread.
+	// Otherwise, attempting to initialize and run parallel queries across
+	// goroutines will result in protected memory errors.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := ole.CoInitializeEx(0, ole.COINIT_MULTITHREADED); err != nil {
+		var oleCode *ole.OleError
+		if errors.As(err, &oleCode) && oleCode.Code() != ole.S_OK && oleCode.Code() != 0x00000001 {
+			return reliabilityData{}, err
+		}
+	}
+
+	defer ole.CoUninitialize()
+
+	service, err := connectWMI()
+	if err != nil {
+		return reliabilityData{}, err
+	}
+
+	defer service.Release()
+
+	data := reliabilityData{
+		records: make(map[string]float64),
+	}
+
+	err = execQuery(service, "SELECT SystemStabilityIndex, TimeGenerated FROM Win32_ReliabilityStabilityMetrics", func(item *ole.IDispatch) error {
+		timeGenerated, err := getStringProperty(item, "TimeGenerated")
+		if err != nil {
+			return err
+		}
+
+		generated, err := parseDMTF(timeGenerated)
+		if err != nil {
+			return err
+		}
+
+		if !generated.After(data.stabilityIndexTime) {
+			return nil
+		}
+
+		stabilityIndex, err := oleutil.GetProperty(item, "SystemStabilityIndex")
+		if err != nil {
+			return fmt.Errorf("failed to get SystemStabilityIndex: %w", err)
+		}
+
+		defer func() {
+			_ = stabilityIndex.Clear()
+		}()
+
+		value, ok := stabilityIndex.Value().(float64)
+		if !ok {
+			return fmt.Errorf("unexpected SystemStabilityIndex type %d", stabilityIndex.VT)
+		}
+
+		data.stabilityIndex = value
+		data.stabilityIndexTime = generated
+
+		return nil
+	})
+	if err != nil {
+		return reliabilityData{}, fmt.Errorf("failed to query Win32_ReliabilityStabilityMetrics: %w", err)
+	}
+
+	records := make([]reliabilityRecord, 0)
+
+	query := fmt.Sprintf("SELECT SourceName, EventIdentifier FROM Win32_ReliabilityRecords WHERE TimeGenerated >= '%s'", formatDMTF(since))
+
+	err = execQuery(service, query, func(item *ole.IDispatch) error {
+		sourceName, err := getStringProperty(item, "SourceName")
+		if err != nil {
+			return err
+		}
+
+		eventIdentifier, err := oleutil.GetProperty(item, "EventIdentifier")
+		if err != nil {
+			return fmt.Errorf("failed to get EventIdentifier: %w", err)
+		}
+
+		defer func() {
+			_ = eventIdentifier.Clear()
+		}()
+
+		records = append(records, reliabilityRecord{
+			sourceName:      sourceName,
+			eventIdentifier: uint32(eventIdentifier.Val),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return reliabilityData{}, fmt.Errorf("failed to query Win32_ReliabilityRecords: %w", err)
+	}
+
+	data.records, data.unexpectedShutdowns = countRecords(records)
+
+	return data, nil
+}
+
+// countRecords returns the number of records by source name and the number of unexpected shutdowns.
+func countRecords(records []reliabilityRecord) (map[string]float64, float64) {
+	bySource := make(map[string]float64)
+
+	var unexpectedShutdowns float64
+
+	for _, record := range records {
+		bySource[record.sourceName]++
+
+		if record.sourceName == eventLogSource && record.eventIdentifier == eventIDUnexpectedShutdown {
+			unexpectedShutdowns++
+		}
+	}
+
+	return bySource, unexpectedShutdowns
+}
+
+// connectWMI connects to the root\CIMV2 namespace.
+// Must be called from a thread with an initialized COM apartment.
+func connectWMI() (*ole.IDispatch, error) {
+	locator, err := oleutil.CreateObject("WbemScripting.SWbemLocator")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SWbemLocator: %w", err)
+	}
+
+	defer locator.Release()
+
+	locatorDispatch, err := locator.QueryInterface(ole.IID_IDispatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query SWbemLocator interface: %w", err)
+	}
+
+	defer locatorDispatch.Release()
+
+	serviceRaw, err := oleutil.CallMethod(locatorDispatch, "ConnectServer", ".", `root\CIMV2`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to WMI namespace: %w", err)
+	}
+
+	return serviceRaw.ToIDispatch(), nil
+}
+
+// execQuery runs the given WQL query and calls fn for each returned object.
+func execQuery(service *ole.IDispatch, query string, fn func(item *ole.IDispatch) error) error {
+	resultRaw, err := oleutil.CallMethod(service, "ExecQuery", query)
+	if err != nil {
+		return err
+	}
+
+	result := resultRaw.ToIDispatch()
+	defer result.Release()
+
+	return oleutil.ForEach(result, func(v *ole.VARIANT) error {
+		item := v.ToIDispatch()
+		defer item.Release()
+
+		return fn(item)
+	})
+}
+
+func getStringProperty(item *ole.IDispatch, name string) (string, error) {
+	property, err := oleutil.GetProperty(item, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s: %w", name, err)
+	}
+
+	defer func() {
+		_ = property.Clear()
+	}()
+
+	value, ok := property.Value().(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected %s type %d", name, property.VT)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package reliability
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountRecords(t *testing.T) {
+	t.Parallel()
+
+	bySource, unexpectedShutdowns := countRecords([]reliabilityRecord{
+		{sourceName: "Application Error", eventIdentifier: 1000},
+		{sourceName: "Application Error", eventIdentifier: 1000},
+		{sourceName: "EventLog", eventIdentifier: 6008},
+		{sourceName: "EventLog", eventIdentifier: 6005},
+		{sourceName: "Microsoft-Windows-WindowsUpdateClient", eventIdentifier: 6008},
+	})
+
+	require.Equal(t, map[string]float64{
+		"Application Error":                     2,
+		"EventLog":                              2,
+		"Microsoft-Windows-WindowsUpdateClient": 1,
+	}, bySource)
+	require.InDelta(t, 1.0, unexpectedShutdowns, 0)
+}
@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package reliability
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Name = "reliability"
+
+type Config struct {
+	RefreshInterval time.Duration `yaml:"refresh-interval"`
+}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{
+	RefreshInterval: 10 * time.Minute,
+}
+
+// A Collector is a Prometheus Collector for the Reliability Monitor data
+// of Win32_ReliabilityStabilityMetrics and Win32_ReliabilityRecords.
+type Collector struct {
+	config Config
+	logger *slog.Logger
+
+	// startTime is the start of the period in which reliability records are counted.
+	startTime time.Time
+
+	// mu protects the cached query result.
+	mu          sync.Mutex
+	lastRefresh time.Time
+	data        reliabilityData
+
+	stabilityIndex      *prometheus.Desc
+	recordsTotal        *prometheus.Desc
+	unexpectedShutdowns *prometheus.Desc
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+
+	app.Flag(
+		"collector.reliability.refresh-interval",
+		"Interval in which the reliability data is queried from WMI. The last result is reported in between.",
+	).Default(ConfigDefaults.RefreshInterval.String()).DurationVar(&c.config.RefreshInterval)
+
+	return c
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceWMI}
+}
+
+func (c *Collector) Close() error {
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+	c.logger = logger.With(slog.String("collector", Name))
+
+	if c.config.RefreshInterval < 0 {
+		return fmt.Errorf("refresh interval must not be negative: %s", c.config.RefreshInterval)
+	}
+
+	c.startTime = time.Now()
+	c.lastRefresh = time.Time{}
+	c.data = reliabilityData{
+		records: make(map[string]float64),
+	}
+
+	c.stabilityIndex = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "stability_index"),
+		"Latest system stability index calculated by the Reliability Analysis Component, from 1 (least stable) to 10 (most stable). (SystemStabilityIndex)",
+		nil,
+		nil,
+	)
+	c.recordsTotal = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "records_total"),
+		"Number of reliability records generated since the exporter started, by the source of the record. (SourceName)",
+		[]string{"source_type"},
+		nil,
+	)
+	c.unexpectedShutdowns = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, "os", "unexpected_shutdowns_total"),
+		"Number of unexpected shutdowns recorded by the Reliability Analysis Component since the exporter started.",
+		nil,
+		nil,
+	)
+
+	return nil
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+// The reliability data is only queried once per refresh interval.
+// If the query fails, the last result is reported.
+func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var err error
+
+	if time.Since(c.lastRefresh) >= c.config.RefreshInterval {
+		var data reliabilityData
+
+		data, err = queryReliability(c.startTime)
+		if err == nil {
+			c.data = data
+			c.lastRefresh = time.Now()
+		} else {
+			err = fmt.Errorf("failed to query reliability data: %w", err)
+		}
+	}
+
+	if !c.data.stabilityIndexTime.IsZero() {
+		ch <- prometheus.MustNewConstMetric(
+			c.stabilityIndex,
+			prometheus.GaugeValue,
+			c.data.stabilityIndex,
+		)
+	}
+
+	for sourceType, count := range c.data.records {
+		ch <- prometheus.MustNewConstMetric(
+			c.recordsTotal,
+			prometheus.CounterValue,
+			count,
+			sourceType,
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.unexpectedShutdowns,
+		prometheus.CounterValue,
+		c.data.unexpectedShutdowns,
+	)
+
+	return err
+}
@@ -97,6 +97,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH}
+}
+
 func (c *Collector) Close() error {
 	c.perfDataCollectorNetwork.Close()
 	c.perfDataCollectorGraphics.Close()
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"regexp"
 	"runtime"
 	"strings"
@@ -50,9 +51,13 @@ var ConfigDefaults = Config{
 type Collector struct {
 	config Config
 
-	lastResult *prometheus.Desc
-	missedRuns *prometheus.Desc
-	state      *prometheus.Desc
+	lastResult       *prometheus.Desc
+	lastResultCode   *prometheus.Desc
+	lastRunTimestamp *prometheus.Desc
+	nextRunTimestamp *prometheus.Desc
+	enabled          *prometheus.Desc
+	missedRuns       *prometheus.Desc
+	state            *prometheus.Desc
 }
 
 // TaskState ...
@@ -81,6 +86,10 @@ type ScheduledTask struct {
 	State           TaskState
 	MissedRunsCount float64
 	LastTaskResult  TaskResult
+	// LastRunTime and NextRunTime are the zero time if the task has
+	// never run or has no next run scheduled.
+	LastRunTime time.Time
+	NextRunTime time.Time
 }
 
 type ScheduledTasks []ScheduledTask
@@ -145,6 +154,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceCOM}
+}
+
 func (c *Collector) Close() error {
 	return nil
 }
@@ -157,6 +170,34 @@ func (c *Collector) Build(_ *slog.Logger, _ *mi.Session) error {
 		nil,
 	)
 
+	c.lastResultCode = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "last_result_code"),
+		"The HRESULT that was returned the last time the registered task was run",
+		[]string{"task"},
+		nil,
+	)
+
+	c.lastRunTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "last_run_timestamp_seconds"),
+		"The time the registered task was last run, as a unix timestamp",
+		[]string{"task"},
+		nil,
+	)
+
+	c.nextRunTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "next_run_timestamp_seconds"),
+		"The time the registered task is scheduled to run next, as a unix timestamp",
+		[]string{"task"},
+		nil,
+	)
+
+	c.enabled = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "enabled"),
+		"Whether the registered task is enabled (1) or not (0)",
+		[]string{"task"},
+		nil,
+	)
+
 	c.missedRuns = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "missed_runs"),
 		"The number of times the registered task missed a scheduled run",
@@ -209,6 +250,27 @@ func (c *Collector) collect(ch chan<- prometheus.Metric) error {
 			)
 		}
 
+		enabled := 0.0
+		if task.Enabled {
+			enabled = 1.0
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.enabled,
+			prometheus.GaugeValue,
+			enabled,
+			task.Path,
+		)
+
+		if !task.NextRunTime.IsZero() {
+			ch <- prometheus.MustNewConstMetric(
+				c.nextRunTimestamp,
+				prometheus.GaugeValue,
+				float64(task.NextRunTime.UnixMilli())/1e3,
+				task.Path,
+			)
+		}
+
 		if task.LastTaskResult == SCHED_S_TASK_HAS_NOT_RUN {
 			continue
 		}
@@ -225,6 +287,22 @@ func (c *Collector) collect(ch chan<- prometheus.Metric) error {
 			task.Path,
 		)
 
+		ch <- prometheus.MustNewConstMetric(
+			c.lastResultCode,
+			prometheus.GaugeValue,
+			float64(task.LastTaskResult),
+			task.Path,
+		)
+
+		if !task.LastRunTime.IsZero() {
+			ch <- prometheus.MustNewConstMetric(
+				c.lastRunTimestamp,
+				prometheus.GaugeValue,
+				float64(task.LastRunTime.UnixMilli())/1e3,
+				task.Path,
+			)
+		}
+
 		ch <- prometheus.MustNewConstMetric(
 			c.missedRuns,
 			prometheus.GaugeValue,
@@ -412,6 +490,28 @@ func parseTask(task *ole.IDispatch) (ScheduledTask, error) {
 		}
 	}()
 
+	taskLastRunTimeVar, err := oleutil.GetProperty(task, "LastRunTime")
+	if err != nil {
+		return scheduledTask, err
+	}
+
+	defer func() {
+		if tempErr := taskLastRunTimeVar.Clear(); tempErr != nil {
+			err = tempErr
+		}
+	}()
+
+	taskNextRunTimeVar, err := oleutil.GetProperty(task, "NextRunTime")
+	if err != nil {
+		return scheduledTask, err
+	}
+
+	defer func() {
+		if tempErr := taskNextRunTimeVar.Clear(); tempErr != nil {
+			err = tempErr
+		}
+	}()
+
 	scheduledTask.Name = taskNameVar.ToString()
 	scheduledTask.Path = strings.ReplaceAll(taskPathVar.ToString(), "\\", "/")
 
@@ -421,7 +521,15 @@ func parseTask(task *ole.IDispatch) (ScheduledTask, error) {
 
 	scheduledTask.State = TaskState(taskStateVar.Val)
 	scheduledTask.MissedRunsCount = float64(taskNumberOfMissedRunsVar.Val)
-	scheduledTask.LastTaskResult = TaskResult(taskLastTaskResultVar.Val)
+	scheduledTask.LastTaskResult = TaskResult(uint32(taskLastTaskResultVar.Val))
+
+	if taskLastRunTimeVar.VT == ole.VT_DATE {
+		scheduledTask.LastRunTime, _ = oleDateToTime(math.Float64frombits(uint64(taskLastRunTimeVar.Val)), time.Local)
+	}
+
+	if taskNextRunTimeVar.VT == ole.VT_DATE {
+		scheduledTask.NextRunTime, _ = oleDateToTime(math.Float64frombits(uint64(taskNextRunTimeVar.Val)), time.Local)
+	}
 
 	return scheduledTask, err
 }
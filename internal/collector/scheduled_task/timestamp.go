@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package scheduled_task
+
+import (
+	"math"
+	"time"
+)
+
+// oleDateToTime converts an OLE automation date, as returned by the LastRunTime
+// and NextRunTime properties of IRegisteredTask, into a time in the given location.
+// The Task Scheduler reports these dates in local time and uses 0 if the task
+// has never run or has no next run scheduled, in which case ok is false.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/oleauto/nf-oleauto-varianttimetosystemtime
+func oleDateToTime(date float64, loc *time.Location) (time.Time, bool) {
+	if date <= 0 || math.IsNaN(date) || math.IsInf(date, 0) {
+		return time.Time{}, false
+	}
+
+	days := math.Floor(date)
+	milliseconds := int(math.Round((date - days) * 24 * 60 * 60 * 1000))
+
+	return time.Date(1899, time.December, 30+int(days), 0, 0, milliseconds/1000, (milliseconds%1000)*int(time.Millisecond), loc), true
+}
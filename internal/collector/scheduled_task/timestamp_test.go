@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package scheduled_task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOleDateToTime(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		date     float64
+		expected time.Time
+		ok       bool
+	}{
+		{name: "never", date: 0, ok: false},
+		{name: "negative", date: -1.5, ok: false},
+		{name: "epoch", date: 25569, expected: time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC), ok: true},
+		{name: "time of day", date: 45658.75, expected: time.Date(2025, time.January, 1, 18, 0, 0, 0, time.UTC), ok: true},
+		{name: "milliseconds", date: 45658 + 1.5/86400, expected: time.Date(2025, time.January, 1, 0, 0, 1, 500*int(time.Millisecond), time.UTC), ok: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual, ok := oleDateToTime(tc.date, time.UTC)
+			require.Equal(t, tc.ok, ok)
+			require.True(t, tc.expected.Equal(actual), "expected %s, got %s", tc.expected, actual)
+		})
+	}
+}
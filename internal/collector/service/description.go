@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package service
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// getServiceDescription returns the description of the service.
+// The service handle must be opened with SERVICE_QUERY_CONFIG.
+func (c *Collector) getServiceDescription(service *mgr.Service) (string, error) {
+	bytesNeeded := uint32(1024)
+
+	buf, ok := c.serviceConfigPoolBytes.Get().(*[]byte)
+	if !ok || len(*buf) == 0 {
+		*buf = make([]byte, bytesNeeded)
+	} else {
+		bytesNeeded = uint32(cap(*buf))
+	}
+
+	defer c.serviceConfigPoolBytes.Put(buf)
+
+	for {
+		err := windows.QueryServiceConfig2(service.Handle, windows.SERVICE_CONFIG_DESCRIPTION, &(*buf)[0], bytesNeeded, &bytesNeeded)
+		if err == nil {
+			break
+		}
+
+		if !errors.Is(err, windows.ERROR_INSUFFICIENT_BUFFER) {
+			return "", err
+		}
+
+		if bytesNeeded <= uint32(len(*buf)) {
+			return "", fmt.Errorf("win32 reports buffer too small (%d), but buffer is large enough (%d): %w", uint32(cap(*buf)), bytesNeeded, err)
+		}
+
+		*buf = make([]byte, bytesNeeded)
+	}
+
+	serviceDescription := (*windows.SERVICE_DESCRIPTION)(unsafe.Pointer(&(*buf)[0]))
+	if serviceDescription.Description == nil {
+		return "", nil
+	}
+
+	return windows.UTF16PtrToString(serviceDescription.Description), nil
+}
+
+// truncateDescription truncates the description to at most maxLength characters.
+// The description is cut at a character boundary, so the result is always valid UTF-8.
+func truncateDescription(description string, maxLength int) string {
+	runes := []rune(description)
+	if len(runes) <= maxLength {
+		return description
+	}
+
+	return string(runes[:maxLength])
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package service
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncateDescription(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		description string
+		maxLength   int
+		expected    string
+	}{
+		{description: "Manages Windows Updates", maxLength: 100, expected: "Manages Windows Updates"},
+		{description: "Manages Windows Updates", maxLength: 7, expected: "Manages"},
+		{description: "Gère les mises à jour", maxLength: 9, expected: "Gère les "},
+		{description: "Windows 更新サービス", maxLength: 10, expected: "Windows 更新"},
+		{description: "", maxLength: 10, expected: ""},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			actual := truncateDescription(tc.description, tc.maxLength)
+			require.Equal(t, tc.expected, actual)
+			require.True(t, utf8.ValidString(actual))
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// collectRecoveryActions sends the configured failure recovery actions of the service.
+// The service handle must be opened with SERVICE_QUERY_CONFIG.
+func (c *Collector) collectRecoveryActions(ch chan<- prometheus.Metric, serviceManager *mgr.Service, serviceName string) {
+	recoveryActions, err := serviceManager.RecoveryActions()
+	if err != nil {
+		c.logger.Log(context.Background(), slog.LevelDebug, "failed collecting service recovery actions",
+			slog.Any("err", err),
+			slog.String("service", serviceName),
+		)
+
+		return
+	}
+
+	labels := recoveryActionLabels(recoveryActions)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.recoveryAction,
+		prometheus.GaugeValue,
+		1.0,
+		serviceName,
+		labels[0],
+		labels[1],
+		labels[2],
+	)
+}
+
+// recoveryActionLabels returns the names of the actions taken on the first, second and subsequent failures.
+// The last configured action is repeated for all subsequent failures.
+func recoveryActionLabels(recoveryActions []mgr.RecoveryAction) [3]string {
+	labels := [3]string{"none", "none", "none"}
+
+	for i := range labels {
+		if len(recoveryActions) == 0 {
+			break
+		}
+
+		action := recoveryActions[min(i, len(recoveryActions)-1)]
+
+		switch action.Type {
+		case windows.SC_ACTION_RESTART:
+			labels[i] = "restart"
+		case windows.SC_ACTION_REBOOT:
+			labels[i] = "reboot"
+		case windows.SC_ACTION_RUN_COMMAND:
+			labels[i] = "run_command"
+		}
+	}
+
+	return labels
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+func TestRecoveryActionLabels(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		actions  []mgr.RecoveryAction
+		expected [3]string
+	}{
+		{
+			name:     "not configured",
+			actions:  nil,
+			expected: [3]string{"none", "none", "none"},
+		},
+		{
+			name: "all configured",
+			actions: []mgr.RecoveryAction{
+				{Type: windows.SC_ACTION_RESTART, Delay: time.Minute},
+				{Type: windows.SC_ACTION_RUN_COMMAND, Delay: time.Minute},
+				{Type: windows.SC_ACTION_REBOOT, Delay: time.Minute},
+			},
+			expected: [3]string{"restart", "run_command", "reboot"},
+		},
+		{
+			name: "last action repeated",
+			actions: []mgr.RecoveryAction{
+				{Type: windows.SC_ACTION_NONE},
+				{Type: windows.SC_ACTION_RESTART, Delay: time.Minute},
+			},
+			expected: [3]string{"none", "restart", "restart"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.expected, recoveryActionLabels(tc.actions))
+		})
+	}
+}
@@ -45,6 +45,7 @@ type Config struct {
 	ServiceInclude          *regexp.Regexp `yaml:"include"`
 	ServiceExclude          *regexp.Regexp `yaml:"exclude"`
 	ServiceStartModeInclude []string       `yaml:"start-mode-include"`
+	MaxDescriptionLength    int            `yaml:"max-description-length"`
 }
 
 //nolint:gochecknoglobals
@@ -52,6 +53,7 @@ var ConfigDefaults = Config{
 	ServiceInclude:          types.RegExpAny,
 	ServiceExclude:          types.RegExpEmpty,
 	ServiceStartModeInclude: []string{"auto", "boot", "manual", "disabled", "system"},
+	MaxDescriptionLength:    0,
 }
 
 // A Collector is a Prometheus Collector for service metrics.
@@ -63,10 +65,12 @@ type Collector struct {
 	apiStateValues     map[uint32]string
 	apiStartModeValues map[uint32]string
 
-	state     *prometheus.Desc
-	processID *prometheus.Desc
-	info      *prometheus.Desc
-	startMode *prometheus.Desc
+	state          *prometheus.Desc
+	process        *prometheus.Desc
+	processID      *prometheus.Desc
+	info           *prometheus.Desc
+	startMode      *prometheus.Desc
+	recoveryAction *prometheus.Desc
 
 	// serviceConfigPoolBytes is a pool of byte slices used to avoid allocations
 	// ref: https://victoriametrics.com/blog/go-sync-pool/
@@ -123,6 +127,11 @@ func NewWithFlags(app *kingpin.Application) *Collector {
 		"Comma separated list of service start modes to include. Possible values: auto, boot, manual, disabled, system.",
 	).Default(strings.Join(ConfigDefaults.ServiceStartModeInclude, ",")).StringVar(&serviceStartModeInclude)
 
+	app.Flag(
+		"collector.service.max-description-length",
+		"Maximum number of characters of the service description exposed in the description label of windows_service_info. Longer descriptions are truncated. 0 leaves the label empty.",
+	).Default(strconv.Itoa(ConfigDefaults.MaxDescriptionLength)).IntVar(&c.config.MaxDescriptionLength)
+
 	app.Action(func(*kingpin.ParseContext) error {
 		var err error
 
@@ -148,9 +157,17 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceAPI}
+}
+
 func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	c.logger = logger.With(slog.String("collector", Name))
 
+	if c.config.MaxDescriptionLength < 0 {
+		return fmt.Errorf("max description length must not be negative: %d", c.config.MaxDescriptionLength)
+	}
+
 	c.serviceConfigPoolBytes = sync.Pool{
 		New: func() any {
 			return new([]byte)
@@ -162,7 +179,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 	c.info = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "info"),
 		"A metric with a constant '1' value labeled with service information",
-		[]string{"name", "display_name", "run_as", "path_name"},
+		[]string{"name", "display_name", "run_as", "path_name", "start_mode", "description"},
 		nil,
 	)
 	c.state = prometheus.NewDesc(
@@ -177,12 +194,25 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		[]string{"name", "start_mode"},
 		nil,
 	)
-	c.processID = prometheus.NewDesc(
+	c.process = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "process"),
 		"Process of started service. The value is the creation time of the process as a unix timestamp.",
 		[]string{"name", "process_id"},
 		nil,
 	)
+	c.processID = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "process_id"),
+		"The process ID of the started service (ProcessId)",
+		[]string{"name"},
+		nil,
+	)
+
+	c.recoveryAction = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "recovery_action"),
+		"A metric with a constant '1' value labeled with the actions taken on the first, second and subsequent failures of the service (SERVICE_CONFIG_FAILURE_ACTIONS)",
+		[]string{"name", "action_1", "action_2", "action_3"},
+		nil,
+	)
 
 	c.apiStateValues = map[uint32]string{
 		windows.SERVICE_CONTINUE_PENDING: "continue pending",
@@ -337,16 +367,40 @@ func (c *Collector) collectService(ch chan<- prometheus.Metric, serviceName stri
 		return nil
 	}
 
+	// The display name of the service list is used, if the service configuration could not be read.
+	displayName := serviceConfig.DisplayName
+	if displayName == "" {
+		displayName = windows.UTF16PtrToString(service.DisplayName)
+	}
+
+	var description string
+
+	if c.config.MaxDescriptionLength > 0 {
+		description, err = c.getServiceDescription(serviceManager)
+		if err != nil {
+			c.logger.Log(context.Background(), slog.LevelDebug, "failed collecting service description",
+				slog.Any("err", err),
+				slog.String("service", serviceName),
+			)
+		}
+
+		description = truncateDescription(description, c.config.MaxDescriptionLength)
+	}
+
 	ch <- prometheus.MustNewConstMetric(
 		c.info,
 		prometheus.GaugeValue,
 		1.0,
 		serviceName,
-		serviceConfig.DisplayName,
+		displayName,
 		serviceConfig.ServiceStartName,
 		serviceConfig.BinaryPathName,
+		serviceStartMode,
+		description,
 	)
 
+	c.collectRecoveryActions(ch, serviceManager, serviceName)
+
 	var (
 		isCurrentStartMode float64
 		isCurrentState     float64
@@ -386,12 +440,19 @@ func (c *Collector) collectService(ch chan<- prometheus.Metric, serviceName stri
 		return nil
 	}
 
+	ch <- prometheus.MustNewConstMetric(
+		c.processID,
+		prometheus.GaugeValue,
+		float64(service.ServiceStatusProcess.ProcessId),
+		serviceName,
+	)
+
 	processID := strconv.FormatUint(uint64(service.ServiceStatusProcess.ProcessId), 10)
 
 	processStartTime, err := c.getProcessStartTime(service.ServiceStatusProcess.ProcessId)
 	if err == nil {
 		ch <- prometheus.MustNewConstMetric(
-			c.processID,
+			c.process,
 			prometheus.GaugeValue,
 			float64(processStartTime/1_000_000_000),
 			serviceName,
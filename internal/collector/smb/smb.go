@@ -20,6 +20,7 @@ package smb
 import (
 	"fmt"
 	"log/slog"
+	"regexp"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
@@ -31,10 +32,16 @@ import (
 
 const Name = "smb"
 
-type Config struct{}
+type Config struct {
+	ShareInclude *regexp.Regexp `yaml:"share-include"`
+	ShareExclude *regexp.Regexp `yaml:"share-exclude"`
+}
 
 //nolint:gochecknoglobals
-var ConfigDefaults = Config{}
+var ConfigDefaults = Config{
+	ShareInclude: types.RegExpAny,
+	ShareExclude: types.RegExpEmpty,
+}
 
 type Collector struct {
 	config Config
@@ -50,6 +57,10 @@ type Collector struct {
 	metadataRequests     *prometheus.Desc
 	sentBytes            *prometheus.Desc
 	filesOpened          *prometheus.Desc
+	readBytes            *prometheus.Desc
+	writeBytes           *prometheus.Desc
+	latencySeconds       *prometheus.Desc
+	latencyOperations    *prometheus.Desc
 }
 
 func New(config *Config) *Collector {
@@ -57,6 +68,14 @@ func New(config *Config) *Collector {
 		config = &ConfigDefaults
 	}
 
+	if config.ShareExclude == nil {
+		config.ShareExclude = ConfigDefaults.ShareExclude
+	}
+
+	if config.ShareInclude == nil {
+		config.ShareInclude = ConfigDefaults.ShareInclude
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -64,14 +83,50 @@ func New(config *Config) *Collector {
 	return c
 }
 
-func NewWithFlags(_ *kingpin.Application) *Collector {
-	return &Collector{}
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+
+	var shareExclude, shareInclude string
+
+	app.Flag(
+		"collector.smb.share-exclude",
+		"Regexp of SMB server shares to exclude. Share name must both match include and not match exclude to be included.",
+	).Default("").StringVar(&shareExclude)
+
+	app.Flag(
+		"collector.smb.share-include",
+		"Regexp of SMB server shares to include. Share name must both match include and not match exclude to be included.",
+	).Default(".+").StringVar(&shareInclude)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		var err error
+
+		c.config.ShareExclude, err = regexp.Compile(fmt.Sprintf("^(?:%s)$", shareExclude))
+		if err != nil {
+			return fmt.Errorf("collector.smb.share-exclude: %w", err)
+		}
+
+		c.config.ShareInclude, err = regexp.Compile(fmt.Sprintf("^(?:%s)$", shareInclude))
+		if err != nil {
+			return fmt.Errorf("collector.smb.share-include: %w", err)
+		}
+
+		return nil
+	})
+
+	return c
 }
 
 func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH}
+}
+
 func (c *Collector) Close() error {
 	c.perfDataCollector.Close()
 
@@ -128,9 +183,35 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 	)
 
+	c.readBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "server_share_read_bytes_total"),
+		"Bytes read from the SMB Server Share",
+		[]string{"share"},
+		nil,
+	)
+	c.writeBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "server_share_write_bytes_total"),
+		"Bytes written to the SMB Server Share",
+		[]string{"share"},
+		nil,
+	)
+	c.latencySeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "server_share_avg_latency_seconds_total"),
+		"Cumulative time spent processing requests on the SMB Server Share. "+
+			"Divide by windows_smb_server_share_avg_latency_operations_total to get the average latency",
+		[]string{"share", "operation"},
+		nil,
+	)
+	c.latencyOperations = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "server_share_avg_latency_operations_total"),
+		"Requests processed on the SMB Server Share, which are accounted in windows_smb_server_share_avg_latency_seconds_total",
+		[]string{"share", "operation"},
+		nil,
+	)
+
 	var err error
 
-	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "SMB Server Shares", pdh.InstancesAll)
+	c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](logger.With(slog.String("collector", Name)), pdh.CounterTypeRaw, "SMB Server Shares", c.shareInstances())
 	if err != nil {
 		return fmt.Errorf("failed to create SMB Server Shares collector: %w", err)
 	}
@@ -146,6 +227,11 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 	}
 
 	for _, data := range c.perfDataObject {
+		if c.config.ShareExclude.MatchString(data.Name) ||
+			!c.config.ShareInclude.MatchString(data.Name) {
+			continue
+		}
+
 		ch <- prometheus.MustNewConstMetric(
 			c.currentOpenFileCount,
 			prometheus.CounterValue,
@@ -201,7 +287,58 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 			data.FilesOpened,
 			data.Name,
 		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.readBytes,
+			prometheus.CounterValue,
+			data.ReadBytes,
+			data.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.writeBytes,
+			prometheus.CounterValue,
+			data.WriteBytes,
+			data.Name,
+		)
+
+		for _, latency := range []struct {
+			operation  string
+			seconds    float64
+			operations float64
+		}{
+			{"read", data.AvgSecPerRead, data.AvgSecPerReadBase},
+			{"write", data.AvgSecPerWrite, data.AvgSecPerWriteBase},
+			{"all", data.AvgSecPerRequest, data.AvgSecPerRequestBase},
+		} {
+			ch <- prometheus.MustNewConstMetric(
+				c.latencySeconds,
+				prometheus.CounterValue,
+				latency.seconds*pdh.TicksToSecondScaleFactor,
+				data.Name,
+				latency.operation,
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				c.latencyOperations,
+				prometheus.CounterValue,
+				latency.operations,
+				data.Name,
+				latency.operation,
+			)
+		}
 	}
 
 	return nil
 }
+
+// shareInstances returns the instances of the SMB Server Shares object matching the include filter.
+// Pushing the filter into the counter path avoids expanding all shares in PDH.
+func (c *Collector) shareInstances() []string {
+	patterns, ok := pdh.InstancesFromRegexp(c.config.ShareInclude)
+	if !ok {
+		return pdh.InstancesAll
+	}
+
+	return patterns
+}
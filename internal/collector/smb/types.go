@@ -28,4 +28,12 @@ type perfDataCounterValues struct {
 	MetadataRequests     float64 `perfdata:"Metadata Requests/sec"`
 	SentBytes            float64 `perfdata:"Sent Bytes/sec"`
 	FilesOpened          float64 `perfdata:"Files Opened/sec"`
+	ReadBytes            float64 `perfdata:"Read Bytes/sec"`
+	WriteBytes           float64 `perfdata:"Write Bytes/sec"`
+	AvgSecPerRead        float64 `perfdata:"Avg. sec/Read"`
+	AvgSecPerReadBase    float64 `perfdata:"Avg. sec/Read,secondvalue"`
+	AvgSecPerWrite       float64 `perfdata:"Avg. sec/Write"`
+	AvgSecPerWriteBase   float64 `perfdata:"Avg. sec/Write,secondvalue"`
+	AvgSecPerRequest     float64 `perfdata:"Avg. sec/Request"`
+	AvgSecPerRequestBase float64 `perfdata:"Avg. sec/Request,secondvalue"`
 }
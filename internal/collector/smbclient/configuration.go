@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package smbclient
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows/registry"
+)
+
+const (
+	// configurationTTL is the duration for which the client configuration is cached.
+	configurationTTL = 5 * time.Minute
+
+	lanmanWorkstationParametersKey = `SYSTEM\CurrentControlSet\Services\LanmanWorkstation\Parameters`
+	mrxSMB10Key                    = `SYSTEM\CurrentControlSet\Services\mrxsmb10`
+
+	// serviceDisabled is the Start value of a disabled service.
+	serviceDisabled = 4
+)
+
+// registryDWORDReader reads a DWORD value from HKEY_LOCAL_MACHINE.
+// It returns [registry.ErrNotExist] if the key or the value does not exist.
+type registryDWORDReader func(path, name string) (uint64, error)
+
+// smbClientConfiguration is the subset of MSFT_SmbClientConfiguration exposed by the collector.
+//
+// https://learn.microsoft.com/en-us/previous-versions/windows/desktop/smb/msft-smbclientconfiguration
+type smbClientConfiguration struct {
+	RequireSecuritySignature  bool `mi:"RequireSecuritySignature"`
+	EnableInsecureGuestLogons bool `mi:"EnableInsecureGuestLogons"`
+}
+
+// clientConfiguration is the SMB client security posture. Settings, which are unknown, are nil.
+type clientConfiguration struct {
+	signingRequired          *bool
+	insecureGuestAuthEnabled *bool
+	smb1Enabled              bool
+	updated                  time.Time
+}
+
+func (c *Collector) buildConfiguration(miSession *mi.Session) error {
+	if c.readRegistryDWORD == nil {
+		c.readRegistryDWORD = readLocalMachineDWORD
+	}
+
+	c.signingRequired = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "signing_required"),
+		"Whether the SMB client requires packet signing (RequireSecuritySignature)",
+		nil,
+		nil,
+	)
+	c.insecureGuestAuthEnabled = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "insecure_guest_auth_enabled"),
+		"Whether the SMB client allows insecure guest logons (EnableInsecureGuestLogons)",
+		nil,
+		nil,
+	)
+	c.smb1Enabled = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "smb1_enabled"),
+		"Whether the SMB 1.0 client driver (MRxSmb10) is installed and not disabled",
+		nil,
+		nil,
+	)
+
+	c.miSession = miSession
+
+	var err error
+
+	c.miQueryConfiguration, err = mi.NewQuery("SELECT RequireSecuritySignature, EnableInsecureGuestLogons FROM MSFT_SmbClientConfiguration")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	return nil
+}
+
+// collectConfiguration sends the SMB client security posture.
+// The configuration is read from MSFT_SmbClientConfiguration and, if WMI is not available,
+// from the LanmanWorkstation registry key. It is cached for configurationTTL.
+func (c *Collector) collectConfiguration(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	if time.Since(c.configuration.updated) >= configurationTTL {
+		configuration, err := c.readConfiguration(maxScrapeDuration)
+		if err != nil {
+			return err
+		}
+
+		c.configuration = configuration
+	}
+
+	if c.configuration.signingRequired != nil {
+		ch <- prometheus.MustNewConstMetric(
+			c.signingRequired,
+			prometheus.GaugeValue,
+			utils.BoolToFloat(*c.configuration.signingRequired),
+		)
+	}
+
+	if c.configuration.insecureGuestAuthEnabled != nil {
+		ch <- prometheus.MustNewConstMetric(
+			c.insecureGuestAuthEnabled,
+			prometheus.GaugeValue,
+			utils.BoolToFloat(*c.configuration.insecureGuestAuthEnabled),
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.smb1Enabled,
+		prometheus.GaugeValue,
+		utils.BoolToFloat(c.configuration.smb1Enabled),
+	)
+
+	return nil
+}
+
+func (c *Collector) readConfiguration(maxScrapeDuration time.Duration) (clientConfiguration, error) {
+	var (
+		configuration clientConfiguration
+		err           error
+	)
+
+	var dst []smbClientConfiguration
+
+	if c.miSession != nil {
+		err = c.miSession.Query(&dst, mi.NamespaceRootSMB, c.miQueryConfiguration, maxScrapeDuration)
+	}
+
+	if c.miSession == nil || err != nil || len(dst) == 0 {
+		c.logger.Debug("MSFT_SmbClientConfiguration is not available, falling back to the registry",
+			slog.Any("err", err),
+		)
+
+		configuration, err = readRegistryConfiguration(c.readRegistryDWORD)
+		if err != nil {
+			return clientConfiguration{}, err
+		}
+	} else {
+		configuration.signingRequired = &dst[0].RequireSecuritySignature
+		configuration.insecureGuestAuthEnabled = &dst[0].EnableInsecureGuestLogons
+	}
+
+	configuration.smb1Enabled, err = readSMB1Enabled(c.readRegistryDWORD)
+	if err != nil {
+		return clientConfiguration{}, err
+	}
+
+	configuration.updated = time.Now()
+
+	return configuration, nil
+}
+
+// readRegistryConfiguration reads the SMB client configuration from the LanmanWorkstation parameters.
+// Settings, which are not configured in the registry, are left nil.
+//
+// https://learn.microsoft.com/en-us/troubleshoot/windows-server/networking/guest-access-in-smb2-is-disabled-by-default
+func readRegistryConfiguration(readDWORD registryDWORDReader) (clientConfiguration, error) {
+	var configuration clientConfiguration
+
+	for name, setting := range map[string]**bool{
+		"RequireSecuritySignature": &configuration.signingRequired,
+		"AllowInsecureGuestAuth":   &configuration.insecureGuestAuthEnabled,
+	} {
+		value, err := readDWORD(lanmanWorkstationParametersKey, name)
+
+		switch {
+		case err == nil:
+			enabled := value != 0
+			*setting = &enabled
+		case errors.Is(err, registry.ErrNotExist):
+		default:
+			return clientConfiguration{}, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+	}
+
+	return configuration, nil
+}
+
+// readSMB1Enabled reports whether the SMB 1.0 client driver is installed and not disabled.
+//
+// https://learn.microsoft.com/en-us/windows-server/storage/file-server/troubleshoot/detect-enable-and-disable-smbv1-v2-v3
+func readSMB1Enabled(readDWORD registryDWORDReader) (bool, error) {
+	start, err := readDWORD(mrxSMB10Key, "Start")
+
+	switch {
+	case err == nil:
+		return start != serviceDisabled, nil
+	case errors.Is(err, registry.ErrNotExist):
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to read MRxSmb10 start type: %w", err)
+	}
+}
+
+func readLocalMachineDWORD(path, name string) (uint64, error) {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, path, registry.QUERY_VALUE)
+	if err != nil {
+		return 0, err
+	}
+
+	defer k.Close()
+
+	value, _, err := k.GetIntegerValue(name)
+
+	return value, err
+}
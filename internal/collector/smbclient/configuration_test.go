@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package smbclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows/registry"
+)
+
+func TestReadRegistryConfiguration(t *testing.T) {
+	t.Parallel()
+
+	readDWORD := func(path, name string) (uint64, error) {
+		require.Equal(t, lanmanWorkstationParametersKey, path)
+
+		if name == "RequireSecuritySignature" {
+			return 1, nil
+		}
+
+		return 0, registry.ErrNotExist
+	}
+
+	configuration, err := readRegistryConfiguration(readDWORD)
+	require.NoError(t, err)
+	require.NotNil(t, configuration.signingRequired)
+	require.True(t, *configuration.signingRequired)
+	require.Nil(t, configuration.insecureGuestAuthEnabled)
+
+	_, err = readRegistryConfiguration(func(string, string) (uint64, error) {
+		return 0, registry.ErrUnexpectedType
+	})
+	require.ErrorIs(t, err, registry.ErrUnexpectedType)
+}
+
+func TestReadSMB1Enabled(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		start    uint64
+		err      error
+		expected bool
+	}{
+		{"manual", 3, nil, true},
+		{"disabled", serviceDisabled, nil, false},
+		{"removed", 0, registry.ErrNotExist, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			enabled, err := readSMB1Enabled(func(path, name string) (uint64, error) {
+				require.Equal(t, mrxSMB10Key, path)
+				require.Equal(t, "Start", name)
+
+				return tc.start, tc.err
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, enabled)
+		})
+	}
+}
@@ -18,6 +18,7 @@
 package smbclient
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -41,10 +42,16 @@ var ConfigDefaults = Config{}
 
 type Collector struct {
 	config Config
+	logger *slog.Logger
 
 	perfDataCollector *pdh.Collector
 	perfDataObject    []perfDataCounterValues
 
+	miSession            *mi.Session
+	miQueryConfiguration mi.Query
+	readRegistryDWORD    registryDWORDReader
+	configuration        clientConfiguration
+
 	readBytesTotal                            *prometheus.Desc
 	readBytesTransmittedViaSMBDirectTotal     *prometheus.Desc
 	readRequestQueueSecsTotal                 *prometheus.Desc
@@ -67,6 +74,10 @@ type Collector struct {
 	metadataRequestsTotal *prometheus.Desc
 	requestQueueSecsTotal *prometheus.Desc
 	requestSecs           *prometheus.Desc
+
+	signingRequired          *prometheus.Desc
+	insecureGuestAuthEnabled *prometheus.Desc
+	smb1Enabled              *prometheus.Desc
 }
 
 func New(config *Config) *Collector {
@@ -89,11 +100,17 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceWMI}
+}
+
 func (c *Collector) Close() error {
 	return nil
 }
 
-func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
+	c.logger = logger.With(slog.String("collector", Name))
+
 	// desc creates a new prometheus description
 	desc := func(metricName string, description string, labels []string) *prometheus.Desc {
 		return prometheus.NewDesc(
@@ -196,11 +213,26 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		return fmt.Errorf("failed to create SMB Client Shares collector: %w", err)
 	}
 
-	return nil
+	return c.buildConfiguration(miSession)
 }
 
 // Collect collects smb client metrics and sends them to prometheus.
-func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	errs := make([]error, 0)
+
+	if err := c.collectShares(ch); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := c.collectConfiguration(ch, maxScrapeDuration); err != nil {
+		errs = append(errs, fmt.Errorf("failed to collect SMB client configuration: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// collectShares collects the SMB Client Shares performance counters.
+func (c *Collector) collectShares(ch chan<- prometheus.Metric) error {
 	err := c.perfDataCollector.Collect(&c.perfDataObject)
 	if err != nil {
 		return fmt.Errorf("failed to collect SMB Client Shares metrics: %w", err)
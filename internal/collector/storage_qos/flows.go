@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package storage_qos
+
+// flowStatuses maps the Status property of MSFT_StorageQoSFlow to a label value.
+//
+//nolint:gochecknoglobals
+var flowStatuses = map[uint16]string{
+	0: "ok",
+	1: "insufficient_throughput",
+	2: "unknown_policy_id",
+	3: "lost_communication",
+}
+
+// policyType returns the label value for the PolicyType property of MSFT_StorageQoSPolicy.
+func policyType(t uint16) string {
+	switch t {
+	case 1:
+		return "aggregated"
+	case 2:
+		return "dedicated"
+	default:
+		return "unknown"
+	}
+}
+
+// dedupFlows merges flows with the same initiator and file path.
+// A virtual disk opened through multiple storage nodes reports one flow per node.
+// IOPS and bandwidth are summed, the latency is weighted by IOPS and
+// the status of the first unhealthy flow wins.
+func dedupFlows(flows []msftStorageQoSFlow) []msftStorageQoSFlow {
+	type flowKey struct {
+		vm   string
+		file string
+	}
+
+	merged := make([]msftStorageQoSFlow, 0, len(flows))
+	index := make(map[flowKey]int, len(flows))
+
+	for _, flow := range flows {
+		key := flowKey{vm: flow.InitiatorName, file: flow.FilePath}
+
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(merged)
+			merged = append(merged, flow)
+
+			continue
+		}
+
+		existing := &merged[i]
+
+		if totalIOPS := existing.InitiatorIOPS + flow.InitiatorIOPS; totalIOPS > 0 {
+			existing.InitiatorLatency = (existing.InitiatorLatency*float64(existing.InitiatorIOPS) +
+				flow.InitiatorLatency*float64(flow.InitiatorIOPS)) / float64(totalIOPS)
+		} else {
+			existing.InitiatorLatency = max(existing.InitiatorLatency, flow.InitiatorLatency)
+		}
+
+		existing.InitiatorIOPS += flow.InitiatorIOPS
+		existing.InitiatorBandwidth += flow.InitiatorBandwidth
+
+		if existing.Status == 0 {
+			existing.Status = flow.Status
+		}
+
+		if existing.PolicyID == "" {
+			existing.PolicyID = flow.PolicyID
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package storage_qos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupFlows(t *testing.T) {
+	t.Parallel()
+
+	flows := []msftStorageQoSFlow{
+		{InitiatorName: "vm1", FilePath: `C:\ClusterStorage\Volume1\vm1.vhdx`, PolicyID: "p1", InitiatorIOPS: 100, InitiatorLatency: 2, InitiatorBandwidth: 1000},
+		{InitiatorName: "vm2", FilePath: `C:\ClusterStorage\Volume1\vm2.vhdx`, InitiatorIOPS: 10, InitiatorLatency: 1},
+		{InitiatorName: "vm1", FilePath: `C:\ClusterStorage\Volume1\vm1.vhdx`, PolicyID: "p1", Status: 1, InitiatorIOPS: 300, InitiatorLatency: 6, InitiatorBandwidth: 3000},
+	}
+
+	merged := dedupFlows(flows)
+
+	require.Len(t, merged, 2)
+	require.Equal(t, "vm1", merged[0].InitiatorName)
+	require.Equal(t, uint64(400), merged[0].InitiatorIOPS)
+	require.Equal(t, uint64(4000), merged[0].InitiatorBandwidth)
+	require.InDelta(t, 5.0, merged[0].InitiatorLatency, 1e-9)
+	require.Equal(t, uint16(1), merged[0].Status)
+	require.Equal(t, "vm2", merged[1].InitiatorName)
+	require.Equal(t, uint64(10), merged[1].InitiatorIOPS)
+}
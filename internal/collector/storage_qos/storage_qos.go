@@ -0,0 +1,283 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package storage_qos
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Name = "storage_qos"
+
+type Config struct{}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{}
+
+// A Collector is a Prometheus Collector for Storage QoS flows and policies.
+// Storage QoS is available on Scale-Out File Servers and Hyper-V hosts using
+// Cluster Shared Volumes.
+type Collector struct {
+	config          Config
+	miSession       *mi.Session
+	miQueryFlows    mi.Query
+	miQueryPolicies mi.Query
+
+	flowNormalizedIOPS *prometheus.Desc
+	flowLatency        *prometheus.Desc
+	flowBandwidth      *prometheus.Desc
+	flowStatus         *prometheus.Desc
+	policyMinimumIOPS  *prometheus.Desc
+	policyMaximumIOPS  *prometheus.Desc
+	policyMaxBandwidth *prometheus.Desc
+	policyInfo         *prometheus.Desc
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(_ *kingpin.Application) *Collector {
+	return &Collector{}
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceWMI}
+}
+
+func (c *Collector) Close() error {
+	return nil
+}
+
+func (c *Collector) Build(_ *slog.Logger, miSession *mi.Session) error {
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	miQueryFlows, err := mi.NewQuery("SELECT * FROM MSFT_StorageQoSFlow")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	miQueryPolicies, err := mi.NewQuery("SELECT * FROM MSFT_StorageQoSPolicy")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQueryFlows = miQueryFlows
+	c.miQueryPolicies = miQueryPolicies
+	c.miSession = miSession
+
+	c.flowNormalizedIOPS = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "flow_normalized_iops"),
+		"Average normalized IOPS of the flow as seen by the initiator. (InitiatorIOPS)",
+		[]string{"vm", "file", "policy"},
+		nil,
+	)
+	c.flowLatency = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "flow_latency_seconds"),
+		"Average I/O latency of the flow as seen by the initiator. (InitiatorLatency)",
+		[]string{"vm", "file", "policy"},
+		nil,
+	)
+	c.flowBandwidth = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "flow_bandwidth_bytes_per_second"),
+		"Average bandwidth of the flow as seen by the initiator. (InitiatorBandwidth)",
+		[]string{"vm", "file", "policy"},
+		nil,
+	)
+	c.flowStatus = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "flow_status"),
+		"Status of the flow. (Status)",
+		[]string{"vm", "file", "policy", "status"},
+		nil,
+	)
+	c.policyMinimumIOPS = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "policy_minimum_iops"),
+		"Minimum normalized IOPS reserved by the policy. 0 means no reservation. (MinimumIops)",
+		[]string{"policy"},
+		nil,
+	)
+	c.policyMaximumIOPS = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "policy_maximum_iops"),
+		"Maximum normalized IOPS allowed by the policy. 0 means unlimited. (MaximumIops)",
+		[]string{"policy"},
+		nil,
+	)
+	c.policyMaxBandwidth = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "policy_maximum_bandwidth_bytes_per_second"),
+		"Maximum bandwidth allowed by the policy. 0 means unlimited. (MaximumIOBandwidth)",
+		[]string{"policy"},
+		nil,
+	)
+	c.policyInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "policy_info"),
+		"Information about the Storage QoS policy.",
+		[]string{"policy", "policy_id", "type"},
+		nil,
+	)
+
+	var dst []msftStorageQoSPolicy
+	if err := c.miSession.Query(&dst, mi.NamespaceRootStorage, c.miQueryPolicies, 0); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+// MSFT_StorageQoSFlow docs:
+// https://learn.microsoft.com/en-us/previous-versions/windows/desktop/stg/msft-storageqosflow
+type msftStorageQoSFlow struct {
+	FlowID             string  `mi:"FlowId"`
+	InitiatorName      string  `mi:"InitiatorName"`
+	FilePath           string  `mi:"FilePath"`
+	PolicyID           string  `mi:"PolicyId"`
+	Status             uint16  `mi:"Status"`
+	InitiatorIOPS      uint64  `mi:"InitiatorIOPS"`
+	InitiatorLatency   float64 `mi:"InitiatorLatency"`
+	InitiatorBandwidth uint64  `mi:"InitiatorBandwidth"`
+}
+
+// MSFT_StorageQoSPolicy docs:
+// https://learn.microsoft.com/en-us/previous-versions/windows/desktop/stg/msft-storageqospolicy
+type msftStorageQoSPolicy struct {
+	PolicyID           string `mi:"PolicyId"`
+	Name               string `mi:"Name"`
+	PolicyType         uint16 `mi:"PolicyType"`
+	MinimumIops        uint64 `mi:"MinimumIops"`
+	MaximumIops        uint64 `mi:"MaximumIops"`
+	MaximumIOBandwidth uint64 `mi:"MaximumIOBandwidth"`
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	var policies []msftStorageQoSPolicy
+	if err := c.miSession.Query(&policies, mi.NamespaceRootStorage, c.miQueryPolicies, maxScrapeDuration); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	// Flows reference their policy by ID, map it to the policy name for readable labels.
+	policyNames := make(map[string]string, len(policies))
+
+	for _, policy := range policies {
+		policyNames[policy.PolicyID] = policy.Name
+
+		ch <- prometheus.MustNewConstMetric(
+			c.policyInfo,
+			prometheus.GaugeValue,
+			1,
+			policy.Name,
+			policy.PolicyID,
+			policyType(policy.PolicyType),
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.policyMinimumIOPS,
+			prometheus.GaugeValue,
+			float64(policy.MinimumIops),
+			policy.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.policyMaximumIOPS,
+			prometheus.GaugeValue,
+			float64(policy.MaximumIops),
+			policy.Name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.policyMaxBandwidth,
+			prometheus.GaugeValue,
+			float64(policy.MaximumIOBandwidth),
+			policy.Name,
+		)
+	}
+
+	// Flows are created and removed with the VM lifecycle. Since all metrics are
+	// rebuilt on every scrape, flows of stopped or migrated VMs disappear automatically.
+	var flows []msftStorageQoSFlow
+	if err := c.miSession.Query(&flows, mi.NamespaceRootStorage, c.miQueryFlows, maxScrapeDuration); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	for _, flow := range dedupFlows(flows) {
+		policy := policyNames[flow.PolicyID]
+
+		ch <- prometheus.MustNewConstMetric(
+			c.flowNormalizedIOPS,
+			prometheus.GaugeValue,
+			float64(flow.InitiatorIOPS),
+			flow.InitiatorName,
+			flow.FilePath,
+			policy,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.flowLatency,
+			prometheus.GaugeValue,
+			utils.MilliSecToSec(flow.InitiatorLatency),
+			flow.InitiatorName,
+			flow.FilePath,
+			policy,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.flowBandwidth,
+			prometheus.GaugeValue,
+			float64(flow.InitiatorBandwidth),
+			flow.InitiatorName,
+			flow.FilePath,
+			policy,
+		)
+
+		for status, name := range flowStatuses {
+			ch <- prometheus.MustNewConstMetric(
+				c.flowStatus,
+				prometheus.GaugeValue,
+				utils.BoolToFloat(flow.Status == status),
+				flow.InitiatorName,
+				flow.FilePath,
+				policy,
+				name,
+			)
+		}
+	}
+
+	return nil
+}
@@ -21,8 +21,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
@@ -38,12 +40,25 @@ import (
 const (
 	Name                             = "terminal_services"
 	ConnectionBrokerFeatureID uint32 = 133
+
+	subCollectorMetrics      = "metrics"
+	subCollectorMappedDrives = "mapped_drives"
 )
 
-type Config struct{}
+type Config struct {
+	CollectorsEnabled []string `yaml:"enabled"`
+	// MappedDrivesTarget exposes the UNC path of mapped network drives.
+	// Disabled by default, since the path may contain personal information.
+	MappedDrivesTarget bool `yaml:"mapped-drives-target"`
+}
 
 //nolint:gochecknoglobals
-var ConfigDefaults = Config{}
+var ConfigDefaults = Config{
+	CollectorsEnabled: []string{
+		subCollectorMetrics,
+	},
+	MappedDrivesTarget: false,
+}
 
 type Win32_ServerFeature struct {
 	ID uint32
@@ -83,6 +98,9 @@ type Collector struct {
 
 	hServer windows.Handle
 
+	// sidCache maps DOMAIN\user to the SID string of the account.
+	sidCache sync.Map
+
 	sessionInfo                 *prometheus.Desc
 	connectionBrokerPerformance *prometheus.Desc
 	handleCount                 *prometheus.Desc
@@ -98,6 +116,9 @@ type Collector struct {
 	virtualBytesPeak            *prometheus.Desc
 	workingSet                  *prometheus.Desc
 	workingSetPeak              *prometheus.Desc
+
+	sessionMappedDrives    *prometheus.Desc
+	sessionMappedDriveInfo *prometheus.Desc
 }
 
 func New(config *Config) *Collector {
@@ -105,6 +126,10 @@ func New(config *Config) *Collector {
 		config = &ConfigDefaults
 	}
 
+	if config.CollectorsEnabled == nil {
+		config.CollectorsEnabled = ConfigDefaults.CollectorsEnabled
+	}
+
 	c := &Collector{
 		config: *config,
 	}
@@ -112,14 +137,41 @@ func New(config *Config) *Collector {
 	return c
 }
 
-func NewWithFlags(_ *kingpin.Application) *Collector {
-	return &Collector{}
+func NewWithFlags(app *kingpin.Application) *Collector {
+	c := &Collector{
+		config: ConfigDefaults,
+	}
+	c.config.CollectorsEnabled = make([]string, 0)
+
+	var collectorsEnabled string
+
+	app.Flag(
+		"collector.terminal_services.enabled",
+		"Comma-separated list of collectors to use. Available collectors: metrics, mapped_drives.",
+	).Default(strings.Join(ConfigDefaults.CollectorsEnabled, ",")).StringVar(&collectorsEnabled)
+
+	app.Flag(
+		"collector.terminal_services.mapped-drives-target",
+		"Expose the UNC path of mapped network drives in windows_terminal_services_session_mapped_drive_info.",
+	).Default(strconv.FormatBool(ConfigDefaults.MappedDrivesTarget)).BoolVar(&c.config.MappedDrivesTarget)
+
+	app.Action(func(*kingpin.ParseContext) error {
+		c.config.CollectorsEnabled = strings.Split(collectorsEnabled, ",")
+
+		return nil
+	})
+
+	return c
 }
 
 func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceWMI, types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
 	err := wtsapi32.WTSCloseServer(c.hServer)
 	if err != nil {
@@ -138,6 +190,35 @@ func (c *Collector) Close() error {
 func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 	c.logger = logger.With(slog.String("collector", Name))
 
+	for _, collector := range c.config.CollectorsEnabled {
+		if !slices.Contains([]string{subCollectorMetrics, subCollectorMappedDrives}, collector) {
+			return fmt.Errorf("unknown sub collector: %s. Possible values: %s", collector,
+				strings.Join([]string{subCollectorMetrics, subCollectorMappedDrives}, ", "),
+			)
+		}
+	}
+
+	var err error
+
+	c.hServer, err = wtsapi32.WTSOpenServer("")
+	if err != nil {
+		return fmt.Errorf("failed to open WTS server: %w", err)
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+		if err := c.buildMetrics(miSession); err != nil {
+			return err
+		}
+	}
+
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMappedDrives) {
+		c.buildMappedDrives()
+	}
+
+	return nil
+}
+
+func (c *Collector) buildMetrics(miSession *mi.Session) error {
 	c.sessionInfo = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "session_info"),
 		"Terminal Services sessions info",
@@ -243,12 +324,7 @@ func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 			return fmt.Errorf("failed to create Remote Desktop Connection Broker Counterset collector: %w", err)
 		}
 	} else {
-		logger.Debug("host is not a connection broker skipping Connection Broker performance metrics.")
-	}
-
-	c.hServer, err = wtsapi32.WTSOpenServer("")
-	if err != nil {
-		return fmt.Errorf("failed to open WTS server: %w", err)
+		c.logger.Debug("host is not a connection broker skipping Connection Broker performance metrics.")
 	}
 
 	c.perfDataCollectorTerminalServicesSession, err = pdh.NewCollector[perfDataCounterValuesTerminalServicesSession](c.logger, pdh.CounterTypeRaw, "Terminal Services Session", pdh.InstancesAll)
@@ -264,18 +340,26 @@ func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
 func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
 	errs := make([]error, 0)
 
-	if err := c.collectWTSSessions(ch); err != nil {
-		errs = append(errs, fmt.Errorf("failed collecting terminal services session infos: %w", err))
-	}
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMetrics) {
+		if err := c.collectWTSSessions(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed collecting terminal services session infos: %w", err))
+		}
+
+		if err := c.collectTSSessionCounters(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed collecting terminal services session count metrics: %w", err))
+		}
 
-	if err := c.collectTSSessionCounters(ch); err != nil {
-		errs = append(errs, fmt.Errorf("failed collecting terminal services session count metrics: %w", err))
+		// only collect CollectionBrokerPerformance if host is a Connection Broker
+		if c.connectionBrokerEnabled {
+			if err := c.collectCollectionBrokerPerformanceCounter(ch); err != nil {
+				errs = append(errs, fmt.Errorf("failed collecting Connection Broker performance metrics: %w", err))
+			}
+		}
 	}
 
-	// only collect CollectionBrokerPerformance if host is a Connection Broker
-	if c.connectionBrokerEnabled {
-		if err := c.collectCollectionBrokerPerformanceCounter(ch); err != nil {
-			errs = append(errs, fmt.Errorf("failed collecting Connection Broker performance metrics: %w", err))
+	if slices.Contains(c.config.CollectorsEnabled, subCollectorMappedDrives) {
+		if err := c.collectMappedDrives(ch); err != nil {
+			errs = append(errs, fmt.Errorf("failed collecting mapped network drives: %w", err))
 		}
 	}
 
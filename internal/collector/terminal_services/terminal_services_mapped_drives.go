@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package terminal_services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/wtsapi32"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+type mappedDrive struct {
+	drive  string
+	target string
+}
+
+func (c *Collector) buildMappedDrives() {
+	c.sessionMappedDrives = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "session_mapped_drives"),
+		"Number of persistent network drives mapped by the user of the session.",
+		[]string{"session"},
+		nil,
+	)
+	c.sessionMappedDriveInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "session_mapped_drive_info"),
+		"Persistent network drive mapped by the user of the session. The target is only exposed if enabled. The reachability of the target is not checked.",
+		[]string{"session", "drive", "target"},
+		nil,
+	)
+}
+
+// collectMappedDrives exposes the persistent network drives of each session with a logged-on user.
+// The drives are read from HKEY_USERS\<sid>\Network, which is available while the user profile is loaded.
+func (c *Collector) collectMappedDrives(ch chan<- prometheus.Metric) error {
+	sessions, err := wtsapi32.WTSEnumerateSessionsEx(c.hServer, c.logger)
+	if err != nil {
+		return fmt.Errorf("failed to enumerate WTS sessions: %w", err)
+	}
+
+	errs := make([]error, 0)
+
+	for _, session := range sessions {
+		if session.UserName == "" {
+			continue
+		}
+
+		sessionID := strconv.FormatUint(uint64(session.SessionID), 10)
+
+		drives, err := c.getMappedDrives(session.DomainName, session.UserName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("session %s: %w", sessionID, err))
+
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.sessionMappedDrives,
+			prometheus.GaugeValue,
+			float64(len(drives)),
+			sessionID,
+		)
+
+		for _, drive := range drives {
+			target := ""
+			if c.config.MappedDrivesTarget {
+				target = drive.target
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.sessionMappedDriveInfo,
+				prometheus.GaugeValue,
+				1,
+				sessionID,
+				drive.drive,
+				target,
+			)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Collector) getMappedDrives(domain, user string) ([]mappedDrive, error) {
+	sid, err := c.lookupSID(domain, user)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := registry.OpenKey(registry.USERS, sid+`\Network`, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		// The key does not exist if the user has no persistent mappings.
+		if errors.Is(err, registry.ErrNotExist) {
+			return []mappedDrive{}, nil
+		}
+
+		return nil, fmt.Errorf("failed to open network drives of %s: %w", sid, err)
+	}
+
+	defer func(key registry.Key) {
+		_ = key.Close()
+	}(key)
+
+	driveLetters, err := key.ReadSubKeyNames(-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read network drives of %s: %w", sid, err)
+	}
+
+	drives := make([]mappedDrive, 0, len(driveLetters))
+
+	for _, driveLetter := range driveLetters {
+		drive := mappedDrive{
+			drive: strings.ToUpper(driveLetter) + ":",
+		}
+
+		driveKey, err := registry.OpenKey(key, driveLetter, registry.QUERY_VALUE)
+		if err == nil {
+			drive.target, _, err = driveKey.GetStringValue("RemotePath")
+			_ = driveKey.Close()
+		}
+
+		if err != nil {
+			c.logger.Debug("failed to read remote path of network drive",
+				slog.String("drive", drive.drive),
+				slog.Any("err", err),
+			)
+		}
+
+		drives = append(drives, drive)
+	}
+
+	return drives, nil
+}
+
+// lookupSID returns the SID string of the given account. The result is cached,
+// since resolving domain accounts may require a round trip to a domain controller.
+func (c *Collector) lookupSID(domain, user string) (string, error) {
+	account := user
+	if domain != "" {
+		account = domain + `\` + user
+	}
+
+	if sidVal, ok := c.sidCache.Load(account); ok {
+		if sid, ok := sidVal.(string); ok {
+			return sid, nil
+		}
+	}
+
+	sid, _, _, err := windows.LookupSID("", account)
+	if err != nil {
+		return "", fmt.Errorf("failed to lookup SID of %s: %w", account, err)
+	}
+
+	sidString := sid.String()
+	c.sidCache.Store(account, sidString)
+
+	return sidString, nil
+}
@@ -31,3 +31,9 @@ func BenchmarkCollector(b *testing.B) {
 func TestCollector(t *testing.T) {
 	testutils.TestCollector(t, terminal_services.New, nil)
 }
+
+func TestCollectorMappedDrives(t *testing.T) {
+	testutils.TestCollector(t, terminal_services.New, &terminal_services.Config{
+		CollectorsEnabled: []string{"mapped_drives"},
+	})
+}
@@ -102,6 +102,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceFile}
+}
+
 func (c *Collector) Close() error {
 	return nil
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/osversion"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sys/windows"
 	"golang.org/x/sys/windows/registry"
@@ -68,12 +69,15 @@ type Collector struct {
 
 	ppbCounterPresent bool
 
+	clockOffset clockOffsetCache
+
 	currentTime                     *prometheus.Desc
 	timezone                        *prometheus.Desc
 	clockSource                     *prometheus.Desc
 	clockFrequencyAdjustment        *prometheus.Desc
 	clockFrequencyAdjustmentPPB     *prometheus.Desc
 	computedTimeOffset              *prometheus.Desc
+	serviceRunning                  *prometheus.Desc
 	ntpClientTimeSourceCount        *prometheus.Desc
 	ntpRoundTripDelay               *prometheus.Desc
 	ntpServerIncomingRequestsTotal  *prometheus.Desc
@@ -122,6 +126,10 @@ func (c *Collector) GetName() string {
 	return Name
 }
 
+func (c *Collector) GetSources() []string {
+	return []string{types.SourcePDH, types.SourceAPI}
+}
+
 func (c *Collector) Close() error {
 	if slices.Contains(c.config.CollectorsEnabled, collectorNTP) {
 		c.perfDataCollector.Close()
@@ -178,6 +186,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 		nil,
 	)
+	c.serviceRunning = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "service_running"),
+		"Whether the Windows Time service (W32Time) is running.",
+		nil,
+		nil,
+	)
 	c.ntpClientTimeSourceCount = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "ntp_client_time_sources"),
 		"Active number of NTP Time sources being used by the client",
@@ -208,7 +222,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 		c.perfDataCollector, err = pdh.NewCollector[perfDataCounterValues](c.logger, pdh.CounterTypeRaw, "Windows Time Service", nil)
 		if err != nil {
-			return fmt.Errorf("failed to create Windows Time Service collector: %w", err)
+			c.logger.Warn("Windows Time Service performance counters are not available, measuring the clock offset with SNTP instead",
+				slog.Any("err", err),
+			)
+
+			c.perfDataCollector.Close()
+			c.perfDataCollector = nil
 		}
 	}
 
@@ -217,7 +236,7 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 // Collect sends the metric values for each metric
 // to the provided prometheus Metric channel.
-func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
 	errs := make([]error, 0)
 
 	if slices.Contains(c.config.CollectorsEnabled, collectorSystemTime) {
@@ -233,7 +252,7 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error
 	}
 
 	if slices.Contains(c.config.CollectorsEnabled, collectorNTP) {
-		if err := c.collectNTP(ch); err != nil {
+		if err := c.collectNTP(ch, maxScrapeDuration); err != nil {
 			errs = append(errs, fmt.Errorf("failed collecting time ntp metrics: %w", err))
 		}
 	}
@@ -298,8 +317,39 @@ func (c *Collector) collectClockSource(ch chan<- prometheus.Metric) error {
 	return nil
 }
 
-func (c *Collector) collectNTP(ch chan<- prometheus.Metric) error {
-	err := c.perfDataCollector.Collect(&c.perfDataObject)
+func (c *Collector) collectNTP(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	running, err := w32timeRunning()
+	if err != nil {
+		return err
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.serviceRunning,
+		prometheus.GaugeValue,
+		utils.BoolToFloat(running),
+	)
+
+	// The performance counters are not updated and the time source is not queried while W32Time is stopped.
+	if !running {
+		return nil
+	}
+
+	if c.perfDataCollector == nil {
+		offset, err := c.clockOffset.get(min(sntpTimeout, maxScrapeDuration))
+		if err != nil {
+			return fmt.Errorf("failed to measure clock offset: %w", err)
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.computedTimeOffset,
+			prometheus.GaugeValue,
+			offset,
+		)
+
+		return nil
+	}
+
+	err = c.perfDataCollector.Collect(&c.perfDataObject)
 	if err != nil {
 		return fmt.Errorf("failed to collect Windows Time Service metrics: %w", err)
 	} else if len(c.perfDataObject) == 0 {
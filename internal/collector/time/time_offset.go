@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package time
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+const (
+	// sntpTimeout is the maximum duration of an SNTP request of the clock offset fallback.
+	sntpTimeout = 2 * time.Second
+	// sntpInterval is the minimum interval between two SNTP requests of the clock offset fallback,
+	// so that the NTP server is not queried on every scrape.
+	sntpInterval = 5 * time.Minute
+	// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and the unix epoch (1970).
+	ntpEpochOffset = 2208988800
+	ntpPacketSize  = 48
+	ntpModeServer  = 4
+)
+
+var errNoNTPServer = errors.New("no NTP server configured")
+
+// w32timeRunning reports whether the Windows Time service is running.
+func w32timeRunning() (bool, error) {
+	scm, err := windows.OpenSCManager(nil, nil, windows.SC_MANAGER_CONNECT)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to service manager: %w", err)
+	}
+
+	defer func(handle windows.Handle) {
+		_ = windows.CloseServiceHandle(handle)
+	}(scm)
+
+	serviceName, err := windows.UTF16PtrFromString("W32Time")
+	if err != nil {
+		return false, err
+	}
+
+	service, err := windows.OpenService(scm, serviceName, windows.SERVICE_QUERY_STATUS)
+	if errors.Is(err, windows.ERROR_SERVICE_DOES_NOT_EXIST) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to open W32Time service: %w", err)
+	}
+
+	defer func(handle windows.Handle) {
+		_ = windows.CloseServiceHandle(handle)
+	}(service)
+
+	var status windows.SERVICE_STATUS
+	if err := windows.QueryServiceStatus(service, &status); err != nil {
+		return false, fmt.Errorf("failed to query W32Time service status: %w", err)
+	}
+
+	return status.CurrentState == windows.SERVICE_RUNNING, nil
+}
+
+// clockOffsetCache holds the result of the last SNTP request of the clock offset fallback.
+type clockOffsetCache struct {
+	mu      sync.Mutex
+	offset  float64
+	err     error
+	updated time.Time
+}
+
+// get returns the clock offset of the last SNTP request. A new request is sent,
+// once the last request is older than sntpInterval. Failed requests are cached as well.
+func (c *clockOffsetCache) get(timeout time.Duration) (float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.updated.IsZero() || time.Since(c.updated) >= sntpInterval {
+		c.offset, c.err = queryClockOffset(timeout)
+		c.updated = time.Now()
+	}
+
+	return c.offset, c.err
+}
+
+// queryClockOffset measures the absolute offset of the system clock against the first
+// NTP server configured for W32Time with a single SNTP request.
+// It is used if the Windows Time Service performance counters are not available.
+func queryClockOffset(timeout time.Duration) (float64, error) {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `SYSTEM\CurrentControlSet\Services\W32Time\Parameters`, registry.QUERY_VALUE)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open registry key: %w", err)
+	}
+
+	defer key.Close()
+
+	ntpServers, _, err := key.GetStringValue("NtpServer")
+	if err != nil && !errors.Is(err, registry.ErrNotExist) {
+		return 0, fmt.Errorf("failed to read 'NtpServer' value: %w", err)
+	}
+
+	server := firstNTPServer(ntpServers)
+	if server == "" {
+		return 0, errNoNTPServer
+	}
+
+	conn, err := net.DialTimeout("udp", net.JoinHostPort(server, "123"), timeout)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to NTP server %s: %w", server, err)
+	}
+
+	defer conn.Close()
+
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, err
+	}
+
+	request := make([]byte, ntpPacketSize)
+	request[0] = 0x23 // LI = 0, VN = 4, Mode = 3 (client)
+
+	originTime := time.Now()
+	binary.BigEndian.PutUint64(request[40:], toNTPTime(originTime))
+
+	if _, err = conn.Write(request); err != nil {
+		return 0, fmt.Errorf("failed to send SNTP request to %s: %w", server, err)
+	}
+
+	response := make([]byte, ntpPacketSize)
+
+	n, err := conn.Read(response)
+	if err != nil {
+		return 0, fmt.Errorf("failed to receive SNTP response from %s: %w", server, err)
+	}
+
+	offset, err := clockOffset(response[:n], originTime, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("invalid SNTP response from %s: %w", server, err)
+	}
+
+	return math.Abs(offset.Seconds()), nil
+}
+
+// firstNTPServer returns the host name of the first entry of the W32Time NtpServer value,
+// e.g. "time.windows.com,0x9 pool.ntp.org,0x1".
+func firstNTPServer(ntpServers string) string {
+	for _, server := range strings.Fields(ntpServers) {
+		server, _, _ = strings.Cut(server, ",")
+		if server != "" {
+			return server
+		}
+	}
+
+	return ""
+}
+
+// clockOffset calculates the offset of the server clock relative to the local clock
+// from an SNTP response, as specified by RFC 4330.
+func clockOffset(response []byte, originTime, destinationTime time.Time) (time.Duration, error) {
+	if len(response) < ntpPacketSize {
+		return 0, fmt.Errorf("response too short: %d bytes", len(response))
+	}
+
+	if mode := response[0] & 0x7; mode != ntpModeServer {
+		return 0, fmt.Errorf("unexpected mode %d", mode)
+	}
+
+	if stratum := response[1]; stratum == 0 {
+		return 0, errors.New("kiss-o'-death response")
+	}
+
+	receiveTime := fromNTPTime(binary.BigEndian.Uint64(response[32:]))
+	transmitTime := fromNTPTime(binary.BigEndian.Uint64(response[40:]))
+
+	return (receiveTime.Sub(originTime) + transmitTime.Sub(destinationTime)) / 2, nil
+}
+
+func toNTPTime(t time.Time) uint64 {
+	seconds := uint64(t.Unix() + ntpEpochOffset)
+	fraction := uint64(t.Nanosecond()) << 32 / 1e9
+
+	return seconds<<32 | fraction
+}
+
+func fromNTPTime(ntpTime uint64) time.Time {
+	seconds := int64(ntpTime>>32) - ntpEpochOffset
+	nanoseconds := int64((ntpTime & 0xFFFFFFFF) * 1e9 >> 32)
+
+	return time.Unix(seconds, nanoseconds)
+}
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package time
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstNTPServer(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "time.windows.com", firstNTPServer("time.windows.com,0x9"))
+	require.Equal(t, "ntp1.example.com", firstNTPServer(" ntp1.example.com,0x1 ntp2.example.com,0x1"))
+	require.Equal(t, "10.0.0.1", firstNTPServer("10.0.0.1"))
+	require.Empty(t, firstNTPServer(""))
+}
+
+func TestClockOffset(t *testing.T) {
+	t.Parallel()
+
+	origin := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	destination := origin.Add(20 * time.Millisecond)
+
+	// The server clock is 40 seconds ahead, the network delay is 10ms in each direction.
+	response := make([]byte, ntpPacketSize)
+	response[0] = 0x24 // LI = 0, VN = 4, Mode = 4 (server)
+	response[1] = 2
+	binary.BigEndian.PutUint64(response[32:], toNTPTime(origin.Add(40*time.Second+10*time.Millisecond)))
+	binary.BigEndian.PutUint64(response[40:], toNTPTime(origin.Add(40*time.Second+10*time.Millisecond)))
+
+	offset, err := clockOffset(response, origin, destination)
+	require.NoError(t, err)
+	require.InDelta(t, 40.0, offset.Seconds(), 1e-6)
+
+	response[1] = 0
+
+	_, err = clockOffset(response, origin, destination)
+	require.Error(t, err)
+}
@@ -0,0 +1,246 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package tpm
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/prometheus-community/windows_exporter/internal/headers/tbs"
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const Name = "tpm"
+
+type Config struct{}
+
+//nolint:gochecknoglobals
+var ConfigDefaults = Config{}
+
+// A Collector is a Prometheus Collector for the Win32_Tpm WMI class.
+type Collector struct {
+	config    Config
+	logger    *slog.Logger
+	miSession mi.Querier
+	miQuery   mi.Query
+
+	// disabled is set if the TPM namespace is not accessible, e.g. due to missing admin rights.
+	disabled bool
+	// isLockedOut returns the dictionary attack lockout state of the TPM.
+	isLockedOut func() (bool, error)
+
+	present         *prometheus.Desc
+	enabled         *prometheus.Desc
+	activated       *prometheus.Desc
+	owned           *prometheus.Desc
+	specVersionInfo *prometheus.Desc
+	lockoutActive   *prometheus.Desc
+}
+
+func New(config *Config) *Collector {
+	if config == nil {
+		config = &ConfigDefaults
+	}
+
+	c := &Collector{
+		config: *config,
+	}
+
+	return c
+}
+
+func NewWithFlags(_ *kingpin.Application) *Collector {
+	return &Collector{}
+}
+
+func (c *Collector) GetName() string {
+	return Name
+}
+
+func (c *Collector) GetSources() []string {
+	return []string{types.SourceWMI, types.SourceAPI}
+}
+
+func (c *Collector) Close() error {
+	return nil
+}
+
+func (c *Collector) Build(logger *slog.Logger, miSession *mi.Session) error {
+	if miSession == nil {
+		return errors.New("miSession is nil")
+	}
+
+	return c.build(logger, miSession, tbs.IsLockedOut)
+}
+
+func (c *Collector) build(logger *slog.Logger, miSession mi.Querier, isLockedOut func() (bool, error)) error {
+	c.logger = logger.With(slog.String("collector", Name))
+	c.isLockedOut = isLockedOut
+
+	c.present = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "present"),
+		"Whether a TPM is present on the system",
+		nil,
+		nil,
+	)
+	c.enabled = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "enabled"),
+		"Whether the TPM is enabled (IsEnabled_InitialValue)",
+		nil,
+		nil,
+	)
+	c.activated = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "activated"),
+		"Whether the TPM is activated (IsActivated_InitialValue)",
+		nil,
+		nil,
+	)
+	c.owned = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "owned"),
+		"Whether the TPM has an owner (IsOwned_InitialValue)",
+		nil,
+		nil,
+	)
+	c.specVersionInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "spec_version_info"),
+		"The TPM specification version supported by the TPM. Value is always 1 (SpecVersion)",
+		[]string{"version", "manufacturer"},
+		nil,
+	)
+	c.lockoutActive = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "lockout_active"),
+		"Whether the TPM is in dictionary attack lockout mode. Only available for TPM 2.0",
+		nil,
+		nil,
+	)
+
+	miQuery, err := mi.NewQuery("SELECT IsActivated_InitialValue, IsEnabled_InitialValue, IsOwned_InitialValue, ManufacturerIdTxt, SpecVersion FROM Win32_Tpm")
+	if err != nil {
+		return fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	c.miQuery = miQuery
+	c.miSession = miSession
+
+	var dst []miTpm
+	if err := c.miSession.Query(&dst, mi.NamespaceRootMicrosoftTpm, c.miQuery, 0); err != nil {
+		if errors.Is(err, mi.MI_RESULT_ACCESS_DENIED) {
+			c.logger.Warn("access to the TPM WMI namespace denied. windows_exporter must run with administrator rights to collect TPM metrics. The tpm collector is disabled")
+
+			c.disabled = true
+
+			return nil
+		}
+
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	return nil
+}
+
+type miTpm struct {
+	IsActivated       bool   `mi:"IsActivated_InitialValue"`
+	IsEnabled         bool   `mi:"IsEnabled_InitialValue"`
+	IsOwned           bool   `mi:"IsOwned_InitialValue"`
+	ManufacturerIDTxt string `mi:"ManufacturerIdTxt"`
+	SpecVersion       string `mi:"SpecVersion"`
+}
+
+// Collect sends the metric values for each metric
+// to the provided prometheus Metric channel.
+func (c *Collector) Collect(ch chan<- prometheus.Metric, maxScrapeDuration time.Duration) error {
+	if c.disabled {
+		return nil
+	}
+
+	var dst []miTpm
+	if err := c.miSession.Query(&dst, mi.NamespaceRootMicrosoftTpm, c.miQuery, maxScrapeDuration); err != nil {
+		return fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	if len(dst) == 0 {
+		ch <- prometheus.MustNewConstMetric(
+			c.present,
+			prometheus.GaugeValue,
+			0,
+		)
+
+		return nil
+	}
+
+	tpm := dst[0]
+
+	ch <- prometheus.MustNewConstMetric(
+		c.present,
+		prometheus.GaugeValue,
+		1,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.enabled,
+		prometheus.GaugeValue,
+		utils.BoolToFloat(tpm.IsEnabled),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.activated,
+		prometheus.GaugeValue,
+		utils.BoolToFloat(tpm.IsActivated),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.owned,
+		prometheus.GaugeValue,
+		utils.BoolToFloat(tpm.IsOwned),
+	)
+
+	// SpecVersion is formatted as "<version>, <level>, <revision>", e.g. "2.0, 0, 1.38".
+	specVersion, _, _ := strings.Cut(tpm.SpecVersion, ",")
+
+	ch <- prometheus.MustNewConstMetric(
+		c.specVersionInfo,
+		prometheus.GaugeValue,
+		1,
+		strings.TrimSpace(specVersion),
+		strings.TrimSpace(tpm.ManufacturerIDTxt),
+	)
+
+	if strings.TrimSpace(specVersion) != "2.0" {
+		return nil
+	}
+
+	lockedOut, err := c.isLockedOut()
+	if err != nil {
+		return fmt.Errorf("failed to get TPM lockout state: %w", err)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.lockoutActive,
+		prometheus.GaugeValue,
+		utils.BoolToFloat(lockedOut),
+	)
+
+	return nil
+}
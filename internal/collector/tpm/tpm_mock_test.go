@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package tpm
+
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeQuerier struct {
+	instances []miTpm
+	err       error
+}
+
+func (f fakeQuerier) Query(dst any, _ mi.Namespace, _ mi.Query, _ time.Duration) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	*dst.(*[]miTpm) = f.instances
+
+	return nil
+}
+
+// collectorFunc adapts the Collect method to a [prometheus.Collector] for testutil.
+type collectorFunc func(ch chan<- prometheus.Metric)
+
+func (f collectorFunc) Describe(_ chan<- *prometheus.Desc) {}
+
+func (f collectorFunc) Collect(ch chan<- prometheus.Metric) { f(ch) }
+
+func collectText(t *testing.T, c *Collector) string {
+	t.Helper()
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(collectorFunc(func(ch chan<- prometheus.Metric) {
+		require.NoError(t, c.Collect(ch, 0))
+	})))
+
+	metrics, err := reg.Gather()
+	require.NoError(t, err)
+
+	var out string
+	for _, mf := range metrics {
+		for _, m := range mf.GetMetric() {
+			out += mf.GetName()
+			for _, l := range m.GetLabel() {
+				out += " " + l.GetName() + "=" + l.GetValue()
+			}
+
+			out += " " + strconv.FormatFloat(m.GetGauge().GetValue(), 'f', -1, 64) + "\n"
+		}
+	}
+
+	return out
+}
+
+func TestCollectTPM20(t *testing.T) {
+	t.Parallel()
+
+	c := New(nil)
+	require.NoError(t, c.build(slog.New(slog.DiscardHandler), fakeQuerier{
+		instances: []miTpm{{
+			IsActivated:       true,
+			IsEnabled:         true,
+			IsOwned:           true,
+			ManufacturerIDTxt: "INTC",
+			SpecVersion:       "2.0, 0, 1.38",
+		}},
+	}, func() (bool, error) { return true, nil }))
+
+	require.Equal(t, `windows_tpm_activated 1
+windows_tpm_enabled 1
+windows_tpm_lockout_active 1
+windows_tpm_owned 1
+windows_tpm_present 1
+windows_tpm_spec_version_info manufacturer=INTC version=2.0 1
+`, collectText(t, c))
+}
+
+func TestCollectTPM12(t *testing.T) {
+	t.Parallel()
+
+	c := New(nil)
+	require.NoError(t, c.build(slog.New(slog.DiscardHandler), fakeQuerier{
+		instances: []miTpm{{
+			IsActivated:       false,
+			IsEnabled:         true,
+			ManufacturerIDTxt: "IFX",
+			SpecVersion:       "1.2, 2, 3",
+		}},
+	}, func() (bool, error) { return false, errors.New("must not be called") }))
+
+	require.Equal(t, `windows_tpm_activated 0
+windows_tpm_enabled 1
+windows_tpm_owned 0
+windows_tpm_present 1
+windows_tpm_spec_version_info manufacturer=IFX version=1.2 1
+`, collectText(t, c))
+}
+
+func TestCollectNoTPM(t *testing.T) {
+	t.Parallel()
+
+	c := New(nil)
+	require.NoError(t, c.build(slog.New(slog.DiscardHandler), fakeQuerier{}, nil))
+
+	require.Equal(t, "windows_tpm_present 0\n", collectText(t, c))
+}
+
+func TestBuildAccessDenied(t *testing.T) {
+	t.Parallel()
+
+	c := New(nil)
+	require.NoError(t, c.build(slog.New(slog.DiscardHandler), fakeQuerier{err: mi.MI_RESULT_ACCESS_DENIED}, nil))
+	require.True(t, c.disabled)
+	require.Empty(t, collectText(t, c))
+}
+
+func TestBuildInvalidNamespace(t *testing.T) {
+	t.Parallel()
+
+	c := New(nil)
+	err := c.build(slog.New(slog.DiscardHandler), fakeQuerier{err: mi.MI_RESULT_INVALID_NAMESPACE}, nil)
+	require.ErrorIs(t, err, mi.MI_RESULT_INVALID_NAMESPACE)
+}
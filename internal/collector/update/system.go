@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package update
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-ole/go-ole"
+	"github.com/go-ole/go-ole/oleutil"
+)
+
+// updateClassifications are the values of the classification label of windows_update_pending_count.
+//
+//nolint:gochecknoglobals
+var updateClassifications = []string{"security", "critical", "optional", "other"}
+
+// classifyUpdate maps the categories of an update to one of updateClassifications.
+// Category names are English, since the UserLocale of the update session is set to 1033.
+// Updates offered as optional updates in the Settings app are browse-only.
+func classifyUpdate(categoryNames []string, browseOnly bool) string {
+	switch {
+	case slices.Contains(categoryNames, "Security Updates"):
+		return "security"
+	case slices.Contains(categoryNames, "Critical Updates"):
+		return "critical"
+	case browseOnly:
+		return "optional"
+	default:
+		return "other"
+	}
+}
+
+// getRebootRequired reports whether a reboot is required to complete the installation of updates.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/wuapi/nf-wuapi-isysteminformation-get_rebootrequired
+func getRebootRequired() (bool, error) {
+	systemInfoObj, err := oleutil.CreateObject("Microsoft.Update.SystemInfo")
+	if err != nil {
+		return false, fmt.Errorf("create Microsoft.Update.SystemInfo: %w", err)
+	}
+
+	defer systemInfoObj.Release()
+
+	systemInfo, err := systemInfoObj.QueryInterface(ole.IID_IDispatch)
+	if err != nil {
+		return false, fmt.Errorf("IID_IDispatch: %w", err)
+	}
+
+	defer systemInfo.Release()
+
+	rebootRequired, err := oleutil.GetProperty(systemInfo, "RebootRequired")
+	if err != nil {
+		return false, fmt.Errorf("get RebootRequired: %w", err)
+	}
+
+	defer func() {
+		_ = rebootRequired.Clear()
+	}()
+
+	return rebootRequired.Val != 0, nil
+}
+
+// getLastInstallationSuccessDate returns the time of the last successful installation of updates.
+// The zero time is returned, if no update was installed yet.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/wuapi/nf-wuapi-iautomaticupdatesresults-get_lastinstallationsuccessdate
+func getLastInstallationSuccessDate() (time.Time, error) {
+	autoUpdateObj, err := oleutil.CreateObject("Microsoft.Update.AutoUpdate")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("create Microsoft.Update.AutoUpdate: %w", err)
+	}
+
+	defer autoUpdateObj.Release()
+
+	autoUpdate, err := autoUpdateObj.QueryInterface(ole.IID_IDispatch)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("IID_IDispatch: %w", err)
+	}
+
+	defer autoUpdate.Release()
+
+	resultsRaw, err := oleutil.GetProperty(autoUpdate, "Results")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("get Results: %w", err)
+	}
+
+	results := resultsRaw.ToIDispatch()
+	defer results.Release()
+
+	lastInstall, err := oleutil.GetProperty(results, "LastInstallationSuccessDate")
+	if err != nil {
+		return time.Time{}, fmt.Errorf("get LastInstallationSuccessDate: %w", err)
+	}
+
+	if lastInstall.VT != ole.VT_DATE {
+		return time.Time{}, nil
+	}
+
+	lastInstallDate, err := ole.GetVariantDate(uint64(lastInstall.Val))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("convert LastInstallationSuccessDate: %w", err)
+	}
+
+	return lastInstallDate, nil
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyUpdate(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name           string
+		categoryNames  []string
+		browseOnly     bool
+		classification string
+	}{
+		{"security update", []string{"Windows 11", "Security Updates"}, false, "security"},
+		{"critical update", []string{"Critical Updates", "Windows Server 2022"}, false, "critical"},
+		{"optional security update", []string{"Security Updates"}, true, "security"},
+		{"optional driver", []string{"Drivers"}, true, "optional"},
+		{"definition update", []string{"Definition Updates", "Microsoft Defender Antivirus"}, false, "other"},
+		{"no categories", nil, false, "other"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.classification, classifyUpdate(tc.categoryNames, tc.browseOnly))
+		})
+	}
+}
@@ -33,10 +33,16 @@ import (
 	"github.com/go-ole/go-ole/oleutil"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
-const Name = "update"
+const (
+	Name = "update"
+
+	// minScrapeInterval is the lower bound of the scrape interval, since searching for updates is expensive.
+	minScrapeInterval = 5 * time.Minute
+)
 
 type Config struct {
 	Online         bool          `yaml:"online"`
@@ -66,6 +72,9 @@ type Collector struct {
 
 	pendingUpdate              *prometheus.Desc
 	pendingUpdateLastPublished *prometheus.Desc
+	pendingCount               *prometheus.Desc
+	lastInstallTimestamp       *prometheus.Desc
+	rebootRequired             *prometheus.Desc
 	queryDurationSeconds       *prometheus.Desc
 	lastScrapeMetric           *prometheus.Desc
 }
@@ -111,6 +120,12 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 	c.logger.Info("update collector is in an experimental state! The configuration and metrics may change in future. Please report any issues.")
 
+	if c.config.ScrapeInterval < minScrapeInterval {
+		c.logger.Warn(fmt.Sprintf("scrape interval %s is too short, using %s", c.config.ScrapeInterval, minScrapeInterval))
+
+		c.config.ScrapeInterval = minScrapeInterval
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	initErrCh := make(chan error, 1)
@@ -136,6 +151,27 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 		nil,
 	)
 
+	c.pendingCount = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "pending_count"),
+		"Number of pending updates by classification",
+		[]string{"classification"},
+		nil,
+	)
+
+	c.lastInstallTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "last_install_timestamp_seconds"),
+		"Timestamp of the last successful installation of updates",
+		nil,
+		nil,
+	)
+
+	c.rebootRequired = prometheus.NewDesc(
+		prometheus.BuildFQName(types.Namespace, Name, "reboot_required"),
+		"Whether a reboot is required to complete the installation of updates",
+		nil,
+		nil,
+	)
+
 	c.queryDurationSeconds = prometheus.NewDesc(
 		prometheus.BuildFQName(types.Namespace, Name, "scrape_query_duration_seconds"),
 		"Duration of the last scrape query to the Windows Update API",
@@ -155,6 +191,8 @@ func (c *Collector) Build(logger *slog.Logger, _ *mi.Session) error {
 
 func (c *Collector) GetName() string { return Name }
 
+func (c *Collector) GetSources() []string { return []string{types.SourceCOM} }
+
 func (c *Collector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -329,6 +367,8 @@ func (c *Collector) fetchUpdates(logger *slog.Logger, usd *ole.IDispatch) ([]pro
 		return nil, fmt.Errorf("get updates count: %w", err)
 	}
 
+	pendingCount := make(map[string]int, len(updateClassifications))
+
 	for i := range int(countUpdd.Val) {
 		update, err := c.getUpdateStatus(updd, i)
 		if err != nil {
@@ -339,6 +379,8 @@ func (c *Collector) fetchUpdates(logger *slog.Logger, usd *ole.IDispatch) ([]pro
 			continue
 		}
 
+		pendingCount[update.classification]++
+
 		metricsBuf = append(metricsBuf, prometheus.MustNewConstMetric(
 			c.pendingUpdate,
 			prometheus.GaugeValue,
@@ -361,6 +403,39 @@ func (c *Collector) fetchUpdates(logger *slog.Logger, usd *ole.IDispatch) ([]pro
 		}
 	}
 
+	for _, classification := range updateClassifications {
+		metricsBuf = append(metricsBuf, prometheus.MustNewConstMetric(
+			c.pendingCount,
+			prometheus.GaugeValue,
+			float64(pendingCount[classification]),
+			classification,
+		))
+	}
+
+	if rebootRequired, err := getRebootRequired(); err != nil {
+		logger.Error("failed to fetch Windows Update reboot status",
+			slog.Any("err", err),
+		)
+	} else {
+		metricsBuf = append(metricsBuf, prometheus.MustNewConstMetric(
+			c.rebootRequired,
+			prometheus.GaugeValue,
+			utils.BoolToFloat(rebootRequired),
+		))
+	}
+
+	if lastInstall, err := getLastInstallationSuccessDate(); err != nil {
+		logger.Error("failed to fetch last Windows Update installation date",
+			slog.Any("err", err),
+		)
+	} else if !lastInstall.IsZero() {
+		metricsBuf = append(metricsBuf, prometheus.MustNewConstMetric(
+			c.lastInstallTimestamp,
+			prometheus.GaugeValue,
+			float64(lastInstall.Unix()),
+		))
+	}
+
 	metricsBuf = append(metricsBuf, prometheus.MustNewConstMetric(
 		c.lastScrapeMetric,
 		prometheus.GaugeValue,
@@ -371,12 +446,13 @@ func (c *Collector) fetchUpdates(logger *slog.Logger, usd *ole.IDispatch) ([]pro
 }
 
 type windowsUpdate struct {
-	identity      string
-	revision      string
-	category      string
-	severity      string
-	title         string
-	lastPublished time.Time
+	identity       string
+	revision       string
+	category       string
+	classification string
+	severity       string
+	title          string
+	lastPublished  time.Time
 }
 
 // getUpdateStatus retrieves the update status of the given item.
@@ -404,11 +480,16 @@ func (c *Collector) getUpdateStatus(updd *ole.IDispatch, item int) (windowsUpdat
 	categories := categoriesRaw.ToIDispatch()
 	defer categories.Release()
 
-	categoryName, err := getUpdateCategory(categories)
+	categoryName, categoryNames, err := getUpdateCategory(categories)
 	if err != nil {
 		return windowsUpdate{}, fmt.Errorf("get Category: %w", err)
 	}
 
+	browseOnly, err := oleutil.GetProperty(updateItem, "BrowseOnly")
+	if err != nil {
+		return windowsUpdate{}, fmt.Errorf("get BrowseOnly: %w", err)
+	}
+
 	title, err := oleutil.GetProperty(updateItem, "Title")
 	if err != nil {
 		return windowsUpdate{}, fmt.Errorf("get Title: %w", err)
@@ -450,23 +531,27 @@ func (c *Collector) getUpdateStatus(updd *ole.IDispatch, item int) (windowsUpdat
 	}
 
 	return windowsUpdate{
-		identity:      updateIDVariant.ToString(),
-		revision:      strconv.FormatInt(revisionVariant.Val, 10),
-		category:      categoryName,
-		severity:      severity.ToString(),
-		title:         title.ToString(),
-		lastPublished: lastPublishedDate,
+		identity:       updateIDVariant.ToString(),
+		revision:       strconv.FormatInt(revisionVariant.Val, 10),
+		category:       categoryName,
+		classification: classifyUpdate(categoryNames, browseOnly.Val != 0),
+		severity:       severity.ToString(),
+		title:          title.ToString(),
+		lastPublished:  lastPublishedDate,
 	}, nil
 }
 
-func getUpdateCategory(categories *ole.IDispatch) (string, error) {
+// getUpdateCategory returns the name of the category with the lowest order and the names of all categories.
+func getUpdateCategory(categories *ole.IDispatch) (string, []string, error) {
 	var categoryName string
 
 	categoryCount, err := oleutil.GetProperty(categories, "Count")
 	if err != nil {
-		return categoryName, fmt.Errorf("get Categories count: %w", err)
+		return categoryName, nil, fmt.Errorf("get Categories count: %w", err)
 	}
 
+	categoryNames := make([]string, 0, categoryCount.Val)
+
 	order := int64(math.MaxInt64)
 
 	for i := range categoryCount.Val {
@@ -489,6 +574,8 @@ func getUpdateCategory(categories *ole.IDispatch) (string, error) {
 				return fmt.Errorf("get Category item Order: %w", err)
 			}
 
+			categoryNames = append(categoryNames, categoryNameRaw.ToString())
+
 			if orderRaw.Val < order {
 				order = orderRaw.Val
 				categoryName = categoryNameRaw.ToString()
@@ -497,9 +584,9 @@ func getUpdateCategory(categories *ole.IDispatch) (string, error) {
 			return nil
 		}(i)
 		if err != nil {
-			return "", fmt.Errorf("get Category item: %w", err)
+			return "", nil, fmt.Errorf("get Category item: %w", err)
 		}
 	}
 
-	return categoryName, nil
+	return categoryName, categoryNames, nil
 }
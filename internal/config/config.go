@@ -38,8 +38,11 @@ type configFile struct {
 	Collectors struct {
 		Enabled string `yaml:"enabled"`
 	} `yaml:"collectors"`
-	Collector collector.Config `yaml:"collector"`
-	Log       struct {
+	Collector          collector.Config            `yaml:"collector"`
+	CounterOverrides   collector.CounterOverrides  `yaml:"counter_overrides"`
+	ClusterRoleLabels  collector.ClusterRoleLabels `yaml:"cluster_roles"`
+	CriticalCollectors []string                    `yaml:"critical_collectors"`
+	Log                struct {
 		Level  string `yaml:"level"`
 		Format string `yaml:"format"`
 		File   string `yaml:"file"`
@@ -166,6 +169,58 @@ func NewConfigFileResolver(filePath string) (*Resolver, error) {
 	return &Resolver{flags: flags}, nil
 }
 
+// ParseCounterOverrides returns the counter_overrides section of the configuration file.
+// Counter overrides are not available as command line flags.
+func ParseCounterOverrides(filePath string) (collector.CounterOverrides, error) {
+	configFileStructure, err := parseConfigFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return configFileStructure.CounterOverrides, nil
+}
+
+// ParseClusterRoleLabels returns the cluster_roles section of the configuration file.
+// Cluster role labels are not available as command line flags.
+func ParseClusterRoleLabels(filePath string) (collector.ClusterRoleLabels, error) {
+	configFileStructure, err := parseConfigFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return configFileStructure.ClusterRoleLabels, nil
+}
+
+// ParseCriticalCollectors returns the critical_collectors list of the configuration file.
+// The list is added to the collectors of the --collectors.critical flag.
+func ParseCriticalCollectors(filePath string) ([]string, error) {
+	configFileStructure, err := parseConfigFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return configFileStructure.CriticalCollectors, nil
+}
+
+func parseConfigFile(filePath string) (configFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return configFile{}, fmt.Errorf("failed to open configuration file: %w", err)
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var configFileStructure configFile
+
+	if err = yaml.NewDecoder(file).Decode(&configFileStructure); err != nil && !errors.Is(err, io.EOF) {
+		return configFile{}, fmt.Errorf("failed to parse configuration file: %w", err)
+	}
+
+	return configFileStructure, nil
+}
+
 func (c *Resolver) setDefault(v getFlagger) {
 	for name, value := range c.flags {
 		if f := v.GetFlag(name); f != nil {
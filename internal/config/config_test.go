@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCriticalCollectors(t *testing.T) {
+	t.Parallel()
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`---
+collectors:
+  enabled: cpu,memory,net
+critical_collectors: [cpu, memory]
+`), 0o600))
+
+	// The key is known to the strict decoder of the flag resolver.
+	_, err := NewConfigFileResolver(configFile)
+	require.NoError(t, err)
+
+	criticalCollectors, err := ParseCriticalCollectors(configFile)
+	require.NoError(t, err)
+	require.Equal(t, []string{"cpu", "memory"}, criticalCollectors)
+}
@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package advapi32
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// LOGON32_LOGON_SERVICE requires the "Log on as a service" right.
+	LOGON32_LOGON_SERVICE    = 5
+	LOGON32_PROVIDER_DEFAULT = 0
+
+	POLICY_GET_PRIVATE_INFORMATION = 0x00000004
+)
+
+//nolint:gochecknoglobals
+var (
+	advapi32 = windows.NewLazySystemDLL("advapi32.dll")
+
+	procLogonUserW              = advapi32.NewProc("LogonUserW")
+	procImpersonateLoggedOnUser = advapi32.NewProc("ImpersonateLoggedOnUser")
+	procLsaOpenPolicy           = advapi32.NewProc("LsaOpenPolicy")
+	procLsaRetrievePrivateData  = advapi32.NewProc("LsaRetrievePrivateData")
+	procLsaFreeMemory           = advapi32.NewProc("LsaFreeMemory")
+	procLsaClose                = advapi32.NewProc("LsaClose")
+	procLsaNtStatusToWinError   = advapi32.NewProc("LsaNtStatusToWinError")
+)
+
+// LSA_OBJECT_ATTRIBUTES is reserved and must be zeroed for LsaOpenPolicy.
+type LSA_OBJECT_ATTRIBUTES struct {
+	Length                   uint32
+	RootDirectory            windows.Handle
+	ObjectName               *windows.NTUnicodeString
+	Attributes               uint32
+	SecurityDescriptor       uintptr
+	SecurityQualityOfService uintptr
+}
+
+// LogonUser logs on the given user to the local computer.
+// A nil password is valid for group managed service accounts.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winbase/nf-winbase-logonuserw
+func LogonUser(user, domain *uint16, password *uint16, logonType, logonProvider uint32) (windows.Token, error) {
+	var token windows.Token
+
+	r0, _, err := procLogonUserW.Call(
+		uintptr(unsafe.Pointer(user)),
+		uintptr(unsafe.Pointer(domain)),
+		uintptr(unsafe.Pointer(password)),
+		uintptr(logonType),
+		uintptr(logonProvider),
+		uintptr(unsafe.Pointer(&token)),
+	)
+	if r0 == 0 {
+		return 0, err
+	}
+
+	return token, nil
+}
+
+// ImpersonateLoggedOnUser lets the calling thread impersonate the security context of the given token.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/securitybaseapi/nf-securitybaseapi-impersonateloggedonuser
+func ImpersonateLoggedOnUser(token windows.Token) error {
+	r0, _, err := procImpersonateLoggedOnUser.Call(uintptr(token))
+	if r0 == 0 {
+		return err
+	}
+
+	return nil
+}
+
+// RetrievePrivateData returns the LSA secret stored under the given key name.
+// The caller must be a member of the Administrators group.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/ntsecapi/nf-ntsecapi-lsaretrieveprivatedata
+func RetrievePrivateData(keyName string) (string, error) {
+	var (
+		objectAttributes LSA_OBJECT_ATTRIBUTES
+		policyHandle     windows.Handle
+	)
+
+	r0, _, _ := procLsaOpenPolicy.Call(
+		0,
+		uintptr(unsafe.Pointer(&objectAttributes)),
+		POLICY_GET_PRIVATE_INFORMATION,
+		uintptr(unsafe.Pointer(&policyHandle)),
+	)
+	if err := LsaNtStatusToWinError(r0); err != nil {
+		return "", fmt.Errorf("LsaOpenPolicy: %w", err)
+	}
+
+	defer func(handle windows.Handle) {
+		_, _, _ = procLsaClose.Call(uintptr(handle))
+	}(policyHandle)
+
+	key, err := windows.NewNTUnicodeString(keyName)
+	if err != nil {
+		return "", err
+	}
+
+	var privateData *windows.NTUnicodeString
+
+	r0, _, _ = procLsaRetrievePrivateData.Call(
+		uintptr(policyHandle),
+		uintptr(unsafe.Pointer(key)),
+		uintptr(unsafe.Pointer(&privateData)),
+	)
+	if err := LsaNtStatusToWinError(r0); err != nil {
+		return "", fmt.Errorf("LsaRetrievePrivateData: %w", err)
+	}
+
+	if privateData == nil {
+		return "", nil
+	}
+
+	defer func(buffer uintptr) {
+		_, _, _ = procLsaFreeMemory.Call(buffer)
+	}(uintptr(unsafe.Pointer(privateData)))
+
+	return privateData.String(), nil
+}
+
+func LsaNtStatusToWinError(ntstatus uintptr) error {
+	r0, _, err := procLsaNtStatusToWinError.Call(ntstatus)
+
+	switch {
+	case errors.Is(err, windows.ERROR_SUCCESS):
+		if r0 == 0 {
+			return nil
+		}
+	case errors.Is(err, windows.ERROR_MR_MID_NOT_FOUND):
+		return fmt.Errorf("unknown LSA NTSTATUS code %x", ntstatus)
+	}
+
+	return windows.Errno(r0)
+}
@@ -35,6 +35,7 @@ var (
 	procDhcpV4EnumSubnetReservations     = modDhcpServer.NewProc("DhcpV4EnumSubnetReservations")
 	procDhcpV4FailoverGetScopeStatistics = modDhcpServer.NewProc("DhcpV4FailoverGetScopeStatistics")
 	procDhcpGetMibInfoV5                 = modDhcpServer.NewProc("DhcpGetMibInfoV5")
+	procDhcpServerQueryAttribute         = modDhcpServer.NewProc("DhcpServerQueryAttribute")
 )
 
 func GetDHCPV4ScopeStatistics() ([]DHCPV4Scope, error) {
@@ -137,6 +138,26 @@ func GetDHCPV4ScopeStatistics() ([]DHCPV4Scope, error) {
 	return scopes, errors.Join(errs...)
 }
 
+// IsRogue reports whether the local DHCP server considers itself unauthorized in Active Directory.
+// A rogue server does not lease any addresses.
+func IsRogue() (bool, error) {
+	var attrib *DHCP_ATTRIB
+
+	if err := dhcpServerQueryAttribute(DHCP_ATTRIB_BOOL_IS_ROGUE, &attrib); err != nil {
+		return false, fmt.Errorf("dhcpServerQueryAttribute: %w", err)
+	} else if attrib == nil {
+		return false, errors.New("dhcpServerQueryAttribute returned nil")
+	}
+
+	defer dhcpRpcFreeMemory(unsafe.Pointer(attrib))
+
+	if attrib.DhcpAttribType != DHCP_ATTRIB_TYPE_BOOL {
+		return false, fmt.Errorf("unexpected attribute type %d", attrib.DhcpAttribType)
+	}
+
+	return attrib.DhcpAttribValue != 0, nil
+}
+
 // dhcpGetSubnetInfo https://learn.microsoft.com/en-us/windows/win32/api/dhcpsapi/nf-dhcpsapi-dhcpgetsubnetinfo
 func dhcpGetSubnetInfo(subnetAddress DHCP_IP_ADDRESS, subnetInfo **DHCP_SUBNET_INFO) error {
 	ret, _, _ := procDhcpGetSubnetInfo.Call(
@@ -223,6 +244,22 @@ func dhcpV4EnumSubnetReservations(subnetAddress DHCP_IP_ADDRESS) (uint32, error)
 	return elementsRead + elementsTotal, nil
 }
 
+// dhcpServerQueryAttribute https://learn.microsoft.com/en-us/windows/win32/api/dhcpsapi/nf-dhcpsapi-dhcpserverqueryattribute
+func dhcpServerQueryAttribute(attribID DHCP_ATTRIB_ID, attrib **DHCP_ATTRIB) error {
+	ret, _, _ := procDhcpServerQueryAttribute.Call(
+		0,
+		0,
+		uintptr(attribID),
+		uintptr(unsafe.Pointer(attrib)),
+	)
+
+	if ret != 0 {
+		return fmt.Errorf("dhcpServerQueryAttribute failed with code %w", windows.Errno(ret))
+	}
+
+	return nil
+}
+
 func dhcpRpcFreeMemory(pointer unsafe.Pointer) {
 	if uintptr(pointer) == 0 {
 		return
@@ -39,3 +39,18 @@ func TestGetDHCPV4ScopeStatistics(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+func TestIsRogue(t *testing.T) {
+	t.Parallel()
+
+	if procDhcpServerQueryAttribute.Find() != nil {
+		t.Skip("DhcpServerQueryAttribute is not available")
+	}
+
+	_, err := IsRogue()
+	if errors.Is(err, windows.Errno(1753)) {
+		t.Skip(err.Error())
+	}
+
+	require.NoError(t, err)
+}
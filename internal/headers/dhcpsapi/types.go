@@ -152,3 +152,20 @@ type DHCP_SUBNET_MIB_INFO_V5 struct {
 	NumAddressesFree  win32.DWORD
 	NumPendingOffers  win32.DWORD
 }
+
+type DHCP_ATTRIB_ID = win32.ULONG
+
+const (
+	DHCP_ATTRIB_BOOL_IS_ROGUE DHCP_ATTRIB_ID = 0x01
+
+	DHCP_ATTRIB_TYPE_BOOL  win32.ULONG = 0x01
+	DHCP_ATTRIB_TYPE_ULONG win32.ULONG = 0x02
+)
+
+// DHCP_ATTRIB https://learn.microsoft.com/en-us/windows/win32/api/dhcpsapi/ns-dhcpsapi-dhcp_attrib
+type DHCP_ATTRIB struct {
+	DhcpAttribId   DHCP_ATTRIB_ID
+	DhcpAttribType win32.ULONG
+	// DhcpAttribValue holds either a BOOL or an ULONG, depending on DhcpAttribType.
+	DhcpAttribValue win32.ULONG
+}
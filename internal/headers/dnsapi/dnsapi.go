@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package dnsapi provides access to the DNS client resolver cache.
+package dnsapi
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+//nolint:gochecknoglobals
+var (
+	modDnsapi                = windows.NewLazySystemDLL("dnsapi.dll")
+	procDnsGetCacheDataTable = modDnsapi.NewProc("DnsGetCacheDataTable")
+	procDnsFree              = modDnsapi.NewProc("DnsFree")
+)
+
+// dnsFreeFlat is DnsFreeFlat of DNS_FREE_TYPE.
+const dnsFreeFlat = 0
+
+// dnsCacheEntry is the undocumented DNS_CACHE_ENTRY structure returned by DnsGetCacheDataTable.
+type dnsCacheEntry struct {
+	Next       *dnsCacheEntry
+	Name       *uint16
+	Type       uint16
+	DataLength uint16
+	Flags      uint32
+}
+
+// CacheEntry is an entry of the DNS client resolver cache.
+type CacheEntry struct {
+	Name string
+	Type uint16
+}
+
+// Available reports whether DnsGetCacheDataTable is exported by dnsapi.dll.
+// The function is undocumented and may not be present on all Windows versions.
+func Available() bool {
+	return procDnsGetCacheDataTable.Find() == nil && procDnsFree.Find() == nil
+}
+
+// GetCacheDataTable returns the entries of the DNS client resolver cache.
+// This is the API used by Get-DnsClientCache and ipconfig /displaydns.
+func GetCacheDataTable() ([]CacheEntry, error) {
+	if err := procDnsGetCacheDataTable.Find(); err != nil {
+		return nil, err
+	}
+
+	var table *dnsCacheEntry
+
+	// DnsGetCacheDataTable returns FALSE without setting the last error if the cache is empty.
+	_, _, _ = procDnsGetCacheDataTable.Call(uintptr(unsafe.Pointer(&table)))
+
+	entries := make([]CacheEntry, 0)
+
+	for entry := table; entry != nil; {
+		entries = append(entries, CacheEntry{
+			Name: windows.UTF16PtrToString(entry.Name),
+			Type: entry.Type,
+		})
+
+		next := entry.Next
+
+		if entry.Name != nil {
+			_, _, _ = procDnsFree.Call(uintptr(unsafe.Pointer(entry.Name)), dnsFreeFlat)
+		}
+
+		_, _, _ = procDnsFree.Call(uintptr(unsafe.Pointer(entry)), dnsFreeFlat)
+
+		entry = next
+	}
+
+	return entries, nil
+}
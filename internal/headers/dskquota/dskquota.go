@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package dskquota
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/go-ole/go-ole"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// StateMask is DISKQUOTA_STATE_MASK. The quota state is one of StateDisabled, StateTrack and StateEnforce.
+	StateMask     = 0x00000003
+	StateDisabled = 0x00000000
+	StateTrack    = 0x00000001
+	StateEnforce  = 0x00000002
+
+	// NoLimit is the value of a quota threshold or limit, which is not set.
+	NoLimit = -1
+
+	// userNameResolveNone is DISKQUOTA_USERNAME_RESOLVE_NONE. SIDs are resolved by the caller.
+	userNameResolveNone = 0
+
+	sFalse = 1
+)
+
+//nolint:gochecknoglobals
+var (
+	clsidDiskQuotaControl = ole.NewGUID("{7988B571-EC89-11cf-9C00-00AA00A14F56}")
+	iidIDiskQuotaControl  = ole.NewGUID("{7988B572-EC89-11cf-9C00-00AA00A14F56}")
+)
+
+// NewDiskQuotaControl creates a quota control object. Must be called from a thread with an initialized COM apartment.
+func NewDiskQuotaControl() (*IDiskQuotaControl, error) {
+	unknown, err := ole.CreateInstance(clsidDiskQuotaControl, iidIDiskQuotaControl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DiskQuotaControl: %w", err)
+	}
+
+	return (*IDiskQuotaControl)(unsafe.Pointer(unknown)), nil
+}
+
+// Initialize binds the quota control object to the volume with the given root path, e.g. C:\.
+func (c *IDiskQuotaControl) Initialize(path string) error {
+	ptrPath, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return fmt.Errorf("failed to convert path to UTF16: %w", err)
+	}
+
+	hr, _, _ := syscall.SyscallN(
+		c.lpVtbl.Initialize,
+		uintptr(unsafe.Pointer(c)),
+		uintptr(unsafe.Pointer(ptrPath)),
+		0, // read only
+	)
+	if hr != 0 {
+		return fmt.Errorf("Initialize failed: %w", ole.NewError(hr))
+	}
+
+	return nil
+}
+
+// GetQuotaState returns the quota state of the volume. See StateMask.
+func (c *IDiskQuotaControl) GetQuotaState() (uint32, error) {
+	var state uint32
+
+	hr, _, _ := syscall.SyscallN(
+		c.lpVtbl.GetQuotaState,
+		uintptr(unsafe.Pointer(c)),
+		uintptr(unsafe.Pointer(&state)),
+	)
+	if hr != 0 {
+		return 0, fmt.Errorf("GetQuotaState failed: %w", ole.NewError(hr))
+	}
+
+	return state, nil
+}
+
+// CreateEnumUsers returns an enumerator of all quota entries of the volume.
+// The user names are not resolved. Use IDiskQuotaUser.GetSid to resolve the account.
+func (c *IDiskQuotaControl) CreateEnumUsers() (*IEnumDiskQuotaUsers, error) {
+	var enum *IEnumDiskQuotaUsers
+
+	hr, _, _ := syscall.SyscallN(
+		c.lpVtbl.CreateEnumUsers,
+		uintptr(unsafe.Pointer(c)),
+		0, // all users
+		0,
+		userNameResolveNone,
+		uintptr(unsafe.Pointer(&enum)),
+	)
+	if hr != 0 {
+		return nil, fmt.Errorf("CreateEnumUsers failed: %w", ole.NewError(hr))
+	}
+
+	if enum == nil {
+		return nil, errors.New("CreateEnumUsers returned nil")
+	}
+
+	return enum, nil
+}
+
+func (c *IDiskQuotaControl) Release() {
+	_, _, _ = syscall.SyscallN(
+		c.lpVtbl.Release,
+		uintptr(unsafe.Pointer(c)),
+	)
+}
+
+// Next returns the next quota entry. nil is returned after the last entry.
+func (e *IEnumDiskQuotaUsers) Next() (*IDiskQuotaUser, error) {
+	var (
+		user    *IDiskQuotaUser
+		fetched uint32
+	)
+
+	hr, _, _ := syscall.SyscallN(
+		e.lpVtbl.Next,
+		uintptr(unsafe.Pointer(e)),
+		1,
+		uintptr(unsafe.Pointer(&user)),
+		uintptr(unsafe.Pointer(&fetched)),
+	)
+
+	switch {
+	case hr == sFalse || (hr == 0 && fetched == 0):
+		return nil, nil //nolint:nilnil
+	case hr != 0:
+		return nil, fmt.Errorf("Next failed: %w", ole.NewError(hr))
+	}
+
+	return user, nil
+}
+
+func (e *IEnumDiskQuotaUsers) Release() {
+	_, _, _ = syscall.SyscallN(
+		e.lpVtbl.Release,
+		uintptr(unsafe.Pointer(e)),
+	)
+}
+
+// GetSid returns the SID of the quota entry.
+func (u *IDiskQuotaUser) GetSid() (*windows.SID, error) {
+	var sidLength uint32
+
+	hr, _, _ := syscall.SyscallN(
+		u.lpVtbl.GetSidLength,
+		uintptr(unsafe.Pointer(u)),
+		uintptr(unsafe.Pointer(&sidLength)),
+	)
+	if hr != 0 {
+		return nil, fmt.Errorf("GetSidLength failed: %w", ole.NewError(hr))
+	}
+
+	if sidLength == 0 {
+		return nil, errors.New("GetSidLength returned 0")
+	}
+
+	buf := make([]byte, sidLength)
+
+	hr, _, _ = syscall.SyscallN(
+		u.lpVtbl.GetSid,
+		uintptr(unsafe.Pointer(u)),
+		uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(sidLength),
+	)
+	if hr != 0 {
+		return nil, fmt.Errorf("GetSid failed: %w", ole.NewError(hr))
+	}
+
+	return (*windows.SID)(unsafe.Pointer(&buf[0])).Copy()
+}
+
+// GetQuotaInformation returns the used bytes, the threshold and the limit of the quota entry.
+func (u *IDiskQuotaUser) GetQuotaInformation() (UserInformation, error) {
+	var info UserInformation
+
+	hr, _, _ := syscall.SyscallN(
+		u.lpVtbl.GetQuotaInformation,
+		uintptr(unsafe.Pointer(u)),
+		uintptr(unsafe.Pointer(&info)),
+		unsafe.Sizeof(info),
+	)
+	if hr != 0 {
+		return UserInformation{}, fmt.Errorf("GetQuotaInformation failed: %w", ole.NewError(hr))
+	}
+
+	return info, nil
+}
+
+func (u *IDiskQuotaUser) Release() {
+	_, _, _ = syscall.SyscallN(
+		u.lpVtbl.Release,
+		uintptr(unsafe.Pointer(u)),
+	)
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package dskquota
+
+// IDiskQuotaControl is the quota control object of a volume.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/dskquota/nn-dskquota-idiskquotacontrol
+type IDiskQuotaControl struct {
+	lpVtbl *iDiskQuotaControlVtbl
+}
+
+type iDiskQuotaControlVtbl struct {
+	// IUnknown
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+
+	// IConnectionPointContainer
+	EnumConnectionPoints uintptr
+	FindConnectionPoint  uintptr
+
+	// IDiskQuotaControl
+	Initialize                     uintptr
+	SetQuotaState                  uintptr
+	GetQuotaState                  uintptr
+	SetQuotaLogFlags               uintptr
+	GetQuotaLogFlags               uintptr
+	SetDefaultQuotaThreshold       uintptr
+	SetDefaultQuotaLimit           uintptr
+	GetDefaultQuotaThreshold       uintptr
+	GetDefaultQuotaThresholdText   uintptr
+	GetDefaultQuotaLimit           uintptr
+	GetDefaultQuotaLimitText       uintptr
+	AddUserSid                     uintptr
+	AddUserName                    uintptr
+	DeleteUser                     uintptr
+	FindUserSid                    uintptr
+	FindUserName                   uintptr
+	CreateEnumUsers                uintptr
+	CreateUserBatch                uintptr
+	InvalidateSidNameCache         uintptr
+	GiveUserNameResolutionPriority uintptr
+	ShutdownNameResolution         uintptr
+}
+
+// IEnumDiskQuotaUsers enumerates the quota entries of a volume.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/dskquota/nn-dskquota-ienumdiskquotausers
+type IEnumDiskQuotaUsers struct {
+	lpVtbl *iEnumDiskQuotaUsersVtbl
+}
+
+type iEnumDiskQuotaUsersVtbl struct {
+	// IUnknown
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+
+	// IEnumDiskQuotaUsers
+	Next  uintptr
+	Skip  uintptr
+	Reset uintptr
+	Clone uintptr
+}
+
+// IDiskQuotaUser is a single quota entry of a volume.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/dskquota/nn-dskquota-idiskquotauser
+type IDiskQuotaUser struct {
+	lpVtbl *iDiskQuotaUserVtbl
+}
+
+type iDiskQuotaUserVtbl struct {
+	// IUnknown
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+
+	// IDiskQuotaUser
+	GetID                 uintptr
+	GetName               uintptr
+	GetSidLength          uintptr
+	GetSid                uintptr
+	GetQuotaThreshold     uintptr
+	GetQuotaThresholdText uintptr
+	GetQuotaLimit         uintptr
+	GetQuotaLimitText     uintptr
+	GetQuotaUsed          uintptr
+	GetQuotaUsedText      uintptr
+	GetQuotaInformation   uintptr
+	SetQuotaThreshold     uintptr
+	SetQuotaLimit         uintptr
+	Invalidate            uintptr
+	GetAccountStatus      uintptr
+}
+
+// UserInformation is DISKQUOTA_USER_INFORMATION.
+// A threshold or limit of NoLimit means, that no threshold or limit is set.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/dskquota/ns-dskquota-diskquota_user_information
+type UserInformation struct {
+	QuotaUsed      int64
+	QuotaThreshold int64
+	QuotaLimit     int64
+}
@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package etw provides a minimal real-time consumer for Event Tracing for Windows (ETW).
+package etw
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+//nolint:gochecknoglobals
+var (
+	advapi32           = windows.NewLazySystemDLL("advapi32.dll")
+	procStartTraceW    = advapi32.NewProc("StartTraceW")
+	procControlTraceW  = advapi32.NewProc("ControlTraceW")
+	procEnableTraceEx2 = advapi32.NewProc("EnableTraceEx2")
+	procOpenTraceW     = advapi32.NewProc("OpenTraceW")
+	procProcessTrace   = advapi32.NewProc("ProcessTrace")
+	procCloseTrace     = advapi32.NewProc("CloseTrace")
+)
+
+//nolint:gochecknoglobals
+var (
+	// eventRecordCallback is shared by all sessions, since the number of callbacks
+	// created by [windows.NewCallback] is limited. The session is identified
+	// by the UserContext of the event record.
+	eventRecordCallback = windows.NewCallback(func(record *EventRecord) uintptr {
+		if handler, ok := handlers.Load(record.UserContext); ok {
+			handler.(EventHandler)(record) //nolint:forcetypeassert
+		}
+
+		return 0
+	})
+
+	handlers      sync.Map
+	nextSessionID atomic.Uintptr
+)
+
+// EventHandler is called for each event received by a [Session].
+// The record and its user data are only valid for the duration of the call.
+type EventHandler func(record *EventRecord)
+
+// Session is a real-time ETW trace session.
+type Session struct {
+	name        *uint16
+	properties  []byte
+	logFileMode uint32
+	enableFlags uint32
+	// rawTimestamps disables the conversion of the event timestamps to system time.
+	rawTimestamps bool
+
+	sessionHandle uint64
+	traceHandle   uint64
+	id            uintptr
+	done          chan error
+}
+
+// StartSession starts a new real-time trace session with the given name.
+// A stale session with the same name, e.g. left behind by a crashed process, is stopped first.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/evntrace/nf-evntrace-starttracew
+func StartSession(name string) (*Session, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return startSession(name, &Session{
+		name:        namePtr,
+		logFileMode: eventTraceRealTimeMode,
+		traceHandle: invalidProcessTraceHandle,
+	})
+}
+
+// StartSystemSession starts a new real-time trace session, which receives the kernel events
+// selected by enableFlags, e.g. [EnableFlagDPC]. Unlike the NT Kernel Logger, multiple system sessions
+// can run at the same time. EventHeader.TimeStamp of the events is a QueryPerformanceCounter value.
+// Requires Windows 8 or later and administrative privileges.
+//
+// https://learn.microsoft.com/en-us/windows/win32/etw/configuring-and-starting-a-systemtraceprovider-session
+func StartSystemSession(name string, enableFlags uint32) (*Session, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return startSession(name, &Session{
+		name:          namePtr,
+		logFileMode:   eventTraceRealTimeMode | eventTraceSystemLoggerMode,
+		enableFlags:   enableFlags,
+		rawTimestamps: true,
+		traceHandle:   invalidProcessTraceHandle,
+	})
+}
+
+// startSession starts the session. A stale session with the same name is stopped first.
+func startSession(name string, session *Session) (*Session, error) {
+	err := session.start()
+	if errors.Is(err, windows.ERROR_ALREADY_EXISTS) {
+		if err = session.stop(); err != nil {
+			return nil, fmt.Errorf("failed to stop existing trace session %s: %w", name, err)
+		}
+
+		err = session.start()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to start trace session %s: %w", name, err)
+	}
+
+	return session, nil
+}
+
+func (s *Session) start() error {
+	s.properties = newTraceProperties(s.logFileMode, s.enableFlags)
+
+	ret, _, _ := procStartTraceW.Call(
+		uintptr(unsafe.Pointer(&s.sessionHandle)),
+		uintptr(unsafe.Pointer(s.name)),
+		uintptr(unsafe.Pointer(&s.properties[0])),
+	)
+	if ret != 0 {
+		return windows.Errno(ret)
+	}
+
+	return nil
+}
+
+// stop stops the trace session by name.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/evntrace/nf-evntrace-controltracew
+func (s *Session) stop() error {
+	properties := newTraceProperties(eventTraceRealTimeMode, 0)
+
+	ret, _, _ := procControlTraceW.Call(
+		0,
+		uintptr(unsafe.Pointer(s.name)),
+		uintptr(unsafe.Pointer(&properties[0])),
+		eventTraceControlStop,
+	)
+	if ret != 0 && !errors.Is(windows.Errno(ret), windows.ERROR_MORE_DATA) {
+		return windows.Errno(ret)
+	}
+
+	return nil
+}
+
+// EnableProvider enables the given provider on the session.
+// A matchAnyKeyword of 0 enables all events of the provider.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/evntrace/nf-evntrace-enabletraceex2
+func (s *Session) EnableProvider(provider windows.GUID, level uint8, matchAnyKeyword uint64) error {
+	ret, _, _ := procEnableTraceEx2.Call(
+		uintptr(s.sessionHandle),
+		uintptr(unsafe.Pointer(&provider)),
+		eventControlCodeEnable,
+		uintptr(level),
+		uintptr(matchAnyKeyword),
+		0,
+		0,
+		0,
+	)
+	if ret != 0 {
+		return fmt.Errorf("failed to enable provider %s: %w", provider, windows.Errno(ret))
+	}
+
+	return nil
+}
+
+// Process opens the session for real-time consumption and calls handler for each event
+// on a dedicated goroutine until the session is closed.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/evntrace/nf-evntrace-processtrace
+func (s *Session) Process(handler EventHandler) error {
+	s.id = nextSessionID.Add(1)
+	handlers.Store(s.id, handler)
+
+	processTraceMode := uint32(processTraceModeRealTime | processTraceModeRecord)
+	if s.rawTimestamps {
+		processTraceMode |= processTraceModeRawTimestamp
+	}
+
+	logfile := eventTraceLogfile{
+		LoggerName:          s.name,
+		ProcessTraceMode:    processTraceMode,
+		EventRecordCallback: eventRecordCallback,
+		Context:             s.id,
+	}
+
+	ret, _, err := procOpenTraceW.Call(uintptr(unsafe.Pointer(&logfile)))
+	if uint64(ret) == invalidProcessTraceHandle {
+		handlers.Delete(s.id)
+
+		return fmt.Errorf("failed to open trace session: %w", err)
+	}
+
+	s.traceHandle = uint64(ret)
+	s.done = make(chan error, 1)
+
+	go func() {
+		ret, _, _ := procProcessTrace.Call(
+			uintptr(unsafe.Pointer(&s.traceHandle)),
+			1,
+			0,
+			0,
+		)
+		if ret != 0 && !errors.Is(windows.Errno(ret), windows.ERROR_CANCELLED) {
+			s.done <- windows.Errno(ret)
+		}
+
+		close(s.done)
+	}()
+
+	return nil
+}
+
+// Close stops the trace session and waits until all pending events are processed.
+func (s *Session) Close() error {
+	err := s.stop()
+
+	if s.traceHandle != invalidProcessTraceHandle {
+		ret, _, _ := procCloseTrace.Call(uintptr(s.traceHandle))
+		if ret != 0 && !errors.Is(windows.Errno(ret), windows.ERROR_CTX_CLOSE_PENDING) {
+			err = errors.Join(err, fmt.Errorf("failed to close trace: %w", windows.Errno(ret)))
+		}
+
+		if processErr := <-s.done; processErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to process trace: %w", processErr))
+		}
+
+		s.traceHandle = invalidProcessTraceHandle
+	}
+
+	handlers.Delete(s.id)
+
+	return err
+}
+
+// newTraceProperties returns an EVENT_TRACE_PROPERTIES structure for a real-time session,
+// followed by enough space for the logger name.
+func newTraceProperties(logFileMode, enableFlags uint32) []byte {
+	size := unsafe.Sizeof(eventTraceProperties{})
+	buf := make([]byte, size+maxLoggerNameLength)
+
+	properties := (*eventTraceProperties)(unsafe.Pointer(&buf[0]))
+	properties.Wnode.BufferSize = uint32(len(buf))
+	properties.Wnode.Flags = wnodeFlagTracedGUID
+	properties.Wnode.ClientContext = 1 // QueryPerformanceCounter
+	properties.LogFileMode = logFileMode
+	properties.EnableFlags = enableFlags
+	properties.LoggerNameOffset = uint32(size)
+
+	return buf
+}
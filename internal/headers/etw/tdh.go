@@ -0,0 +1,226 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package etw
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+//nolint:gochecknoglobals
+var (
+	tdh                        = windows.NewLazySystemDLL("tdh.dll")
+	procTdhGetEventInformation = tdh.NewProc("TdhGetEventInformation")
+	procTdhFormatProperty      = tdh.NewProc("TdhFormatProperty")
+)
+
+var ErrUnsupportedProperty = errors.New("unsupported event property")
+
+type eventKey struct {
+	provider windows.GUID
+	id       uint16
+	version  uint8
+}
+
+// Decoder formats the properties of event records using the Trace Data Helper (TDH) API.
+// The event schema is cached per provider, event ID and version.
+// A Decoder is not safe for concurrent use, which is fine within an [EventHandler].
+type Decoder struct {
+	schemas map[eventKey][]byte
+	buf     []uint16
+}
+
+// NewDecoder returns a new Decoder.
+func NewDecoder() *Decoder {
+	return &Decoder{
+		schemas: make(map[eventKey][]byte),
+		buf:     make([]uint16, 256),
+	}
+}
+
+// Properties returns the formatted values of the named top-level properties of the event.
+// Decoding stops as soon as all requested properties are found, so properties
+// after them in the payload are not decoded.
+func (d *Decoder) Properties(record *EventRecord, names ...string) (map[string]string, error) {
+	if record.EventHeader.Flags&eventHeaderFlagStringOnly != 0 {
+		return nil, fmt.Errorf("%w: string only event", ErrUnsupportedProperty)
+	}
+
+	schema, err := d.schema(record)
+	if err != nil {
+		return nil, err
+	}
+
+	info := (*traceEventInfo)(unsafe.Pointer(&schema[0]))
+	properties := unsafe.Slice(
+		(*eventPropertyInfo)(unsafe.Add(unsafe.Pointer(&schema[0]), unsafe.Sizeof(traceEventInfo{}))),
+		info.PropertyCount,
+	)
+
+	wanted := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		wanted[name] = struct{}{}
+	}
+
+	pointerSize := uintptr(8)
+	if record.EventHeader.Flags&eventHeaderFlag32BitHeader != 0 {
+		pointerSize = 4
+	}
+
+	values := make(map[string]string, len(names))
+	formatted := make([]string, 0, info.TopLevelPropertyCount)
+	userData := record.UserData
+	userDataLength := uintptr(record.UserDataLength)
+
+	for _, property := range properties[:info.TopLevelPropertyCount] {
+		if len(wanted) == 0 {
+			break
+		}
+
+		name := windows.UTF16PtrToString((*uint16)(unsafe.Add(unsafe.Pointer(&schema[0]), property.NameOffset)))
+
+		// Structures and arrays are not supported.
+		if property.Flags&(propertyFlagStruct|propertyFlagParamCount) != 0 || property.Count > 1 {
+			return values, fmt.Errorf("%w: %s", ErrUnsupportedProperty, name)
+		}
+
+		length := property.Length
+
+		if property.Flags&propertyFlagParamLength != 0 {
+			if int(property.Length) >= len(formatted) {
+				return values, fmt.Errorf("%w: %s", ErrUnsupportedProperty, name)
+			}
+
+			paramLength, err := strconv.ParseUint(formatted[property.Length], 10, 16)
+			if err != nil {
+				return values, fmt.Errorf("%w: %s", ErrUnsupportedProperty, name)
+			}
+
+			length = uint16(paramLength)
+		}
+
+		value, consumed, err := d.formatProperty(schema, property, length, pointerSize, userData, userDataLength)
+		if err != nil {
+			return values, fmt.Errorf("failed to format property %s: %w", name, err)
+		}
+
+		if _, ok := wanted[name]; ok {
+			values[name] = value
+
+			delete(wanted, name)
+		}
+
+		formatted = append(formatted, value)
+		userData += uintptr(consumed)
+		userDataLength -= uintptr(consumed)
+	}
+
+	return values, nil
+}
+
+// schema returns the TRACE_EVENT_INFO of the event.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/tdh/nf-tdh-tdhgeteventinformation
+func (d *Decoder) schema(record *EventRecord) ([]byte, error) {
+	key := eventKey{
+		provider: record.EventHeader.ProviderID,
+		id:       record.EventHeader.EventDescriptor.ID,
+		version:  record.EventHeader.EventDescriptor.Version,
+	}
+
+	if schema, ok := d.schemas[key]; ok {
+		return schema, nil
+	}
+
+	var bufferSize uint32
+
+	ret, _, _ := procTdhGetEventInformation.Call(
+		uintptr(unsafe.Pointer(record)),
+		0,
+		0,
+		0,
+		uintptr(unsafe.Pointer(&bufferSize)),
+	)
+	if !errors.Is(windows.Errno(ret), windows.ERROR_INSUFFICIENT_BUFFER) {
+		return nil, fmt.Errorf("TdhGetEventInformation failed: %w", windows.Errno(ret))
+	}
+
+	// Use a []uint64 as backing array, to keep the structure 8 byte aligned.
+	aligned := make([]uint64, (bufferSize+7)/8)
+	schema := unsafe.Slice((*byte)(unsafe.Pointer(&aligned[0])), bufferSize)
+
+	ret, _, _ = procTdhGetEventInformation.Call(
+		uintptr(unsafe.Pointer(record)),
+		0,
+		0,
+		uintptr(unsafe.Pointer(&schema[0])),
+		uintptr(unsafe.Pointer(&bufferSize)),
+	)
+	if ret != 0 {
+		return nil, fmt.Errorf("TdhGetEventInformation failed: %w", windows.Errno(ret))
+	}
+
+	d.schemas[key] = schema
+
+	return schema, nil
+}
+
+// formatProperty formats a single property and returns the number of user data bytes consumed.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/tdh/nf-tdh-tdhformatproperty
+func (d *Decoder) formatProperty(
+	schema []byte,
+	property eventPropertyInfo,
+	length uint16,
+	pointerSize uintptr,
+	userData uintptr,
+	userDataLength uintptr,
+) (string, uint16, error) {
+	for {
+		var consumed uint16
+
+		bufferSize := uint32(len(d.buf) * 2)
+
+		ret, _, _ := procTdhFormatProperty.Call(
+			uintptr(unsafe.Pointer(&schema[0])),
+			0,
+			pointerSize,
+			uintptr(property.InType),
+			uintptr(property.OutType),
+			uintptr(length),
+			userDataLength,
+			userData,
+			uintptr(unsafe.Pointer(&bufferSize)),
+			uintptr(unsafe.Pointer(&d.buf[0])),
+			uintptr(unsafe.Pointer(&consumed)),
+		)
+
+		switch {
+		case ret == 0:
+			return windows.UTF16ToString(d.buf), consumed, nil
+		case errors.Is(windows.Errno(ret), windows.ERROR_INSUFFICIENT_BUFFER):
+			d.buf = make([]uint16, bufferSize/2+1)
+		default:
+			return "", 0, windows.Errno(ret)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package etw
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	wnodeFlagTracedGUID = 0x00020000
+
+	eventTraceRealTimeMode       = 0x00000100
+	eventTraceSystemLoggerMode   = 0x02000000
+	eventTraceControlStop        = 1
+	eventControlCodeEnable       = 1
+	processTraceModeRealTime     = 0x00000100
+	processTraceModeRecord       = 0x10000000
+	processTraceModeRawTimestamp = 0x00001000
+
+	eventHeaderFlagStringOnly  = 0x0004
+	eventHeaderFlag32BitHeader = 0x0020
+	eventHeaderFlag64BitHeader = 0x0040
+	invalidProcessTraceHandle  = ^uint64(0)
+	maxLoggerNameLength        = 1024
+	propertyFlagStruct         = 0x1
+	propertyFlagParamLength    = 0x2
+	propertyFlagParamCount     = 0x4
+)
+
+// Trace levels passed to [Session.EnableProvider].
+const (
+	LevelCritical    = 1
+	LevelError       = 2
+	LevelWarning     = 3
+	LevelInformation = 4
+	LevelVerbose     = 5
+)
+
+// Kernel event flags passed to [StartSystemSession].
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/evntrace/ns-evntrace-event_trace_properties
+const (
+	EnableFlagDPC       = 0x00000020 // EVENT_TRACE_FLAG_DPC
+	EnableFlagInterrupt = 0x00000040 // EVENT_TRACE_FLAG_INTERRUPT
+)
+
+// wnodeHeader is WNODE_HEADER.
+//
+// https://learn.microsoft.com/en-us/windows/win32/etw/wnode-header
+type wnodeHeader struct {
+	BufferSize        uint32
+	ProviderID        uint32
+	HistoricalContext uint64
+	TimeStamp         int64
+	GUID              windows.GUID
+	ClientContext     uint32
+	Flags             uint32
+}
+
+// eventTraceProperties is EVENT_TRACE_PROPERTIES.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/evntrace/ns-evntrace-event_trace_properties
+type eventTraceProperties struct {
+	Wnode               wnodeHeader
+	BufferSize          uint32
+	MinimumBuffers      uint32
+	MaximumBuffers      uint32
+	MaximumFileSize     uint32
+	LogFileMode         uint32
+	FlushTimer          uint32
+	EnableFlags         uint32
+	AgeLimit            int32
+	NumberOfBuffers     uint32
+	FreeBuffers         uint32
+	EventsLost          uint32
+	BuffersWritten      uint32
+	LogBuffersLost      uint32
+	RealTimeBuffersLost uint32
+	LoggerThreadID      windows.Handle
+	LogFileNameOffset   uint32
+	LoggerNameOffset    uint32
+}
+
+// eventTraceHeader is EVENT_TRACE_HEADER.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/evntrace/ns-evntrace-event_trace_header
+type eventTraceHeader struct {
+	Size           uint16
+	FieldTypeFlags uint16
+	Version        uint32
+	ThreadID       uint32
+	ProcessID      uint32
+	TimeStamp      int64
+	GUID           windows.GUID
+	ProcessorTime  uint64
+}
+
+// eventTrace is EVENT_TRACE.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/evntrace/ns-evntrace-event_trace
+type eventTrace struct {
+	Header           eventTraceHeader
+	InstanceID       uint32
+	ParentInstanceID uint32
+	ParentGUID       windows.GUID
+	MofData          uintptr
+	MofLength        uint32
+	ClientContext    uint32
+}
+
+// traceLogfileHeader is TRACE_LOGFILE_HEADER.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/evntrace/ns-evntrace-trace_logfile_header
+type traceLogfileHeader struct {
+	BufferSize         uint32
+	Version            uint32
+	ProviderVersion    uint32
+	NumberOfProcessors uint32
+	EndTime            int64
+	TimerResolution    uint32
+	MaximumFileSize    uint32
+	LogFileMode        uint32
+	BuffersWritten     uint32
+	LogInstanceGUID    windows.GUID
+	LoggerName         *uint16
+	LogFileName        *uint16
+	TimeZone           windows.Timezoneinformation
+	BootTime           int64
+	PerfFreq           int64
+	StartTime          int64
+	ReservedFlags      uint32
+	BuffersLost        uint32
+}
+
+// eventTraceLogfile is EVENT_TRACE_LOGFILEW.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/evntrace/ns-evntrace-event_trace_logfilew
+type eventTraceLogfile struct {
+	LogFileName         *uint16
+	LoggerName          *uint16
+	CurrentTime         int64
+	BuffersRead         uint32
+	ProcessTraceMode    uint32
+	CurrentEvent        eventTrace
+	LogfileHeader       traceLogfileHeader
+	BufferCallback      uintptr
+	BufferSize          uint32
+	Filled              uint32
+	EventsLost          uint32
+	EventRecordCallback uintptr
+	IsKernelTrace       uint32
+	Context             uintptr
+}
+
+// EventDescriptor is EVENT_DESCRIPTOR.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/evntprov/ns-evntprov-event_descriptor
+type EventDescriptor struct {
+	ID      uint16
+	Version uint8
+	Channel uint8
+	Level   uint8
+	Opcode  uint8
+	Task    uint16
+	Keyword uint64
+}
+
+// EventHeader is EVENT_HEADER.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/evntcons/ns-evntcons-event_header
+type EventHeader struct {
+	Size            uint16
+	HeaderType      uint16
+	Flags           uint16
+	EventProperty   uint16
+	ThreadID        uint32
+	ProcessID       uint32
+	TimeStamp       int64
+	ProviderID      windows.GUID
+	EventDescriptor EventDescriptor
+	ProcessorTime   uint64
+	ActivityID      windows.GUID
+}
+
+// EventRecord is EVENT_RECORD.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/evntcons/ns-evntcons-event_record
+type EventRecord struct {
+	EventHeader       EventHeader
+	BufferContext     uint32
+	ExtendedDataCount uint16
+	UserDataLength    uint16
+	ExtendedData      uintptr
+	UserData          uintptr
+	UserContext       uintptr
+}
+
+// Data returns the payload of the event. The slice is only valid for the duration of the [EventHandler] call.
+func (r *EventRecord) Data() []byte {
+	if r.UserData == 0 || r.UserDataLength == 0 {
+		return nil
+	}
+
+	// UserData points to a buffer owned by ETW, which is not moved by the garbage collector.
+	userData := *(*unsafe.Pointer)(unsafe.Pointer(&r.UserData))
+
+	return unsafe.Slice((*byte)(userData), r.UserDataLength)
+}
+
+// PointerSize returns the size of pointers in the payload of the event.
+func (r *EventRecord) PointerSize() int {
+	if r.EventHeader.Flags&eventHeaderFlag32BitHeader != 0 {
+		return 4
+	}
+
+	return 8
+}
+
+// traceEventInfo is the fixed part of TRACE_EVENT_INFO.
+// The EVENT_PROPERTY_INFO array follows directly after the structure.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/tdh/ns-tdh-trace_event_info
+type traceEventInfo struct {
+	ProviderGUID                windows.GUID
+	EventGUID                   windows.GUID
+	EventDescriptor             EventDescriptor
+	DecodingSource              uint32
+	ProviderNameOffset          uint32
+	LevelNameOffset             uint32
+	ChannelNameOffset           uint32
+	KeywordsNameOffset          uint32
+	TaskNameOffset              uint32
+	OpcodeNameOffset            uint32
+	EventMessageOffset          uint32
+	ProviderMessageOffset       uint32
+	BinaryXMLOffset             uint32
+	BinaryXMLSize               uint32
+	ActivityIDNameOffset        uint32
+	RelatedActivityIDNameOffset uint32
+	PropertyCount               uint32
+	TopLevelPropertyCount       uint32
+	Flags                       uint32
+}
+
+// eventPropertyInfo is EVENT_PROPERTY_INFO for non-struct properties.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/tdh/ns-tdh-event_property_info
+type eventPropertyInfo struct {
+	Flags         uint32
+	NameOffset    uint32
+	InType        uint16
+	OutType       uint16
+	MapNameOffset uint32
+	Count         uint16
+	Length        uint16
+	Tags          uint32
+}
@@ -38,6 +38,12 @@ var (
 )
 
 // CreateOperation creates a new operation.
+// Available returns an error if the Host Compute Service API can't be loaded,
+// e.g. if the Containers feature is not installed.
+func Available() error {
+	return procHcsEnumerateComputeSystems.Find()
+}
+
 func CreateOperation() (Operation, error) {
 	r1, r2, _ := procHcsCreateOperation.Call(0, 0)
 	if r2 != 0 {
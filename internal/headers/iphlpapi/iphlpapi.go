@@ -62,6 +62,44 @@ func GetTCPConnectionStates(family uint32) (map[MIB_TCP_STATE]uint32, error) {
 	}
 }
 
+// TCPStatePort is a TCP connection state and local port.
+type TCPStatePort struct {
+	State     MIB_TCP_STATE
+	LocalPort uint16
+}
+
+// GetTCPConnectionStatesByPort returns the number of TCP connections per state and local port.
+func GetTCPConnectionStatesByPort(family uint32) (map[TCPStatePort]uint32, error) {
+	counts := make(map[TCPStatePort]uint32)
+
+	switch family {
+	case windows.AF_INET:
+		table, err := getExtendedTcpTable[MIB_TCPROW_OWNER_PID](family, TCPTableOwnerPIDAll)
+		if err != nil {
+			return nil, fmt.Errorf("failed getExtendedTcpTable: %w", err)
+		}
+
+		for _, row := range table {
+			counts[TCPStatePort{State: row.dwState, LocalPort: row.dwLocalPort.uint16()}]++
+		}
+
+		return counts, nil
+	case windows.AF_INET6:
+		table, err := getExtendedTcpTable[MIB_TCP6ROW_OWNER_PID](family, TCPTableOwnerPIDAll)
+		if err != nil {
+			return nil, fmt.Errorf("failed getExtendedTcpTable: %w", err)
+		}
+
+		for _, row := range table {
+			counts[TCPStatePort{State: row.dwState, LocalPort: row.dwLocalPort.uint16()}]++
+		}
+
+		return counts, nil
+	default:
+		return nil, fmt.Errorf("unsupported address family %d", family)
+	}
+}
+
 func GetOwnerPIDOfTCPPort(family uint32, tcpPort uint16) (uint32, error) {
 	switch family {
 	case windows.AF_INET:
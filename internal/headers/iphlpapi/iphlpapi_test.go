@@ -35,6 +35,14 @@ func TestGetTCPConnectionStates(t *testing.T) {
 	require.NotEmpty(t, pid)
 }
 
+func TestGetTCPConnectionStatesByPort(t *testing.T) {
+	t.Parallel()
+
+	states, err := iphlpapi.GetTCPConnectionStatesByPort(windows.AF_INET)
+	require.NoError(t, err)
+	require.NotEmpty(t, states)
+}
+
 func TestGetOwnerPIDOfTCPPort(t *testing.T) {
 	t.Parallel()
 
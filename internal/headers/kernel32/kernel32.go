@@ -32,6 +32,10 @@ var (
 	procGetTickCount                     = modkernel32.NewProc("GetTickCount64")
 	procOpenJobObject                    = modkernel32.NewProc("OpenJobObjectW")
 	procIsProcessInJob                   = modkernel32.NewProc("IsProcessInJob")
+	procGetSystemPowerStatus             = modkernel32.NewProc("GetSystemPowerStatus")
+	procQueryPerformanceFrequency        = modkernel32.NewProc("QueryPerformanceFrequency")
+	procGetNumaHighestNodeNumber         = modkernel32.NewProc("GetNumaHighestNodeNumber")
+	procGetNumaNodeProcessorMaskEx       = modkernel32.NewProc("GetNumaNodeProcessorMaskEx")
 )
 
 // SYSTEMTIME contains a date and time.
@@ -87,3 +91,83 @@ func GetTickCount64() uint64 {
 
 	return uint64(ret)
 }
+
+// SystemPowerStatus contains information about the power status of the system.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/winbase/ns-winbase-system_power_status
+type SystemPowerStatus struct {
+	ACLineStatus        uint8
+	BatteryFlag         uint8
+	BatteryLifePercent  uint8
+	SystemStatusFlag    uint8
+	BatteryLifeTime     uint32
+	BatteryFullLifeTime uint32
+}
+
+const (
+	// ACLineStatusOffline indicates that the system is running on battery.
+	ACLineStatusOffline = 0
+	// BatteryFlagNoSystemBattery indicates that the system has no battery.
+	BatteryFlagNoSystemBattery = 128
+	// BatteryStatusUnknown is used by ACLineStatus, BatteryFlag and BatteryLifePercent if the status is unknown.
+	BatteryStatusUnknown = 255
+)
+
+// GetSystemPowerStatus retrieves the power status of the system.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/winbase/nf-winbase-getsystempowerstatus
+func GetSystemPowerStatus() (SystemPowerStatus, error) {
+	var status SystemPowerStatus
+
+	r0, _, err := procGetSystemPowerStatus.Call(uintptr(unsafe.Pointer(&status)))
+	if r0 == 0 {
+		return status, err
+	}
+
+	return status, nil
+}
+
+// QueryPerformanceFrequency returns the frequency of the performance counter in counts per second.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/profileapi/nf-profileapi-queryperformancefrequency
+func QueryPerformanceFrequency() (int64, error) {
+	var frequency int64
+
+	r1, _, err := procQueryPerformanceFrequency.Call(uintptr(unsafe.Pointer(&frequency)))
+	if r1 == 0 {
+		return 0, err
+	}
+
+	return frequency, nil
+}
+
+// GroupAffinity represents a processor group-specific affinity.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/winnt/ns-winnt-group_affinity
+type GroupAffinity struct {
+	Mask     uintptr
+	Group    uint16
+	Reserved [3]uint16
+}
+
+// GetNumaHighestNodeNumber retrieves the node that currently has the highest number.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/systemtopologyapi/nf-systemtopologyapi-getnumahighestnodenumber
+func GetNumaHighestNodeNumber() (uint32, error) {
+	var highestNodeNumber uint32
+
+	r1, _, err := procGetNumaHighestNodeNumber.Call(uintptr(unsafe.Pointer(&highestNodeNumber)))
+	if r1 == 0 {
+		return 0, err
+	}
+
+	return highestNodeNumber, nil
+}
+
+// GetNumaNodeProcessorMaskEx retrieves the processor mask of the specified node.
+// 📑 https://learn.microsoft.com/en-us/windows/win32/api/systemtopologyapi/nf-systemtopologyapi-getnumanodeprocessormaskex
+func GetNumaNodeProcessorMaskEx(node uint16) (GroupAffinity, error) {
+	var affinity GroupAffinity
+
+	r1, _, err := procGetNumaNodeProcessorMaskEx.Call(uintptr(node), uintptr(unsafe.Pointer(&affinity)))
+	if r1 == 0 {
+		return affinity, err
+	}
+
+	return affinity, nil
+}
@@ -18,13 +18,20 @@
 package ntdll
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"unsafe"
+
 	"golang.org/x/sys/windows"
 )
 
 //nolint:gochecknoglobals
 var (
-	modNtdll                  = windows.NewLazySystemDLL("ntdll.dll")
-	procRtlNtStatusToDosError = modNtdll.NewProc("RtlNtStatusToDosError")
+	modNtdll                   = windows.NewLazySystemDLL("ntdll.dll")
+	procRtlNtStatusToDosError  = modNtdll.NewProc("RtlNtStatusToDosError")
+	procNtOpenDirectoryObject  = modNtdll.NewProc("NtOpenDirectoryObject")
+	procNtQueryDirectoryObject = modNtdll.NewProc("NtQueryDirectoryObject")
 )
 
 func RtlNtStatusToDosError(status uintptr) error {
@@ -35,3 +42,201 @@ func RtlNtStatusToDosError(status uintptr) error {
 
 	return windows.Errno(ret)
 }
+
+// systemPoolTagInformation is the SystemPoolTagInformation information class of NtQuerySystemInformation.
+const systemPoolTagInformation = 22
+
+// SystemPoolTag is SYSTEM_POOLTAG.
+//
+// https://www.geoffchappell.com/studies/windows/km/ntoskrnl/api/ex/sysinfo/pooltag.htm
+type SystemPoolTag struct {
+	Tag            [4]byte
+	PagedAllocs    uint32
+	PagedFrees     uint32
+	PagedUsed      uintptr
+	NonPagedAllocs uint32
+	NonPagedFrees  uint32
+	NonPagedUsed   uintptr
+}
+
+// QuerySystemPoolTagInformation returns the kernel pool usage per pool tag.
+func QuerySystemPoolTagInformation() ([]SystemPoolTag, error) {
+	// Start with room for 4096 tags. The buffer is grown on STATUS_INFO_LENGTH_MISMATCH.
+	bufferSize := uint32(unsafe.Sizeof(uintptr(0)) + 4096*unsafe.Sizeof(SystemPoolTag{}))
+
+	for {
+		// Use a []uintptr as backing array, to keep the structure pointer aligned.
+		buffer := make([]uintptr, bufferSize/uint32(unsafe.Sizeof(uintptr(0)))+1)
+
+		var returnLength uint32
+
+		err := windows.NtQuerySystemInformation(systemPoolTagInformation, unsafe.Pointer(&buffer[0]), bufferSize, &returnLength)
+		if errors.Is(err, windows.STATUS_INFO_LENGTH_MISMATCH) {
+			bufferSize = max(returnLength, bufferSize*2)
+
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// SYSTEM_POOLTAG_INFORMATION is a ULONG count followed by the pointer aligned SYSTEM_POOLTAG array.
+		count := *(*uint32)(unsafe.Pointer(&buffer[0]))
+		if uintptr(count) > uintptr(len(buffer)-1)*unsafe.Sizeof(uintptr(0))/unsafe.Sizeof(SystemPoolTag{}) {
+			return nil, fmt.Errorf("pool tag count %d exceeds buffer size %d", count, bufferSize)
+		}
+
+		tags := unsafe.Slice((*SystemPoolTag)(unsafe.Pointer(&buffer[1])), count)
+
+		return slices.Clone(tags), nil
+	}
+}
+
+// ProcessIOCounters are the I/O transfer counters of a process, read from SYSTEM_PROCESS_INFORMATION.
+type ProcessIOCounters struct {
+	ProcessID          uint32
+	ImageName          string
+	ReadTransferCount  uint64
+	WriteTransferCount uint64
+	OtherTransferCount uint64
+}
+
+// QuerySystemProcessIOCounters returns the I/O counters of all processes. In contrast to
+// GetProcessIoCounters, no process handle is required, so protected processes are included as well.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winternl/nf-winternl-ntquerysysteminformation
+func QuerySystemProcessIOCounters() ([]ProcessIOCounters, error) {
+	bufferSize := uint32(256 * 1024)
+
+	for {
+		// Use a []uintptr as backing array, to keep the structures pointer aligned.
+		buffer := make([]uintptr, bufferSize/uint32(unsafe.Sizeof(uintptr(0)))+1)
+
+		var returnLength uint32
+
+		err := windows.NtQuerySystemInformation(windows.SystemProcessInformation, unsafe.Pointer(&buffer[0]), bufferSize, &returnLength)
+		if errors.Is(err, windows.STATUS_INFO_LENGTH_MISMATCH) {
+			// Processes may be started between both calls, so add some headroom.
+			bufferSize = max(returnLength+64*1024, bufferSize*2)
+
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		var processes []ProcessIOCounters
+
+		for offset := uintptr(0); ; {
+			if offset+unsafe.Sizeof(windows.SYSTEM_PROCESS_INFORMATION{}) > uintptr(returnLength) {
+				return nil, fmt.Errorf("process information at offset %d exceeds returned length %d", offset, returnLength)
+			}
+
+			info := (*windows.SYSTEM_PROCESS_INFORMATION)(unsafe.Add(unsafe.Pointer(&buffer[0]), offset))
+
+			processes = append(processes, ProcessIOCounters{
+				ProcessID:          uint32(info.UniqueProcessID),
+				ImageName:          info.ImageName.String(),
+				ReadTransferCount:  uint64(info.ReadTransferCount),
+				WriteTransferCount: uint64(info.WriteTransferCount),
+				OtherTransferCount: uint64(info.OtherTransferCount),
+			})
+
+			if info.NextEntryOffset == 0 {
+				return processes, nil
+			}
+
+			offset += uintptr(info.NextEntryOffset)
+		}
+	}
+}
+
+// directoryQuery is the DIRECTORY_QUERY access right of object directories.
+const directoryQuery = 0x0001
+
+// objectDirectoryInformation is OBJECT_DIRECTORY_INFORMATION.
+//
+// https://learn.microsoft.com/en-us/windows/win32/devnotes/ntquerydirectoryobject
+type objectDirectoryInformation struct {
+	Name     windows.NTUnicodeString
+	TypeName windows.NTUnicodeString
+}
+
+// QueryDirectoryObject returns the names of all objects of the given type
+// in an object manager directory, e.g. \BaseNamedObjects.
+func QueryDirectoryObject(directory string, typeName string) ([]string, error) {
+	directoryName, err := windows.NewNTUnicodeString(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	objectAttributes := windows.OBJECT_ATTRIBUTES{
+		ObjectName: directoryName,
+	}
+	objectAttributes.Length = uint32(unsafe.Sizeof(objectAttributes))
+
+	var handle windows.Handle
+
+	status, _, _ := procNtOpenDirectoryObject.Call(
+		uintptr(unsafe.Pointer(&handle)),
+		directoryQuery,
+		uintptr(unsafe.Pointer(&objectAttributes)),
+	)
+	if status != 0 {
+		return nil, fmt.Errorf("NtOpenDirectoryObject: %w", windows.NTStatus(status))
+	}
+
+	defer func(fd windows.Handle) {
+		_ = windows.Close(fd)
+	}(handle)
+
+	// Use a []uintptr as backing array, to keep the structures pointer aligned.
+	buffer := make([]uintptr, 64*1024/unsafe.Sizeof(uintptr(0)))
+	bufferSize := uint32(len(buffer)) * uint32(unsafe.Sizeof(uintptr(0)))
+
+	var (
+		names   []string
+		context uint32
+	)
+
+	for restartScan := uintptr(1); ; restartScan = 0 {
+		var returnLength uint32
+
+		status, _, _ = procNtQueryDirectoryObject.Call(
+			uintptr(unsafe.Pointer(&buffer[0])),
+			uintptr(bufferSize),
+			0,
+			restartScan,
+			uintptr(unsafe.Pointer(&context)),
+			uintptr(unsafe.Pointer(&returnLength)),
+		)
+
+		switch windows.NTStatus(status) {
+		case windows.STATUS_SUCCESS, windows.STATUS_MORE_ENTRIES:
+		case windows.STATUS_NO_MORE_ENTRIES:
+			return names, nil
+		default:
+			return nil, fmt.Errorf("NtQueryDirectoryObject: %w", windows.NTStatus(status))
+		}
+
+		// The buffer contains an array of OBJECT_DIRECTORY_INFORMATION, terminated by an empty entry.
+		entries := unsafe.Slice((*objectDirectoryInformation)(unsafe.Pointer(&buffer[0])),
+			uintptr(bufferSize)/unsafe.Sizeof(objectDirectoryInformation{}))
+
+		for _, entry := range entries {
+			if entry.Name.Length == 0 {
+				break
+			}
+
+			if entry.TypeName.String() == typeName {
+				names = append(names, entry.Name.String())
+			}
+		}
+
+		if windows.NTStatus(status) == windows.STATUS_SUCCESS {
+			return names, nil
+		}
+	}
+}
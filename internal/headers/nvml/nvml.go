@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package nvml loads the NVIDIA Management Library, which is installed with the NVIDIA display driver.
+//
+// https://docs.nvidia.com/deploy/nvml-api/index.html
+package nvml
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	SUCCESS             Return = 0
+	ERROR_NOT_SUPPORTED Return = 3
+
+	TEMPERATURE_GPU = 0
+
+	// DEVICE_NAME_V2_BUFFER_SIZE is the buffer size guaranteed to be large enough for nvmlDeviceGetName.
+	DEVICE_NAME_V2_BUFFER_SIZE = 96
+	// DEVICE_UUID_V2_BUFFER_SIZE is the buffer size guaranteed to be large enough for nvmlDeviceGetUUID.
+	DEVICE_UUID_V2_BUFFER_SIZE = 96
+)
+
+//nolint:gochecknoglobals
+var (
+	nvml = windows.NewLazySystemDLL("nvml.dll")
+
+	procNvmlInitV2                    = nvml.NewProc("nvmlInit_v2")
+	procNvmlShutdown                  = nvml.NewProc("nvmlShutdown")
+	procNvmlDeviceGetCountV2          = nvml.NewProc("nvmlDeviceGetCount_v2")
+	procNvmlDeviceGetHandleByIndexV2  = nvml.NewProc("nvmlDeviceGetHandleByIndex_v2")
+	procNvmlDeviceGetName             = nvml.NewProc("nvmlDeviceGetName")
+	procNvmlDeviceGetUUID             = nvml.NewProc("nvmlDeviceGetUUID")
+	procNvmlDeviceGetUtilizationRates = nvml.NewProc("nvmlDeviceGetUtilizationRates")
+	procNvmlDeviceGetMemoryInfo       = nvml.NewProc("nvmlDeviceGetMemoryInfo")
+	procNvmlDeviceGetTemperature      = nvml.NewProc("nvmlDeviceGetTemperature")
+	procNvmlDeviceGetPowerUsage       = nvml.NewProc("nvmlDeviceGetPowerUsage")
+)
+
+// returnNames contains the names of the nvmlReturn_t values, which are expected on Windows.
+//
+//nolint:gochecknoglobals
+var returnNames = map[Return]string{
+	1:   "UNINITIALIZED",
+	2:   "INVALID_ARGUMENT",
+	3:   "NOT_SUPPORTED",
+	4:   "NO_PERMISSION",
+	6:   "NOT_FOUND",
+	9:   "DRIVER_NOT_LOADED",
+	12:  "LIBRARY_NOT_FOUND",
+	15:  "GPU_IS_LOST",
+	999: "UNKNOWN",
+}
+
+// Interface guard.
+var _ error = SUCCESS
+
+var ErrLibraryNotLoaded = errors.New("nvml.dll is not loaded")
+
+// Return is nvmlReturn_t.
+type Return uint32
+
+func (r Return) Error() string {
+	if name, ok := returnNames[r]; ok {
+		return fmt.Sprintf("NVML_ERROR_%s (%d)", name, uint32(r))
+	}
+
+	return fmt.Sprintf("NVML error %d", uint32(r))
+}
+
+// Device is nvmlDevice_t.
+type Device uintptr
+
+// Utilization is nvmlUtilization_t. Both values are percentages over the last sample period.
+type Utilization struct {
+	GPU    uint32
+	Memory uint32
+}
+
+// Memory is nvmlMemory_t.
+type Memory struct {
+	Total uint64
+	Free  uint64
+	Used  uint64
+}
+
+// Available reports whether nvml.dll is installed.
+func Available() bool {
+	return nvml.Load() == nil
+}
+
+func call(proc *windows.LazyProc, args ...uintptr) error {
+	if err := proc.Find(); err != nil {
+		return fmt.Errorf("%w: %w", ErrLibraryNotLoaded, err)
+	}
+
+	r0, _, _ := proc.Call(args...)
+	if Return(r0) != SUCCESS {
+		return Return(r0)
+	}
+
+	return nil
+}
+
+// Init initializes NVML. Each successful call must be paired with a call to Shutdown.
+//
+// https://docs.nvidia.com/deploy/nvml-api/group__nvmlInitializationAndCleanup.html
+func Init() error {
+	return call(procNvmlInitV2)
+}
+
+func Shutdown() error {
+	return call(procNvmlShutdown)
+}
+
+func DeviceGetCount() (uint32, error) {
+	var count uint32
+
+	err := call(procNvmlDeviceGetCountV2, uintptr(unsafe.Pointer(&count)))
+
+	return count, err
+}
+
+func DeviceGetHandleByIndex(index uint32) (Device, error) {
+	var device Device
+
+	err := call(procNvmlDeviceGetHandleByIndexV2, uintptr(index), uintptr(unsafe.Pointer(&device)))
+
+	return device, err
+}
+
+func DeviceGetName(device Device) (string, error) {
+	buf := make([]byte, DEVICE_NAME_V2_BUFFER_SIZE)
+
+	if err := call(procNvmlDeviceGetName, uintptr(device), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf))); err != nil {
+		return "", err
+	}
+
+	return windows.ByteSliceToString(buf), nil
+}
+
+func DeviceGetUUID(device Device) (string, error) {
+	buf := make([]byte, DEVICE_UUID_V2_BUFFER_SIZE)
+
+	if err := call(procNvmlDeviceGetUUID, uintptr(device), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf))); err != nil {
+		return "", err
+	}
+
+	return windows.ByteSliceToString(buf), nil
+}
+
+func DeviceGetUtilizationRates(device Device) (Utilization, error) {
+	var utilization Utilization
+
+	err := call(procNvmlDeviceGetUtilizationRates, uintptr(device), uintptr(unsafe.Pointer(&utilization)))
+
+	return utilization, err
+}
+
+func DeviceGetMemoryInfo(device Device) (Memory, error) {
+	var memory Memory
+
+	err := call(procNvmlDeviceGetMemoryInfo, uintptr(device), uintptr(unsafe.Pointer(&memory)))
+
+	return memory, err
+}
+
+// DeviceGetTemperature returns the temperature of the given sensor in degrees Celsius.
+func DeviceGetTemperature(device Device, sensor uint32) (uint32, error) {
+	var temperature uint32
+
+	err := call(procNvmlDeviceGetTemperature, uintptr(device), uintptr(sensor), uintptr(unsafe.Pointer(&temperature)))
+
+	return temperature, err
+}
+
+// DeviceGetPowerUsage returns the power usage of the GPU and its associated circuitry in milliwatts.
+func DeviceGetPowerUsage(device Device) (uint32, error) {
+	var power uint32
+
+	err := call(procNvmlDeviceGetPowerUsage, uintptr(device), uintptr(unsafe.Pointer(&power)))
+
+	return power, err
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package powrprof provides access to the power schemes of the system.
+package powrprof
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+//nolint:gochecknoglobals
+var (
+	modPowrprof               = windows.NewLazySystemDLL("powrprof.dll")
+	procPowerGetActiveScheme  = modPowrprof.NewProc("PowerGetActiveScheme")
+	procPowerReadFriendlyName = modPowrprof.NewProc("PowerReadFriendlyName")
+)
+
+// GetActiveScheme returns the GUID of the active power scheme.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/powersetting/nf-powersetting-powergetactivescheme
+func GetActiveScheme() (windows.GUID, error) {
+	var scheme *windows.GUID
+
+	r0, _, _ := procPowerGetActiveScheme.Call(0, uintptr(unsafe.Pointer(&scheme)))
+	if r0 != uintptr(windows.ERROR_SUCCESS) {
+		return windows.GUID{}, windows.Errno(r0)
+	}
+
+	defer func() {
+		_, _ = windows.LocalFree(windows.Handle(uintptr(unsafe.Pointer(scheme))))
+	}()
+
+	return *scheme, nil
+}
+
+// ReadFriendlyName returns the friendly name of the given power scheme.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/powersetting/nf-powersetting-powerreadfriendlyname
+func ReadFriendlyName(scheme windows.GUID) (string, error) {
+	var bufferSize uint32
+
+	r0, _, _ := procPowerReadFriendlyName.Call(0, uintptr(unsafe.Pointer(&scheme)), 0, 0, 0, uintptr(unsafe.Pointer(&bufferSize)))
+	if r0 != uintptr(windows.ERROR_SUCCESS) {
+		return "", windows.Errno(r0)
+	}
+
+	if bufferSize == 0 {
+		return "", nil
+	}
+
+	// The buffer size is returned in bytes.
+	buf := make([]uint16, (bufferSize+1)/2)
+
+	r0, _, _ = procPowerReadFriendlyName.Call(0, uintptr(unsafe.Pointer(&scheme)), 0, 0, uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&bufferSize)))
+	if r0 != uintptr(windows.ERROR_SUCCESS) {
+		return "", windows.Errno(r0)
+	}
+
+	return windows.UTF16ToString(buf), nil
+}
@@ -44,10 +44,19 @@ type PerformanceInformation struct {
 
 //nolint:gochecknoglobals
 var (
-	psapi                  = windows.NewLazySystemDLL("psapi.dll")
-	procGetPerformanceInfo = psapi.NewProc("GetPerformanceInfo")
+	psapi                        = windows.NewLazySystemDLL("psapi.dll")
+	procGetPerformanceInfo       = psapi.NewProc("GetPerformanceInfo")
+	procEnumDeviceDrivers        = psapi.NewProc("EnumDeviceDrivers")
+	procGetDeviceDriverBaseNameW = psapi.NewProc("GetDeviceDriverBaseNameW")
 )
 
+// DeviceDriver is a loaded kernel module.
+type DeviceDriver struct {
+	// ImageBase is the load address of the module. It is 0, if the caller is not elevated.
+	ImageBase uintptr
+	Name      string
+}
+
 // GetPerformanceInfo returns the dereferenced version of GetLPPerformanceInfo.
 func GetPerformanceInfo() (PerformanceInformation, error) {
 	var lppi PerformanceInformation
@@ -62,3 +71,55 @@ func GetPerformanceInfo() (PerformanceInformation, error) {
 
 	return lppi, nil
 }
+
+// EnumDeviceDrivers returns the load address and base name of all loaded kernel modules.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/psapi/nf-psapi-enumdevicedrivers
+func EnumDeviceDrivers() ([]DeviceDriver, error) {
+	imageBases := make([]uintptr, 512)
+
+	for {
+		var needed uint32
+
+		size := uint32(len(imageBases)) * uint32(unsafe.Sizeof(imageBases[0]))
+
+		r1, _, err := procEnumDeviceDrivers.Call(
+			uintptr(unsafe.Pointer(&imageBases[0])),
+			uintptr(size),
+			uintptr(unsafe.Pointer(&needed)),
+		)
+		if r1 == 0 {
+			return nil, err
+		}
+
+		if needed <= size {
+			imageBases = imageBases[:needed/uint32(unsafe.Sizeof(imageBases[0]))]
+
+			break
+		}
+
+		imageBases = make([]uintptr, needed/uint32(unsafe.Sizeof(imageBases[0]))+16)
+	}
+
+	drivers := make([]DeviceDriver, 0, len(imageBases))
+	name := make([]uint16, windows.MAX_PATH)
+
+	for _, imageBase := range imageBases {
+		// GetDeviceDriverBaseNameW takes the load address as LPVOID. It is only used as lookup key.
+		r1, _, _ := procGetDeviceDriverBaseNameW.Call(
+			imageBase,
+			uintptr(unsafe.Pointer(&name[0])),
+			uintptr(len(name)),
+		)
+		if r1 == 0 {
+			continue
+		}
+
+		drivers = append(drivers, DeviceDriver{
+			ImageBase: imageBase,
+			Name:      windows.UTF16ToString(name[:r1]),
+		})
+	}
+
+	return drivers, nil
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package tbs provides access to the TPM Base Services (TBS) API.
+package tbs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+//nolint:gochecknoglobals
+var (
+	tbs                    = windows.NewLazySystemDLL("tbs.dll")
+	procTbsiContextCreate  = tbs.NewProc("Tbsi_Context_Create")
+	procTbsipContextClose  = tbs.NewProc("Tbsip_Context_Close")
+	procTbsipSubmitCommand = tbs.NewProc("Tbsip_Submit_Command")
+)
+
+var ErrUnexpectedResponse = errors.New("unexpected TPM response")
+
+const (
+	tbsContextVersionTwo       = 2
+	tbsContextIncludeTPM20Flag = 0x4
+
+	tbsCommandLocalityZero   = 0
+	tbsCommandPriorityNormal = 200
+
+	tpmSTNoSessions       = 0x8001
+	tpmCCGetCapability    = 0x0000017A
+	tpmCapTPMProperties   = 0x00000006
+	tpmPTPermanent        = 0x00000200
+	tpmaPermanentLockout  = 1 << 9
+	getCapabilityRespSize = 27
+)
+
+// Context is a TBS context handle.
+type Context uintptr
+
+// tbsContextParams2 is TBS_CONTEXT_PARAMS2.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/tbs/ns-tbs-tbs_context_params2
+type tbsContextParams2 struct {
+	Version uint32
+	Flags   uint32
+}
+
+// ContextCreate creates a TBS context for a TPM 2.0 device.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/tbs/nf-tbs-tbsi_context_create
+func ContextCreate() (Context, error) {
+	var handle Context
+
+	params := tbsContextParams2{
+		Version: tbsContextVersionTwo,
+		Flags:   tbsContextIncludeTPM20Flag,
+	}
+
+	ret, _, _ := procTbsiContextCreate.Call(
+		uintptr(unsafe.Pointer(&params)),
+		uintptr(unsafe.Pointer(&handle)),
+	)
+	if ret != 0 {
+		return 0, fmt.Errorf("Tbsi_Context_Create failed: %w", windows.Errno(ret))
+	}
+
+	return handle, nil
+}
+
+// Close closes the TBS context.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/tbs/nf-tbs-tbsip_context_close
+func (c Context) Close() error {
+	ret, _, _ := procTbsipContextClose.Call(uintptr(c))
+	if ret != 0 {
+		return fmt.Errorf("Tbsip_Context_Close failed: %w", windows.Errno(ret))
+	}
+
+	return nil
+}
+
+// SubmitCommand submits a raw TPM command and returns the raw response.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/tbs/nf-tbs-tbsip_submit_command
+func (c Context) SubmitCommand(command []byte) ([]byte, error) {
+	response := make([]byte, 4096)
+	responseLen := uint32(len(response))
+
+	ret, _, _ := procTbsipSubmitCommand.Call(
+		uintptr(c),
+		tbsCommandLocalityZero,
+		tbsCommandPriorityNormal,
+		uintptr(unsafe.Pointer(&command[0])),
+		uintptr(len(command)),
+		uintptr(unsafe.Pointer(&response[0])),
+		uintptr(unsafe.Pointer(&responseLen)),
+	)
+	if ret != 0 {
+		return nil, fmt.Errorf("Tbsip_Submit_Command failed: %w", windows.Errno(ret))
+	}
+
+	return response[:responseLen], nil
+}
+
+// IsLockedOut reports whether the TPM 2.0 is in dictionary attack lockout mode.
+// It reads the inLockout bit of TPM_PT_PERMANENT via TPM2_GetCapability.
+func IsLockedOut() (bool, error) {
+	ctx, err := ContextCreate()
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		_ = ctx.Close()
+	}()
+
+	command := make([]byte, 22)
+	binary.BigEndian.PutUint16(command[0:], tpmSTNoSessions)
+	binary.BigEndian.PutUint32(command[2:], uint32(len(command)))
+	binary.BigEndian.PutUint32(command[6:], tpmCCGetCapability)
+	binary.BigEndian.PutUint32(command[10:], tpmCapTPMProperties)
+	binary.BigEndian.PutUint32(command[14:], tpmPTPermanent)
+	binary.BigEndian.PutUint32(command[18:], 1)
+
+	response, err := ctx.SubmitCommand(command)
+	if err != nil {
+		return false, err
+	}
+
+	if len(response) < 10 {
+		return false, ErrUnexpectedResponse
+	}
+
+	if rc := binary.BigEndian.Uint32(response[6:]); rc != 0 {
+		return false, fmt.Errorf("TPM2_GetCapability failed with response code 0x%X", rc)
+	}
+
+	// header (10) + moreData (1) + capability (4) + count (4) + property (4) + value (4)
+	if len(response) < getCapabilityRespSize || binary.BigEndian.Uint32(response[15:]) == 0 ||
+		binary.BigEndian.Uint32(response[19:]) != tpmPTPermanent {
+		return false, ErrUnexpectedResponse
+	}
+
+	return binary.BigEndian.Uint32(response[23:])&tpmaPermanentLockout != 0, nil
+}
@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package wevtapi provides access to the Windows Event Log API.
+package wevtapi
+
+import (
+	"errors"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+//nolint:gochecknoglobals
+var (
+	modWevtapi                 = windows.NewLazySystemDLL("wevtapi.dll")
+	procEvtQuery               = modWevtapi.NewProc("EvtQuery")
+	procEvtNext                = modWevtapi.NewProc("EvtNext")
+	procEvtCreateRenderContext = modWevtapi.NewProc("EvtCreateRenderContext")
+	procEvtRender              = modWevtapi.NewProc("EvtRender")
+	procEvtClose               = modWevtapi.NewProc("EvtClose")
+)
+
+const (
+	// EvtQueryChannelPath specifies that the path is the name of a channel.
+	EvtQueryChannelPath = 0x1
+	// EvtQueryReverseDirection returns the newest events first.
+	EvtQueryReverseDirection = 0x200
+
+	evtRenderContextSystem = 1
+	evtRenderEventValues   = 0
+
+	// evtSystemEventRecordID is EvtSystemEventRecordId of EVT_SYSTEM_PROPERTY_ID.
+	evtSystemEventRecordID = 9
+	// evtSystemPropertyCount is EvtSystemPropertyIdEND of EVT_SYSTEM_PROPERTY_ID.
+	evtSystemPropertyCount = 18
+	// evtVarTypeUInt64 is EvtVarTypeUInt64 of EVT_VARIANT_TYPE.
+	evtVarTypeUInt64 = 10
+)
+
+var ErrUnexpectedType = errors.New("unexpected EVT_VARIANT type")
+
+// Handle is an EVT_HANDLE.
+type Handle uintptr
+
+// evtVariant is EVT_VARIANT.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winevt/ns-winevt-evt_variant
+type evtVariant struct {
+	Value uint64
+	Count uint32
+	Type  uint32
+}
+
+// Query runs an XPath query against the given channel.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winevt/nf-winevt-evtquery
+func Query(path string, query string, flags uint32) (Handle, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+
+	queryPtr, err := windows.UTF16PtrFromString(query)
+	if err != nil {
+		return 0, err
+	}
+
+	ret, _, err := procEvtQuery.Call(
+		0,
+		uintptr(unsafe.Pointer(pathPtr)),
+		uintptr(unsafe.Pointer(queryPtr)),
+		uintptr(flags),
+	)
+	if ret == 0 {
+		return 0, err
+	}
+
+	return Handle(ret), nil
+}
+
+// Next fills events with the next event handles of the result set and returns the number of events.
+// It returns 0 and no error, if there are no more events.
+// The returned handles must be closed with [Close].
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winevt/nf-winevt-evtnext
+func Next(resultSet Handle, events []Handle, timeout uint32) (int, error) {
+	var returned uint32
+
+	ret, _, err := procEvtNext.Call(
+		uintptr(resultSet),
+		uintptr(len(events)),
+		uintptr(unsafe.Pointer(&events[0])),
+		uintptr(timeout),
+		0,
+		uintptr(unsafe.Pointer(&returned)),
+	)
+	if ret == 0 {
+		if errors.Is(err, windows.ERROR_NO_MORE_ITEMS) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return int(returned), nil
+}
+
+// CreateSystemRenderContext creates a render context for the system properties of events.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winevt/nf-winevt-evtcreaterendercontext
+func CreateSystemRenderContext() (Handle, error) {
+	ret, _, err := procEvtCreateRenderContext.Call(0, 0, evtRenderContextSystem)
+	if ret == 0 {
+		return 0, err
+	}
+
+	return Handle(ret), nil
+}
+
+// RenderEventRecordID returns the EventRecordID of the event.
+// The context must be created by [CreateSystemRenderContext].
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winevt/nf-winevt-evtrender
+func RenderEventRecordID(context Handle, event Handle) (uint64, error) {
+	// The system properties reference string data, e.g. the provider name, that is stored after the values.
+	buffer := make([]evtVariant, evtSystemPropertyCount*8)
+
+	var bufferUsed, propertyCount uint32
+
+	for {
+		ret, _, err := procEvtRender.Call(
+			uintptr(context),
+			uintptr(event),
+			evtRenderEventValues,
+			uintptr(len(buffer))*unsafe.Sizeof(evtVariant{}),
+			uintptr(unsafe.Pointer(&buffer[0])),
+			uintptr(unsafe.Pointer(&bufferUsed)),
+			uintptr(unsafe.Pointer(&propertyCount)),
+		)
+		if ret != 0 {
+			break
+		}
+
+		if !errors.Is(err, windows.ERROR_INSUFFICIENT_BUFFER) {
+			return 0, err
+		}
+
+		buffer = make([]evtVariant, uintptr(bufferUsed)/unsafe.Sizeof(evtVariant{})+1)
+	}
+
+	if propertyCount <= evtSystemEventRecordID || buffer[evtSystemEventRecordID].Type != evtVarTypeUInt64 {
+		return 0, ErrUnexpectedType
+	}
+
+	return buffer[evtSystemEventRecordID].Value, nil
+}
+
+// Close closes an event log handle.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winevt/nf-winevt-evtclose
+func Close(handle Handle) error {
+	ret, _, err := procEvtClose.Call(uintptr(handle))
+	if ret == 0 {
+		return err
+	}
+
+	return nil
+}
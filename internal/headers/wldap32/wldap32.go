@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package wldap32 provides a minimal read-only LDAP client based on the Windows LDAP API.
+package wldap32
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+//nolint:gochecknoglobals
+var (
+	modWldap32           = windows.NewLazySystemDLL("wldap32.dll")
+	procLdapInitW        = modWldap32.NewProc("ldap_initW")
+	procLdapSetOptionW   = modWldap32.NewProc("ldap_set_optionW")
+	procLdapBindSW       = modWldap32.NewProc("ldap_bind_sW")
+	procLdapSearchSW     = modWldap32.NewProc("ldap_search_sW")
+	procLdapFirstEntry   = modWldap32.NewProc("ldap_first_entry")
+	procLdapGetValuesW   = modWldap32.NewProc("ldap_get_valuesW")
+	procLdapValueFreeW   = modWldap32.NewProc("ldap_value_freeW")
+	procLdapMsgFree      = modWldap32.NewProc("ldap_msgfree")
+	procLdapUnbind       = modWldap32.NewProc("ldap_unbind")
+	procLdapGetLastError = modWldap32.NewProc("LdapGetLastError")
+)
+
+const (
+	ldapPort                 = 389
+	ldapOptProtocolVersion   = 0x11
+	ldapVersion3             = 3
+	ldapAuthNegotiate        = 0x0486
+	ldapScopeBase            = 0
+	ldapFilterAnyObjectClass = "(objectClass=*)"
+)
+
+var ErrNoEntry = errors.New("no LDAP entry found")
+
+// Error is an LDAP result code.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winldap/ne-winldap-ldap_retcode
+type Error uint32
+
+func (e Error) Error() string {
+	return fmt.Sprintf("LDAP error 0x%02X", uint32(e))
+}
+
+// Conn is an LDAP connection authenticated with the credentials of the current process.
+type Conn struct {
+	ld uintptr
+}
+
+// Dial connects to the given host. An empty host connects to a domain controller of the computer's domain.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winldap/nf-winldap-ldap_initw
+func Dial(host string) (*Conn, error) {
+	var hostPtr *uint16
+
+	if host != "" {
+		var err error
+
+		hostPtr, err = windows.UTF16PtrFromString(host)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ld, _, _ := procLdapInitW.Call(uintptr(unsafe.Pointer(hostPtr)), ldapPort)
+	if ld == 0 {
+		return nil, fmt.Errorf("ldap_init failed: %w", lastError())
+	}
+
+	conn := &Conn{ld: ld}
+
+	version := uint32(ldapVersion3)
+
+	ret, _, _ := procLdapSetOptionW.Call(ld, ldapOptProtocolVersion, uintptr(unsafe.Pointer(&version)))
+	if ret != 0 {
+		conn.Close()
+
+		return nil, fmt.Errorf("ldap_set_option failed: %w", Error(ret))
+	}
+
+	ret, _, _ = procLdapBindSW.Call(ld, 0, 0, ldapAuthNegotiate)
+	if ret != 0 {
+		conn.Close()
+
+		return nil, fmt.Errorf("ldap_bind failed: %w", Error(ret))
+	}
+
+	return conn, nil
+}
+
+// ReadAttribute returns the values of an attribute of the object with the given distinguished name.
+// An empty distinguished name reads the rootDSE.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winldap/nf-winldap-ldap_search_sw
+func (c *Conn) ReadAttribute(dn string, attribute string) ([]string, error) {
+	dnPtr, err := windows.UTF16PtrFromString(dn)
+	if err != nil {
+		return nil, err
+	}
+
+	filterPtr, err := windows.UTF16PtrFromString(ldapFilterAnyObjectClass)
+	if err != nil {
+		return nil, err
+	}
+
+	attributePtr, err := windows.UTF16PtrFromString(attribute)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := []*uint16{attributePtr, nil}
+
+	var result uintptr
+
+	ret, _, _ := procLdapSearchSW.Call(
+		c.ld,
+		uintptr(unsafe.Pointer(dnPtr)),
+		ldapScopeBase,
+		uintptr(unsafe.Pointer(filterPtr)),
+		uintptr(unsafe.Pointer(&attributes[0])),
+		0,
+		uintptr(unsafe.Pointer(&result)),
+	)
+
+	if result != 0 {
+		defer procLdapMsgFree.Call(result) //nolint:errcheck
+	}
+
+	if ret != 0 {
+		return nil, fmt.Errorf("ldap_search failed: %w", Error(ret))
+	}
+
+	entry, _, _ := procLdapFirstEntry.Call(c.ld, result)
+	if entry == 0 {
+		return nil, ErrNoEntry
+	}
+
+	values, _, _ := procLdapGetValuesW.Call(c.ld, entry, uintptr(unsafe.Pointer(attributePtr)))
+	if values == 0 {
+		return nil, nil
+	}
+
+	defer procLdapValueFreeW.Call(values) //nolint:errcheck
+
+	// values is a NULL-terminated array of strings allocated by wldap32.
+	valuePtrs := *(***uint16)(unsafe.Pointer(&values))
+
+	var attributeValues []string
+
+	for i := 0; ; i++ {
+		valuePtr := *(**uint16)(unsafe.Add(unsafe.Pointer(valuePtrs), uintptr(i)*unsafe.Sizeof(valuePtrs)))
+		if valuePtr == nil {
+			break
+		}
+
+		attributeValues = append(attributeValues, windows.UTF16PtrToString(valuePtr))
+	}
+
+	return attributeValues, nil
+}
+
+// Close closes the connection.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/winldap/nf-winldap-ldap_unbind
+func (c *Conn) Close() {
+	_, _, _ = procLdapUnbind.Call(c.ld)
+}
+
+func lastError() error {
+	ret, _, _ := procLdapGetLastError.Call()
+
+	return Error(ret)
+}
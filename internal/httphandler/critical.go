@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Interface guard.
+var _ prometheus.Gatherer = criticalGatherer{}
+
+// collectionErrorer reports the error of the last collection, see [collector.Handler.Err].
+type collectionErrorer interface {
+	Err() error
+}
+
+// criticalGatherer discards all gathered metrics, if a critical collector failed.
+// promhttp responds with HTTP 500, if no metrics were gathered, so that the target is marked as down.
+type criticalGatherer struct {
+	next       prometheus.Gatherer
+	collection collectionErrorer
+}
+
+func newCriticalGatherer(next prometheus.Gatherer, collection collectionErrorer) criticalGatherer {
+	return criticalGatherer{next: next, collection: collection}
+}
+
+func (g criticalGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.next.Gather()
+
+	if collectionErr := g.collection.Err(); collectionErr != nil {
+		return nil, collectionErr
+	}
+
+	return families, err
+}
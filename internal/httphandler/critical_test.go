@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/require"
+)
+
+type staticErrorer struct {
+	err error
+}
+
+func (e staticErrorer) Err() error {
+	return e.err
+}
+
+func TestCriticalGatherer(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		err        error
+		statusCode int
+	}{
+		{"collection succeeded", nil, http.StatusOK},
+		{"critical collector failed", errors.New("critical collector failed: cpu"), http.StatusInternalServerError},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reg := prometheus.NewRegistry()
+			reg.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "windows_test", Help: "Test metric."}))
+
+			handler := promhttp.HandlerFor(
+				newCriticalGatherer(reg, staticErrorer{err: tc.err}),
+				promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError},
+			)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+			require.Equal(t, tc.statusCode, rec.Code)
+
+			if tc.err != nil {
+				require.Contains(t, rec.Body.String(), tc.err.Error())
+				require.NotContains(t, rec.Body.String(), "windows_test")
+			} else {
+				require.Contains(t, rec.Body.String(), "windows_test")
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+)
+
+// Interface guard.
+var _ http.Handler = (*EstimateHandler)(nil)
+
+// EstimateHandler runs a dry-run collection of a single collector
+// and returns the number of series it would emit.
+type EstimateHandler struct {
+	logger           *slog.Logger
+	metricCollectors *collector.Collection
+	timeout          time.Duration
+}
+
+func NewEstimateHandler(logger *slog.Logger, metricCollectors *collector.Collection, timeout time.Duration) EstimateHandler {
+	return EstimateHandler{
+		logger:           logger,
+		metricCollectors: metricCollectors,
+		timeout:          timeout,
+	}
+}
+
+func (h EstimateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("collector")
+	if name == "" {
+		http.Error(w, "missing collector query parameter", http.StatusBadRequest)
+
+		return
+	}
+
+	estimate, err := h.metricCollectors.Estimate(r.Context(), h.logger, name, h.timeout)
+	if err != nil {
+		status := http.StatusInternalServerError
+
+		switch {
+		case errors.Is(err, collector.ErrUnknownCollector):
+			status = http.StatusBadRequest
+		case errors.Is(err, collector.ErrEstimateRunning):
+			status = http.StatusTooManyRequests
+		}
+
+		http.Error(w, fmt.Sprintf("error estimating collector: %s", err), status)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err = json.NewEncoder(w).Encode(estimate); err != nil {
+		http.Error(w, fmt.Sprintf("error encoding JSON: %s", err), http.StatusInternalServerError)
+	}
+}
@@ -24,6 +24,8 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/prometheus-community/windows_exporter/internal/nodecompat"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
 	"github.com/prometheus-community/windows_exporter/pkg/collector"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -41,6 +43,7 @@ type MetricsHTTPHandler struct {
 	// exporterMetricsRegistry is a separate registry for the metrics about
 	// the exporter itself.
 	exporterMetricsRegistry *prometheus.Registry
+	instrumentation         *Instrumentation
 
 	logger  *slog.Logger
 	options Options
@@ -49,6 +52,14 @@ type MetricsHTTPHandler struct {
 type Options struct {
 	DisableExporterMetrics bool
 	TimeoutMargin          float64
+	// HTTPClientInfoLimit is the number of distinct remote IPs tracked by
+	// windows_exporter_http_client_info. 0 disables the metric.
+	HTTPClientInfoLimit int
+	// NodeExporterCompat appends node_exporter aliases of a curated set of metrics.
+	NodeExporterCompat bool
+	// Roles exposes windows_exporter_detected_role on every scrape, independent of
+	// DisableExporterMetrics. nil disables the metric.
+	Roles prometheus.Collector
 }
 
 func New(logger *slog.Logger, metricCollectors *collector.Collection, options *Options) *MetricsHTTPHandler {
@@ -71,12 +82,26 @@ func New(logger *slog.Logger, metricCollectors *collector.Collection, options *O
 			collectors.NewBuildInfoCollector(),
 			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
 			collectors.NewGoCollector(),
+			newRuntimeCollector(),
+			pdh.NewStaleCollector(),
 		)
+
+		handler.instrumentation = NewInstrumentation(handler.exporterMetricsRegistry, options.HTTPClientInfoLimit)
 	}
 
 	return handler
 }
 
+// Instrument wraps the given handler with the HTTP request metrics of the exporter.
+// If exporter metrics are disabled, the handler is returned unchanged.
+func (c *MetricsHTTPHandler) Instrument(name string, handler http.Handler) http.Handler {
+	if c.instrumentation == nil {
+		return handler
+	}
+
+	return c.instrumentation.Handler(name, handler)
+}
+
 func (c *MetricsHTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	logger := c.logger.With(
 		slog.String("remote", r.RemoteAddr),
@@ -124,6 +149,10 @@ func (c *MetricsHTTPHandler) handlerFactory(logger *slog.Logger, scrapeTimeout t
 	reg := prometheus.NewRegistry()
 	reg.MustRegister(version.NewCollector("windows_exporter"))
 
+	if c.options.Roles != nil {
+		reg.MustRegister(c.options.Roles)
+	}
+
 	collectionHandler, err := c.metricCollectors.NewHandler(scrapeTimeout, c.logger, requestedCollectors)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't create collector handler: %w", err)
@@ -133,10 +162,15 @@ func (c *MetricsHTTPHandler) handlerFactory(logger *slog.Logger, scrapeTimeout t
 		return nil, fmt.Errorf("couldn't register Prometheus collector: %w", err)
 	}
 
+	var gatherer prometheus.Gatherer = reg
+	if c.options.NodeExporterCompat {
+		gatherer = nodecompat.NewGatherer(reg)
+	}
+
 	var regHandler http.Handler
 	if c.exporterMetricsRegistry != nil {
 		regHandler = promhttp.HandlerFor(
-			prometheus.Gatherers{c.exporterMetricsRegistry, reg},
+			newCriticalGatherer(newUnitGatherer(prometheus.Gatherers{c.exporterMetricsRegistry, gatherer}), collectionHandler),
 			promhttp.HandlerOpts{
 				ErrorLog:            slog.NewLogLogger(logger.Handler(), slog.LevelError),
 				ErrorHandling:       promhttp.ContinueOnError,
@@ -154,7 +188,7 @@ func (c *MetricsHTTPHandler) handlerFactory(logger *slog.Logger, scrapeTimeout t
 		)
 	} else {
 		regHandler = promhttp.HandlerFor(
-			reg,
+			newCriticalGatherer(newUnitGatherer(gatherer), collectionHandler),
 			promhttp.HandlerOpts{
 				ErrorLog:            slog.NewLogLogger(logger.Handler(), slog.LevelError),
 				ErrorHandling:       promhttp.ContinueOnError,
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Interface guard.
+var _ prometheus.Collector = (*clientTracker)(nil)
+
+// Instrumentation wraps the HTTP handlers of the exporter and records
+// metrics about the requests served by the exporter itself.
+type Instrumentation struct {
+	requestsTotal   *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	clients         *clientTracker
+}
+
+// NewInstrumentation returns a new Instrumentation and registers its metrics on the given registerer.
+// If maxClients is greater than zero, the last scrape timestamp of up to maxClients distinct
+// remote addresses is exposed as windows_exporter_http_client_info.
+func NewInstrumentation(registerer prometheus.Registerer, maxClients int) *Instrumentation {
+	i := &Instrumentation{
+		requestsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: types.Namespace,
+				Subsystem: "exporter",
+				Name:      "http_requests_total",
+				Help:      "windows_exporter: Total number of HTTP requests by handler and status code.",
+			},
+			[]string{"handler", "code"},
+		),
+		requestDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: types.Namespace,
+				Subsystem: "exporter",
+				Name:      "http_request_duration_seconds",
+				Help:      "windows_exporter: Duration of HTTP requests by handler.",
+				Buckets:   []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+			},
+			[]string{"handler"},
+		),
+	}
+
+	registerer.MustRegister(i.requestsTotal, i.requestDuration)
+
+	if maxClients > 0 {
+		i.clients = newClientTracker(maxClients)
+
+		registerer.MustRegister(i.clients)
+	}
+
+	return i
+}
+
+// Handler instruments the given handler with the request counter, duration histogram and client tracker.
+func (i *Instrumentation) Handler(name string, handler http.Handler) http.Handler {
+	labels := prometheus.Labels{"handler": name}
+
+	instrumented := promhttp.InstrumentHandlerDuration(
+		i.requestDuration.MustCurryWith(labels),
+		promhttp.InstrumentHandlerCounter(i.requestsTotal.MustCurryWith(labels), handler),
+	)
+
+	if i.clients == nil {
+		return instrumented
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i.clients.observe(r.RemoteAddr)
+
+		instrumented.ServeHTTP(w, r)
+	})
+}
+
+// clientTracker records the last time a remote address sent a request.
+// Only the most recent maxClients distinct addresses are kept.
+type clientTracker struct {
+	mu         sync.Mutex
+	maxClients int
+	lastSeen   map[string]time.Time
+
+	clientInfoDesc *prometheus.Desc
+}
+
+func newClientTracker(maxClients int) *clientTracker {
+	return &clientTracker{
+		maxClients: maxClients,
+		lastSeen:   make(map[string]time.Time, maxClients),
+		clientInfoDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "http_client_info"),
+			"windows_exporter: Unix timestamp of the last request received from the remote IP.",
+			[]string{"remote_ip"},
+			nil,
+		),
+	}
+}
+
+func (c *clientTracker) observe(remoteAddr string) {
+	remoteIP, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		remoteIP = remoteAddr
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.lastSeen[remoteIP]; !ok && len(c.lastSeen) >= c.maxClients {
+		var (
+			oldestIP   string
+			oldestTime time.Time
+		)
+
+		for ip, lastSeen := range c.lastSeen {
+			if oldestIP == "" || lastSeen.Before(oldestTime) {
+				oldestIP, oldestTime = ip, lastSeen
+			}
+		}
+
+		delete(c.lastSeen, oldestIP)
+	}
+
+	c.lastSeen[remoteIP] = time.Now()
+}
+
+func (c *clientTracker) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.clientInfoDesc
+}
+
+func (c *clientTracker) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for remoteIP, lastSeen := range c.lastSeen {
+		ch <- prometheus.MustNewConstMetric(
+			c.clientInfoDesc,
+			prometheus.GaugeValue,
+			float64(lastSeen.UnixMicro())/1e6,
+			remoteIP,
+		)
+	}
+}
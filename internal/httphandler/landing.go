@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/pkg/collector"
+	"github.com/prometheus/common/version"
+)
+
+// Interface guard.
+var _ http.Handler = (*LandingHandler)(nil)
+
+// landingTemplate renders the status page. It must not reference external assets,
+// since the page is mostly opened from a browser on the host itself.
+//
+//nolint:gochecknoglobals
+var landingTemplate = template.Must(template.New("landing").Funcs(template.FuncMap{
+	"formatTime": formatTime,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>windows_exporter</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+td.failed { color: #b00; }
+</style>
+</head>
+<body>
+<h1>windows_exporter</h1>
+<p><a href="{{ .MetricsPath }}">Metrics</a> | <a href="/health">Health</a> | <a href="/version">Version</a></p>
+<table>
+<tr><th>Version</th><td>{{ .Version }}</td></tr>
+<tr><th>Revision</th><td>{{ .Revision }}</td></tr>
+<tr><th>Started</th><td>{{ formatTime .StartTime }}</td></tr>
+<tr><th>Configuration file</th><td>{{ if .ConfigFile }}{{ .ConfigFile }}{{ else }}none{{ end }}</td></tr>
+</table>
+<h2>Collectors</h2>
+<table>
+<tr><th>Collector</th><th>Last scrape</th><th>Duration</th><th>Last success</th><th>Error</th></tr>
+{{- range .Collectors }}
+<tr>
+<td>{{ .Name }}</td>
+<td>{{ formatTime .LastScrape }}</td>
+<td>{{ if not .LastScrape.IsZero }}{{ .Duration }}{{ end }}</td>
+<td>{{ formatTime .LastSuccess }}</td>
+<td class="failed">{{ .Error }}</td>
+</tr>
+{{- end }}
+</table>
+</body>
+</html>
+`))
+
+type landingPageData struct {
+	Version     string
+	Revision    string
+	StartTime   time.Time
+	ConfigFile  string
+	MetricsPath string
+	Collectors  []collector.CollectorStatus
+}
+
+// LandingHandler serves a status page listing the enabled collectors
+// and the result of their last collection.
+type LandingHandler struct {
+	metricCollectors *collector.Collection
+	metricsPath      string
+	configFile       string
+}
+
+func NewLandingHandler(metricCollectors *collector.Collection, metricsPath, configFile string) LandingHandler {
+	return LandingHandler{
+		metricCollectors: metricCollectors,
+		metricsPath:      metricsPath,
+		configFile:       configFile,
+	}
+}
+
+func (h LandingHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	var buf bytes.Buffer
+
+	err := landingTemplate.Execute(&buf, landingPageData{
+		Version:     version.Version,
+		Revision:    version.GetRevision(),
+		StartTime:   h.metricCollectors.GetStartTime(),
+		ConfigFile:  h.configFile,
+		MetricsPath: h.metricsPath,
+		Collectors:  h.metricCollectors.Status(),
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error rendering landing page: %s", err), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = buf.WriteTo(w)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"math"
+	"runtime/metrics"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Interface guard.
+var _ prometheus.Collector = (*runtimeCollector)(nil)
+
+const (
+	runtimeMetricMemoryTotal = "/memory/classes/total:bytes"
+	runtimeMetricMemoryLimit = "/gc/gomemlimit:bytes"
+	runtimeMetricGCPauses    = "/sched/pauses/total/gc:seconds"
+)
+
+// runtimeCollector exposes the memory usage and GC pauses of the exporter, read from [runtime/metrics].
+type runtimeCollector struct {
+	memoryBytesDesc      *prometheus.Desc
+	memoryLimitBytesDesc *prometheus.Desc
+	gcPauseSecondsDesc   *prometheus.Desc
+}
+
+func newRuntimeCollector() *runtimeCollector {
+	return &runtimeCollector{
+		memoryBytesDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "self_memory_bytes"),
+			"windows_exporter: Memory mapped by the Go runtime of the exporter.",
+			nil,
+			nil,
+		),
+		memoryLimitBytesDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "self_memory_limit_bytes"),
+			"windows_exporter: Soft memory limit of the Go runtime of the exporter.",
+			nil,
+			nil,
+		),
+		gcPauseSecondsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "self_gc_pause_seconds_total"),
+			"windows_exporter: Approximate total time the exporter was paused by the garbage collector.",
+			nil,
+			nil,
+		),
+	}
+}
+
+func (c *runtimeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.memoryBytesDesc
+	ch <- c.memoryLimitBytesDesc
+	ch <- c.gcPauseSecondsDesc
+}
+
+func (c *runtimeCollector) Collect(ch chan<- prometheus.Metric) {
+	samples := []metrics.Sample{
+		{Name: runtimeMetricMemoryTotal},
+		{Name: runtimeMetricMemoryLimit},
+		{Name: runtimeMetricGCPauses},
+	}
+
+	metrics.Read(samples)
+
+	for _, sample := range samples {
+		switch sample.Value.Kind() {
+		case metrics.KindUint64:
+			desc := c.memoryBytesDesc
+			if sample.Name == runtimeMetricMemoryLimit {
+				desc = c.memoryLimitBytesDesc
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				desc,
+				prometheus.GaugeValue,
+				float64(sample.Value.Uint64()),
+			)
+		case metrics.KindFloat64Histogram:
+			ch <- prometheus.MustNewConstMetric(
+				c.gcPauseSecondsDesc,
+				prometheus.CounterValue,
+				histogramSum(sample.Value.Float64Histogram()),
+			)
+		case metrics.KindBad, metrics.KindFloat64:
+			// The metric is not supported by the Go runtime.
+		}
+	}
+}
+
+// histogramSum approximates the sum of all observations of a runtime histogram,
+// since the runtime does not record it. Each observation is counted with the midpoint of its bucket.
+// Buckets with an infinite boundary are counted with their finite boundary.
+func histogramSum(histogram *metrics.Float64Histogram) float64 {
+	var sum float64
+
+	for i, count := range histogram.Counts {
+		if count == 0 {
+			continue
+		}
+
+		lower, upper := histogram.Buckets[i], histogram.Buckets[i+1]
+
+		var value float64
+
+		switch {
+		case math.IsInf(lower, -1):
+			value = upper
+		case math.IsInf(upper, 1):
+			value = lower
+		default:
+			value = (lower + upper) / 2
+		}
+
+		sum += float64(count) * value
+	}
+
+	return sum
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"math"
+	"runtime/metrics"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogramSum(t *testing.T) {
+	t.Parallel()
+
+	histogram := &metrics.Float64Histogram{
+		Counts:  []uint64{1, 2, 0, 4},
+		Buckets: []float64{math.Inf(-1), 0.001, 0.003, 0.01, math.Inf(1)},
+	}
+
+	// 1*0.001 + 2*0.002 + 4*0.01
+	require.InDelta(t, 0.045, histogramSum(histogram), 1e-12)
+}
+
+func TestRuntimeCollector(t *testing.T) {
+	t.Parallel()
+
+	collector := newRuntimeCollector()
+
+	require.Equal(t, 3, testutil.CollectAndCount(collector))
+
+	problems, err := testutil.CollectAndLint(collector)
+	require.NoError(t, err)
+	require.Empty(t, problems)
+}
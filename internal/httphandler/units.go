@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Interface guard.
+var _ prometheus.Gatherer = unitGatherer{}
+
+// baseUnits are the OpenMetrics base units a metric name may end with.
+//
+//nolint:gochecknoglobals
+var baseUnits = []string{
+	"seconds",
+	"bytes",
+	"ratio",
+	"celsius",
+	"volts",
+	"amperes",
+	"joules",
+	"grams",
+	"meters",
+	"hertz",
+	"watts",
+}
+
+// unitGatherer sets the unit of metric families without an explicit unit,
+// based on the unit suffix of the metric name. The unit is exposed as
+// UNIT line in the OpenMetrics exposition format.
+type unitGatherer struct {
+	next prometheus.Gatherer
+}
+
+func newUnitGatherer(next prometheus.Gatherer) unitGatherer {
+	return unitGatherer{next: next}
+}
+
+func (g unitGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.next.Gather()
+
+	for _, family := range families {
+		if family.GetUnit() != "" {
+			continue
+		}
+
+		if unit := inferUnit(family.GetName(), family.GetType()); unit != "" {
+			family.Unit = &unit
+		}
+	}
+
+	return families, err
+}
+
+// inferUnit returns the base unit of the metric name, or an empty string if
+// the name does not end with a known unit. OpenMetrics requires the unit to be
+// the suffix of the name, followed by _total for counters.
+func inferUnit(name string, metricType dto.MetricType) string {
+	if metricType == dto.MetricType_COUNTER {
+		name = strings.TrimSuffix(name, "_total")
+	}
+
+	for _, unit := range baseUnits {
+		if strings.HasSuffix(name, "_"+unit) {
+			return unit
+		}
+	}
+
+	return ""
+}
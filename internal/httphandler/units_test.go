@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package httphandler
+
+import (
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferUnit(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name       string
+		metricType dto.MetricType
+		unit       string
+	}{
+		{"windows_cpu_time_total", dto.MetricType_COUNTER, ""},
+		{"windows_memory_available_bytes", dto.MetricType_GAUGE, "bytes"},
+		{"windows_net_bytes_received_total", dto.MetricType_COUNTER, ""},
+		{"windows_logical_disk_read_seconds_total", dto.MetricType_COUNTER, "seconds"},
+		{"windows_exporter_http_request_duration_seconds", dto.MetricType_HISTOGRAM, "seconds"},
+		{"windows_thermalzone_temperature_celsius", dto.MetricType_GAUGE, "celsius"},
+		// _total on a gauge is part of the name, so the name does not end with the unit.
+		{"windows_dns_memory_used_bytes_total", dto.MetricType_GAUGE, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.unit, inferUnit(tc.name, tc.metricType))
+		})
+	}
+}
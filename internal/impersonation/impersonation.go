@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package impersonation runs functions under the security context of another account.
+//
+// Impersonation applies to the calling OS thread only. [Impersonator.Run] locks the
+// calling goroutine to its thread for the duration of the function. Goroutines started
+// by the function run on other threads and are not impersonated, so Run must be called
+// on the goroutine which performs the access, e.g. the worker of pdh.NewImpersonatedCollector.
+package impersonation
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Config describes the account to impersonate.
+type Config struct {
+	// User is the account in DOMAIN\user or user@domain format.
+	// Group managed service accounts end with a $.
+	User string `yaml:"user"`
+	// Secret is the name of the LSA secret holding the password of the account.
+	// It must be empty for group managed service accounts.
+	Secret string `yaml:"secret"`
+}
+
+// Enabled reports whether an account is configured.
+func (c Config) Enabled() bool {
+	return c.User != ""
+}
+
+// Token is a logon session that can be impersonated by the calling thread.
+type Token interface {
+	// Impersonate lets the calling thread impersonate the token.
+	Impersonate() error
+	// Revert ends the impersonation of the calling thread.
+	Revert() error
+	Close() error
+}
+
+// LogonFunc returns a token for the given account.
+type LogonFunc func(config Config) (Token, error)
+
+// Impersonator runs functions under the security context of the configured account.
+// The logon session is created on first use and reused afterward.
+type Impersonator struct {
+	config Config
+	logon  LogonFunc
+
+	mu    sync.Mutex
+	token Token
+}
+
+// New returns a new Impersonator for the given account.
+func New(config Config) *Impersonator {
+	return &Impersonator{
+		config: config,
+		logon:  Logon,
+	}
+}
+
+// Run calls fn while the calling thread impersonates the configured account.
+// If the impersonation can't be reverted, the goroutine stays locked to the
+// impersonating thread, which is terminated once the goroutine exits.
+func (i *Impersonator) Run(fn func() error) (err error) {
+	token, err := i.getToken()
+	if err != nil {
+		return err
+	}
+
+	runtime.LockOSThread()
+
+	if err := token.Impersonate(); err != nil {
+		runtime.UnlockOSThread()
+
+		// The logon session may be no longer valid. Create a new one on the next call.
+		i.reset(token)
+
+		return fmt.Errorf("failed to impersonate %s: %w", i.config.User, err)
+	}
+
+	defer func() {
+		if revertErr := token.Revert(); revertErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to revert impersonation of %s: %w", i.config.User, revertErr))
+
+			return
+		}
+
+		runtime.UnlockOSThread()
+	}()
+
+	return fn()
+}
+
+// Close closes the logon session.
+func (i *Impersonator) Close() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.token == nil {
+		return nil
+	}
+
+	err := i.token.Close()
+	i.token = nil
+
+	return err
+}
+
+func (i *Impersonator) getToken() (Token, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.token != nil {
+		return i.token, nil
+	}
+
+	token, err := i.logon(i.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to log on %s: %w", i.config.User, err)
+	}
+
+	i.token = token
+
+	return token, nil
+}
+
+func (i *Impersonator) reset(token Token) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.token != token {
+		return
+	}
+
+	_ = i.token.Close()
+	i.token = nil
+}
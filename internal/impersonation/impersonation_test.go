@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package impersonation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockToken struct {
+	calls          []string
+	impersonateErr error
+	revertErr      error
+}
+
+func (t *mockToken) Impersonate() error {
+	t.calls = append(t.calls, "impersonate")
+
+	return t.impersonateErr
+}
+
+func (t *mockToken) Revert() error {
+	t.calls = append(t.calls, "revert")
+
+	return t.revertErr
+}
+
+func (t *mockToken) Close() error {
+	t.calls = append(t.calls, "close")
+
+	return nil
+}
+
+func newMockImpersonator(token *mockToken, logons *int) *Impersonator {
+	return &Impersonator{
+		config: Config{User: `DOMAIN\gmsa$`},
+		logon: func(config Config) (Token, error) {
+			*logons++
+
+			if config.User != `DOMAIN\gmsa$` {
+				return nil, errors.New("unexpected user")
+			}
+
+			return token, nil
+		},
+	}
+}
+
+func TestImpersonatorRun(t *testing.T) {
+	t.Parallel()
+
+	token := &mockToken{}
+
+	var logons int
+
+	impersonator := newMockImpersonator(token, &logons)
+
+	for range 2 {
+		err := impersonator.Run(func() error {
+			token.calls = append(token.calls, "fn")
+
+			return nil
+		})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, impersonator.Close())
+
+	require.Equal(t, 1, logons)
+	require.Equal(t, []string{"impersonate", "fn", "revert", "impersonate", "fn", "revert", "close"}, token.calls)
+}
+
+func TestImpersonatorRunError(t *testing.T) {
+	t.Parallel()
+
+	errFn := errors.New("fn failed")
+	token := &mockToken{}
+
+	var logons int
+
+	err := newMockImpersonator(token, &logons).Run(func() error {
+		return errFn
+	})
+
+	require.ErrorIs(t, err, errFn)
+	require.Equal(t, []string{"impersonate", "revert"}, token.calls)
+}
+
+func TestImpersonatorRunImpersonateError(t *testing.T) {
+	t.Parallel()
+
+	token := &mockToken{impersonateErr: errors.New("access denied")}
+
+	var logons int
+
+	impersonator := newMockImpersonator(token, &logons)
+
+	err := impersonator.Run(func() error {
+		t.Fatal("fn must not be called")
+
+		return nil
+	})
+	require.ErrorIs(t, err, token.impersonateErr)
+
+	// The token is closed and a new logon session is created on the next call.
+	require.Equal(t, []string{"impersonate", "close"}, token.calls)
+	require.Error(t, impersonator.Run(func() error { return nil }))
+	require.Equal(t, 2, logons)
+}
+
+func TestImpersonatorRunRevertError(t *testing.T) {
+	t.Parallel()
+
+	token := &mockToken{revertErr: errors.New("revert failed")}
+
+	var logons int
+
+	done := make(chan error)
+
+	// The goroutine stays locked to its thread, so run it in a separate goroutine.
+	go func() {
+		done <- newMockImpersonator(token, &logons).Run(func() error { return nil })
+	}()
+
+	require.ErrorIs(t, <-done, token.revertErr)
+}
+
+func TestSplitUser(t *testing.T) {
+	t.Parallel()
+
+	user, domain := splitUser(`DOMAIN\gmsa$`)
+	require.Equal(t, "gmsa$", user)
+	require.Equal(t, "DOMAIN", domain)
+
+	user, domain = splitUser("gmsa$@example.com")
+	require.Equal(t, "gmsa$@example.com", user)
+	require.Empty(t, domain)
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package impersonation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/prometheus-community/windows_exporter/internal/headers/advapi32"
+	"golang.org/x/sys/windows"
+)
+
+type systemToken struct {
+	token windows.Token
+}
+
+// Logon creates a service logon session for the given account.
+// Without a secret, the password of the group managed service account is retrieved
+// by Windows. This requires the computer to be allowed to retrieve the managed password.
+func Logon(config Config) (Token, error) {
+	user, domain := splitUser(config.User)
+
+	userPtr, err := windows.UTF16PtrFromString(user)
+	if err != nil {
+		return nil, err
+	}
+
+	var domainPtr, passwordPtr *uint16
+
+	if domain != "" {
+		if domainPtr, err = windows.UTF16PtrFromString(domain); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Secret != "" {
+		password, err := advapi32.RetrievePrivateData(config.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve LSA secret %s: %w", config.Secret, err)
+		}
+
+		passwordBuf, err := windows.UTF16FromString(password)
+		if err != nil {
+			return nil, err
+		}
+
+		defer clear(passwordBuf)
+
+		passwordPtr = &passwordBuf[0]
+	}
+
+	token, err := advapi32.LogonUser(userPtr, domainPtr, passwordPtr, advapi32.LOGON32_LOGON_SERVICE, advapi32.LOGON32_PROVIDER_DEFAULT)
+	if err != nil {
+		return nil, fmt.Errorf("LogonUser: %w", err)
+	}
+
+	return &systemToken{token: token}, nil
+}
+
+func (t *systemToken) Impersonate() error {
+	return advapi32.ImpersonateLoggedOnUser(t.token)
+}
+
+func (t *systemToken) Revert() error {
+	return windows.RevertToSelf()
+}
+
+func (t *systemToken) Close() error {
+	return t.token.Close()
+}
+
+// splitUser splits DOMAIN\user into user and domain.
+// Names in user@domain format are passed to LogonUser without a domain.
+func splitUser(account string) (string, string) {
+	if domain, user, ok := strings.Cut(account, `\`); ok {
+		return user, domain
+	}
+
+	return account, ""
+}
@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package eventlog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Interface guard.
+var _ slog.Handler = (*MirrorHandler)(nil)
+
+// DefaultRateLimit is the minimum interval between two identical events written by a MirrorHandler.
+const DefaultRateLimit = 5 * time.Minute
+
+// EventWriter is the subset of [eventlog.Log] used by MirrorHandler.
+type EventWriter interface {
+	Error(eid uint32, msg string) error
+	Warning(eid uint32, msg string) error
+	Info(eid uint32, msg string) error
+	Close() error
+}
+
+// MirrorHandler is a [slog.Handler] that passes all records to the next handler
+// and additionally writes records at or above a given level to the Windows Event Log.
+// Identical events are only written once per rate limit interval.
+type MirrorHandler struct {
+	next      slog.Handler
+	writer    EventWriter
+	level     slog.Leveler
+	rateLimit time.Duration
+	attrs     []slog.Attr
+	groups    []string
+
+	// state is shared between all handlers derived via WithAttrs and WithGroup.
+	state *mirrorState
+}
+
+type mirrorState struct {
+	mu       sync.Mutex
+	lastSent map[string]time.Time
+	closed   bool
+}
+
+// NewMirrorHandler returns a new MirrorHandler.
+func NewMirrorHandler(next slog.Handler, writer EventWriter, level slog.Leveler, rateLimit time.Duration) *MirrorHandler {
+	return &MirrorHandler{
+		next:      next,
+		writer:    writer,
+		level:     level,
+		rateLimit: rateLimit,
+		state: &mirrorState{
+			lastSent: make(map[string]time.Time),
+		},
+	}
+}
+
+func (h *MirrorHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() || h.next.Enabled(ctx, level)
+}
+
+func (h *MirrorHandler) Handle(ctx context.Context, record slog.Record) error {
+	var err error
+
+	if h.next.Enabled(ctx, record.Level) {
+		err = h.next.Handle(ctx, record)
+	}
+
+	if record.Level < h.level.Level() {
+		return err
+	}
+
+	msg := h.format(record)
+	if !h.allow(msg) {
+		return err
+	}
+
+	var eventErr error
+
+	switch {
+	case record.Level >= slog.LevelError:
+		eventErr = h.writer.Error(102, msg)
+	case record.Level >= slog.LevelWarn:
+		eventErr = h.writer.Warning(101, msg)
+	default:
+		eventErr = h.writer.Info(100, msg)
+	}
+
+	if err == nil && eventErr != nil {
+		err = fmt.Errorf("failed to write event log entry: %w", eventErr)
+	}
+
+	return err
+}
+
+func (h *MirrorHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithAttrs(attrs)
+	clone.attrs = append(clone.attrs[:len(clone.attrs):len(clone.attrs)], h.prefixAttrs(attrs)...)
+
+	return &clone
+}
+
+func (h *MirrorHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	clone := *h
+	clone.next = h.next.WithGroup(name)
+	clone.groups = append(clone.groups[:len(clone.groups):len(clone.groups)], name)
+
+	return &clone
+}
+
+// Close closes the event log writer. Records handled afterward are only passed to the next handler.
+func (h *MirrorHandler) Close() error {
+	h.state.mu.Lock()
+	defer h.state.mu.Unlock()
+
+	if h.state.closed {
+		return nil
+	}
+
+	h.state.closed = true
+
+	return h.writer.Close()
+}
+
+// allow reports whether the given event may be written, based on the rate limit.
+func (h *MirrorHandler) allow(msg string) bool {
+	h.state.mu.Lock()
+	defer h.state.mu.Unlock()
+
+	if h.state.closed {
+		return false
+	}
+
+	if h.rateLimit <= 0 {
+		return true
+	}
+
+	now := time.Now()
+
+	for event, lastSent := range h.state.lastSent {
+		if now.Sub(lastSent) >= h.rateLimit {
+			delete(h.state.lastSent, event)
+		}
+	}
+
+	if _, ok := h.state.lastSent[msg]; ok {
+		return false
+	}
+
+	h.state.lastSent[msg] = now
+
+	return true
+}
+
+// format renders the record as message followed by key=value pairs, without time and level.
+func (h *MirrorHandler) format(record slog.Record) string {
+	var sb strings.Builder
+
+	sb.WriteString(record.Message)
+
+	for _, attr := range h.attrs {
+		writeAttr(&sb, attr)
+	}
+
+	record.Attrs(func(attr slog.Attr) bool {
+		for _, attr := range h.prefixAttrs([]slog.Attr{attr}) {
+			writeAttr(&sb, attr)
+		}
+
+		return true
+	})
+
+	return sb.String()
+}
+
+func (h *MirrorHandler) prefixAttrs(attrs []slog.Attr) []slog.Attr {
+	if len(h.groups) == 0 {
+		return attrs
+	}
+
+	prefix := strings.Join(h.groups, ".") + "."
+	prefixed := make([]slog.Attr, 0, len(attrs))
+
+	for _, attr := range attrs {
+		prefixed = append(prefixed, slog.Attr{Key: prefix + attr.Key, Value: attr.Value})
+	}
+
+	return prefixed
+}
+
+func writeAttr(sb *strings.Builder, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Value.Kind() == slog.KindGroup {
+		for _, groupAttr := range attr.Value.Group() {
+			writeAttr(sb, slog.Attr{Key: attr.Key + "." + groupAttr.Key, Value: groupAttr.Value})
+		}
+
+		return
+	}
+
+	fmt.Fprintf(sb, " %s=%q", attr.Key, attr.Value.String())
+}
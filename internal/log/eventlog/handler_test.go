@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package eventlog_test
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/log/eventlog"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEvent struct {
+	kind string
+	eid  uint32
+	msg  string
+}
+
+type fakeEventWriter struct {
+	events []fakeEvent
+	closed int
+}
+
+func (w *fakeEventWriter) Error(eid uint32, msg string) error {
+	w.events = append(w.events, fakeEvent{"error", eid, msg})
+
+	return nil
+}
+
+func (w *fakeEventWriter) Warning(eid uint32, msg string) error {
+	w.events = append(w.events, fakeEvent{"warning", eid, msg})
+
+	return nil
+}
+
+func (w *fakeEventWriter) Info(eid uint32, msg string) error {
+	w.events = append(w.events, fakeEvent{"info", eid, msg})
+
+	return nil
+}
+
+func (w *fakeEventWriter) Close() error {
+	w.closed++
+
+	return nil
+}
+
+func TestMirrorHandlerLevelRouting(t *testing.T) {
+	t.Parallel()
+
+	writer := &fakeEventWriter{}
+	next := slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug})
+	logger := slog.New(eventlog.NewMirrorHandler(next, writer, slog.LevelWarn, 0))
+
+	logger.Debug("debug message")
+	logger.Info("info message")
+	logger.Warn("warn message")
+	logger.Error("collector cpu failed", slog.Any("err", errors.New("access denied")))
+
+	require.Equal(t, []fakeEvent{
+		{"warning", 101, "warn message"},
+		{"error", 102, `collector cpu failed err="access denied"`},
+	}, writer.events)
+}
+
+func TestMirrorHandlerAttrs(t *testing.T) {
+	t.Parallel()
+
+	writer := &fakeEventWriter{}
+	next := slog.NewTextHandler(io.Discard, nil)
+	logger := slog.New(eventlog.NewMirrorHandler(next, writer, slog.LevelError, 0)).
+		With(slog.String("collector", "logical_disk")).
+		WithGroup("volume")
+
+	logger.Error("failed", slog.String("name", "C:"))
+
+	require.Len(t, writer.events, 1)
+	require.Equal(t, `failed collector="logical_disk" volume.name="C:"`, writer.events[0].msg)
+}
+
+func TestMirrorHandlerRateLimit(t *testing.T) {
+	t.Parallel()
+
+	writer := &fakeEventWriter{}
+	next := slog.NewTextHandler(io.Discard, nil)
+	logger := slog.New(eventlog.NewMirrorHandler(next, writer, slog.LevelError, time.Hour))
+
+	for range 10 {
+		logger.Error("collector cpu failed")
+	}
+
+	logger.Error("collector memory failed")
+
+	require.Len(t, writer.events, 2)
+	require.Contains(t, writer.events[1].msg, "memory")
+}
+
+func TestMirrorHandlerNextHandler(t *testing.T) {
+	t.Parallel()
+
+	var sb strings.Builder
+
+	writer := &fakeEventWriter{}
+	next := slog.NewTextHandler(&sb, &slog.HandlerOptions{Level: slog.LevelInfo})
+	logger := slog.New(eventlog.NewMirrorHandler(next, writer, slog.LevelError, 0))
+
+	logger.Debug("debug message")
+	logger.Info("info message")
+
+	require.NotContains(t, sb.String(), "debug message")
+	require.Contains(t, sb.String(), "info message")
+	require.Empty(t, writer.events)
+}
+
+func TestMirrorHandlerClose(t *testing.T) {
+	t.Parallel()
+
+	var sb strings.Builder
+
+	writer := &fakeEventWriter{}
+	handler := eventlog.NewMirrorHandler(slog.NewTextHandler(&sb, nil), writer, slog.LevelError, 0)
+	logger := slog.New(handler).With(slog.String("collector", "cpu"))
+
+	require.NoError(t, handler.Close())
+	require.NoError(t, handler.Close())
+	require.Equal(t, 1, writer.closed)
+
+	logger.Error("collector cpu failed")
+
+	require.Empty(t, writer.events)
+	require.Contains(t, sb.String(), "collector cpu failed")
+}
@@ -30,6 +30,13 @@ const FileFlagName = "log.file"
 // FileFlagHelp is the help description for the log.file flag.
 const FileFlagHelp = "Output file of log messages. One of [stdout, stderr, eventlog, <path to log file>]"
 
+// EventLogLevelFlagName is the canonical flag name to configure the event log mirroring level.
+const EventLogLevelFlagName = "log.eventlog-level"
+
+// EventLogLevelFlagHelp is the help description for the log.eventlog-level flag.
+const EventLogLevelFlagHelp = "Additionally write log messages at or above this level to the Windows Application event log. " +
+	"Identical messages are written at most once every 5 minutes. One of [debug, info, warn, error]. Empty disables it."
+
 // AddFlags adds the flags used by this package to the Kingpin application.
 // To use the default Kingpin application, call AddFlags(kingpin.CommandLine).
 func AddFlags(a *kingpin.Application, config *log.Config) {
@@ -41,4 +48,6 @@ func AddFlags(a *kingpin.Application, config *log.Config) {
 	}
 
 	a.Flag(FileFlagName, FileFlagHelp).Default(config.File.String()).SetValue(config.File)
+	a.Flag(EventLogLevelFlagName, EventLogLevelFlagHelp).Default("").
+		EnumVar(&config.EventLogLevel, "", "debug", "info", "warn", "error")
 }
@@ -76,6 +76,10 @@ type Config struct {
 	*promslog.Config
 
 	File *AllowedFile
+
+	// EventLogLevel is the minimum level of records that are additionally
+	// written to the Windows Event Log. Empty disables mirroring.
+	EventLogLevel string
 }
 
 func New(config *Config) (*slog.Logger, error) {
@@ -86,5 +90,31 @@ func New(config *Config) (*slog.Logger, error) {
 	config.Writer = config.File.w
 	config.Style = promslog.SlogStyle
 
-	return promslog.New(config.Config), nil
+	logger := promslog.New(config.Config)
+
+	// If the log file is already the event log, there is nothing to mirror.
+	if config.EventLogLevel == "" || config.File.s == "eventlog" {
+		return logger, nil
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(config.EventLogLevel)); err != nil {
+		return nil, fmt.Errorf("invalid event log level %q: %w", config.EventLogLevel, err)
+	}
+
+	eventLog, err := wineventlog.Open("windows_exporter")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event log: %w", err)
+	}
+
+	return slog.New(eventlog.NewMirrorHandler(logger.Handler(), eventLog, level, eventlog.DefaultRateLimit)), nil
+}
+
+// Close closes the event log opened by [New] for mirroring log messages, if any.
+func Close(logger *slog.Logger) error {
+	if handler, ok := logger.Handler().(*eventlog.MirrorHandler); ok {
+		return handler.Close()
+	}
+
+	return nil
 }
@@ -101,6 +101,7 @@ func (instance *Instance) GetElement(elementName string) (*Element, error) {
 	var (
 		value     uintptr
 		valueType ValueType
+		flags     uint32
 	)
 
 	r0, _, _ := syscall.SyscallN(
@@ -109,7 +110,7 @@ func (instance *Instance) GetElement(elementName string) (*Element, error) {
 		uintptr(unsafe.Pointer(elementNameUTF16)),
 		uintptr(unsafe.Pointer(&value)),
 		uintptr(unsafe.Pointer(&valueType)),
-		0,
+		uintptr(unsafe.Pointer(&flags)),
 		0,
 	)
 
@@ -120,6 +121,7 @@ func (instance *Instance) GetElement(elementName string) (*Element, error) {
 	return &Element{
 		value:     value,
 		valueType: valueType,
+		flags:     flags,
 	}, nil
 }
 
@@ -244,6 +244,18 @@ func (o *Operation) Unmarshal(dst any) error {
 				return fmt.Errorf("failed to get element %s: %w", miTag, err)
 			}
 
+			// Null values are left at the zero value, i.e. nil for pointer fields.
+			if element.IsNull() {
+				field.SetZero()
+
+				continue
+			}
+
+			if field.Kind() == reflect.Pointer {
+				field.Set(reflect.New(field.Type().Elem()))
+				field = field.Elem()
+			}
+
 			switch element.valueType {
 			case ValueTypeBOOLEAN:
 				field.SetBool(element.value == 1)
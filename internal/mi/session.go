@@ -274,6 +274,18 @@ func (s *Session) QueryUnmarshal(dst any,
 				return fmt.Errorf("failed to get element %s: %w", miTag, err)
 			}
 
+			// Null values are left at the zero value, i.e. nil for pointer fields.
+			if element.IsNull() {
+				field.SetZero()
+
+				continue
+			}
+
+			if field.Kind() == reflect.Pointer {
+				field.Set(reflect.New(field.Type().Elem()))
+				field = field.Elem()
+			}
+
 			switch element.valueType {
 			case ValueTypeBOOLEAN:
 				field.SetBool(element.value == 1)
@@ -308,6 +320,15 @@ func (s *Session) QueryUnmarshal(dst any,
 	return nil
 }
 
+// Querier is the subset of [Session] used by collectors to run WQL queries.
+// It allows replacing the MI query layer in tests.
+type Querier interface {
+	Query(dst any, namespaceName Namespace, queryExpression Query, queryTimeout time.Duration) error
+}
+
+// Interface guard.
+var _ Querier = (*Session)(nil)
+
 // Query queries for a set of instances based on a query expression.
 //
 //nolint:nestif
@@ -50,11 +50,15 @@ func NewNamespace(namespace string) (Namespace, error) {
 //nolint:gochecknoglobals
 var (
 	NamespaceRootCIMv2             = utils.Must(NewNamespace("root/CIMv2"))
+	NamespaceRootTerminalServices  = utils.Must(NewNamespace("root/CIMv2/TerminalServices"))
 	NamespaceRootWindowsFSRM       = utils.Must(NewNamespace("root/microsoft/windows/fsrm"))
 	NamespaceRootWebAdministration = utils.Must(NewNamespace("root/WebAdministration"))
 	NamespaceRootMSCluster         = utils.Must(NewNamespace("root/MSCluster"))
 	NamespaceRootMicrosoftDNS      = utils.Must(NewNamespace("root/MicrosoftDNS"))
 	NamespaceRootStorage           = utils.Must(NewNamespace("root/Microsoft/Windows/Storage"))
+	NamespaceRootMicrosoftTpm      = utils.Must(NewNamespace("root/CIMv2/Security/MicrosoftTpm"))
+	NamespaceRootVirtualizationV2  = utils.Must(NewNamespace("root/virtualization/v2"))
+	NamespaceRootSMB               = utils.Must(NewNamespace("root/Microsoft/Windows/SMB"))
 )
 
 type Query *uint16
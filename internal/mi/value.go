@@ -64,9 +64,18 @@ const (
 	ValueTypeARRAY ValueType = 16
 )
 
+// flagNull is MI_FLAG_NULL. It is set in the flags of an element, if the value is null.
+const flagNull = 0x20000000
+
 type Element struct {
 	value     uintptr
 	valueType ValueType
+	flags     uint32
+}
+
+// IsNull reports whether the value of the element is null.
+func (e *Element) IsNull() bool {
+	return e.flags&flagNull != 0
 }
 
 func (e *Element) GetValue() (any, error) {
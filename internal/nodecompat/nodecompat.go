@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package nodecompat translates a curated set of windows_exporter metrics
+// into their node_exporter equivalents, to allow shared dashboards across Linux and Windows hosts.
+package nodecompat
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Interface guard.
+var _ prometheus.Gatherer = (*Gatherer)(nil)
+
+// labelMapping renames the label From to To. Values are optionally rewritten by Values.
+// Labels of the source metric without a mapping are dropped.
+type labelMapping struct {
+	From   string
+	To     string
+	Values map[string]string
+}
+
+// Mapping describes the translation of a windows_exporter metric family into a node_exporter metric family.
+type Mapping struct {
+	From   string
+	To     string
+	Help   string
+	Labels []labelMapping
+}
+
+// Mappings is the list of node_exporter metric families exposed by the compatibility layer.
+//
+//nolint:gochecknoglobals
+var Mappings = []Mapping{
+	{
+		From: "windows_cpu_time_total",
+		To:   "node_cpu_seconds_total",
+		Help: "Seconds the CPUs spent in each mode. Translated from windows_cpu_time_total.",
+		Labels: []labelMapping{
+			{From: "core", To: "cpu"},
+			{From: "mode", To: "mode", Values: map[string]string{
+				"dpc":        "softirq",
+				"idle":       "idle",
+				"interrupt":  "irq",
+				"privileged": "system",
+				"user":       "user",
+			}},
+		},
+	},
+	{
+		From: "windows_logical_disk_free_bytes",
+		To:   "node_filesystem_avail_bytes",
+		Help: "Filesystem space available to non-root users in bytes. Translated from windows_logical_disk_free_bytes.",
+		Labels: []labelMapping{
+			{From: "volume", To: "device"},
+			{From: "volume", To: "mountpoint"},
+		},
+	},
+	{
+		From: "windows_memory_available_bytes",
+		To:   "node_memory_MemAvailable_bytes",
+		Help: "Memory information field MemAvailable_bytes. Translated from windows_memory_available_bytes.",
+	},
+	{
+		From: "windows_net_bytes_received_total",
+		To:   "node_network_receive_bytes_total",
+		Help: "Network device statistic receive_bytes. Translated from windows_net_bytes_received_total.",
+		Labels: []labelMapping{
+			{From: "nic", To: "device"},
+		},
+	},
+}
+
+// Gatherer wraps a [prometheus.Gatherer] and appends the node_exporter aliases
+// of the gathered windows_exporter metric families.
+type Gatherer struct {
+	next prometheus.Gatherer
+}
+
+// NewGatherer returns a new Gatherer.
+func NewGatherer(next prometheus.Gatherer) *Gatherer {
+	return &Gatherer{next: next}
+}
+
+// Gather implements [prometheus.Gatherer]. Errors of the wrapped gatherer are passed through,
+// together with the aliases of the metric families that were gathered successfully.
+func (g *Gatherer) Gather() ([]*dto.MetricFamily, error) {
+	metricFamilies, err := g.next.Gather()
+
+	metricFamilies = append(metricFamilies, Translate(metricFamilies)...)
+	slices.SortFunc(metricFamilies, func(a, b *dto.MetricFamily) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+
+	return metricFamilies, err
+}
+
+// Translate returns the node_exporter aliases of the given metric families.
+// Aliases are skipped if a metric family with the same name already exists, e.g. from the textfile collector.
+func Translate(metricFamilies []*dto.MetricFamily) []*dto.MetricFamily {
+	byName := make(map[string]*dto.MetricFamily, len(metricFamilies))
+	for _, metricFamily := range metricFamilies {
+		byName[metricFamily.GetName()] = metricFamily
+	}
+
+	aliases := make([]*dto.MetricFamily, 0, len(Mappings))
+
+	for _, mapping := range Mappings {
+		source, ok := byName[mapping.From]
+		if !ok {
+			continue
+		}
+
+		if _, ok := byName[mapping.To]; ok {
+			continue
+		}
+
+		aliases = append(aliases, mapping.translate(source))
+	}
+
+	return aliases
+}
+
+func (m Mapping) translate(source *dto.MetricFamily) *dto.MetricFamily {
+	alias := &dto.MetricFamily{
+		Name:   new(m.To),
+		Help:   new(m.Help),
+		Type:   source.Type,
+		Metric: make([]*dto.Metric, 0, len(source.GetMetric())),
+	}
+
+	for _, metric := range source.GetMetric() {
+		translated := &dto.Metric{
+			Gauge:       metric.GetGauge(),
+			Counter:     metric.GetCounter(),
+			Untyped:     metric.GetUntyped(),
+			TimestampMs: metric.TimestampMs,
+			Label:       m.translateLabels(metric.GetLabel()),
+		}
+
+		alias.Metric = append(alias.Metric, translated)
+	}
+
+	return alias
+}
+
+func (m Mapping) translateLabels(labels []*dto.LabelPair) []*dto.LabelPair {
+	translated := make([]*dto.LabelPair, 0, len(m.Labels))
+
+	for _, labelMapping := range m.Labels {
+		for _, label := range labels {
+			if label.GetName() != labelMapping.From {
+				continue
+			}
+
+			value := label.GetValue()
+			if v, ok := labelMapping.Values[value]; ok {
+				value = v
+			}
+
+			translated = append(translated, &dto.LabelPair{
+				Name:  new(labelMapping.To),
+				Value: new(value),
+			})
+		}
+	}
+
+	slices.SortFunc(translated, func(a, b *dto.LabelPair) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+
+	return translated
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package nodecompat_test
+
+import (
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/nodecompat"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func newRegistry(t *testing.T) *prometheus.Registry {
+	t.Helper()
+
+	cpuTime := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "windows_cpu_time_total"}, []string{"core", "mode"})
+	cpuTime.WithLabelValues("0,0", "idle").Add(100)
+	cpuTime.WithLabelValues("0,0", "privileged").Add(20)
+	cpuTime.WithLabelValues("0,0", "dpc").Add(1)
+
+	freeBytes := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "windows_logical_disk_free_bytes"}, []string{"volume"})
+	freeBytes.WithLabelValues("C:").Set(1024)
+	freeBytes.WithLabelValues("D:").Set(2048)
+
+	availableBytes := prometheus.NewGauge(prometheus.GaugeOpts{Name: "windows_memory_available_bytes"})
+	availableBytes.Set(4096)
+
+	bytesReceived := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "windows_net_bytes_received_total"}, []string{"nic"})
+	bytesReceived.WithLabelValues("Ethernet").Add(512)
+
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(cpuTime, freeBytes, availableBytes, bytesReceived)
+
+	return reg
+}
+
+func familyValues(metricFamily *dto.MetricFamily) []float64 {
+	values := make([]float64, 0, len(metricFamily.GetMetric()))
+
+	for _, metric := range metricFamily.GetMetric() {
+		switch metricFamily.GetType() {
+		case dto.MetricType_COUNTER:
+			values = append(values, metric.GetCounter().GetValue())
+		case dto.MetricType_GAUGE:
+			values = append(values, metric.GetGauge().GetValue())
+		default:
+			values = append(values, metric.GetUntyped().GetValue())
+		}
+	}
+
+	return values
+}
+
+func TestGatherer(t *testing.T) {
+	t.Parallel()
+
+	metricFamilies, err := nodecompat.NewGatherer(newRegistry(t)).Gather()
+	require.NoError(t, err)
+
+	byName := make(map[string]*dto.MetricFamily, len(metricFamilies))
+	for _, metricFamily := range metricFamilies {
+		byName[metricFamily.GetName()] = metricFamily
+	}
+
+	for _, mapping := range nodecompat.Mappings {
+		source, ok := byName[mapping.From]
+		require.True(t, ok, "missing source family %s", mapping.From)
+
+		alias, ok := byName[mapping.To]
+		require.True(t, ok, "missing alias family %s", mapping.To)
+
+		require.Equal(t, source.GetType(), alias.GetType(), mapping.To)
+		require.Equal(t, familyValues(source), familyValues(alias), mapping.To)
+	}
+}
+
+func TestTranslateLabels(t *testing.T) {
+	t.Parallel()
+
+	metricFamilies, err := newRegistry(t).Gather()
+	require.NoError(t, err)
+
+	labels := make(map[string][]map[string]string)
+
+	for _, alias := range nodecompat.Translate(metricFamilies) {
+		for _, metric := range alias.GetMetric() {
+			metricLabels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				metricLabels[label.GetName()] = label.GetValue()
+			}
+
+			labels[alias.GetName()] = append(labels[alias.GetName()], metricLabels)
+		}
+	}
+
+	require.Equal(t, []map[string]string{
+		{"cpu": "0,0", "mode": "softirq"},
+		{"cpu": "0,0", "mode": "idle"},
+		{"cpu": "0,0", "mode": "system"},
+	}, labels["node_cpu_seconds_total"])
+	require.Equal(t, []map[string]string{
+		{"device": "C:", "mountpoint": "C:"},
+		{"device": "D:", "mountpoint": "D:"},
+	}, labels["node_filesystem_avail_bytes"])
+	require.Equal(t, []map[string]string{{}}, labels["node_memory_MemAvailable_bytes"])
+	require.Equal(t, []map[string]string{{"device": "Ethernet"}}, labels["node_network_receive_bytes_total"])
+}
+
+func TestTranslateSkipsExistingFamilies(t *testing.T) {
+	t.Parallel()
+
+	reg := newRegistry(t)
+	reg.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{Name: "node_memory_MemAvailable_bytes"}))
+
+	metricFamilies, err := reg.Gather()
+	require.NoError(t, err)
+
+	for _, alias := range nodecompat.Translate(metricFamilies) {
+		require.NotEqual(t, "node_memory_MemAvailable_bytes", alias.GetName())
+	}
+}
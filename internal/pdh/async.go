@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"golang.org/x/sys/windows"
+)
+
+// asyncSnapshot is the last sample of an asynchronous collector.
+type asyncSnapshot struct {
+	mu        sync.RWMutex
+	valueType reflect.Type
+	// data is a slice of valueType.
+	data    reflect.Value
+	err     error
+	updated time.Time
+
+	// event is signaled by PDH once a new sample is available.
+	event   windows.Handle
+	closing atomic.Bool
+	doneCh  chan struct{}
+}
+
+// NewAsyncCollector returns a collector, whose query is collected by PDH in the background every interval
+// using PdhCollectQueryDataEx. Collect returns a copy of the last sample and never waits for PDH,
+// so slow performance counter objects don't block the scrape. Values are up to interval old.
+// The interval is rounded up to full seconds.
+//
+// Asynchronous collectors always use their own query, even if the shared query mode is enabled.
+// If a performance counter log file is set, the collector is synchronous, since each collection
+// reads the next sample of the log file.
+func NewAsyncCollector[T any](logger *slog.Logger, resultType CounterType, object string, instances []string, interval time.Duration) (*Collector, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid interval: must be positive, got %s", interval)
+	}
+
+	if isLogFile() {
+		interval = 0
+	}
+
+	return newCollector(logger, resultType, object, instances, reflect.TypeFor[T](), collectorOptions{asyncInterval: interval})
+}
+
+// startAsync reads the initial sample synchronously and starts the background collection.
+func (c *Collector) startAsync(valueType reflect.Type, interval time.Duration) error {
+	snapshot := &asyncSnapshot{
+		valueType: valueType,
+		doneCh:    make(chan struct{}),
+	}
+
+	rawBuf := make([]byte, 1)
+	formattedBuf := make([]byte, 1)
+
+	// The initial sample is read synchronously, so that Collect returns data before the first background sample.
+	if ret := CollectQueryData(c.handle); ret != ErrorSuccess {
+		return fmt.Errorf("failed to collect initial data: %w", NewPdhError(ret))
+	}
+
+	snapshot.refresh(c, &rawBuf, &formattedBuf)
+
+	event, err := windows.CreateEvent(nil, 0, 0, nil)
+	if err != nil {
+		return fmt.Errorf("CreateEvent: %w", err)
+	}
+
+	intervalSeconds := uint32((interval + time.Second - 1) / time.Second)
+
+	if ret := CollectQueryDataEx(c.handle, intervalSeconds, event); ret != ErrorSuccess {
+		_ = windows.CloseHandle(event)
+
+		return fmt.Errorf("failed to start background collection: %w", NewPdhError(ret))
+	}
+
+	snapshot.event = event
+	c.async = snapshot
+
+	go c.asyncWorker(rawBuf, formattedBuf)
+
+	return nil
+}
+
+// asyncWorker refreshes the snapshot each time PDH signals a new sample.
+func (c *Collector) asyncWorker(rawBuf, formattedBuf []byte) {
+	defer close(c.async.doneCh)
+
+	for {
+		if _, err := windows.WaitForSingleObject(c.async.event, windows.INFINITE); err != nil {
+			c.logger.Error("failed to wait for performance counter data",
+				slog.String("object", c.object),
+				slog.Any("err", err),
+			)
+
+			return
+		}
+
+		if c.async.closing.Load() {
+			return
+		}
+
+		c.async.refresh(c, &rawBuf, &formattedBuf)
+	}
+}
+
+// stopAsync stops the worker. The background collection of PDH stops, once the query is closed.
+// PDH signals the event until then, so the event must be closed by closeEvent after the query.
+func (c *Collector) stopAsync() {
+	c.async.closing.Store(true)
+
+	_ = windows.SetEvent(c.async.event)
+
+	<-c.async.doneCh
+}
+
+// closeEvent closes the event signaled by PDH. It must be called after the query has been closed.
+func (s *asyncSnapshot) closeEvent() {
+	if s.event == 0 {
+		return
+	}
+
+	_ = windows.CloseHandle(s.event)
+
+	s.event = 0
+}
+
+// refresh reads the last collected sample of the query into the snapshot.
+func (s *asyncSnapshot) refresh(c *Collector, rawBuf, formattedBuf *[]byte) {
+	data := reflect.New(reflect.SliceOf(s.valueType))
+	err := c.read(data.Interface(), rawBuf, formattedBuf)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data = data.Elem()
+	s.err = err
+	s.updated = time.Now()
+}
+
+// copyTo copies the snapshot into dst, which must be a pointer to a slice of the value type of the collector.
+func (s *asyncSnapshot) copyTo(dst any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Type() != reflect.SliceOf(s.valueType) {
+		return fmt.Errorf("expected a pointer to a slice of %s, got %T: %w", s.valueType, dst, mi.ErrInvalidEntityType)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.err != nil {
+		return s.err
+	}
+
+	data := reflect.MakeSlice(s.data.Type(), s.data.Len(), s.data.Len())
+	reflect.Copy(data, s.data)
+	dv.Elem().Set(data)
+
+	return nil
+}
+
+// age returns the time since the last sample was read.
+func (s *asyncSnapshot) age() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return time.Since(s.updated)
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh_test
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncCollector(t *testing.T) {
+	t.Parallel()
+
+	performanceData, err := pdh.NewAsyncCollector[process](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll, time.Second)
+	require.NoError(t, err)
+
+	t.Cleanup(performanceData.Close)
+
+	// The initial sample is available immediately.
+	var data []process
+
+	require.NoError(t, performanceData.Collect(&data))
+	require.NotEmpty(t, data)
+
+	// Collect returns a copy of the snapshot.
+	data[0].ThreadCount = -1
+
+	var snapshot []process
+
+	require.NoError(t, performanceData.Collect(&snapshot))
+	require.NotEqual(t, -1.0, snapshot[0].ThreadCount)
+
+	// The snapshot is refreshed in the background.
+	time.Sleep(1500 * time.Millisecond)
+
+	require.NoError(t, performanceData.Collect(&data))
+	require.NotEmpty(t, data)
+
+	var wrongType []processOptional
+
+	require.ErrorIs(t, performanceData.Collect(&wrongType), mi.ErrInvalidEntityType)
+}
+
+func TestAsyncCollectorClose(t *testing.T) {
+	t.Parallel()
+
+	performanceData, err := pdh.NewAsyncCollector[process](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll, time.Second)
+	require.NoError(t, err)
+
+	performanceData.Close()
+
+	var data []process
+
+	require.ErrorIs(t, performanceData.Collect(&data), pdh.ErrPerformanceCounterNotInitialized)
+}
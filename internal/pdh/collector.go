@@ -26,6 +26,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/prometheus-community/windows_exporter/internal/mi"
@@ -38,6 +39,8 @@ import (
 var (
 	InstancesAll   = []string{"*"}
 	InstancesTotal = []string{InstanceTotal}
+	// InstancesAllWithTotal expands all instances, including the _Total instance skipped by InstancesAll.
+	InstancesAllWithTotal = []string{"*", InstanceTotal}
 )
 
 type CounterValues = map[string]map[string]CounterValue
@@ -55,6 +58,25 @@ type Collector struct {
 
 	collectCh chan any
 	errorCh   chan error
+
+	staleness staleTracker
+
+	// shared is true, if the counters are part of the shared query. See SetSharedQuery.
+	shared bool
+	// async holds the snapshot of asynchronous collectors. See NewAsyncCollector.
+	async *asyncSnapshot
+	// impersonator runs the collection of the query. See NewImpersonatedCollector.
+	impersonator Impersonator
+	// collectQueryData is CollectQueryData, except in tests.
+	collectQueryData func(hQuery pdhQueryHandle) uint32
+}
+
+// collectorOptions are the optional settings of newCollector.
+type collectorOptions struct {
+	// asyncInterval is the interval of the background collection, see NewAsyncCollector.
+	asyncInterval time.Duration
+	// impersonator runs the collection of the query, see NewImpersonatedCollector.
+	impersonator Impersonator
 }
 
 type Counter struct {
@@ -64,6 +86,8 @@ type Counter struct {
 	Instances  map[string]pdhCounterHandle
 	Type       uint32
 	Frequency  int64
+	// ResultType is the value type read from the query. Defaults to the result type of the collector.
+	ResultType CounterType
 
 	FieldIndexValue       int
 	FieldIndexSecondValue int
@@ -76,11 +100,13 @@ func NewCollector[T any](logger *slog.Logger, resultType CounterType, object str
 }
 
 func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, object string, instances []string, valueType reflect.Type) (*Collector, error) {
-	var handle pdhQueryHandle
+	return newCollector(logger, resultType, object, instances, valueType, collectorOptions{})
+}
 
-	if ret := OpenQuery(0, 0, &handle); ret != ErrorSuccess {
-		return nil, NewPdhError(ret)
-	}
+// newCollector creates a collector. If the async interval is greater than zero, the query is collected
+// in the background, see NewAsyncCollector.
+func newCollector(logger *slog.Logger, resultType CounterType, object string, instances []string, valueType reflect.Type, options collectorOptions) (*Collector, error) {
+	asyncInterval := options.asyncInterval
 
 	if len(instances) == 0 {
 		instances = []string{InstanceEmpty}
@@ -90,15 +116,46 @@ func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, obj
 		return nil, fmt.Errorf("invalid result type: %v", resultType)
 	}
 
+	var (
+		handle   pdhQueryHandle
+		isShared bool
+		err      error
+	)
+
+	// Asynchronous collectors are collected by PDH in the background and impersonated collectors
+	// collect under another account, so both use their own query, unless a log file is replayed.
+	if (asyncInterval <= 0 && options.impersonator == nil) || isLogFile() {
+		handle, isShared, err = shared.acquire()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open shared query: %w", err)
+		}
+	}
+
+	if !isShared {
+		if ret := openQuery(&handle); ret != ErrorSuccess {
+			return nil, NewPdhError(ret)
+		}
+	}
+
 	collector := &Collector{
 		object:                object,
 		counters:              make(map[string]Counter, valueType.NumField()),
 		handle:                handle,
 		totalCounterRequested: slices.Contains(instances, InstanceTotal),
+		shared:                isShared,
 		mu:                    sync.RWMutex{},
 		logger:                logger,
 		nameIndexValue:        -1,
 		metricsTypeIndexValue: -1,
+		impersonator:          options.impersonator,
+		collectQueryData:      CollectQueryData,
+	}
+
+	// The _Total instance is part of the wildcard expansion, so it is not added as a separate counter.
+	if collector.totalCounterRequested && slices.Contains(instances, "*") {
+		instances = slices.DeleteFunc(slices.Clone(instances), func(instance string) bool {
+			return instance == InstanceTotal
+		})
 	}
 
 	errs := make([]error, 0, valueType.NumField())
@@ -132,10 +189,34 @@ func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, obj
 			counterName = strings.TrimSuffix(counterName, ",secondvalue")
 		}
 
+		counterResultType := resultType
+
+		if mode, ok := f.Tag.Lookup("pdhmode"); ok {
+			counterResultType = CounterType(mode)
+			if counterResultType != CounterTypeRaw && counterResultType != CounterTypeFormatted {
+				errs = append(errs, fmt.Errorf("field %s: invalid pdhmode %q", f.Name, mode))
+
+				continue
+			}
+		}
+
+		if secondValue && counterResultType != CounterTypeRaw {
+			errs = append(errs, fmt.Errorf("field %s: secondvalue requires pdhmode raw", f.Name))
+
+			continue
+		}
+
+		// A counter may be read in both modes. Each mode gets its own counter handles.
+		counterKey := counterName
+		if counterResultType != resultType {
+			counterKey += "," + string(counterResultType)
+		}
+
 		var counter Counter
-		if counter, ok = collector.counters[counterName]; !ok {
+		if counter, ok = collector.counters[counterKey]; !ok {
 			counter = Counter{
 				Name:                  counterName,
+				ResultType:            counterResultType,
 				Instances:             make(map[string]pdhCounterHandle, len(instances)),
 				FieldIndexSecondValue: -1,
 				FieldIndexValue:       -1,
@@ -149,7 +230,7 @@ func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, obj
 		}
 
 		if len(counter.Instances) != 0 {
-			collector.counters[counterName] = counter
+			collector.counters[counterKey] = counter
 
 			continue
 		}
@@ -162,8 +243,13 @@ func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, obj
 			var counterHandle pdhCounterHandle
 
 			//nolint:nestif
-			if ret := AddEnglishCounter(handle, counterPath, 0, &counterHandle); ret != ErrorSuccess {
+			if ret := collector.addCounter(counterPath, &counterHandle); ret != ErrorSuccess {
 				if ret == CstatusNoCounter {
+					// Optional counters are only available on some systems, e.g. depending on the installed features.
+					if _, ok := f.Tag.Lookup("perfdata_optional"); ok {
+						continue
+					}
+
 					if minOSBuildTag, ok := f.Tag.Lookup("perfdata_min_build"); ok {
 						if minOSBuild, err := strconv.Atoi(minOSBuildTag); err == nil {
 							if uint16(minOSBuild) > osversion.Build() {
@@ -229,7 +315,7 @@ func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, obj
 			}
 		}
 
-		collector.counters[counterName] = counter
+		collector.counters[counterKey] = counter
 	}
 
 	if err := errors.Join(errs...); err != nil {
@@ -237,16 +323,38 @@ func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, obj
 	}
 
 	if len(collector.counters) == 0 {
+		if collector.shared {
+			shared.release(collector.counters)
+		}
+
 		return nil, errors.New("no counters configured")
 	}
 
+	if asyncInterval > 0 {
+		if err := collector.startAsync(valueType, asyncInterval); err != nil {
+			collector.Close()
+
+			return nil, err
+		}
+
+		registerLiveCollector(collector)
+
+		return collector, nil
+	}
+
 	collector.collectCh = make(chan any)
 	collector.errorCh = make(chan error)
 
-	if resultType == CounterTypeRaw {
-		go collector.collectWorkerRaw()
-	} else {
-		go collector.collectWorkerFormatted()
+	go collector.collectWorker()
+
+	registerLiveCollector(collector)
+
+	// Each collection of a replayed log file reads the next sample, which is done once per scrape.
+	// The shared query is collected once per scrape only as well, since collecting it here would
+	// resample the counters of all other collectors, possibly in the middle of a scrape.
+	// The counters of shared collectors are primed once all collectors are built instead.
+	if isLogFile() || collector.shared {
+		return collector, nil
 	}
 
 	// Collect initial data because some counters need to be read twice to get the correct value.
@@ -258,6 +366,27 @@ func NewCollectorWithReflection(logger *slog.Logger, resultType CounterType, obj
 	return collector, nil
 }
 
+// addCounter adds the counter to the query of the collector.
+// English counter paths are preferred. Localized counter paths are tried if the English path is unknown.
+func (c *Collector) addCounter(counterPath string, counterHandle *pdhCounterHandle) uint32 {
+	if c.shared {
+		shared.mu.Lock()
+		defer shared.mu.Unlock()
+	}
+
+	ret := AddEnglishCounter(c.handle, counterPath, 0, counterHandle)
+	if ret != CstatusNoObject && ret != CstatusNoCounter {
+		return ret
+	}
+
+	// The counter path may be localized, e.g. if configured by the user on a non-English system.
+	if localizedRet := AddCounter(c.handle, counterPath, 0, counterHandle); localizedRet == ErrorSuccess {
+		return localizedRet
+	}
+
+	return ret
+}
+
 func (c *Collector) Describe() map[string]string {
 	if c == nil {
 		return map[string]string{}
@@ -275,6 +404,25 @@ func (c *Collector) Describe() map[string]string {
 	return desc
 }
 
+// HasCounter reports whether the counter was added to the query.
+// Counters tagged with perfdata_optional or perfdata_min_build are skipped, if they are not available.
+func (c *Collector) HasCounter(counterName string) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, counter := range c.counters {
+		if counter.Name == counterName && len(counter.Instances) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *Collector) Collect(dst any) error {
 	if c == nil {
 		return ErrPerformanceCounterNotInitialized
@@ -283,6 +431,10 @@ func (c *Collector) Collect(dst any) error {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	if c.async != nil && c.handle != 0 {
+		return c.async.copyTo(dst)
+	}
+
 	if len(c.counters) == 0 || c.handle == 0 || c.collectCh == nil || c.errorCh == nil {
 		return ErrPerformanceCounterNotInitialized
 	}
@@ -292,307 +444,277 @@ func (c *Collector) Collect(dst any) error {
 	return <-c.errorCh
 }
 
-func (c *Collector) collectWorkerRaw() {
-	var (
-		err         error
-		itemCount   uint32
-		items       []RawCounterItem
-		bytesNeeded uint32
-	)
+// collectRows maps instance names to the elements of the destination slice of a single collection.
+type collectRows struct {
+	dv        reflect.Value
+	elemValue reflect.Value
+	indexMap  map[string]int
+	stringMap map[*uint16]string
+	sample    staleSample
+}
 
-	buf := make([]byte, 1)
+// collectWorker reads the values of all counters for each destination received on collectCh.
+// Raw and formatted counters are read from the same query, so all values belong to the same sample.
+// Impersonation is bound to the OS thread, so impersonated collectors impersonate on the worker goroutine,
+// which calls PdhCollectQueryData.
+func (c *Collector) collectWorker() {
+	rawBuf := make([]byte, 1)
+	formattedBuf := make([]byte, 1)
 
 	for data := range c.collectCh {
-		err = (func() error {
-			if ret := CollectQueryData(c.handle); ret != ErrorSuccess {
-				return fmt.Errorf("failed to collect query data: %w", NewPdhError(ret))
-			}
-
-			dv := reflect.ValueOf(data)
-			if dv.Kind() != reflect.Pointer || dv.IsNil() {
-				return fmt.Errorf("expected a pointer, got %s: %w", dv.Kind(), mi.ErrInvalidEntityType)
-			}
-
-			dv = dv.Elem()
+		if c.impersonator == nil {
+			c.errorCh <- c.collect(data, &rawBuf, &formattedBuf)
 
-			if dv.Kind() != reflect.Slice {
-				return fmt.Errorf("expected a pointer to a slice, got %s: %w", dv.Kind(), mi.ErrInvalidEntityType)
-			}
-
-			elemType := dv.Type().Elem()
-
-			if elemType.Kind() != reflect.Struct {
-				return fmt.Errorf("expected a pointer to a slice of structs, got a slice of %s: %w", elemType.Kind(), mi.ErrInvalidEntityType)
-			}
-
-			if dv.Len() != 0 {
-				dv.Set(reflect.MakeSlice(dv.Type(), 0, 0))
-			}
-
-			dv.Clear()
-
-			elemValue := reflect.ValueOf(reflect.New(elemType).Interface()).Elem()
-
-			indexMap := map[string]int{}
-			stringMap := map[*uint16]string{}
-
-			for _, counter := range c.counters {
-				for _, instance := range counter.Instances {
-					// Get the info with the current buffer size
-					bytesNeeded = uint32(cap(buf))
+			continue
+		}
 
-					for {
-						ret := GetRawCounterArray(instance, &bytesNeeded, &itemCount, &buf[0])
+		c.errorCh <- c.impersonator.Run(func() error {
+			return c.collect(data, &rawBuf, &formattedBuf)
+		})
+	}
+}
 
-						if ret == ErrorSuccess {
-							break
-						}
+func (c *Collector) collect(data any, rawBuf, formattedBuf *[]byte) error {
+	if c.shared {
+		// The shared query is collected once per scrape by CollectSharedQuery.
+		shared.mu.RLock()
+		defer shared.mu.RUnlock()
 
-						if err := NewPdhError(ret); ret != MoreData {
-							if isKnownCounterDataError(err) {
-								break
-							}
+		if shared.err != nil {
+			return shared.err
+		}
+	} else if ret := c.collectQueryData(c.handle); ret != ErrorSuccess {
+		return fmt.Errorf("failed to collect query data: %w", NewPdhError(ret))
+	}
 
-							return fmt.Errorf("GetRawCounterArray: %w", err)
-						}
+	return c.read(data, rawBuf, formattedBuf)
+}
 
-						if bytesNeeded <= uint32(cap(buf)) {
-							return fmt.Errorf("GetRawCounterArray reports buffer too small (%d), but buffer is large enough (%d): %w", uint32(cap(buf)), bytesNeeded, NewPdhError(ret))
-						}
+// read reads the values of all counters from the last collected sample of the query into data.
+func (c *Collector) read(data any, rawBuf, formattedBuf *[]byte) error {
+	dv := reflect.ValueOf(data)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() {
+		return fmt.Errorf("expected a pointer, got %s: %w", dv.Kind(), mi.ErrInvalidEntityType)
+	}
 
-						buf = make([]byte, bytesNeeded)
-					}
+	dv = dv.Elem()
 
-					items = unsafe.Slice((*RawCounterItem)(unsafe.Pointer(&buf[0])), itemCount)
+	if dv.Kind() != reflect.Slice {
+		return fmt.Errorf("expected a pointer to a slice, got %s: %w", dv.Kind(), mi.ErrInvalidEntityType)
+	}
 
-					var (
-						instanceName string
-						ok           bool
-					)
+	elemType := dv.Type().Elem()
 
-					for _, item := range items {
-						if item.RawValue.CStatus != CstatusValidData && item.RawValue.CStatus != CstatusNewData {
-							c.logger.Debug("skipping counter item with invalid data status",
-								slog.String("counter", counter.Name),
-								slog.String("instance", windows.UTF16PtrToString(item.SzName)),
-								slog.Uint64("status", uint64(item.RawValue.CStatus)),
-							)
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a pointer to a slice of structs, got a slice of %s: %w", elemType.Kind(), mi.ErrInvalidEntityType)
+	}
 
-							continue
-						}
+	if dv.Len() != 0 {
+		dv.Set(reflect.MakeSlice(dv.Type(), 0, 0))
+	}
 
-						if instanceName, ok = stringMap[item.SzName]; !ok {
-							instanceName = windows.UTF16PtrToString(item.SzName)
-							stringMap[item.SzName] = instanceName
-						}
+	dv.Clear()
 
-						if strings.HasSuffix(instanceName, InstanceTotal) && !c.totalCounterRequested {
-							continue
-						}
+	rows := &collectRows{
+		dv:        dv,
+		elemValue: reflect.ValueOf(reflect.New(elemType).Interface()).Elem(),
+		indexMap:  map[string]int{},
+		stringMap: map[*uint16]string{},
+	}
 
-						if instanceName == "" || instanceName == "*" {
-							instanceName = InstanceEmpty
-						}
+	for _, counter := range c.counters {
+		for _, instance := range counter.Instances {
+			var err error
 
-						var (
-							index int
-							ok    bool
-						)
+			if counter.ResultType == CounterTypeFormatted {
+				err = c.collectFormattedCounter(rows, counter, instance, formattedBuf)
+			} else {
+				err = c.collectRawCounter(rows, counter, instance, rawBuf)
+			}
 
-						if index, ok = indexMap[instanceName]; !ok {
-							index = dv.Len()
-							indexMap[instanceName] = index
+			if err != nil {
+				return err
+			}
+		}
+	}
 
-							if c.nameIndexValue != -1 {
-								elemValue.Field(c.nameIndexValue).SetString(instanceName)
-							}
+	c.staleness.observe(rows.sample)
 
-							if c.metricsTypeIndexValue != -1 {
-								var metricsType prometheus.ValueType
-								if metricsType, ok = SupportedCounterTypes[counter.Type]; !ok {
-									metricsType = prometheus.GaugeValue
-								}
+	if dv.Len() == 0 {
+		return ErrNoData
+	}
 
-								elemValue.Field(c.metricsTypeIndexValue).Set(reflect.ValueOf(metricsType))
-							}
+	return nil
+}
 
-							dv.Set(reflect.Append(dv, elemValue))
-						}
+// rowIndex returns the index of the element for the given instance name. The element is appended,
+// if it does not exist yet. ok is false, if the instance is skipped.
+func (c *Collector) rowIndex(rows *collectRows, szName *uint16, metricType prometheus.ValueType) (int, bool) {
+	instanceName, ok := rows.stringMap[szName]
+	if !ok {
+		instanceName = windows.UTF16PtrToString(szName)
+		rows.stringMap[szName] = instanceName
+	}
 
-						// This is a workaround for the issue with the elapsed time counter type.
-						// Source: https://github.com/prometheus-community/windows_exporter/pull/335/files#diff-d5d2528f559ba2648c2866aec34b1eaa5c094dedb52bd0ff22aa5eb83226bd8dR76-R83
-						// Ref: https://learn.microsoft.com/en-us/windows/win32/perfctrs/calculating-counter-values
-						switch counter.Type {
-						case PERF_ELAPSED_TIME:
-							dv.Index(index).
-								Field(counter.FieldIndexValue).
-								SetFloat(float64((item.RawValue.SecondValue - item.RawValue.FirstValue) / counter.Frequency))
-						case PERF_100NSEC_TIMER, PERF_PRECISION_100NS_TIMER:
-							dv.Index(index).
-								Field(counter.FieldIndexValue).
-								SetFloat(float64(item.RawValue.FirstValue) * TicksToSecondScaleFactor)
-						default:
-							if counter.FieldIndexSecondValue != -1 {
-								dv.Index(index).
-									Field(counter.FieldIndexSecondValue).
-									SetFloat(float64(item.RawValue.SecondValue))
-							}
+	if strings.HasSuffix(instanceName, InstanceTotal) && !c.totalCounterRequested {
+		return 0, false
+	}
 
-							if counter.FieldIndexValue != -1 {
-								dv.Index(index).
-									Field(counter.FieldIndexValue).
-									SetFloat(float64(item.RawValue.FirstValue))
-							}
-						}
-					}
-				}
-			}
+	if instanceName == "" || instanceName == "*" {
+		instanceName = InstanceEmpty
+	}
 
-			if dv.Len() == 0 {
-				return ErrNoData
-			}
+	if index, ok := rows.indexMap[instanceName]; ok {
+		return index, true
+	}
 
-			return nil
-		})()
+	index := rows.dv.Len()
+	rows.indexMap[instanceName] = index
 
-		c.errorCh <- err
+	if c.nameIndexValue != -1 {
+		rows.elemValue.Field(c.nameIndexValue).SetString(instanceName)
 	}
-}
 
-func (c *Collector) collectWorkerFormatted() {
-	var (
-		err         error
-		itemCount   uint32
-		items       []FmtCounterValueItemDouble
-		bytesNeeded uint32
-	)
+	if c.metricsTypeIndexValue != -1 {
+		rows.elemValue.Field(c.metricsTypeIndexValue).Set(reflect.ValueOf(metricType))
+	}
 
-	buf := make([]byte, 1)
+	rows.dv.Set(reflect.Append(rows.dv, rows.elemValue))
 
-	for data := range c.collectCh {
-		err = (func() error {
-			if ret := CollectQueryData(c.handle); ret != ErrorSuccess {
-				return fmt.Errorf("failed to collect query data: %w", NewPdhError(ret))
-			}
-
-			dv := reflect.ValueOf(data)
-			if dv.Kind() != reflect.Pointer || dv.IsNil() {
-				return fmt.Errorf("expected a pointer, got %s: %w", dv.Kind(), mi.ErrInvalidEntityType)
-			}
+	return index, true
+}
 
-			dv = dv.Elem()
+func (c *Collector) collectRawCounter(rows *collectRows, counter Counter, instance pdhCounterHandle, buf *[]byte) error {
+	var itemCount uint32
 
-			if dv.Kind() != reflect.Slice {
-				return fmt.Errorf("expected a pointer to a slice, got %s: %w", dv.Kind(), mi.ErrInvalidEntityType)
-			}
+	// Get the info with the current buffer size
+	bytesNeeded := uint32(cap(*buf))
 
-			elemType := dv.Type().Elem()
+	for {
+		ret := GetRawCounterArray(instance, &bytesNeeded, &itemCount, &(*buf)[0])
 
-			if elemType.Kind() != reflect.Struct {
-				return fmt.Errorf("expected a pointer to a slice of structs, got a slice of %s: %w", elemType.Kind(), mi.ErrInvalidEntityType)
-			}
+		if ret == ErrorSuccess {
+			break
+		}
 
-			if dv.Len() != 0 {
-				dv.Set(reflect.MakeSlice(dv.Type(), 0, 0))
+		if err := NewPdhError(ret); ret != MoreData {
+			if isKnownCounterDataError(err) {
+				break
 			}
 
-			dv.Clear()
-
-			elemValue := reflect.ValueOf(reflect.New(elemType).Interface()).Elem()
+			return fmt.Errorf("GetRawCounterArray: %w", err)
+		}
 
-			indexMap := map[string]int{}
-			stringMap := map[*uint16]string{}
+		if bytesNeeded <= uint32(cap(*buf)) {
+			return fmt.Errorf("GetRawCounterArray reports buffer too small (%d), but buffer is large enough (%d): %w", uint32(cap(*buf)), bytesNeeded, NewPdhError(ret))
+		}
 
-			for _, counter := range c.counters {
-				for _, instance := range counter.Instances {
-					// Get the info with the current buffer size
-					bytesNeeded = uint32(cap(buf))
+		*buf = make([]byte, bytesNeeded)
+	}
 
-					for {
-						ret := GetFormattedCounterArrayDouble(instance, &bytesNeeded, &itemCount, &buf[0])
+	items := unsafe.Slice((*RawCounterItem)(unsafe.Pointer(&(*buf)[0])), itemCount)
 
-						if ret == ErrorSuccess {
-							break
-						}
-
-						if err := NewPdhError(ret); ret != MoreData {
-							if isKnownCounterDataError(err) {
-								break
-							}
+	metricType, ok := SupportedCounterTypes[counter.Type]
+	if !ok {
+		metricType = prometheus.GaugeValue
+	}
 
-							return fmt.Errorf("GetFormattedCounterArrayDouble: %w", err)
-						}
+	for _, item := range items {
+		if item.RawValue.CStatus != CstatusValidData && item.RawValue.CStatus != CstatusNewData {
+			c.logger.Debug("skipping counter item with invalid data status",
+				slog.String("counter", counter.Name),
+				slog.String("instance", windows.UTF16PtrToString(item.SzName)),
+				slog.Uint64("status", uint64(item.RawValue.CStatus)),
+			)
 
-						if bytesNeeded <= uint32(cap(buf)) {
-							return fmt.Errorf("GetFormattedCounterArrayDouble reports buffer too small (%d), but buffer is large enough (%d): %w", uint32(cap(buf)), bytesNeeded, NewPdhError(ret))
-						}
+			continue
+		}
 
-						buf = make([]byte, bytesNeeded)
-					}
+		index, ok := c.rowIndex(rows, item.SzName, metricType)
+		if !ok {
+			continue
+		}
 
-					items = unsafe.Slice((*FmtCounterValueItemDouble)(unsafe.Pointer(&buf[0])), itemCount)
+		rows.sample.add(counter.Name, item.RawValue)
+
+		// This is a workaround for the issue with the elapsed time counter type.
+		// Source: https://github.com/prometheus-community/windows_exporter/pull/335/files#diff-d5d2528f559ba2648c2866aec34b1eaa5c094dedb52bd0ff22aa5eb83226bd8dR76-R83
+		// Ref: https://learn.microsoft.com/en-us/windows/win32/perfctrs/calculating-counter-values
+		switch counter.Type {
+		case PERF_ELAPSED_TIME:
+			rows.dv.Index(index).
+				Field(counter.FieldIndexValue).
+				SetFloat(float64((item.RawValue.SecondValue - item.RawValue.FirstValue) / counter.Frequency))
+		case PERF_100NSEC_TIMER, PERF_PRECISION_100NS_TIMER:
+			rows.dv.Index(index).
+				Field(counter.FieldIndexValue).
+				SetFloat(float64(item.RawValue.FirstValue) * TicksToSecondScaleFactor)
+		default:
+			if counter.FieldIndexSecondValue != -1 {
+				rows.dv.Index(index).
+					Field(counter.FieldIndexSecondValue).
+					SetFloat(float64(item.RawValue.SecondValue))
+			}
 
-					var (
-						instanceName string
-						ok           bool
-					)
+			if counter.FieldIndexValue != -1 {
+				rows.dv.Index(index).
+					Field(counter.FieldIndexValue).
+					SetFloat(float64(item.RawValue.FirstValue))
+			}
+		}
+	}
 
-					for _, item := range items {
-						if item.FmtValue.CStatus != CstatusValidData && item.FmtValue.CStatus != CstatusNewData {
-							continue
-						}
+	return nil
+}
 
-						if instanceName, ok = stringMap[item.SzName]; !ok {
-							instanceName = windows.UTF16PtrToString(item.SzName)
-							stringMap[item.SzName] = instanceName
-						}
+func (c *Collector) collectFormattedCounter(rows *collectRows, counter Counter, instance pdhCounterHandle, buf *[]byte) error {
+	var itemCount uint32
 
-						if strings.HasSuffix(instanceName, InstanceTotal) && !c.totalCounterRequested {
-							continue
-						}
+	// Get the info with the current buffer size
+	bytesNeeded := uint32(cap(*buf))
 
-						if instanceName == "" || instanceName == "*" {
-							instanceName = InstanceEmpty
-						}
+	for {
+		ret := GetFormattedCounterArrayDouble(instance, &bytesNeeded, &itemCount, &(*buf)[0])
 
-						var (
-							index int
-							ok    bool
-						)
+		if ret == ErrorSuccess {
+			break
+		}
 
-						if index, ok = indexMap[instanceName]; !ok {
-							index = dv.Len()
-							indexMap[instanceName] = index
+		if err := NewPdhError(ret); ret != MoreData {
+			if isKnownCounterDataError(err) {
+				break
+			}
 
-							if c.nameIndexValue != -1 {
-								elemValue.Field(c.nameIndexValue).SetString(instanceName)
-							}
+			return fmt.Errorf("GetFormattedCounterArrayDouble: %w", err)
+		}
 
-							if c.metricsTypeIndexValue != -1 {
-								elemValue.Field(c.metricsTypeIndexValue).Set(reflect.ValueOf(prometheus.GaugeValue))
-							}
+		if bytesNeeded <= uint32(cap(*buf)) {
+			return fmt.Errorf("GetFormattedCounterArrayDouble reports buffer too small (%d), but buffer is large enough (%d): %w", uint32(cap(*buf)), bytesNeeded, NewPdhError(ret))
+		}
 
-							dv.Set(reflect.Append(dv, elemValue))
-						}
+		*buf = make([]byte, bytesNeeded)
+	}
 
-						if counter.FieldIndexValue != -1 {
-							dv.Index(index).
-								Field(counter.FieldIndexValue).
-								SetFloat(item.FmtValue.DoubleValue)
-						}
-					}
-				}
-			}
+	items := unsafe.Slice((*FmtCounterValueItemDouble)(unsafe.Pointer(&(*buf)[0])), itemCount)
 
-			if dv.Len() == 0 {
-				return ErrNoData
-			}
+	for _, item := range items {
+		if item.FmtValue.CStatus != CstatusValidData && item.FmtValue.CStatus != CstatusNewData {
+			continue
+		}
 
-			return nil
-		})()
+		index, ok := c.rowIndex(rows, item.SzName, prometheus.GaugeValue)
+		if !ok {
+			continue
+		}
 
-		c.errorCh <- err
+		if counter.FieldIndexValue != -1 {
+			rows.dv.Index(index).
+				Field(counter.FieldIndexValue).
+				SetFloat(item.FmtValue.DoubleValue)
+		}
 	}
+
+	return nil
 }
 
 func (c *Collector) Close() {
@@ -603,7 +725,21 @@ func (c *Collector) Close() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	CloseQuery(c.handle)
+	if c.async != nil && c.handle != 0 {
+		c.stopAsync()
+	}
+
+	if !c.shared {
+		CloseQuery(c.handle)
+	} else if c.handle != 0 {
+		shared.release(c.counters)
+	}
+
+	if c.async != nil {
+		c.async.closeEvent()
+	}
+
+	unregisterLiveCollector(c)
 
 	c.handle = 0
 
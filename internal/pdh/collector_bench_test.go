@@ -72,3 +72,69 @@ func BenchmarkTestCollector(b *testing.B) {
 
 	b.ReportAllocs()
 }
+
+func BenchmarkTestCollectorInstanceFilter(b *testing.B) {
+	performanceData, err := pdh.NewCollector[processFull](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", []string{"svchost*"})
+	require.NoError(b, err)
+
+	var data []processFull
+
+	for b.Loop() {
+		_ = performanceData.Collect(&data)
+	}
+
+	performanceData.Close()
+
+	b.ReportAllocs()
+}
+
+type processorInformation struct {
+	Name string
+
+	ProcessorTime  float64 `perfdata:"% Processor Time"`
+	PrivilegedTime float64 `perfdata:"% Privileged Time"`
+	UserTime       float64 `perfdata:"% User Time"`
+	InterruptTime  float64 `perfdata:"% Interrupt Time"`
+}
+
+// benchmarkScrape collects the Process and Processor Information objects the same way a scrape of
+// the process and cpu collectors does.
+func benchmarkScrape(b *testing.B, sharedQuery bool) {
+	b.Helper()
+
+	pdh.SetSharedQuery(sharedQuery)
+	b.Cleanup(func() {
+		pdh.SetSharedQuery(false)
+	})
+
+	processCollector, err := pdh.NewCollector[process](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll)
+	require.NoError(b, err)
+
+	b.Cleanup(processCollector.Close)
+
+	cpuCollector, err := pdh.NewCollector[processorInformation](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Processor Information", pdh.InstancesAll)
+	require.NoError(b, err)
+
+	b.Cleanup(cpuCollector.Close)
+
+	var (
+		processData []process
+		cpuData     []processorInformation
+	)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_ = pdh.CollectSharedQuery()
+		_ = processCollector.Collect(&processData)
+		_ = cpuCollector.Collect(&cpuData)
+	}
+}
+
+func BenchmarkScrapePerCollectorQuery(b *testing.B) {
+	benchmarkScrape(b, false)
+}
+
+func BenchmarkScrapeSharedQuery(b *testing.B) {
+	benchmarkScrape(b, true)
+}
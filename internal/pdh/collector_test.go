@@ -19,6 +19,7 @@ package pdh_test
 
 import (
 	"log/slog"
+	"slices"
 	"testing"
 	"time"
 
@@ -71,3 +72,190 @@ func TestCollector(t *testing.T) {
 		})
 	}
 }
+
+type processOptional struct {
+	Name        string
+	ThreadCount float64 `perfdata:"Thread Count"`
+	NotExisting float64 `perfdata:"Not Existing Counter" perfdata_optional:"true"`
+}
+
+func TestCollectorOptionalCounter(t *testing.T) {
+	t.Parallel()
+
+	performanceData, err := pdh.NewCollector[processOptional](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll)
+	require.NoError(t, err)
+
+	t.Cleanup(performanceData.Close)
+
+	require.True(t, performanceData.HasCounter("Thread Count"))
+	require.False(t, performanceData.HasCounter("Not Existing Counter"))
+
+	var data []processOptional
+
+	require.NoError(t, performanceData.Collect(&data))
+	require.NotEmpty(t, data)
+}
+
+func TestCollectorInstancesAllWithTotal(t *testing.T) {
+	t.Parallel()
+
+	hasTotal := func(instances []string) bool {
+		performanceData, err := pdh.NewCollector[process](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", instances)
+		require.NoError(t, err)
+
+		t.Cleanup(performanceData.Close)
+
+		var data []process
+
+		require.NoError(t, performanceData.Collect(&data))
+		require.NotEmpty(t, data)
+
+		return slices.ContainsFunc(data, func(instance process) bool {
+			return instance.Name == pdh.InstanceTotal
+		})
+	}
+
+	require.False(t, hasTotal(pdh.InstancesAll))
+	require.True(t, hasTotal(pdh.InstancesAllWithTotal))
+}
+
+type processMixed struct {
+	Name                 string
+	ThreadCount          float64 `perfdata:"Thread Count"`
+	ProcessorTime        float64 `perfdata:"% Processor Time"`
+	ProcessorTimePercent float64 `perfdata:"% Processor Time" pdhmode:"formatted"`
+}
+
+func TestCollectorMixedResultTypes(t *testing.T) {
+	t.Parallel()
+
+	performanceData, err := pdh.NewCollector[processMixed](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll)
+	require.NoError(t, err)
+
+	t.Cleanup(performanceData.Close)
+
+	time.Sleep(100 * time.Millisecond)
+
+	var data []processMixed
+
+	require.NoError(t, performanceData.Collect(&data))
+	require.NotEmpty(t, data)
+
+	var processorTime float64
+
+	for _, instance := range data {
+		// The raw value is the total processor time in seconds, the formatted value the utilization
+		// in percent of a single processor since the previous collection.
+		processorTime += instance.ProcessorTime
+
+		require.GreaterOrEqual(t, instance.ProcessorTimePercent, 0.0, instance.Name)
+	}
+
+	require.Positive(t, processorTime)
+}
+
+type processInvalidMode struct {
+	Name        string
+	ThreadCount float64 `perfdata:"Thread Count" pdhmode:"cooked"`
+}
+
+func TestCollectorInvalidResultType(t *testing.T) {
+	t.Parallel()
+
+	performanceData, err := pdh.NewCollector[processInvalidMode](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll)
+	require.ErrorContains(t, err, "invalid pdhmode")
+
+	performanceData.Close()
+}
+
+// TestCollectorSharedQuery is not parallel, since the shared query mode applies to all collectors created meanwhile.
+func TestCollectorSharedQuery(t *testing.T) {
+	perCollector, err := pdh.NewCollector[process](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll)
+	require.NoError(t, err)
+
+	t.Cleanup(perCollector.Close)
+
+	pdh.SetSharedQuery(true)
+	t.Cleanup(func() {
+		pdh.SetSharedQuery(false)
+	})
+
+	sharedProcess, err := pdh.NewCollector[process](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll)
+	require.NoError(t, err)
+
+	t.Cleanup(sharedProcess.Close)
+
+	sharedMixed, err := pdh.NewCollector[processMixed](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll)
+	require.NoError(t, err)
+
+	t.Cleanup(sharedMixed.Close)
+
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, pdh.CollectSharedQuery())
+
+	var (
+		perCollectorData []process
+		processData      []process
+		mixedData        []processMixed
+	)
+
+	require.NoError(t, perCollector.Collect(&perCollectorData))
+	require.NoError(t, sharedProcess.Collect(&processData))
+	require.NoError(t, sharedMixed.Collect(&mixedData))
+
+	// Both collectors read the same sample of the shared query, so the values are identical.
+	threadCounts := make(map[string]float64, len(processData))
+	for _, instance := range processData {
+		threadCounts[instance.Name] = instance.ThreadCount
+	}
+
+	require.Len(t, mixedData, len(processData))
+
+	for _, instance := range mixedData {
+		require.Contains(t, threadCounts, instance.Name)
+		require.InDelta(t, threadCounts[instance.Name], instance.ThreadCount, 0, instance.Name)
+	}
+
+	// Reading the shared query again without a new collection returns the same sample.
+	var processDataAgain []process
+
+	require.NoError(t, sharedProcess.Collect(&processDataAgain))
+	require.ElementsMatch(t, processData, processDataAgain)
+
+	// The shared query reports the same instances as a collector with its own query.
+	perCollectorNames := make(map[string]struct{}, len(perCollectorData))
+	for _, instance := range perCollectorData {
+		perCollectorNames[instance.Name] = struct{}{}
+	}
+
+	require.Contains(t, perCollectorNames, "System")
+	require.Contains(t, threadCounts, "System")
+	require.Positive(t, threadCounts["System"])
+}
+
+// TestWithIsolatedQuery is not parallel, since the shared query mode applies to all collectors created meanwhile.
+func TestWithIsolatedQuery(t *testing.T) {
+	pdh.SetSharedQuery(true)
+	t.Cleanup(func() {
+		pdh.SetSharedQuery(false)
+	})
+
+	var isolated *pdh.Collector
+
+	require.NoError(t, pdh.WithIsolatedQuery(func() error {
+		var err error
+
+		isolated, err = pdh.NewCollector[process](slog.New(slog.DiscardHandler), pdh.CounterTypeRaw, "Process", pdh.InstancesAll)
+
+		return err
+	}))
+
+	t.Cleanup(isolated.Close)
+
+	// The isolated collector collects its own query, without a collection of the shared query.
+	var data []process
+
+	require.NoError(t, isolated.Collect(&data))
+	require.NotEmpty(t, data)
+}
@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"errors"
+	"log/slog"
+	"reflect"
+)
+
+// Impersonator runs a function under the security context of another account.
+// Impersonation applies to the calling OS thread only, see impersonation.Impersonator.
+type Impersonator interface {
+	Run(fn func() error) error
+}
+
+// NewImpersonatedCollector returns a collector, whose query is collected under the security context
+// of the given impersonator. PdhCollectQueryData is called on the collector's worker goroutine,
+// so the impersonation is active on the thread which reads the performance data.
+//
+// Impersonated collectors always use their own query, even if the shared query mode is enabled.
+func NewImpersonatedCollector[T any](logger *slog.Logger, impersonator Impersonator, resultType CounterType, object string, instances []string) (*Collector, error) {
+	if impersonator == nil {
+		return nil, errors.New("impersonator must not be nil")
+	}
+
+	return newCollector(logger, resultType, object, instances, reflect.TypeFor[T](), collectorOptions{impersonator: impersonator})
+}
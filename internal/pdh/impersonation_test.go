@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"log/slog"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows"
+)
+
+// selfImpersonator impersonates the security context of the process on the calling thread.
+type selfImpersonator struct {
+	calls int
+}
+
+func (i *selfImpersonator) Run(fn func() error) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := windows.ImpersonateSelf(windows.SecurityImpersonation); err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = windows.RevertToSelf()
+	}()
+
+	i.calls++
+
+	return fn()
+}
+
+// isImpersonating reports whether the calling thread has an impersonation token.
+// The thread token can't be opened without impersonation (ERROR_NO_TOKEN).
+func isImpersonating() bool {
+	var token windows.Token
+
+	if err := windows.OpenThreadToken(windows.CurrentThread(), windows.TOKEN_QUERY, true, &token); err != nil {
+		return false
+	}
+
+	_ = token.Close()
+
+	return true
+}
+
+func TestImpersonatedCollector(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name          string
+		impersonator  *selfImpersonator
+		impersonating bool
+	}{
+		{name: "impersonated", impersonator: &selfImpersonator{}, impersonating: true},
+		{name: "not impersonated", impersonating: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			type processes struct {
+				Name        string
+				ThreadCount float64 `perfdata:"Thread Count"`
+			}
+
+			var (
+				collector *Collector
+				err       error
+			)
+
+			if tc.impersonator != nil {
+				collector, err = NewImpersonatedCollector[processes](slog.New(slog.DiscardHandler), tc.impersonator, CounterTypeRaw, "Process", InstancesAll)
+			} else {
+				collector, err = NewCollector[processes](slog.New(slog.DiscardHandler), CounterTypeRaw, "Process", InstancesAll)
+			}
+
+			require.NoError(t, err)
+			t.Cleanup(collector.Close)
+
+			// Record the token of the thread, which reads the performance data.
+			impersonating := make(chan bool, 1)
+
+			collector.mu.Lock()
+			collector.collectQueryData = func(hQuery pdhQueryHandle) uint32 {
+				impersonating <- isImpersonating()
+
+				return CollectQueryData(hQuery)
+			}
+			collector.mu.Unlock()
+
+			var data []processes
+
+			require.NoError(t, collector.Collect(&data))
+			require.NotEmpty(t, data)
+			require.Equal(t, tc.impersonating, <-impersonating)
+
+			if tc.impersonator != nil {
+				// The initial collection of the constructor is impersonated as well.
+				require.Equal(t, 2, tc.impersonator.calls)
+			}
+		})
+	}
+}
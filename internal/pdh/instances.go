@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// maxWildcardInstances limits the number of counter paths created by InstancesFromRegexp.
+// Each pattern adds one counter per field, so a large number of patterns is slower than a full expansion.
+const maxWildcardInstances = 16
+
+// InstancesFromRegexp converts an anchored include regular expression into a list of PDH instance
+// wildcard patterns, e.g. ^(?:chrome.*|firefox)$ becomes [chrome*, firefox].
+// Passing the patterns to NewCollector lets PDH expand only the matching instances,
+// instead of expanding and formatting all instances of the object.
+//
+// The patterns match a superset of the regular expression, so the regular expression must
+// still be applied to the collected instances. The second return value is false, if the
+// regular expression can't be expressed as a small number of patterns. In this case,
+// InstancesAll should be used.
+func InstancesFromRegexp(re *regexp.Regexp) ([]string, bool) {
+	if re == nil {
+		return nil, false
+	}
+
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return nil, false
+	}
+
+	parsed = parsed.Simplify()
+
+	// Only fully anchored expressions can be converted. Unanchored expressions match substrings.
+	if parsed.Op != syntax.OpConcat || len(parsed.Sub) < 2 ||
+		parsed.Sub[0].Op != syntax.OpBeginText || parsed.Sub[len(parsed.Sub)-1].Op != syntax.OpEndText {
+		return nil, false
+	}
+
+	patterns, ok := wildcardPatterns(parsed.Sub[1 : len(parsed.Sub)-1])
+	if !ok || len(patterns) == 0 {
+		return nil, false
+	}
+
+	for _, pattern := range patterns {
+		// A pattern matching everything is the same as a full expansion.
+		if strings.Trim(pattern, "*") == "" {
+			return nil, false
+		}
+	}
+
+	return patterns, true
+}
+
+// wildcardPatterns returns the wildcard patterns matching the concatenation of the given expressions.
+func wildcardPatterns(subs []*syntax.Regexp) ([]string, bool) {
+	patterns := []string{""}
+
+	for _, sub := range subs {
+		subPatterns, ok := wildcardPattern(sub)
+		if !ok {
+			return nil, false
+		}
+
+		if len(patterns)*len(subPatterns) > maxWildcardInstances {
+			return nil, false
+		}
+
+		product := make([]string, 0, len(patterns)*len(subPatterns))
+
+		for _, prefix := range patterns {
+			for _, suffix := range subPatterns {
+				pattern := prefix + suffix
+				// Collapse consecutive wildcards.
+				for strings.Contains(pattern, "**") {
+					pattern = strings.ReplaceAll(pattern, "**", "*")
+				}
+
+				product = append(product, pattern)
+			}
+		}
+
+		patterns = product
+	}
+
+	return patterns, true
+}
+
+// wildcardPattern returns the wildcard patterns matching a single expression.
+func wildcardPattern(re *syntax.Regexp) ([]string, bool) {
+	switch re.Op {
+	case syntax.OpEmptyMatch:
+		return []string{""}, true
+	case syntax.OpLiteral:
+		literal := string(re.Rune)
+		// These characters have a special meaning in counter paths.
+		if strings.ContainsAny(literal, `*?()\/`) {
+			return nil, false
+		}
+
+		return []string{literal}, true
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL, syntax.OpStar, syntax.OpPlus:
+		if re.Op != syntax.OpAnyChar && re.Op != syntax.OpAnyCharNotNL &&
+			re.Sub[0].Op != syntax.OpAnyChar && re.Sub[0].Op != syntax.OpAnyCharNotNL {
+			return nil, false
+		}
+
+		// PDH has no single character wildcard, * matches a superset.
+		return []string{"*"}, true
+	case syntax.OpQuest:
+		patterns, ok := wildcardPattern(re.Sub[0])
+		if !ok {
+			return nil, false
+		}
+
+		return append([]string{""}, patterns...), true
+	case syntax.OpCapture:
+		return wildcardPattern(re.Sub[0])
+	case syntax.OpConcat:
+		return wildcardPatterns(re.Sub)
+	case syntax.OpAlternate:
+		patterns := make([]string, 0, len(re.Sub))
+
+		for _, sub := range re.Sub {
+			subPatterns, ok := wildcardPattern(sub)
+			if !ok {
+				return nil, false
+			}
+
+			patterns = append(patterns, subPatterns...)
+		}
+
+		if len(patterns) > maxWildcardInstances {
+			return nil, false
+		}
+
+		return patterns, true
+	case syntax.OpCharClass:
+		// Expand small character classes, which are the result of factoring
+		// alternations like foo|fop into fo[op].
+		patterns := make([]string, 0)
+
+		for i := 0; i < len(re.Rune); i += 2 {
+			for r := re.Rune[i]; r <= re.Rune[i+1]; r++ {
+				if len(patterns) >= maxWildcardInstances || strings.ContainsRune(`*?()\/`, r) {
+					return nil, false
+				}
+
+				patterns = append(patterns, string(r))
+			}
+		}
+
+		return patterns, true
+	default:
+		return nil, false
+	}
+}
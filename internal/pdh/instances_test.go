@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstancesFromRegexp(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		regexp    string
+		instances []string
+		ok        bool
+	}{
+		{regexp: `^(?:chrome)$`, instances: []string{"chrome"}, ok: true},
+		{regexp: `^(?:chrome.*|firefox)$`, instances: []string{"chrome*", "firefox"}, ok: true},
+		{regexp: `^(?:chrome|chromium)$`, instances: []string{"chrome", "chromium"}, ok: true},
+		{regexp: `^(?:sql.+agent)$`, instances: []string{"sql*agent"}, ok: true},
+		{regexp: `^(?:w3wp(?:_.*)?)$`, instances: []string{"w3wp", "w3wp_*"}, ok: true},
+		{regexp: `^(?:.*)$`, ok: false},
+		{regexp: `^(?:.+)$`, ok: false},
+		{regexp: `^(?:.*|chrome)$`, ok: false},
+		{regexp: `chrome`, ok: false},
+		{regexp: `^(?:[a-z]+)$`, ok: false},
+		{regexp: `^(?:chrome\w)$`, ok: false},
+	} {
+		t.Run(tc.regexp, func(t *testing.T) {
+			t.Parallel()
+
+			instances, ok := pdh.InstancesFromRegexp(regexp.MustCompile(tc.regexp))
+
+			require.Equal(t, tc.ok, ok)
+			require.ElementsMatch(t, tc.instances, instances)
+		})
+	}
+}
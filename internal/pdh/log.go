@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"fmt"
+	"sync"
+)
+
+//nolint:gochecknoglobals
+var (
+	logDataSourceMu sync.RWMutex
+	logDataSource   pdhLogHandle
+)
+
+// SetLogFile makes all collectors created afterwards read the performance data from the given
+// performance counter log file (.blg or .csv) instead of the live system.
+// All collectors add their counters to the shared query, which reads the next sample of the log file
+// once per scrape in CollectSharedQuery, so all collectors of a scrape read the same sample.
+// Once the end of the log file has been reached, collections fail with PDH_NO_MORE_DATA.
+//
+// This is intended for offline testing against captured performance data.
+func SetLogFile(path string) error {
+	logDataSourceMu.Lock()
+	defer logDataSourceMu.Unlock()
+
+	var handle pdhLogHandle
+
+	if ret := BindInputDataSource(&handle, path); ret != ErrorSuccess {
+		return fmt.Errorf("failed to bind performance counter log %s: %w", path, NewPdhError(ret))
+	}
+
+	logDataSource = handle
+
+	return nil
+}
+
+// closeLogFile closes the log file set by SetLogFile. Collectors created afterwards read from the live system.
+func closeLogFile() {
+	logDataSourceMu.Lock()
+	defer logDataSourceMu.Unlock()
+
+	if logDataSource != 0 {
+		CloseLog(logDataSource, 0)
+	}
+
+	logDataSource = 0
+}
+
+// isLogFile reports whether the performance data is read from a log file set by SetLogFile.
+func isLogFile() bool {
+	logDataSourceMu.RLock()
+	defer logDataSourceMu.RUnlock()
+
+	return logDataSource != 0
+}
+
+// openQuery opens a query against the live system, or against the log file set by SetLogFile.
+func openQuery(phQuery *pdhQueryHandle) uint32 {
+	logDataSourceMu.RLock()
+	defer logDataSourceMu.RUnlock()
+
+	if logDataSource != 0 {
+		return OpenQueryH(logDataSource, 0, phQuery)
+	}
+
+	return OpenQuery(0, 0, phQuery)
+}
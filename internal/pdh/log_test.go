@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type memoryLog struct {
+	AvailableBytes float64 `perfdata:"Available Bytes"`
+}
+
+// TestLogFile is not parallel, since the log file applies to all collectors created meanwhile.
+func TestLogFile(t *testing.T) {
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+
+	lines := []string{
+		fmt.Sprintf(`"(PDH-CSV 4.0) (Coordinated Universal Time)(0)","\\%s\Memory\Available Bytes"`, strings.ToUpper(hostname)),
+		`"10/16/2026 10:00:00.000","1000"`,
+		`"10/16/2026 10:00:15.000","2000"`,
+		`"10/16/2026 10:00:30.000","3000"`,
+	}
+
+	path := filepath.Join(t.TempDir(), "memory.csv")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\r\n")+"\r\n"), 0o600))
+
+	require.NoError(t, SetLogFile(path))
+	t.Cleanup(closeLogFile)
+
+	first, err := NewCollector[memoryLog](slog.New(slog.DiscardHandler), CounterTypeFormatted, "Memory", nil)
+	require.NoError(t, err)
+
+	t.Cleanup(first.Close)
+
+	second, err := NewCollector[memoryLog](slog.New(slog.DiscardHandler), CounterTypeFormatted, "Memory", nil)
+	require.NoError(t, err)
+
+	t.Cleanup(second.Close)
+
+	// Creating the collectors doesn't consume a sample and both collectors read the same sample per scrape.
+	for _, expected := range []float64{1000, 2000} {
+		require.NoError(t, CollectSharedQuery())
+
+		for _, collector := range []*Collector{first, second} {
+			var data []memoryLog
+
+			require.NoError(t, collector.Collect(&data))
+			require.Len(t, data, 1)
+			require.InDelta(t, expected, data[0].AvailableBytes, 0)
+		}
+	}
+}
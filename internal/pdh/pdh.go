@@ -270,6 +270,7 @@ const (
 type (
 	pdhQueryHandle   HANDLE // query handle
 	pdhCounterHandle HANDLE // counter handle
+	pdhLogHandle     HANDLE // log data source handle
 )
 
 //nolint:gochecknoglobals
@@ -278,18 +279,23 @@ var (
 
 	pdhAddCounterW               = libPdhDll.NewProc("PdhAddCounterW")
 	pdhAddEnglishCounterW        = libPdhDll.NewProc("PdhAddEnglishCounterW")
+	pdhBindInputDataSourceW      = libPdhDll.NewProc("PdhBindInputDataSourceW")
+	pdhCloseLog                  = libPdhDll.NewProc("PdhCloseLog")
 	pdhCloseQuery                = libPdhDll.NewProc("PdhCloseQuery")
 	pdhCollectQueryData          = libPdhDll.NewProc("PdhCollectQueryData")
+	pdhCollectQueryDataEx        = libPdhDll.NewProc("PdhCollectQueryDataEx")
 	pdhCollectQueryDataWithTime  = libPdhDll.NewProc("PdhCollectQueryDataWithTime")
 	pdhGetFormattedCounterValue  = libPdhDll.NewProc("PdhGetFormattedCounterValue")
 	pdhGetFormattedCounterArrayW = libPdhDll.NewProc("PdhGetFormattedCounterArrayW")
 	pdhOpenQuery                 = libPdhDll.NewProc("PdhOpenQuery")
+	pdhOpenQueryH                = libPdhDll.NewProc("PdhOpenQueryH")
 	pdhValidatePathW             = libPdhDll.NewProc("PdhValidatePathW")
 	pdhExpandWildCardPathW       = libPdhDll.NewProc("PdhExpandWildCardPathW")
 	pdhGetCounterInfoW           = libPdhDll.NewProc("PdhGetCounterInfoW")
 	pdhGetRawCounterValue        = libPdhDll.NewProc("PdhGetRawCounterValue")
 	pdhGetRawCounterArrayW       = libPdhDll.NewProc("PdhGetRawCounterArrayW")
 	pdhPdhGetCounterTimeBase     = libPdhDll.NewProc("PdhGetCounterTimeBase")
+	pdhRemoveCounter             = libPdhDll.NewProc("PdhRemoveCounter")
 )
 
 // AddCounter adds the specified counter to the query. This is the internationalized version. Preferably, use the
@@ -366,6 +372,23 @@ func CloseQuery(hQuery pdhQueryHandle) uint32 {
 	return uint32(ret)
 }
 
+// CloseLog closes the log data source bound by BindInputDataSource.
+// This function returns a PDH_ constant error code, or ErrorSuccess if the call succeeded.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/pdh/nf-pdh-pdhcloselog
+func CloseLog(hLog pdhLogHandle, dwFlags uint32) uint32 {
+	ret, _, _ := pdhCloseLog.Call(uintptr(hLog), uintptr(dwFlags))
+
+	return uint32(ret)
+}
+
+// RemoveCounter removes a counter from its query and closes the counter handle.
+func RemoveCounter(hCounter pdhCounterHandle) uint32 {
+	ret, _, _ := pdhRemoveCounter.Call(uintptr(hCounter))
+
+	return uint32(ret)
+}
+
 // CollectQueryData collects the current raw data value for all counters in the specified query and updates the status
 // code of each counter. With some counters, this function needs to be repeatedly called before the value
 // of the counter can be extracted with PdhGetFormattedCounterValue(). For example, the following code
@@ -393,6 +416,15 @@ func CollectQueryData(hQuery pdhQueryHandle) uint32 {
 	return uint32(ret)
 }
 
+// CollectQueryDataEx starts a PDH thread, which collects the current raw data value for all counters in the
+// specified query every dwIntervalTime seconds and signals hNewDataEvent once new data is available.
+// The collection stops, when the query is closed.
+func CollectQueryDataEx(hQuery pdhQueryHandle, dwIntervalTime uint32, hNewDataEvent windows.Handle) uint32 {
+	ret, _, _ := pdhCollectQueryDataEx.Call(uintptr(hQuery), uintptr(dwIntervalTime), uintptr(hNewDataEvent))
+
+	return uint32(ret)
+}
+
 // CollectQueryDataWithTime queries data from perfmon, retrieving the device/windows timestamp from the node it was collected on.
 // Converts the filetime structure to a GO time class and returns the native time.
 func CollectQueryDataWithTime(hQuery pdhQueryHandle) (uint32, time.Time) {
@@ -491,6 +523,41 @@ func OpenQuery(szDataSource uintptr, dwUserData uintptr, phQuery *pdhQueryHandle
 	return uint32(ret)
 }
 
+// OpenQueryH creates a new query that reads the performance data from the data source
+// bound by BindInputDataSource. Each call to CollectQueryData reads the next sample of the data source.
+// This function returns a PDH_ constant error code, or ErrorSuccess if the call succeeded.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/pdh/nf-pdh-pdhopenqueryh
+func OpenQueryH(hDataSource pdhLogHandle, dwUserData uintptr, phQuery *pdhQueryHandle) uint32 {
+	ret, _, _ := pdhOpenQueryH.Call(
+		uintptr(hDataSource),
+		dwUserData,
+		uintptr(unsafe.Pointer(phQuery)))
+
+	return uint32(ret)
+}
+
+// BindInputDataSource binds the performance counter log file (.blg or .csv) as data source
+// for queries opened by OpenQueryH. This function returns a PDH_ constant error code,
+// or ErrorSuccess if the call succeeded.
+//
+// https://learn.microsoft.com/en-us/windows/win32/api/pdh/nf-pdh-pdhbindinputdatasourcew
+func BindInputDataSource(phDataSource *pdhLogHandle, logFileName string) uint32 {
+	// LogFileNameList is a MULTI_SZ string, terminated by two null characters.
+	logFileNameList, err := windows.UTF16FromString(logFileName)
+	if err != nil {
+		return InvalidArgument
+	}
+
+	logFileNameList = append(logFileNameList, 0)
+
+	ret, _, _ := pdhBindInputDataSourceW.Call(
+		uintptr(unsafe.Pointer(phDataSource)),
+		uintptr(unsafe.Pointer(&logFileNameList[0])))
+
+	return uint32(ret)
+}
+
 // ExpandWildCardPath examines the specified computer or log file and returns those counter paths that match the given counter path
 // which contains wildcard characters. The general counter path format is as follows:
 //
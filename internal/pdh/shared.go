@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"fmt"
+	"sync"
+)
+
+//nolint:gochecknoglobals
+var shared sharedQuery
+
+// sharedQuery is a single PDH query shared by all collectors created while the shared query mode is enabled.
+// The query is collected once per scrape by CollectSharedQuery and each collector reads its counters
+// from the same sample.
+type sharedQuery struct {
+	// mu is held for writing while counters are added or removed and while the query is collected.
+	// Collectors hold it for reading while they read their counter values.
+	mu      sync.RWMutex
+	enabled bool
+	// isolated is the number of running WithIsolatedQuery calls.
+	isolated int
+	handle   pdhQueryHandle
+	refs     int
+	// err is the result of the last collection.
+	err error
+}
+
+// SetSharedQuery enables or disables the shared query mode for all collectors created afterwards.
+//
+// In shared query mode, all collectors add their counters to a single PDH query, which is collected
+// once per scrape by CollectSharedQuery. The values of all collectors belong to the same sample,
+// e.g. the processor and process totals are consistent, and PdhCollectQueryData is called once
+// per scrape instead of once per collector.
+func SetSharedQuery(enabled bool) {
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+
+	shared.enabled = enabled
+}
+
+// WithIsolatedQuery runs fn and creates all collectors, which are created meanwhile, with their own query,
+// even if the shared query mode is enabled. It is used to build collectors temporarily, e.g. for a dry-run
+// collection, without adding their counters to the shared query collected by the scrapes.
+func WithIsolatedQuery(fn func() error) error {
+	shared.mu.Lock()
+	shared.isolated++
+	shared.mu.Unlock()
+
+	defer func() {
+		shared.mu.Lock()
+		shared.isolated--
+		shared.mu.Unlock()
+	}()
+
+	return fn()
+}
+
+// CollectSharedQuery collects the shared query. It must be called once per scrape, before the
+// collectors are collected. It is a no-op, if no collector uses the shared query.
+func CollectSharedQuery() error {
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+
+	return shared.collect()
+}
+
+func (q *sharedQuery) collect() error {
+	if q.handle == 0 {
+		return nil
+	}
+
+	q.err = nil
+
+	if ret := CollectQueryData(q.handle); ret != ErrorSuccess {
+		q.err = fmt.Errorf("failed to collect shared query data: %w", NewPdhError(ret))
+	}
+
+	return q.err
+}
+
+// acquire returns the handle of the shared query and opens the query, if required.
+// ok is false, if the shared query mode is disabled or a WithIsolatedQuery call is running. If a log file
+// is replayed, the shared query is used otherwise, so that the log file is advanced once per scrape.
+func (q *sharedQuery) acquire() (pdhQueryHandle, bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.isolated > 0 || (!q.enabled && !isLogFile()) {
+		return 0, false, nil
+	}
+
+	if q.handle == 0 {
+		if ret := openQuery(&q.handle); ret != ErrorSuccess {
+			return 0, true, NewPdhError(ret)
+		}
+	}
+
+	q.refs++
+
+	return q.handle, true, nil
+}
+
+// release removes the given counters from the shared query and closes the query,
+// once the last collector has been released.
+func (q *sharedQuery) release(counters map[string]Counter) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.handle == 0 {
+		return
+	}
+
+	for _, counter := range counters {
+		for _, instance := range counter.Instances {
+			RemoveCounter(instance)
+		}
+	}
+
+	q.refs--
+	if q.refs > 0 {
+		return
+	}
+
+	CloseQuery(q.handle)
+
+	q.handle = 0
+	q.refs = 0
+	q.err = nil
+}
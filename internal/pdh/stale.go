@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultStaleThreshold is the default number of consecutive identical collections,
+// after which the data of a collector is reported as stale.
+const DefaultStaleThreshold = 5
+
+//nolint:gochecknoglobals
+var (
+	staleThreshold atomic.Int64
+
+	liveCollectorsMu sync.Mutex
+	liveCollectors   = map[*Collector]struct{}{}
+)
+
+func init() {
+	staleThreshold.Store(DefaultStaleThreshold)
+}
+
+// SetStaleThreshold sets the number of consecutive collections with identical raw values and
+// an identical timestamp, after which windows_exporter_pdh_data_stale reports a collector as stale.
+// 0 disables the metric.
+func SetStaleThreshold(threshold int) {
+	staleThreshold.Store(int64(max(threshold, 0)))
+}
+
+// staleSample is a fingerprint of the raw values and the timestamp of a single collection.
+type staleSample struct {
+	hash      uint64
+	timestamp int64
+	valid     bool
+}
+
+// add adds a raw counter value to the sample. The values are combined independently of their order,
+// since the counters of a collector are not read in a stable order.
+func (s *staleSample) add(counterName string, value RawCounter) {
+	buf := make([]byte, 0, 16)
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(value.FirstValue))
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(value.SecondValue))
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(counterName))
+	_, _ = h.Write(buf)
+
+	s.hash += h.Sum64()
+	s.timestamp = max(s.timestamp, value.TimeStamp.Nanoseconds())
+	s.valid = true
+}
+
+// staleTracker counts the consecutive collections with identical raw values and timestamp.
+// Counters which are genuinely idle keep their values, but the timestamp still advances,
+// so they are not reported as stale.
+type staleTracker struct {
+	mu        sync.Mutex
+	last      staleSample
+	unchanged int
+}
+
+// observe records the sample of a collection. Collections without raw values are ignored.
+// The count is reset immediately, once the values or the timestamp change.
+func (t *staleTracker) observe(sample staleSample) {
+	if !sample.valid {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.last.valid && t.last.hash == sample.hash && t.last.timestamp == sample.timestamp {
+		t.unchanged++
+	} else {
+		t.unchanged = 0
+	}
+
+	t.last = sample
+}
+
+// stale reports whether the last threshold collections returned identical data.
+func (t *staleTracker) stale(threshold int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return threshold > 0 && t.unchanged >= threshold
+}
+
+// Interface guard.
+var _ prometheus.Collector = (*StaleCollector)(nil)
+
+// StaleCollector exposes windows_pdh_data_stale for all open collectors
+// and windows_pdh_async_snapshot_age_seconds for all open asynchronous collectors.
+type StaleCollector struct {
+	dataStaleDesc        *prometheus.Desc
+	asyncSnapshotAgeDesc *prometheus.Desc
+}
+
+// NewStaleCollector returns a new StaleCollector.
+func NewStaleCollector() *StaleCollector {
+	return &StaleCollector{
+		dataStaleDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "pdh", "data_stale"),
+			"windows_exporter: Whether the raw values and the timestamp of a performance counter object did not change for a number of consecutive collections.",
+			[]string{"object"},
+			nil,
+		),
+		asyncSnapshotAgeDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "pdh", "async_snapshot_age_seconds"),
+			"windows_exporter: Time since the last sample of an asynchronously collected performance counter object was read.",
+			[]string{"object"},
+			nil,
+		),
+	}
+}
+
+func (c *StaleCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.dataStaleDesc
+	ch <- c.asyncSnapshotAgeDesc
+}
+
+// Collect emits one series per performance counter object. Objects which are queried by multiple
+// collectors are reported as stale, if any of them is stale.
+func (c *StaleCollector) Collect(ch chan<- prometheus.Metric) {
+	c.collectAsyncSnapshotAge(ch)
+
+	threshold := int(staleThreshold.Load())
+	if threshold == 0 {
+		return
+	}
+
+	liveCollectorsMu.Lock()
+
+	staleByObject := make(map[string]float64, len(liveCollectors))
+
+	for collector := range liveCollectors {
+		var value float64
+		if collector.staleness.stale(threshold) {
+			value = 1
+		}
+
+		staleByObject[collector.object] = math.Max(staleByObject[collector.object], value)
+	}
+
+	liveCollectorsMu.Unlock()
+
+	for object, value := range staleByObject {
+		ch <- prometheus.MustNewConstMetric(
+			c.dataStaleDesc,
+			prometheus.GaugeValue,
+			value,
+			object,
+		)
+	}
+}
+
+// collectAsyncSnapshotAge emits one series per asynchronously collected performance counter object.
+// Objects which are queried by multiple collectors report the oldest snapshot.
+func (c *StaleCollector) collectAsyncSnapshotAge(ch chan<- prometheus.Metric) {
+	liveCollectorsMu.Lock()
+
+	ageByObject := make(map[string]float64)
+
+	for collector := range liveCollectors {
+		if collector.async == nil {
+			continue
+		}
+
+		ageByObject[collector.object] = math.Max(ageByObject[collector.object], collector.async.age().Seconds())
+	}
+
+	liveCollectorsMu.Unlock()
+
+	for object, value := range ageByObject {
+		ch <- prometheus.MustNewConstMetric(
+			c.asyncSnapshotAgeDesc,
+			prometheus.GaugeValue,
+			value,
+			object,
+		)
+	}
+}
+
+func registerLiveCollector(collector *Collector) {
+	liveCollectorsMu.Lock()
+	defer liveCollectorsMu.Unlock()
+
+	liveCollectors[collector] = struct{}{}
+}
+
+func unregisterLiveCollector(collector *Collector) {
+	liveCollectorsMu.Lock()
+	defer liveCollectorsMu.Unlock()
+
+	delete(liveCollectors, collector)
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package pdh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows"
+)
+
+func newStaleSample(timestamp int64, values ...int64) staleSample {
+	var sample staleSample
+
+	for i, value := range values {
+		sample.add("counter", RawCounter{
+			TimeStamp:   windows.NsecToFiletime(timestamp),
+			FirstValue:  value,
+			SecondValue: int64(i),
+		})
+	}
+
+	return sample
+}
+
+func TestStaleSampleOrder(t *testing.T) {
+	t.Parallel()
+
+	var a, b staleSample
+
+	a.add("x", RawCounter{FirstValue: 1})
+	a.add("y", RawCounter{FirstValue: 2})
+	b.add("y", RawCounter{FirstValue: 2})
+	b.add("x", RawCounter{FirstValue: 1})
+
+	require.Equal(t, a, b)
+
+	var c staleSample
+
+	c.add("x", RawCounter{FirstValue: 2})
+	c.add("y", RawCounter{FirstValue: 1})
+
+	require.NotEqual(t, a.hash, c.hash)
+}
+
+func TestStaleTracker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("identical collections", func(t *testing.T) {
+		t.Parallel()
+
+		var tracker staleTracker
+
+		tracker.observe(newStaleSample(100, 1, 2))
+		tracker.observe(newStaleSample(100, 1, 2))
+		tracker.observe(newStaleSample(100, 1, 2))
+		require.False(t, tracker.stale(3))
+
+		tracker.observe(newStaleSample(100, 1, 2))
+		require.True(t, tracker.stale(3))
+		require.False(t, tracker.stale(0), "threshold 0 disables the check")
+
+		// The flag clears immediately, once the values change.
+		tracker.observe(newStaleSample(100, 1, 3))
+		require.False(t, tracker.stale(3))
+	})
+
+	t.Run("idle counters", func(t *testing.T) {
+		t.Parallel()
+
+		var tracker staleTracker
+
+		for timestamp := range int64(10) {
+			tracker.observe(newStaleSample(timestamp*1e9, 0, 0))
+		}
+
+		require.False(t, tracker.stale(1))
+	})
+
+	t.Run("empty collections", func(t *testing.T) {
+		t.Parallel()
+
+		var tracker staleTracker
+
+		tracker.observe(newStaleSample(100, 1))
+		tracker.observe(staleSample{})
+		tracker.observe(newStaleSample(100, 1))
+		require.True(t, tracker.stale(1))
+	})
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package roles detects well-known server roles of the host, e.g. to let dashboards
+// show panels only for roles the host runs.
+package roles
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/types"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// refreshInterval is the interval, after which the roles are detected again,
+// e.g. to pick up a role installed while the exporter is running.
+const refreshInterval = 10 * time.Minute
+
+// Interface guard.
+var _ prometheus.Collector = (*Collector)(nil)
+
+// Source provides the information the role detection is based on.
+type Source interface {
+	// ServiceExists reports whether a service with the given name is installed.
+	ServiceExists(name string) (bool, error)
+	// RegistryKeyExists reports whether the given key exists below HKEY_LOCAL_MACHINE.
+	RegistryKeyExists(path string) (bool, error)
+}
+
+// check detects a role by an installed service or an existing registry key.
+type check struct {
+	service     string
+	registryKey string
+}
+
+// roleChecks maps each role to its checks. A role is detected if any check matches.
+//
+//nolint:gochecknoglobals
+var roleChecks = map[string][]check{
+	"iis":      {{service: "W3SVC"}},
+	"mssql":    {{registryKey: `Software\Microsoft\Microsoft SQL Server\Instance Names\SQL`}},
+	"hyperv":   {{service: "vmms"}},
+	"dc":       {{service: "NTDS"}},
+	"dns":      {{service: "DNS"}},
+	"dhcp":     {{service: "DHCPServer"}},
+	"exchange": {{service: "MSExchangeServiceHost"}},
+	// TermService is installed on every Windows host, so the RDS role services
+	// Connection Broker, Gateway and Licensing are checked instead.
+	"rds": {{service: "Tssdis"}, {service: "TSGateway"}, {service: "TermServLicensing"}},
+}
+
+// Detect returns the sorted list of roles detected by the given source.
+// Checks which fail are logged and treated as not matching.
+func Detect(logger *slog.Logger, source Source) []string {
+	detected := make([]string, 0, len(roleChecks))
+
+	for role, checks := range roleChecks {
+		for _, check := range checks {
+			var (
+				found bool
+				err   error
+			)
+
+			if check.service != "" {
+				found, err = source.ServiceExists(check.service)
+			} else {
+				found, err = source.RegistryKeyExists(check.registryKey)
+			}
+
+			if err != nil {
+				logger.Debug("role detection check failed",
+					slog.String("role", role),
+					slog.String("service", check.service),
+					slog.String("registry_key", check.registryKey),
+					slog.Any("err", err),
+				)
+
+				continue
+			}
+
+			if found {
+				detected = append(detected, role)
+
+				break
+			}
+		}
+	}
+
+	slices.Sort(detected)
+
+	return detected
+}
+
+// Collector exposes windows_exporter_detected_role for each detected role.
+// The roles are detected on creation and again in the background once they are older than refreshInterval.
+type Collector struct {
+	logger *slog.Logger
+	source Source
+
+	roles *utils.TTLCache[[]string]
+
+	detectedRoleDesc *prometheus.Desc
+}
+
+// NewCollector returns a new Collector and detects the roles of the host.
+func NewCollector(logger *slog.Logger, source Source) *Collector {
+	c := &Collector{
+		logger: logger,
+		source: source,
+		roles:  utils.NewTTLCache[[]string](refreshInterval),
+		detectedRoleDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "detected_role"),
+			"windows_exporter: Server roles detected on the host. Value is always 1.",
+			[]string{"role"},
+			nil,
+		),
+	}
+
+	c.Refresh()
+
+	return c
+}
+
+// Refresh detects the roles of the host again.
+func (c *Collector) Refresh() {
+	c.roles.Set(Detect(c.logger, c.source))
+}
+
+func (c *Collector) detect() ([]string, error) {
+	return Detect(c.logger, c.source), nil
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.detectedRoleDesc
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	roles, _, _ := c.roles.Get(c.detect)
+
+	for _, role := range roles {
+		ch <- prometheus.MustNewConstMetric(
+			c.detectedRoleDesc,
+			prometheus.GaugeValue,
+			1,
+			role,
+		)
+	}
+}
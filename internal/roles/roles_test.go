@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package roles
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	services     map[string]bool
+	registryKeys map[string]bool
+	err          error
+}
+
+func (s *fakeSource) ServiceExists(name string) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+
+	return s.services[name], nil
+}
+
+func (s *fakeSource) RegistryKeyExists(path string) (bool, error) {
+	return s.registryKeys[path], nil
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	source := &fakeSource{
+		services: map[string]bool{
+			"W3SVC":     true,
+			"NTDS":      true,
+			"DNS":       true,
+			"TSGateway": true,
+			// TermService exists on every host and must not indicate the rds role.
+			"TermService": true,
+		},
+		registryKeys: map[string]bool{
+			`Software\Microsoft\Microsoft SQL Server\Instance Names\SQL`: true,
+		},
+	}
+
+	require.Equal(t, []string{"dc", "dns", "iis", "mssql", "rds"}, Detect(logger, source))
+	require.Empty(t, Detect(logger, &fakeSource{}))
+
+	// Failing checks are treated as not matching.
+	source.err = errors.New("access denied")
+	require.Equal(t, []string{"mssql"}, Detect(logger, source))
+}
+
+func TestCollectorRefresh(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	source := &fakeSource{services: map[string]bool{"vmms": true}}
+
+	collector := NewCollector(logger, source)
+
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP windows_exporter_detected_role windows_exporter: Server roles detected on the host. Value is always 1.
+# TYPE windows_exporter_detected_role gauge
+windows_exporter_detected_role{role="hyperv"} 1
+`)))
+
+	source.services = map[string]bool{"DHCPServer": true}
+	collector.Refresh()
+
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP windows_exporter_detected_role windows_exporter: Server roles detected on the host. Value is always 1.
+# TYPE windows_exporter_detected_role gauge
+windows_exporter_detected_role{role="dhcp"} 1
+`)))
+}
+
+func TestCollectorRedetect(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	source := &fakeSource{services: map[string]bool{"vmms": true}}
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	collector := NewCollector(logger, source)
+	collector.roles.WithClock(func() time.Time { return now })
+	collector.Refresh()
+
+	expected := func(role string) *strings.Reader {
+		return strings.NewReader(`
+# HELP windows_exporter_detected_role windows_exporter: Server roles detected on the host. Value is always 1.
+# TYPE windows_exporter_detected_role gauge
+windows_exporter_detected_role{role="` + role + `"} 1
+`)
+	}
+
+	// A changed role set is not picked up before the refresh interval elapsed.
+	source.services = map[string]bool{"DHCPServer": true}
+
+	require.NoError(t, testutil.CollectAndCompare(collector, expected("hyperv")))
+
+	// Once the roles are older than the refresh interval, they are detected again in the background.
+	now = now.Add(refreshInterval)
+
+	require.NoError(t, testutil.CollectAndCompare(collector, expected("hyperv")))
+
+	collector.roles.Wait()
+
+	require.NoError(t, testutil.CollectAndCompare(collector, expected("dhcp")))
+}
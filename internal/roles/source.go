@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package roles
+
+import (
+	"errors"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+// SystemSource is a [Source] backed by the service control manager and the registry of the local host.
+type SystemSource struct{}
+
+func (SystemSource) ServiceExists(name string) (bool, error) {
+	scm, err := windows.OpenSCManager(nil, nil, windows.SC_MANAGER_CONNECT)
+	if err != nil {
+		return false, err
+	}
+
+	defer func(handle windows.Handle) {
+		_ = windows.CloseServiceHandle(handle)
+	}(scm)
+
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return false, err
+	}
+
+	service, err := windows.OpenService(scm, namePtr, windows.SERVICE_QUERY_STATUS)
+	if err != nil {
+		if errors.Is(err, windows.ERROR_SERVICE_DOES_NOT_EXIST) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	_ = windows.CloseServiceHandle(service)
+
+	return true, nil
+}
+
+func (SystemSource) RegistryKeyExists(path string) (bool, error) {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, path, registry.QUERY_VALUE)
+	if err != nil {
+		if errors.Is(err, registry.ErrNotExist) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	_ = key.Close()
+
+	return true, nil
+}
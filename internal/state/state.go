@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+// Package state persists the state of collectors across exporter restarts.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// version is the format version of the state file. State files with a different version are discarded.
+const version = 1
+
+type file struct {
+	Version    int                        `json:"version"`
+	Collectors map[string]json.RawMessage `json:"collectors"`
+}
+
+// Store holds the state of collectors, keyed by collector name, and persists it as JSON file.
+type Store struct {
+	path   string
+	logger *slog.Logger
+
+	mu     sync.Mutex
+	states map[string]json.RawMessage
+}
+
+// DefaultPath returns the default path of the state file under %ProgramData%.
+func DefaultPath() string {
+	programData := os.Getenv("ProgramData")
+	if programData == "" {
+		programData = `C:\ProgramData`
+	}
+
+	return filepath.Join(programData, "windows_exporter", "state.json")
+}
+
+// Open reads the state file at path. A missing state file results in an empty Store.
+// A corrupt state file is logged and discarded. It is overwritten by the next Save.
+func Open(logger *slog.Logger, path string) *Store {
+	s := &Store{
+		path:   path,
+		logger: logger,
+		states: make(map[string]json.RawMessage),
+	}
+
+	states, err := readFile(path)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		logger.Warn("discarding unreadable state file",
+			slog.String("path", path),
+			slog.Any("err", err),
+		)
+	default:
+		s.states = states
+	}
+
+	return s
+}
+
+func readFile(path string) (map[string]json.RawMessage, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f file
+
+	if err = json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse state file: %w", err)
+	}
+
+	if f.Version != version {
+		return nil, fmt.Errorf("unsupported state file version %d", f.Version)
+	}
+
+	if f.Collectors == nil {
+		f.Collectors = make(map[string]json.RawMessage)
+	}
+
+	return f.Collectors, nil
+}
+
+// Get returns the state of the given collector.
+func (s *Store) Get(name string) (json.RawMessage, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[name]
+
+	return state, ok
+}
+
+// Set sets the state of the given collector. The state is persisted by the next Save.
+func (s *Store) Set(name string, state json.RawMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.states[name] = state
+}
+
+// Delete removes the state of the given collector, e.g. if it could not be restored.
+func (s *Store) Delete(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.states, name)
+}
+
+// Save writes the state file. The file is replaced atomically, so that an interrupted
+// write does not corrupt the previous state.
+func (s *Store) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(file{
+		Version:    version,
+		Collectors: s.states,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary state file: %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+
+	_, err = tmpFile.Write(data)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write temporary state file: %w", err)
+	}
+
+	if err = os.Rename(tmpFile.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace state file: %w", err)
+	}
+
+	return nil
+}
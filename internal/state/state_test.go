@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package state_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus-community/windows_exporter/internal/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "windows_exporter", "state.json")
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	store := state.Open(logger, path)
+
+	_, ok := store.Get("ad")
+	require.False(t, ok)
+
+	store.Set("ad", json.RawMessage(`{"account_lockouts":3}`))
+	require.NoError(t, store.Save())
+
+	restored, ok := state.Open(logger, path).Get("ad")
+	require.True(t, ok)
+	require.JSONEq(t, `{"account_lockouts":3}`, string(restored))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temporary state file was not removed")
+}
+
+func TestStoreDiscardsCorruptFile(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	for name, content := range map[string]string{
+		"truncated":       `{"version":1,"collectors":{"ad":{"account_lock`,
+		"unknown version": `{"version":99,"collectors":{"ad":{}}}`,
+		"binary":          "\x00\x01\x02",
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "state.json")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			store := state.Open(logger, path)
+
+			_, ok := store.Get("ad")
+			require.False(t, ok)
+
+			store.Set("ad", json.RawMessage(`{}`))
+			require.NoError(t, store.Save())
+
+			_, ok = state.Open(logger, path).Get("ad")
+			require.True(t, ok)
+		})
+	}
+}
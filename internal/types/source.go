@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package types
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Data sources of a collector, exposed as source label of the collector metrics.
+const (
+	SourcePDH  = "pdh"
+	SourceWMI  = "wmi"
+	SourceAPI  = "api"
+	SourceCOM  = "com"
+	SourceFile = "file"
+)
+
+// SourceDurationDesc describes the duration of a collection by data source.
+// It is used by collectors that read from multiple data sources.
+//
+//nolint:gochecknoglobals
+var SourceDurationDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(Namespace, "exporter", "collector_source_duration_seconds"),
+	"windows_exporter: Duration of a collection by data source.",
+	[]string{"collector", "source"},
+	nil,
+)
+
+// NewSourceDurationMetric returns a windows_exporter_collector_source_duration_seconds metric.
+func NewSourceDurationMetric(collector, source string, duration time.Duration) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		SourceDurationDesc,
+		prometheus.GaugeValue,
+		duration.Seconds(),
+		collector, source,
+	)
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// TTLCache holds a value, which is expensive to load, e.g. the result of a walk over a large directory tree.
+// Once the last load is older than the TTL, the cached value is still returned and a new load is started
+// in the background, so a scrape is never blocked by a load. At most one load runs at a time.
+type TTLCache[T any] struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu         sync.Mutex
+	value      T
+	loaded     bool
+	lastLoad   time.Time
+	lastErr    error
+	refreshing bool
+
+	// wg tracks the background loads.
+	wg sync.WaitGroup
+}
+
+// NewTTLCache creates a new TTLCache, which loads the value again after ttl.
+func NewTTLCache[T any](ttl time.Duration) *TTLCache[T] {
+	return &TTLCache[T]{
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+// WithClock replaces the clock of the cache. It is used by tests.
+func (c *TTLCache[T]) WithClock(now func() time.Time) *TTLCache[T] {
+	c.now = now
+
+	return c
+}
+
+// Get returns the value of the last successful load and the error of the last load.
+// ok is false, if no load has succeeded yet. If the last load is older than the TTL,
+// load is called in the background. A failed load keeps the previous value and is retried after the TTL.
+func (c *TTLCache[T]) Get(load func() (T, error)) (T, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.refreshing && (c.lastLoad.IsZero() || c.now().Sub(c.lastLoad) >= c.ttl) {
+		c.refreshing = true
+
+		c.wg.Go(func() {
+			c.refresh(load)
+		})
+	}
+
+	return c.value, c.loaded, c.lastErr
+}
+
+// Set stores a value, which was loaded by the caller, e.g. synchronously on the first lookup.
+func (c *TTLCache[T]) Set(value T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.value = value
+	c.loaded = true
+	c.lastLoad = c.now()
+	c.lastErr = nil
+}
+
+// Wait blocks until the background load finished.
+func (c *TTLCache[T]) Wait() {
+	c.wg.Wait()
+}
+
+func (c *TTLCache[T]) refresh(load func() (T, error)) {
+	value, err := load()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.refreshing = false
+	c.lastLoad = c.now()
+	c.lastErr = err
+
+	if err == nil {
+		c.value = value
+		c.loaded = true
+	}
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package utils_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTTLCache(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := utils.NewTTLCache[int](time.Minute).WithClock(func() time.Time { return now })
+
+	loads := 0
+	errLoad := errors.New("access denied")
+
+	var loadErr error
+
+	load := func() (int, error) {
+		loads++
+
+		return loads, loadErr
+	}
+
+	// The first lookup starts the load in the background.
+	_, ok, err := cache.Get(load)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	cache.Wait()
+
+	value, ok, err := cache.Get(load)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	// A failed load keeps the previous value.
+	now = now.Add(2 * time.Minute)
+	loadErr = errLoad
+
+	_, _, _ = cache.Get(load)
+	cache.Wait()
+
+	value, ok, err = cache.Get(load)
+	require.ErrorIs(t, err, errLoad)
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+	require.Equal(t, 2, loads)
+
+	// The failed load is retried after the TTL.
+	now = now.Add(2 * time.Minute)
+	loadErr = nil
+
+	_, _, _ = cache.Get(load)
+	cache.Wait()
+
+	value, ok, err = cache.Get(load)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 3, value)
+}
+
+func TestTTLCacheSet(t *testing.T) {
+	t.Parallel()
+
+	cache := utils.NewTTLCache[string](time.Hour)
+	cache.Set("cached")
+
+	value, ok, err := cache.Get(func() (string, error) {
+		return "", errors.New("unexpected load")
+	})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "cached", value)
+
+	cache.Wait()
+}
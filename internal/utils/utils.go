@@ -21,6 +21,10 @@ func MilliSecToSec(t float64) float64 {
 	return t / 1000
 }
 
+func MicroSecToSec(t float64) float64 {
+	return t / 1000000
+}
+
 func MBToBytes(mb float64) float64 {
 	return mb * 1024 * 1024
 }
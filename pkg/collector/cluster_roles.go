@@ -0,0 +1,312 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"golang.org/x/sys/windows"
+)
+
+const (
+	// clusterRoleLabel is the label added to metrics of clustered resources.
+	clusterRoleLabel = "cluster_role"
+
+	// clusterRolesRefreshTimeout is the timeout of each WMI query of a cluster role refresh.
+	clusterRolesRefreshTimeout = 5 * time.Second
+)
+
+// ClusterRoleLabels maps collector names to the label, whose values are matched against the
+// clustered resources owned by the local node, e.g. logical_disk: volume.
+type ClusterRoleLabels map[string]string
+
+//nolint:gochecknoglobals
+var (
+	clusterGroupQuery    = utils.Must(mi.NewQuery("SELECT Name,OwnerNode FROM MSCluster_ResourceGroup"))
+	clusterResourceQuery = utils.Must(mi.NewQuery("SELECT Name,Type,OwnerGroup FROM MSCluster_Resource"))
+
+	wqlStringEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+)
+
+// msClusterResourceGroup represents the MSCluster_ResourceGroup WMI class.
+type msClusterResourceGroup struct {
+	Name      string `mi:"Name"`
+	OwnerNode string `mi:"OwnerNode"`
+}
+
+// msClusterResource represents the MSCluster_Resource WMI class.
+type msClusterResource struct {
+	Name       string `mi:"Name"`
+	Type       string `mi:"Type"`
+	OwnerGroup string `mi:"OwnerGroup"`
+}
+
+// msClusterDisk represents the MSCluster_Disk WMI class.
+type msClusterDisk struct {
+	ID string `mi:"Id"`
+}
+
+// msClusterDiskPartition represents the MSCluster_DiskPartition WMI class.
+type msClusterDiskPartition struct {
+	Path string `mi:"Path"`
+}
+
+// clusterRoles resolves label values to the cluster role (resource group) owning them.
+// A refresh of the mapping is started in the background by every scrape, so that failovers are
+// reflected by one of the next scrapes. Scrapes always apply the last good mapping.
+type clusterRoles struct {
+	labels    ClusterRoleLabels
+	miSession *mi.Session
+	nodeName  string
+
+	// refreshing is set while a refresh runs in the background. wg tracks the refresh.
+	refreshing atomic.Bool
+	wg         sync.WaitGroup
+	// diskPaths caches the volume paths of clustered disk resources by resource name.
+	// The volumes of a disk do not change on failover. It is only accessed by the running refresh.
+	diskPaths map[string][]string
+
+	// roles maps lower-cased label values to the cluster role owning them.
+	roles atomic.Pointer[map[string]string]
+}
+
+// SetClusterRoleLabels enables the cluster_role label for the metrics of the given collectors.
+// The label is only added to metrics of clustered resources owned by the local node.
+func (c *Collection) SetClusterRoleLabels(labels ClusterRoleLabels) error {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0)
+
+	for _, collectorName := range slices.Sorted(maps.Keys(labels)) {
+		if _, ok := c.collectors[collectorName]; !ok {
+			errs = append(errs, fmt.Errorf("cluster role label for collector %s: collector is not enabled", collectorName))
+		}
+
+		if labels[collectorName] == "" {
+			errs = append(errs, fmt.Errorf("cluster role label for collector %s: label must not be empty", collectorName))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	nodeName, err := windows.ComputerName()
+	if err != nil {
+		return fmt.Errorf("failed to get computer name: %w", err)
+	}
+
+	c.clusterRoles = &clusterRoles{
+		labels:    labels,
+		miSession: c.miSession,
+		nodeName:  nodeName,
+		diskPaths: make(map[string][]string),
+	}
+
+	return nil
+}
+
+// refresh starts a query of the resource groups owned by the local node and their resources in the background,
+// unless the previous refresh is still running. On failure, the previous mapping is kept.
+func (r *clusterRoles) refresh(logger *slog.Logger) {
+	if !r.refreshing.CompareAndSwap(false, true) {
+		return
+	}
+
+	r.wg.Go(func() {
+		defer r.refreshing.Store(false)
+
+		roles, err := r.query(clusterRolesRefreshTimeout)
+		if err != nil {
+			logger.LogAttrs(context.Background(), slog.LevelWarn, "failed to refresh cluster roles, keeping the previous mapping",
+				slog.Any("err", err),
+			)
+
+			return
+		}
+
+		r.roles.Store(&roles)
+	})
+}
+
+// wait blocks until the background refresh finished.
+func (r *clusterRoles) wait() {
+	r.wg.Wait()
+}
+
+func (r *clusterRoles) query(timeout time.Duration) (map[string]string, error) {
+	var groups []msClusterResourceGroup
+	if err := r.miSession.Query(&groups, mi.NamespaceRootMSCluster, clusterGroupQuery, timeout); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	var resources []msClusterResource
+	if err := r.miSession.Query(&resources, mi.NamespaceRootMSCluster, clusterResourceQuery, timeout); err != nil {
+		return nil, fmt.Errorf("WMI query failed: %w", err)
+	}
+
+	roles := make(map[string]string)
+
+	for _, group := range groups {
+		if strings.EqualFold(group.OwnerNode, r.nodeName) {
+			roles[strings.ToLower(group.Name)] = group.Name
+		}
+	}
+
+	for _, resource := range resources {
+		role, ok := roles[strings.ToLower(resource.OwnerGroup)]
+		if !ok {
+			continue
+		}
+
+		roles[strings.ToLower(resource.Name)] = role
+
+		if resource.Type != "Physical Disk" {
+			continue
+		}
+
+		paths, err := r.resourceDiskPaths(resource.Name, timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, path := range paths {
+			roles[strings.ToLower(path)] = role
+		}
+	}
+
+	return roles, nil
+}
+
+// resourceDiskPaths returns the volume paths of a clustered disk resource, e.g. E:.
+func (r *clusterRoles) resourceDiskPaths(resourceName string, timeout time.Duration) ([]string, error) {
+	if paths, ok := r.diskPaths[resourceName]; ok {
+		return paths, nil
+	}
+
+	disksQuery, err := mi.NewQuery(fmt.Sprintf("ASSOCIATORS OF {MSCluster_Resource.Name='%s'} WHERE AssocClass = MSCluster_ResourceToDisk",
+		wqlStringEscaper.Replace(resourceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create WMI query: %w", err)
+	}
+
+	var disks []msClusterDisk
+	if err = r.miSession.Query(&disks, mi.NamespaceRootMSCluster, disksQuery, timeout); err != nil {
+		return nil, fmt.Errorf("WMI query for disks of resource %s failed: %w", resourceName, err)
+	}
+
+	paths := make([]string, 0)
+
+	for _, disk := range disks {
+		partitionsQuery, err := mi.NewQuery(fmt.Sprintf("ASSOCIATORS OF {MSCluster_Disk.Id='%s'} WHERE AssocClass = MSCluster_DiskToDiskPartition",
+			wqlStringEscaper.Replace(disk.ID),
+		))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create WMI query: %w", err)
+		}
+
+		var partitions []msClusterDiskPartition
+		if err = r.miSession.Query(&partitions, mi.NamespaceRootMSCluster, partitionsQuery, timeout); err != nil {
+			return nil, fmt.Errorf("WMI query for partitions of disk %s failed: %w", disk.ID, err)
+		}
+
+		for _, partition := range partitions {
+			if partition.Path != "" {
+				paths = append(paths, partition.Path)
+			}
+		}
+	}
+
+	r.diskPaths[resourceName] = paths
+
+	return paths, nil
+}
+
+// applyClusterRole wraps the metric, if the collector is configured for the cluster_role label.
+func (c *Collection) applyClusterRole(name string, m prometheus.Metric) prometheus.Metric {
+	if c.clusterRoles == nil {
+		return m
+	}
+
+	label, ok := c.clusterRoles.labels[name]
+	if !ok {
+		return m
+	}
+
+	roles := c.clusterRoles.roles.Load()
+	if roles == nil || len(*roles) == 0 {
+		return m
+	}
+
+	return clusterRoleMetric{Metric: m, label: label, roles: *roles}
+}
+
+// clusterRoleMetric wraps a metric and adds the cluster_role label, if the value
+// of the configured label belongs to a cluster role owned by the local node.
+type clusterRoleMetric struct {
+	prometheus.Metric
+
+	label string
+	roles map[string]string
+}
+
+func (m clusterRoleMetric) Write(out *dto.Metric) error {
+	if err := m.Metric.Write(out); err != nil {
+		return err
+	}
+
+	var role string
+
+	for _, labelPair := range out.GetLabel() {
+		switch labelPair.GetName() {
+		case clusterRoleLabel:
+			return nil
+		case m.label:
+			role = m.roles[strings.ToLower(labelPair.GetValue())]
+		}
+	}
+
+	if role == "" {
+		return nil
+	}
+
+	out.Label = append(out.Label, &dto.LabelPair{Name: new(clusterRoleLabel), Value: new(role)})
+
+	slices.SortFunc(out.Label, func(a, b *dto.LabelPair) int {
+		return cmp.Compare(a.GetName(), b.GetName())
+	})
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetClusterRoleLabelsValidation(t *testing.T) {
+	t.Parallel()
+
+	synthetic := &syntheticCollector{
+		series: 1,
+		desc:   prometheus.NewDesc("windows_synthetic_series", "Synthetic metric.", []string{"id"}, nil),
+	}
+
+	collection := New(Map{synthetic.GetName(): synthetic})
+
+	require.NoError(t, collection.SetClusterRoleLabels(nil))
+	require.Nil(t, collection.clusterRoles)
+
+	require.ErrorContains(t, collection.SetClusterRoleLabels(ClusterRoleLabels{
+		"logical_disk": "volume",
+	}), "collector is not enabled")
+
+	require.ErrorContains(t, collection.SetClusterRoleLabels(ClusterRoleLabels{
+		"synthetic": "",
+	}), "label must not be empty")
+
+	require.NoError(t, collection.SetClusterRoleLabels(ClusterRoleLabels{
+		"synthetic": "id",
+	}))
+	require.NotNil(t, collection.clusterRoles)
+}
+
+func TestClusterRoleMetric(t *testing.T) {
+	t.Parallel()
+
+	desc := prometheus.NewDesc("windows_logical_disk_free_bytes", "", []string{"volume"}, nil)
+	roles := map[string]string{"e:": "SQLGroup1"}
+
+	for _, tc := range []struct {
+		volume string
+		labels map[string]string
+	}{
+		{"E:", map[string]string{"cluster_role": "SQLGroup1", "volume": "E:"}},
+		{"C:", map[string]string{"volume": "C:"}},
+	} {
+		metric := clusterRoleMetric{
+			Metric: prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, tc.volume),
+			label:  "volume",
+			roles:  roles,
+		}
+
+		var m dto.Metric
+
+		require.NoError(t, metric.Write(&m))
+
+		labels := make(map[string]string)
+		names := make([]string, 0, len(m.GetLabel()))
+
+		for _, label := range m.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+			names = append(names, label.GetName())
+		}
+
+		require.Equal(t, tc.labels, labels, tc.volume)
+		require.IsIncreasing(t, names, tc.volume)
+	}
+}
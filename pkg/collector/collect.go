@@ -18,11 +18,16 @@
 package collector
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"runtime/debug"
+	"runtime/metrics"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -46,9 +51,24 @@ const (
 	failed
 )
 
-func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger, maxScrapeDuration time.Duration) {
+// collectAll runs all collectors concurrently and sends their metrics to ch.
+// It returns ErrCriticalCollectorFailed, if a critical collector failed or timed out.
+func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger, maxScrapeDuration time.Duration) error {
 	collectorStartTime := time.Now()
 
+	var heapAllocsBefore uint64
+	if c.allocationWarningThreshold > 0 {
+		heapAllocsBefore = heapAllocs()
+	}
+
+	if c.clusterRoles != nil {
+		c.clusterRoles.refresh(logger)
+	}
+
+	// Collectors using the shared performance counter query read from this sample.
+	// Errors are reported by each of these collectors.
+	_ = pdh.CollectSharedQuery()
+
 	// WaitGroup to wait for all collectors to finish
 	wg := sync.WaitGroup{}
 	wg.Add(len(c.collectors))
@@ -76,7 +96,11 @@ func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger
 	// Close the channel since we are done writing to it
 	close(collectorStatusCh)
 
+	statuses := make([]collectorStatus, 0, len(c.collectors))
+
 	for status := range collectorStatusCh {
+		statuses = append(statuses, status)
+
 		var successValue, timeoutValue float64
 		if status.statusCode == pending {
 			timeoutValue = 1.0
@@ -91,6 +115,7 @@ func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger
 			prometheus.GaugeValue,
 			successValue,
 			status.name,
+			sources(c.collectors[status.name]),
 		)
 
 		ch <- prometheus.MustNewConstMetric(
@@ -101,11 +126,37 @@ func (c *Collection) collectAll(ch chan<- prometheus.Metric, logger *slog.Logger
 		)
 	}
 
+	c.collectCounterOverrides(ch)
+
 	ch <- prometheus.MustNewConstMetric(
 		c.scrapeDurationDesc,
 		prometheus.GaugeValue,
 		time.Since(collectorStartTime).Seconds(),
 	)
+
+	if c.allocationWarningThreshold > 0 {
+		if allocated := heapAllocs() - heapAllocsBefore; allocated > c.allocationWarningThreshold {
+			logger.LogAttrs(context.Background(), slog.LevelWarn,
+				fmt.Sprintf("collection allocated %d bytes, exceeding the warning threshold of %d bytes", allocated, c.allocationWarningThreshold),
+				slog.Any("collectors", slices.Sorted(maps.Keys(c.collectors))),
+			)
+		}
+	}
+
+	return criticalError(statuses, c.criticalCollectors)
+}
+
+// heapAllocs returns the cumulative number of bytes allocated on the heap by the process.
+func heapAllocs() uint64 {
+	sample := []metrics.Sample{{Name: "/gc/heap/allocs:bytes"}}
+
+	metrics.Read(sample)
+
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+
+	return sample[0].Value.Uint64()
 }
 
 func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.Logger, name string, collector Collector, maxScrapeDuration time.Duration) collectorStatusCode {
@@ -114,8 +165,12 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 		numMetrics int
 		duration   time.Duration
 		timeout    atomic.Bool
+		truncated  atomic.Bool
 	)
 
+	// seriesByDesc counts the series per metric to log the top offenders if the series budget is exceeded.
+	seriesByDesc := make(map[*prometheus.Desc]int)
+
 	// bufCh is a buffer channel to store the metrics
 	// This is needed because once timeout is reached, the prometheus registry channel is closed.
 	bufCh := make(chan prometheus.Metric, 1000)
@@ -163,7 +218,17 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 				}
 
 				if !timeout.Load() {
-					ch <- m
+					if c.maxSeries > 0 {
+						seriesByDesc[m.Desc()]++
+
+						if numMetrics >= c.maxSeries {
+							truncated.Store(true)
+
+							continue
+						}
+					}
+
+					ch <- c.applyClusterRole(name, c.applyCounterOverride(name, m))
 
 					numMetrics++
 				}
@@ -184,7 +249,15 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 			prometheus.GaugeValue,
 			duration.Seconds(),
 			name,
+			sources(collector),
 		)
+
+		if truncated.Load() {
+			logger.LogAttrs(ctx, slog.LevelWarn,
+				fmt.Sprintf("collector %s exceeded the limit of %d series, further series were dropped", name, c.maxSeries),
+				slog.Any("top_metrics", topSeries(seriesByDesc, 5)),
+			)
+		}
 	case <-ctx.Done():
 		timeout.Store(true)
 
@@ -194,10 +267,15 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 			prometheus.GaugeValue,
 			duration.Seconds(),
 			name,
+			sources(collector),
 		)
 
 		logger.LogAttrs(ctx, slog.LevelWarn, fmt.Sprintf("collector %s timeouted after %s, resulting in %d metrics", name, maxScrapeDuration, numMetrics))
 
+		c.collectTruncated(ch, name, truncated.Load())
+
+		c.status.record(name, t, duration, fmt.Errorf("timeout after %s", maxScrapeDuration))
+
 		go func() {
 			// Drain channel in case of premature return to not leak a goroutine.
 			for range bufCh {
@@ -207,6 +285,8 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 		return pending
 	}
 
+	c.collectTruncated(ch, name, truncated.Load())
+
 	slogAttrs := make([]slog.Attr, 0)
 
 	result := "succeeded"
@@ -222,6 +302,8 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 				slog.Any("err", err),
 			)
 
+			c.status.record(name, t, duration, err)
+
 			return failed
 		}
 
@@ -236,5 +318,74 @@ func (c *Collection) collectCollector(ch chan<- prometheus.Metric, logger *slog.
 		slogAttrs...,
 	)
 
+	c.status.record(name, t, duration, nil)
+
 	return success
 }
+
+// sources returns the data sources declared by the collector as comma-separated list.
+func sources(collector Collector) string {
+	sourceCollector, ok := collector.(SourceCollector)
+	if !ok {
+		return ""
+	}
+
+	return strings.Join(slices.Sorted(slices.Values(sourceCollector.GetSources())), ",")
+}
+
+// collectTruncated emits windows_exporter_collector_series_truncated, if a series budget is configured.
+func (c *Collection) collectTruncated(ch chan<- prometheus.Metric, name string, truncated bool) {
+	if c.maxSeries <= 0 {
+		return
+	}
+
+	var truncatedValue float64
+	if truncated {
+		truncatedValue = 1.0
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.collectorTruncatedDesc,
+		prometheus.GaugeValue,
+		truncatedValue,
+		name,
+	)
+}
+
+// topSeries returns the names of the n metrics with the most series, formatted as name=count.
+func topSeries(seriesByDesc map[*prometheus.Desc]int, n int) []string {
+	seriesByName := make(map[string]int, len(seriesByDesc))
+
+	for desc, count := range seriesByDesc {
+		seriesByName[descName(desc)] += count
+	}
+
+	names := slices.SortedFunc(maps.Keys(seriesByName), func(a, b string) int {
+		if c := cmp.Compare(seriesByName[b], seriesByName[a]); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a, b)
+	})
+
+	top := make([]string, 0, n)
+
+	for _, name := range names[:min(n, len(names))] {
+		top = append(top, fmt.Sprintf("%s=%d", name, seriesByName[name]))
+	}
+
+	return top
+}
+
+// descName returns the fully-qualified name of a metric descriptor.
+// [prometheus.Desc] does not expose the name, so it is parsed from the string representation.
+func descName(desc *prometheus.Desc) string {
+	_, name, ok := strings.Cut(desc.String(), `fqName: "`)
+	if !ok {
+		return desc.String()
+	}
+
+	name, _, _ = strings.Cut(name, `"`)
+
+	return name
+}
@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"log/slog"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+// syntheticCollector emits a fixed number of series in a deterministic order.
+type syntheticCollector struct {
+	series int
+	desc   *prometheus.Desc
+}
+
+func (c *syntheticCollector) GetName() string { return "synthetic" }
+
+func (c *syntheticCollector) Build(*slog.Logger, *mi.Session) error { return nil }
+
+func (c *syntheticCollector) Close() error { return nil }
+
+func (c *syntheticCollector) Collect(ch chan<- prometheus.Metric, _ time.Duration) error {
+	for i := range c.series {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(i), strconv.Itoa(i))
+	}
+
+	return nil
+}
+
+func TestCollectAllSeriesBudget(t *testing.T) {
+	t.Parallel()
+
+	synthetic := &syntheticCollector{
+		series: 10000,
+		desc:   prometheus.NewDesc("windows_synthetic_series", "Synthetic high cardinality metric.", []string{"id"}, nil),
+	}
+
+	collection := New(Map{synthetic.GetName(): synthetic})
+	collection.SetMaxSeriesPerCollector(100)
+
+	collect := func() ([]string, float64) {
+		ch := make(chan prometheus.Metric, synthetic.series+100)
+
+		_ = collection.collectAll(ch, slog.New(slog.DiscardHandler), 30*time.Second)
+		close(ch)
+
+		var (
+			ids       []string
+			truncated = -1.0
+		)
+
+		for m := range ch {
+			var metric dto.Metric
+
+			require.NoError(t, m.Write(&metric))
+
+			switch m.Desc() {
+			case synthetic.desc:
+				ids = append(ids, metric.GetLabel()[0].GetValue())
+			case collection.collectorTruncatedDesc:
+				truncated = metric.GetGauge().GetValue()
+			}
+		}
+
+		return ids, truncated
+	}
+
+	firstIDs, truncated := collect()
+	require.Len(t, firstIDs, 100)
+	require.Equal(t, "0", firstIDs[0])
+	require.Equal(t, "99", firstIDs[99])
+	require.InDelta(t, 1.0, truncated, 0)
+
+	secondIDs, truncated := collect()
+	require.Equal(t, firstIDs, secondIDs)
+	require.InDelta(t, 1.0, truncated, 0)
+}
+
+func TestCollectAllSeriesBudgetNotExceeded(t *testing.T) {
+	t.Parallel()
+
+	synthetic := &syntheticCollector{
+		series: 50,
+		desc:   prometheus.NewDesc("windows_synthetic_series", "Synthetic metric.", []string{"id"}, nil),
+	}
+
+	collection := New(Map{synthetic.GetName(): synthetic})
+	collection.SetMaxSeriesPerCollector(100)
+
+	ch := make(chan prometheus.Metric, 1000)
+
+	_ = collection.collectAll(ch, slog.New(slog.DiscardHandler), 30*time.Second)
+	close(ch)
+
+	var series int
+
+	for m := range ch {
+		var metric dto.Metric
+
+		require.NoError(t, m.Write(&metric))
+
+		switch m.Desc() {
+		case synthetic.desc:
+			series++
+		case collection.collectorTruncatedDesc:
+			require.InDelta(t, 0.0, metric.GetGauge().GetValue(), 0)
+		}
+	}
+
+	require.Equal(t, 50, series)
+}
+
+func TestTopSeries(t *testing.T) {
+	t.Parallel()
+
+	a := prometheus.NewDesc("windows_a", "a", []string{"x"}, nil)
+	b := prometheus.NewDesc("windows_b", "b", []string{"x"}, nil)
+	c := prometheus.NewDesc("windows_c", "c", nil, nil)
+
+	top := topSeries(map[*prometheus.Desc]int{a: 5, b: 50, c: 5}, 2)
+
+	require.Equal(t, []string{"windows_b=50", "windows_a=5"}, top)
+}
@@ -31,21 +31,30 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/ad"
 	"github.com/prometheus-community/windows_exporter/internal/collector/adcs"
 	"github.com/prometheus-community/windows_exporter/internal/collector/adfs"
+	"github.com/prometheus-community/windows_exporter/internal/collector/branchcache"
 	"github.com/prometheus-community/windows_exporter/internal/collector/cache"
 	"github.com/prometheus-community/windows_exporter/internal/collector/container"
 	"github.com/prometheus-community/windows_exporter/internal/collector/cpu"
 	"github.com/prometheus-community/windows_exporter/internal/collector/cpu_info"
+	"github.com/prometheus-community/windows_exporter/internal/collector/dfsn"
 	"github.com/prometheus-community/windows_exporter/internal/collector/dfsr"
 	"github.com/prometheus-community/windows_exporter/internal/collector/dhcp"
 	"github.com/prometheus-community/windows_exporter/internal/collector/diskdrive"
 	"github.com/prometheus-community/windows_exporter/internal/collector/dns"
+	"github.com/prometheus-community/windows_exporter/internal/collector/dns_client"
+	"github.com/prometheus-community/windows_exporter/internal/collector/etw_latency"
 	"github.com/prometheus-community/windows_exporter/internal/collector/exchange"
 	"github.com/prometheus-community/windows_exporter/internal/collector/file"
 	"github.com/prometheus-community/windows_exporter/internal/collector/fsrmquota"
+	"github.com/prometheus-community/windows_exporter/internal/collector/gpo"
 	"github.com/prometheus-community/windows_exporter/internal/collector/gpu"
+	"github.com/prometheus-community/windows_exporter/internal/collector/hotfix"
 	"github.com/prometheus-community/windows_exporter/internal/collector/hyperv"
+	"github.com/prometheus-community/windows_exporter/internal/collector/hyperv_vm"
 	"github.com/prometheus-community/windows_exporter/internal/collector/iis"
+	"github.com/prometheus-community/windows_exporter/internal/collector/jobobject"
 	"github.com/prometheus-community/windows_exporter/internal/collector/license"
+	"github.com/prometheus-community/windows_exporter/internal/collector/listener_certificate"
 	"github.com/prometheus-community/windows_exporter/internal/collector/logical_disk"
 	"github.com/prometheus-community/windows_exporter/internal/collector/memory"
 	"github.com/prometheus-community/windows_exporter/internal/collector/mscluster"
@@ -60,23 +69,28 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/physical_disk"
 	"github.com/prometheus-community/windows_exporter/internal/collector/printer"
 	"github.com/prometheus-community/windows_exporter/internal/collector/process"
+	"github.com/prometheus-community/windows_exporter/internal/collector/rdgateway"
+	"github.com/prometheus-community/windows_exporter/internal/collector/reliability"
 	"github.com/prometheus-community/windows_exporter/internal/collector/remote_fx"
 	"github.com/prometheus-community/windows_exporter/internal/collector/scheduled_task"
 	"github.com/prometheus-community/windows_exporter/internal/collector/service"
 	"github.com/prometheus-community/windows_exporter/internal/collector/smb"
 	"github.com/prometheus-community/windows_exporter/internal/collector/smbclient"
 	"github.com/prometheus-community/windows_exporter/internal/collector/smtp"
+	"github.com/prometheus-community/windows_exporter/internal/collector/storage_qos"
 	"github.com/prometheus-community/windows_exporter/internal/collector/system"
 	"github.com/prometheus-community/windows_exporter/internal/collector/tcp"
 	"github.com/prometheus-community/windows_exporter/internal/collector/terminal_services"
 	"github.com/prometheus-community/windows_exporter/internal/collector/textfile"
 	"github.com/prometheus-community/windows_exporter/internal/collector/thermalzone"
 	"github.com/prometheus-community/windows_exporter/internal/collector/time"
+	"github.com/prometheus-community/windows_exporter/internal/collector/tpm"
 	"github.com/prometheus-community/windows_exporter/internal/collector/udp"
 	"github.com/prometheus-community/windows_exporter/internal/collector/update"
 	"github.com/prometheus-community/windows_exporter/internal/collector/vmware"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
 	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus-community/windows_exporter/internal/state"
 	"github.com/prometheus-community/windows_exporter/internal/types"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sys/windows/registry"
@@ -101,21 +115,30 @@ func NewWithConfig(config Config) *Collection {
 	collectors[ad.Name] = ad.New(&config.AD)
 	collectors[adcs.Name] = adcs.New(&config.ADCS)
 	collectors[adfs.Name] = adfs.New(&config.ADFS)
+	collectors[branchcache.Name] = branchcache.New(&config.BranchCache)
 	collectors[cache.Name] = cache.New(&config.Cache)
 	collectors[container.Name] = container.New(&config.Container)
 	collectors[cpu.Name] = cpu.New(&config.CPU)
 	collectors[cpu_info.Name] = cpu_info.New(&config.CPUInfo)
+	collectors[dfsn.Name] = dfsn.New(&config.DFSN)
 	collectors[dfsr.Name] = dfsr.New(&config.DFSR)
 	collectors[dhcp.Name] = dhcp.New(&config.Dhcp)
 	collectors[diskdrive.Name] = diskdrive.New(&config.DiskDrive)
 	collectors[dns.Name] = dns.New(&config.DNS)
+	collectors[dns_client.Name] = dns_client.New(&config.DNSClient)
+	collectors[etw_latency.Name] = etw_latency.New(&config.EtwLatency)
 	collectors[exchange.Name] = exchange.New(&config.Exchange)
 	collectors[file.Name] = file.New(&config.File)
 	collectors[fsrmquota.Name] = fsrmquota.New(&config.Fsrmquota)
+	collectors[gpo.Name] = gpo.New(&config.GPO)
 	collectors[gpu.Name] = gpu.New(&config.GPU)
+	collectors[hotfix.Name] = hotfix.New(&config.Hotfix)
 	collectors[hyperv.Name] = hyperv.New(&config.HyperV)
+	collectors[hyperv_vm.Name] = hyperv_vm.New(&config.HypervVM)
 	collectors[iis.Name] = iis.New(&config.IIS)
+	collectors[jobobject.Name] = jobobject.New(&config.JobObject)
 	collectors[license.Name] = license.New(&config.License)
+	collectors[listener_certificate.Name] = listener_certificate.New(&config.ListenerCertificate)
 	collectors[logical_disk.Name] = logical_disk.New(&config.LogicalDisk)
 	collectors[memory.Name] = memory.New(&config.Memory)
 	collectors[mscluster.Name] = mscluster.New(&config.MSCluster)
@@ -130,18 +153,22 @@ func NewWithConfig(config Config) *Collection {
 	collectors[physical_disk.Name] = physical_disk.New(&config.PhysicalDisk)
 	collectors[printer.Name] = printer.New(&config.Printer)
 	collectors[process.Name] = process.New(&config.Process)
+	collectors[rdgateway.Name] = rdgateway.New(&config.RDGateway)
+	collectors[reliability.Name] = reliability.New(&config.Reliability)
 	collectors[remote_fx.Name] = remote_fx.New(&config.RemoteFx)
 	collectors[scheduled_task.Name] = scheduled_task.New(&config.ScheduledTask)
 	collectors[service.Name] = service.New(&config.Service)
 	collectors[smb.Name] = smb.New(&config.SMB)
 	collectors[smbclient.Name] = smbclient.New(&config.SMBClient)
 	collectors[smtp.Name] = smtp.New(&config.SMTP)
+	collectors[storage_qos.Name] = storage_qos.New(&config.StorageQoS)
 	collectors[system.Name] = system.New(&config.System)
 	collectors[tcp.Name] = tcp.New(&config.TCP)
 	collectors[terminal_services.Name] = terminal_services.New(&config.TerminalServices)
 	collectors[textfile.Name] = textfile.New(&config.Textfile)
 	collectors[thermalzone.Name] = thermalzone.New(&config.ThermalZone)
 	collectors[time.Name] = time.New(&config.Time)
+	collectors[tpm.Name] = tpm.New(&config.TPM)
 	collectors[udp.Name] = udp.New(&config.UDP)
 	collectors[update.Name] = update.New(&config.Update)
 	collectors[vmware.Name] = vmware.New(&config.Vmware)
@@ -153,7 +180,9 @@ func NewWithConfig(config Config) *Collection {
 func New(collectors Map) *Collection {
 	return &Collection{
 		collectors:    collectors,
+		available:     maps.Clone(collectors),
 		concurrencyCh: make(chan struct{}, 1),
+		status:        newStatusTracker(),
 		scrapeDurationDesc: prometheus.NewDesc(
 			prometheus.BuildFQName(types.Namespace, "exporter", "scrape_duration_seconds"),
 			"windows_exporter: Total scrape duration.",
@@ -163,13 +192,13 @@ func New(collectors Map) *Collection {
 		collectorScrapeDurationDesc: prometheus.NewDesc(
 			prometheus.BuildFQName(types.Namespace, "exporter", "collector_duration_seconds"),
 			"windows_exporter: Duration of a collection.",
-			[]string{"collector"},
+			[]string{"collector", "source"},
 			nil,
 		),
 		collectorScrapeSuccessDesc: prometheus.NewDesc(
 			prometheus.BuildFQName(types.Namespace, "exporter", "collector_success"),
 			"windows_exporter: Whether the collector was successful.",
-			[]string{"collector"},
+			[]string{"collector", "source"},
 			nil,
 		),
 		collectorScrapeTimeoutDesc: prometheus.NewDesc(
@@ -178,6 +207,18 @@ func New(collectors Map) *Collection {
 			[]string{"collector"},
 			nil,
 		),
+		collectorTruncatedDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "collector_series_truncated"),
+			"windows_exporter: Whether the collector exceeded the series budget and series were dropped.",
+			[]string{"collector"},
+			nil,
+		),
+		counterOverridesAppliedDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(types.Namespace, "exporter", "counter_overrides_applied_total"),
+			"windows_exporter: Number of metric values rescaled by a counter override.",
+			[]string{"collector", "metric"},
+			nil,
+		),
 	}
 }
 
@@ -207,12 +248,35 @@ func (c *Collection) Disable(disabledCollectors []string) {
 	}
 }
 
+// SetMaxSeriesPerCollector limits the number of series a single collector may emit per scrape.
+// Further series are dropped and windows_exporter_collector_series_truncated is set to 1.
+// 0 means unlimited.
+func (c *Collection) SetMaxSeriesPerCollector(maxSeries int) {
+	c.maxSeries = maxSeries
+}
+
+// SetAllocationWarningThreshold logs a warning, if a single collection allocates more than
+// the given number of bytes on the heap. 0 disables the warning.
+func (c *Collection) SetAllocationWarningThreshold(threshold uint64) {
+	c.allocationWarningThreshold = threshold
+}
+
+// SetStateStore enables the state persistence of collectors implementing StatefulCollector.
+// It must be called before Build.
+func (c *Collection) SetStateStore(store *state.Store) {
+	c.stateStore = store
+}
+
 // Build To be called by the exporter for collector initialization.
 // Instead, fail fast, it will try to build all collectors and return all errors.
 // errors are joined with errors.Join.
 func (c *Collection) Build(ctx context.Context, logger *slog.Logger) error {
 	c.startTime = gotime.Now()
 
+	if err := validateNames(c.collectors); err != nil {
+		return err
+	}
+
 	err := c.initMI()
 	if err != nil {
 		return fmt.Errorf("error from initialize MI: %w", err)
@@ -223,13 +287,24 @@ func (c *Collection) Build(ctx context.Context, logger *slog.Logger) error {
 
 	errCh := make(chan error, len(c.collectors))
 
-	for _, collector := range c.collectors {
+	builtMu := sync.Mutex{}
+	built := make(Map, len(c.collectors))
+
+	for name, collector := range c.collectors {
 		go func() {
 			defer wg.Done()
 
 			if err := collector.Build(logger, c.miSession); err != nil {
 				errCh <- fmt.Errorf("error build collector %s: %w", collector.GetName(), err)
+
+				return
 			}
+
+			builtMu.Lock()
+			built[name] = collector
+			builtMu.Unlock()
+
+			c.restoreState(ctx, logger, collector)
 		}()
 	}
 
@@ -237,7 +312,7 @@ func (c *Collection) Build(ctx context.Context, logger *slog.Logger) error {
 
 	close(errCh)
 
-	errs := make([]error, 0, len(c.collectors))
+	errs := make([]error, 0, len(c.collectors)+1)
 
 	for err := range errCh {
 		if errors.Is(err, pdh.ErrNoData) ||
@@ -254,12 +329,79 @@ func (c *Collection) Build(ctx context.Context, logger *slog.Logger) error {
 		errs = append(errs, err)
 	}
 
+	// Collectors without Describe are described by one collection, which reads from the shared query.
+	_ = pdh.CollectSharedQuery()
+
+	if err := validateDescriptors(built); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// restoreState restores the persisted state of the collector, if any.
+// A state which can't be restored is discarded, so that the collector starts from zero.
+func (c *Collection) restoreState(ctx context.Context, logger *slog.Logger, collector Collector) {
+	statefulCollector, ok := collector.(StatefulCollector)
+	if !ok || c.stateStore == nil {
+		return
+	}
+
+	collectorState, ok := c.stateStore.Get(collector.GetName())
+	if !ok {
+		return
+	}
+
+	if err := statefulCollector.RestoreState(collectorState); err != nil {
+		logger.LogAttrs(ctx, slog.LevelWarn, "discarding persisted collector state",
+			slog.String("collector", collector.GetName()),
+			slog.Any("err", err),
+		)
+
+		c.stateStore.Delete(collector.GetName())
+	}
+}
+
+// SaveState writes the state of all collectors implementing StatefulCollector to the state file.
+// It is a no-op, if state persistence is disabled.
+func (c *Collection) SaveState() error {
+	if c.stateStore == nil {
+		return nil
+	}
+
+	errs := make([]error, 0, len(c.collectors))
+
+	for name, collector := range c.collectors {
+		statefulCollector, ok := collector.(StatefulCollector)
+		if !ok {
+			continue
+		}
+
+		collectorState, err := statefulCollector.SaveState()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error from save state of collector %s: %w", name, err))
+
+			continue
+		}
+
+		c.stateStore.Set(name, collectorState)
+	}
+
+	if err := c.stateStore.Save(); err != nil {
+		errs = append(errs, err)
+	}
+
 	return errors.Join(errs...)
 }
 
 // Close To be called by the exporter for collector cleanup.
+// The state of the collectors is saved before the collectors are closed.
 func (c *Collection) Close() error {
-	errs := make([]error, 0, len(c.collectors))
+	errs := make([]error, 0, len(c.collectors)+1)
+
+	if err := c.SaveState(); err != nil {
+		errs = append(errs, fmt.Errorf("error from save collector state: %w", err))
+	}
 
 	for _, collector := range c.collectors {
 		if err := collector.Close(); err != nil {
@@ -267,6 +409,11 @@ func (c *Collection) Close() error {
 		}
 	}
 
+	// The cluster role refresh queries the MI session.
+	if c.clusterRoles != nil {
+		c.clusterRoles.wait()
+	}
+
 	app, err := c.miSession.GetApplication()
 	if err != nil && !errors.Is(err, mi.ErrNotInitialized) {
 		errs = append(errs, fmt.Errorf("error from get MI application: %w", err))
@@ -317,11 +464,21 @@ func (c *Collection) WithCollectors(collectors []string) (*Collection, error) {
 		miSession:                   c.miSession,
 		startTime:                   c.startTime,
 		concurrencyCh:               c.concurrencyCh,
+		status:                      c.status,
 		scrapeDurationDesc:          c.scrapeDurationDesc,
 		collectorScrapeDurationDesc: c.collectorScrapeDurationDesc,
 		collectorScrapeSuccessDesc:  c.collectorScrapeSuccessDesc,
 		collectorScrapeTimeoutDesc:  c.collectorScrapeTimeoutDesc,
+		collectorTruncatedDesc:      c.collectorTruncatedDesc,
+		counterOverridesAppliedDesc: c.counterOverridesAppliedDesc,
+		maxSeries:                   c.maxSeries,
+		allocationWarningThreshold:  c.allocationWarningThreshold,
+		stateStore:                  c.stateStore,
+		counterOverrides:            c.counterOverrides,
+		clusterRoles:                c.clusterRoles,
+		criticalCollectors:          c.criticalCollectors,
 		collectors:                  maps.Clone(c.collectors),
+		available:                   c.available,
 	}
 
 	if err := metricCollectors.Enable(collectors); err != nil {
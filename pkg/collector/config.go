@@ -21,21 +21,30 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/ad"
 	"github.com/prometheus-community/windows_exporter/internal/collector/adcs"
 	"github.com/prometheus-community/windows_exporter/internal/collector/adfs"
+	"github.com/prometheus-community/windows_exporter/internal/collector/branchcache"
 	"github.com/prometheus-community/windows_exporter/internal/collector/cache"
 	"github.com/prometheus-community/windows_exporter/internal/collector/container"
 	"github.com/prometheus-community/windows_exporter/internal/collector/cpu"
 	"github.com/prometheus-community/windows_exporter/internal/collector/cpu_info"
+	"github.com/prometheus-community/windows_exporter/internal/collector/dfsn"
 	"github.com/prometheus-community/windows_exporter/internal/collector/dfsr"
 	"github.com/prometheus-community/windows_exporter/internal/collector/dhcp"
 	"github.com/prometheus-community/windows_exporter/internal/collector/diskdrive"
 	"github.com/prometheus-community/windows_exporter/internal/collector/dns"
+	"github.com/prometheus-community/windows_exporter/internal/collector/dns_client"
+	"github.com/prometheus-community/windows_exporter/internal/collector/etw_latency"
 	"github.com/prometheus-community/windows_exporter/internal/collector/exchange"
 	"github.com/prometheus-community/windows_exporter/internal/collector/file"
 	"github.com/prometheus-community/windows_exporter/internal/collector/fsrmquota"
+	"github.com/prometheus-community/windows_exporter/internal/collector/gpo"
 	"github.com/prometheus-community/windows_exporter/internal/collector/gpu"
+	"github.com/prometheus-community/windows_exporter/internal/collector/hotfix"
 	"github.com/prometheus-community/windows_exporter/internal/collector/hyperv"
+	"github.com/prometheus-community/windows_exporter/internal/collector/hyperv_vm"
 	"github.com/prometheus-community/windows_exporter/internal/collector/iis"
+	"github.com/prometheus-community/windows_exporter/internal/collector/jobobject"
 	"github.com/prometheus-community/windows_exporter/internal/collector/license"
+	"github.com/prometheus-community/windows_exporter/internal/collector/listener_certificate"
 	"github.com/prometheus-community/windows_exporter/internal/collector/logical_disk"
 	"github.com/prometheus-community/windows_exporter/internal/collector/memory"
 	"github.com/prometheus-community/windows_exporter/internal/collector/mscluster"
@@ -50,71 +59,88 @@ import (
 	"github.com/prometheus-community/windows_exporter/internal/collector/physical_disk"
 	"github.com/prometheus-community/windows_exporter/internal/collector/printer"
 	"github.com/prometheus-community/windows_exporter/internal/collector/process"
+	"github.com/prometheus-community/windows_exporter/internal/collector/rdgateway"
+	"github.com/prometheus-community/windows_exporter/internal/collector/reliability"
 	"github.com/prometheus-community/windows_exporter/internal/collector/remote_fx"
 	"github.com/prometheus-community/windows_exporter/internal/collector/scheduled_task"
 	"github.com/prometheus-community/windows_exporter/internal/collector/service"
 	"github.com/prometheus-community/windows_exporter/internal/collector/smb"
 	"github.com/prometheus-community/windows_exporter/internal/collector/smbclient"
 	"github.com/prometheus-community/windows_exporter/internal/collector/smtp"
+	"github.com/prometheus-community/windows_exporter/internal/collector/storage_qos"
 	"github.com/prometheus-community/windows_exporter/internal/collector/system"
 	"github.com/prometheus-community/windows_exporter/internal/collector/tcp"
 	"github.com/prometheus-community/windows_exporter/internal/collector/terminal_services"
 	"github.com/prometheus-community/windows_exporter/internal/collector/textfile"
 	"github.com/prometheus-community/windows_exporter/internal/collector/thermalzone"
 	"github.com/prometheus-community/windows_exporter/internal/collector/time"
+	"github.com/prometheus-community/windows_exporter/internal/collector/tpm"
 	"github.com/prometheus-community/windows_exporter/internal/collector/udp"
 	"github.com/prometheus-community/windows_exporter/internal/collector/update"
 	"github.com/prometheus-community/windows_exporter/internal/collector/vmware"
 )
 
 type Config struct {
-	AD                 ad.Config                 `yaml:"ad"`
-	ADCS               adcs.Config               `yaml:"adcs"`
-	ADFS               adfs.Config               `yaml:"adfs"`
-	Cache              cache.Config              `yaml:"cache"`
-	Container          container.Config          `yaml:"container"`
-	CPU                cpu.Config                `yaml:"cpu"`
-	CPUInfo            cpu_info.Config           `yaml:"cpu_info"`
-	DFSR               dfsr.Config               `yaml:"dfsr"`
-	Dhcp               dhcp.Config               `yaml:"dhcp"`
-	DiskDrive          diskdrive.Config          `yaml:"diskdrive"`
-	DNS                dns.Config                `yaml:"dns"`
-	Exchange           exchange.Config           `yaml:"exchange"`
-	File               file.Config               `yaml:"file"`
-	Fsrmquota          fsrmquota.Config          `yaml:"fsrmquota"`
-	GPU                gpu.Config                `yaml:"gpu"`
-	HyperV             hyperv.Config             `yaml:"hyperv"`
-	IIS                iis.Config                `yaml:"iis"`
-	License            license.Config            `yaml:"license"`
-	LogicalDisk        logical_disk.Config       `yaml:"logical_disk"`
-	Memory             memory.Config             `yaml:"memory"`
-	MSCluster          mscluster.Config          `yaml:"mscluster"`
-	Msmq               msmq.Config               `yaml:"msmq"`
-	Mssql              mssql.Config              `yaml:"mssql"`
-	Net                net.Config                `yaml:"net"`
-	NetFramework       netframework.Config       `yaml:"netframework"`
-	Nps                nps.Config                `yaml:"nps"`
-	OS                 os.Config                 `yaml:"os"`
-	Paging             pagefile.Config           `yaml:"paging"`
-	PerformanceCounter performancecounter.Config `yaml:"performancecounter"`
-	PhysicalDisk       physical_disk.Config      `yaml:"physical_disk"`
-	Printer            printer.Config            `yaml:"printer"`
-	Process            process.Config            `yaml:"process"`
-	RemoteFx           remote_fx.Config          `yaml:"remote_fx"`
-	ScheduledTask      scheduled_task.Config     `yaml:"scheduled_task"`
-	Service            service.Config            `yaml:"service"`
-	SMB                smb.Config                `yaml:"smb"`
-	SMBClient          smbclient.Config          `yaml:"smb_client"`
-	SMTP               smtp.Config               `yaml:"smtp"`
-	System             system.Config             `yaml:"system"`
-	TCP                tcp.Config                `yaml:"tcp"`
-	TerminalServices   terminal_services.Config  `yaml:"terminal_services"`
-	Textfile           textfile.Config           `yaml:"textfile"`
-	ThermalZone        thermalzone.Config        `yaml:"thermalzone"`
-	Time               time.Config               `yaml:"time"`
-	UDP                udp.Config                `yaml:"udp"`
-	Update             update.Config             `yaml:"update"`
-	Vmware             vmware.Config             `yaml:"vmware"`
+	AD                  ad.Config                   `yaml:"ad"`
+	ADCS                adcs.Config                 `yaml:"adcs"`
+	ADFS                adfs.Config                 `yaml:"adfs"`
+	BranchCache         branchcache.Config          `yaml:"branchcache"`
+	Cache               cache.Config                `yaml:"cache"`
+	Container           container.Config            `yaml:"container"`
+	CPU                 cpu.Config                  `yaml:"cpu"`
+	CPUInfo             cpu_info.Config             `yaml:"cpu_info"`
+	DFSN                dfsn.Config                 `yaml:"dfsn"`
+	DFSR                dfsr.Config                 `yaml:"dfsr"`
+	Dhcp                dhcp.Config                 `yaml:"dhcp"`
+	DiskDrive           diskdrive.Config            `yaml:"diskdrive"`
+	DNS                 dns.Config                  `yaml:"dns"`
+	DNSClient           dns_client.Config           `yaml:"dns_client"`
+	EtwLatency          etw_latency.Config          `yaml:"etw_latency"`
+	Exchange            exchange.Config             `yaml:"exchange"`
+	File                file.Config                 `yaml:"file"`
+	Fsrmquota           fsrmquota.Config            `yaml:"fsrmquota"`
+	GPO                 gpo.Config                  `yaml:"gpo"`
+	GPU                 gpu.Config                  `yaml:"gpu"`
+	Hotfix              hotfix.Config               `yaml:"hotfix"`
+	HyperV              hyperv.Config               `yaml:"hyperv"`
+	HypervVM            hyperv_vm.Config            `yaml:"hyperv_vm"`
+	IIS                 iis.Config                  `yaml:"iis"`
+	JobObject           jobobject.Config            `yaml:"jobobject"`
+	License             license.Config              `yaml:"license"`
+	ListenerCertificate listener_certificate.Config `yaml:"listener_certificate"`
+	LogicalDisk         logical_disk.Config         `yaml:"logical_disk"`
+	Memory              memory.Config               `yaml:"memory"`
+	MSCluster           mscluster.Config            `yaml:"mscluster"`
+	Msmq                msmq.Config                 `yaml:"msmq"`
+	Mssql               mssql.Config                `yaml:"mssql"`
+	Net                 net.Config                  `yaml:"net"`
+	NetFramework        netframework.Config         `yaml:"netframework"`
+	Nps                 nps.Config                  `yaml:"nps"`
+	OS                  os.Config                   `yaml:"os"`
+	Paging              pagefile.Config             `yaml:"paging"`
+	PerformanceCounter  performancecounter.Config   `yaml:"performancecounter"`
+	PhysicalDisk        physical_disk.Config        `yaml:"physical_disk"`
+	Printer             printer.Config              `yaml:"printer"`
+	Process             process.Config              `yaml:"process"`
+	RDGateway           rdgateway.Config            `yaml:"rdgateway"`
+	Reliability         reliability.Config          `yaml:"reliability"`
+	RemoteFx            remote_fx.Config            `yaml:"remote_fx"`
+	ScheduledTask       scheduled_task.Config       `yaml:"scheduled_task"`
+	Service             service.Config              `yaml:"service"`
+	SMB                 smb.Config                  `yaml:"smb"`
+	SMBClient           smbclient.Config            `yaml:"smb_client"`
+	SMTP                smtp.Config                 `yaml:"smtp"`
+	StorageQoS          storage_qos.Config          `yaml:"storage_qos"`
+	System              system.Config               `yaml:"system"`
+	TCP                 tcp.Config                  `yaml:"tcp"`
+	TerminalServices    terminal_services.Config    `yaml:"terminal_services"`
+	Textfile            textfile.Config             `yaml:"textfile"`
+	ThermalZone         thermalzone.Config          `yaml:"thermalzone"`
+	Time                time.Config                 `yaml:"time"`
+	TPM                 tpm.Config                  `yaml:"tpm"`
+	UDP                 udp.Config                  `yaml:"udp"`
+	Update              update.Config               `yaml:"update"`
+	Vmware              vmware.Config               `yaml:"vmware"`
 }
 
 // ConfigDefaults Is an interface to be used by the external libraries. It holds all ConfigDefaults form all collectors
@@ -122,51 +148,64 @@ type Config struct {
 //nolint:gochecknoglobals
 //goland:noinspection GoUnusedGlobalVariable
 var ConfigDefaults = Config{
-	AD:                 ad.ConfigDefaults,
-	ADCS:               adcs.ConfigDefaults,
-	ADFS:               adfs.ConfigDefaults,
-	Cache:              cache.ConfigDefaults,
-	Container:          container.ConfigDefaults,
-	CPU:                cpu.ConfigDefaults,
-	CPUInfo:            cpu_info.ConfigDefaults,
-	DFSR:               dfsr.ConfigDefaults,
-	Dhcp:               dhcp.ConfigDefaults,
-	DiskDrive:          diskdrive.ConfigDefaults,
-	DNS:                dns.ConfigDefaults,
-	Exchange:           exchange.ConfigDefaults,
-	File:               file.ConfigDefaults,
-	Fsrmquota:          fsrmquota.ConfigDefaults,
-	GPU:                gpu.ConfigDefaults,
-	HyperV:             hyperv.ConfigDefaults,
-	IIS:                iis.ConfigDefaults,
-	License:            license.ConfigDefaults,
-	LogicalDisk:        logical_disk.ConfigDefaults,
-	Memory:             memory.ConfigDefaults,
-	MSCluster:          mscluster.ConfigDefaults,
-	Msmq:               msmq.ConfigDefaults,
-	Mssql:              mssql.ConfigDefaults,
-	Net:                net.ConfigDefaults,
-	NetFramework:       netframework.ConfigDefaults,
-	Nps:                nps.ConfigDefaults,
-	OS:                 os.ConfigDefaults,
-	Paging:             pagefile.ConfigDefaults,
-	PerformanceCounter: performancecounter.ConfigDefaults,
-	PhysicalDisk:       physical_disk.ConfigDefaults,
-	Printer:            printer.ConfigDefaults,
-	Process:            process.ConfigDefaults,
-	RemoteFx:           remote_fx.ConfigDefaults,
-	ScheduledTask:      scheduled_task.ConfigDefaults,
-	Service:            service.ConfigDefaults,
-	SMB:                smb.ConfigDefaults,
-	SMBClient:          smbclient.ConfigDefaults,
-	SMTP:               smtp.ConfigDefaults,
-	System:             system.ConfigDefaults,
-	TCP:                tcp.ConfigDefaults,
-	TerminalServices:   terminal_services.ConfigDefaults,
-	Textfile:           textfile.ConfigDefaults,
-	ThermalZone:        thermalzone.ConfigDefaults,
-	Time:               time.ConfigDefaults,
-	UDP:                udp.ConfigDefaults,
-	Update:             update.ConfigDefaults,
-	Vmware:             vmware.ConfigDefaults,
+	AD:                  ad.ConfigDefaults,
+	ADCS:                adcs.ConfigDefaults,
+	ADFS:                adfs.ConfigDefaults,
+	BranchCache:         branchcache.ConfigDefaults,
+	Cache:               cache.ConfigDefaults,
+	Container:           container.ConfigDefaults,
+	CPU:                 cpu.ConfigDefaults,
+	CPUInfo:             cpu_info.ConfigDefaults,
+	DFSN:                dfsn.ConfigDefaults,
+	DFSR:                dfsr.ConfigDefaults,
+	Dhcp:                dhcp.ConfigDefaults,
+	DiskDrive:           diskdrive.ConfigDefaults,
+	DNS:                 dns.ConfigDefaults,
+	DNSClient:           dns_client.ConfigDefaults,
+	EtwLatency:          etw_latency.ConfigDefaults,
+	Exchange:            exchange.ConfigDefaults,
+	File:                file.ConfigDefaults,
+	Fsrmquota:           fsrmquota.ConfigDefaults,
+	GPO:                 gpo.ConfigDefaults,
+	GPU:                 gpu.ConfigDefaults,
+	Hotfix:              hotfix.ConfigDefaults,
+	HyperV:              hyperv.ConfigDefaults,
+	HypervVM:            hyperv_vm.ConfigDefaults,
+	IIS:                 iis.ConfigDefaults,
+	JobObject:           jobobject.ConfigDefaults,
+	License:             license.ConfigDefaults,
+	ListenerCertificate: listener_certificate.ConfigDefaults,
+	LogicalDisk:         logical_disk.ConfigDefaults,
+	Memory:              memory.ConfigDefaults,
+	MSCluster:           mscluster.ConfigDefaults,
+	Msmq:                msmq.ConfigDefaults,
+	Mssql:               mssql.ConfigDefaults,
+	Net:                 net.ConfigDefaults,
+	NetFramework:        netframework.ConfigDefaults,
+	Nps:                 nps.ConfigDefaults,
+	OS:                  os.ConfigDefaults,
+	Paging:              pagefile.ConfigDefaults,
+	PerformanceCounter:  performancecounter.ConfigDefaults,
+	PhysicalDisk:        physical_disk.ConfigDefaults,
+	Printer:             printer.ConfigDefaults,
+	Process:             process.ConfigDefaults,
+	RDGateway:           rdgateway.ConfigDefaults,
+	Reliability:         reliability.ConfigDefaults,
+	RemoteFx:            remote_fx.ConfigDefaults,
+	ScheduledTask:       scheduled_task.ConfigDefaults,
+	Service:             service.ConfigDefaults,
+	SMB:                 smb.ConfigDefaults,
+	SMBClient:           smbclient.ConfigDefaults,
+	SMTP:                smtp.ConfigDefaults,
+	StorageQoS:          storage_qos.ConfigDefaults,
+	System:              system.ConfigDefaults,
+	TCP:                 tcp.ConfigDefaults,
+	TerminalServices:    terminal_services.ConfigDefaults,
+	Textfile:            textfile.ConfigDefaults,
+	ThermalZone:         thermalzone.ConfigDefaults,
+	Time:                time.ConfigDefaults,
+	TPM:                 tpm.ConfigDefaults,
+	UDP:                 udp.ConfigDefaults,
+	Update:              update.ConfigDefaults,
+	Vmware:              vmware.ConfigDefaults,
 }
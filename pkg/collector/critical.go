@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrCriticalCollectorFailed is returned by a collection, if a critical collector failed or timed out.
+var ErrCriticalCollectorFailed = errors.New("critical collector failed")
+
+// SetCriticalCollectors marks the given collectors as critical. If any of them fails or times out,
+// the collection returns ErrCriticalCollectorFailed, and the scrape fails as a whole.
+// All critical collectors must be enabled.
+func (c *Collection) SetCriticalCollectors(collectors []string) error {
+	for _, name := range collectors {
+		if _, ok := c.collectors[name]; !ok {
+			return fmt.Errorf("critical collector %s is not enabled", name)
+		}
+	}
+
+	c.criticalCollectors = collectors
+
+	return nil
+}
+
+// criticalError returns ErrCriticalCollectorFailed listing all critical collectors,
+// which did not collect successfully. It returns nil, if all critical collectors succeeded.
+func criticalError(statuses []collectorStatus, criticalCollectors []string) error {
+	var failedCollectors []string
+
+	for _, status := range statuses {
+		if status.statusCode != success && slices.Contains(criticalCollectors, status.name) {
+			failedCollectors = append(failedCollectors, status.name)
+		}
+	}
+
+	if len(failedCollectors) == 0 {
+		return nil
+	}
+
+	slices.Sort(failedCollectors)
+
+	return fmt.Errorf("%w: %s", ErrCriticalCollectorFailed, strings.Join(failedCollectors, ", "))
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// failingCollector returns err from Collect.
+type failingCollector struct {
+	name string
+	err  error
+}
+
+func (c *failingCollector) GetName() string { return c.name }
+
+func (c *failingCollector) Build(*slog.Logger, *mi.Session) error { return nil }
+
+func (c *failingCollector) Close() error { return nil }
+
+func (c *failingCollector) Collect(_ chan<- prometheus.Metric, _ time.Duration) error {
+	return c.err
+}
+
+func TestCriticalError(t *testing.T) {
+	t.Parallel()
+
+	statuses := []collectorStatus{
+		{name: "cpu", statusCode: success},
+		{name: "memory", statusCode: failed},
+		{name: "net", statusCode: pending},
+		{name: "os", statusCode: failed},
+	}
+
+	for _, tc := range []struct {
+		name               string
+		criticalCollectors []string
+		err                string
+	}{
+		{"no critical collectors", nil, ""},
+		{"critical collector succeeded", []string{"cpu"}, ""},
+		{"critical collector failed", []string{"cpu", "memory"}, "critical collector failed: memory"},
+		{"critical collector timed out", []string{"net"}, "critical collector failed: net"},
+		{"multiple critical collectors failed", []string{"os", "net", "memory", "cpu"}, "critical collector failed: memory, net, os"},
+		{"critical collector not scraped", []string{"service"}, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := criticalError(statuses, tc.criticalCollectors)
+			if tc.err == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, ErrCriticalCollectorFailed)
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestSetCriticalCollectors(t *testing.T) {
+	t.Parallel()
+
+	collection := New(Map{
+		"cpu":    &failingCollector{name: "cpu"},
+		"memory": &failingCollector{name: "memory"},
+	})
+
+	require.NoError(t, collection.SetCriticalCollectors([]string{"cpu"}))
+	require.EqualError(t, collection.SetCriticalCollectors([]string{"cpu", "net"}), "critical collector net is not enabled")
+}
+
+func TestHandlerCriticalCollectors(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name               string
+		criticalCollectors []string
+		collect            []string
+		wantErr            bool
+	}{
+		{"non-critical collector failed", []string{"cpu"}, nil, false},
+		{"critical collector failed", []string{"memory"}, nil, true},
+		{"failed critical collector not requested", []string{"memory"}, []string{"cpu"}, false},
+		{"failed critical collector requested", []string{"cpu", "memory"}, []string{"cpu", "memory"}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			collection := New(Map{
+				"cpu":    &failingCollector{name: "cpu"},
+				"memory": &failingCollector{name: "memory", err: errors.New("query failed")},
+			})
+
+			require.NoError(t, collection.SetCriticalCollectors(tc.criticalCollectors))
+
+			handler, err := collection.NewHandler(30*time.Second, slog.New(slog.DiscardHandler), tc.collect)
+			require.NoError(t, err)
+
+			reg := prometheus.NewRegistry()
+			require.NoError(t, reg.Register(handler))
+
+			_, err = reg.Gather()
+			require.NoError(t, err)
+
+			if tc.wantErr {
+				require.ErrorIs(t, handler.Err(), ErrCriticalCollectorFailed)
+			} else {
+				require.NoError(t, handler.Err())
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/pdh"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// ErrUnknownCollector is returned if a collector name is not known to the Collection.
+	ErrUnknownCollector = errors.New("unknown collector")
+	// ErrEstimateRunning is returned if the collection of a previous estimate is still running.
+	ErrEstimateRunning = errors.New("the collection of a previous estimate is still running")
+)
+
+// estimateRunning is set while the collection of an estimate runs, including a collection
+// abandoned after the timeout. Dry-run collections are serialized, since disabled collectors are built on demand.
+//
+//nolint:gochecknoglobals
+var estimateRunning atomic.Bool
+
+// Estimate is the result of a dry-run collection of a single collector.
+type Estimate struct {
+	Collector string `json:"collector"`
+	// Series is the number of series the collector emitted.
+	Series int `json:"series"`
+	// LabelsCardinalityByMetric is the number of distinct values per label, keyed by metric name.
+	LabelsCardinalityByMetric map[string]map[string]int `json:"labels_cardinality_by_metric"`
+	DurationMS                int64                     `json:"duration_ms"`
+}
+
+// metricsReplayer is an unchecked [prometheus.Collector] that sends previously collected metrics.
+type metricsReplayer []prometheus.Metric
+
+func (r metricsReplayer) Describe(_ chan<- *prometheus.Desc) {}
+
+func (r metricsReplayer) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range r {
+		ch <- m
+	}
+}
+
+// Estimate runs a single collection of the named collector and counts the emitted series.
+// The collector does not need to be enabled. Disabled collectors are built before
+// and closed after the collection. The metrics are not exposed on the metrics endpoint.
+// ErrEstimateRunning is returned, while the collection of a previous estimate is still running.
+func (c *Collection) Estimate(ctx context.Context, logger *slog.Logger, name string, timeout time.Duration) (Estimate, error) {
+	metricsCollector, ok := c.available[name]
+	if !ok {
+		return Estimate{}, fmt.Errorf("%w: %s", ErrUnknownCollector, name)
+	}
+
+	_, enabled := c.collectors[name]
+
+	if !estimateRunning.CompareAndSwap(false, true) {
+		return Estimate{}, ErrEstimateRunning
+	}
+
+	if enabled {
+		// Enabled collectors are already built and may be scraped at the same time. Like a scrape,
+		// the lock is released on return, even if the collection is abandoned after the timeout.
+		concurrencyMu.Lock()
+		defer concurrencyMu.Unlock()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	startTime := time.Now()
+	resultCh := make(chan error, 1)
+	bufCh := make(chan prometheus.Metric, 1000)
+
+	go func() {
+		defer estimateRunning.Store(false)
+		defer close(bufCh)
+
+		if enabled {
+			resultCh <- metricsCollector.Collect(bufCh, timeout)
+
+			return
+		}
+
+		// The disabled collector is built with its own performance counter query, so that the
+		// shared query collected by the scrapes is not changed.
+		resultCh <- pdh.WithIsolatedQuery(func() error {
+			if err := metricsCollector.Build(logger, c.miSession); err != nil {
+				return fmt.Errorf("failed to build collector %s: %w", name, err)
+			}
+
+			err := metricsCollector.Collect(bufCh, timeout)
+
+			if closeErr := metricsCollector.Close(); closeErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to close collector %s: %w", name, closeErr))
+			}
+
+			return err
+		})
+	}()
+
+	metrics := make(metricsReplayer, 0, 1000)
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			go func() {
+				// Drain channel in case of premature return to not leak a goroutine.
+				for range bufCh {
+				}
+			}()
+
+			return Estimate{}, fmt.Errorf("collector %s timed out after %s: %w", name, timeout, ctx.Err())
+		case m, ok := <-bufCh:
+			if !ok {
+				break loop
+			}
+
+			metrics = append(metrics, m)
+		}
+	}
+
+	if err := <-resultCh; err != nil {
+		logger.LogAttrs(ctx, slog.LevelDebug, "estimate collection finished with errors",
+			slog.String("collector", name),
+			slog.Any("err", err),
+		)
+	}
+
+	duration := time.Since(startTime)
+
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(metrics); err != nil {
+		return Estimate{}, fmt.Errorf("failed to register collected metrics: %w", err)
+	}
+
+	metricFamilies, err := reg.Gather()
+	if err != nil {
+		return Estimate{}, fmt.Errorf("failed to gather collected metrics: %w", err)
+	}
+
+	estimate := Estimate{
+		Collector:                 name,
+		LabelsCardinalityByMetric: make(map[string]map[string]int, len(metricFamilies)),
+		DurationMS:                duration.Milliseconds(),
+	}
+
+	for _, metricFamily := range metricFamilies {
+		labelValues := make(map[string]map[string]struct{})
+
+		for _, metric := range metricFamily.GetMetric() {
+			estimate.Series++
+
+			for _, label := range metric.GetLabel() {
+				if _, ok := labelValues[label.GetName()]; !ok {
+					labelValues[label.GetName()] = make(map[string]struct{})
+				}
+
+				labelValues[label.GetName()][label.GetValue()] = struct{}{}
+			}
+		}
+
+		cardinality := make(map[string]int, len(labelValues))
+		for label, values := range labelValues {
+			cardinality[label] = len(values)
+		}
+
+		estimate.LabelsCardinalityByMetric[metricFamily.GetName()] = cardinality
+	}
+
+	return estimate, nil
+}
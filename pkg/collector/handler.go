@@ -40,6 +40,10 @@ type Handler struct {
 	maxScrapeDuration time.Duration
 	logger            *slog.Logger
 	collection        *Collection
+
+	mu sync.Mutex
+	// err is the result of the last collection.
+	err error
 }
 
 // NewHandler returns a new Handler that implements a [prometheus.Collector] for the given metrics Collection.
@@ -68,6 +72,19 @@ func (p *Handler) Describe(_ chan<- *prometheus.Desc) {}
 // prometheus.
 func (p *Handler) Collect(ch chan<- prometheus.Metric) {
 	concurrencyMu.Lock()
-	p.collection.collectAll(ch, p.logger, p.maxScrapeDuration)
+	err := p.collection.collectAll(ch, p.logger, p.maxScrapeDuration)
 	concurrencyMu.Unlock()
+
+	p.mu.Lock()
+	p.err = err
+	p.mu.Unlock()
+}
+
+// Err returns the error of the last collection. It wraps ErrCriticalCollectorFailed,
+// if a critical collector failed or timed out.
+func (p *Handler) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.err
 }
@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// CounterOverrides contains the counter overrides keyed by collector name and metric name.
+type CounterOverrides map[string]map[string]CounterOverride
+
+// CounterOverride rescales the values of a metric, e.g. to work around
+// performance counter providers reporting values in the wrong unit.
+type CounterOverride struct {
+	// Multiplier is applied to the value. Defaults to 1.
+	Multiplier *float64 `yaml:"multiplier"`
+	// Min clamps the scaled value to a lower bound.
+	Min *float64 `yaml:"min"`
+	// Max clamps the scaled value to an upper bound.
+	Max *float64 `yaml:"max"`
+}
+
+// counterOverride is a CounterOverride of a metric emitted by the collector.
+type counterOverride struct {
+	CounterOverride
+
+	collector string
+	metric    string
+	applied   atomic.Uint64
+}
+
+func (o *counterOverride) apply(value float64) float64 {
+	if o.Multiplier != nil {
+		value *= *o.Multiplier
+	}
+
+	if o.Min != nil {
+		value = max(value, *o.Min)
+	}
+
+	if o.Max != nil {
+		value = min(value, *o.Max)
+	}
+
+	return value
+}
+
+// overriddenMetric wraps a metric and applies a counter override to gauge, counter and untyped values.
+type overriddenMetric struct {
+	prometheus.Metric
+
+	override *counterOverride
+}
+
+func (m overriddenMetric) Write(out *dto.Metric) error {
+	if err := m.Metric.Write(out); err != nil {
+		return err
+	}
+
+	switch {
+	case out.Gauge != nil:
+		out.Gauge.Value = new(m.override.apply(out.GetGauge().GetValue()))
+	case out.Counter != nil:
+		out.Counter.Value = new(m.override.apply(out.GetCounter().GetValue()))
+	case out.Untyped != nil:
+		out.Untyped.Value = new(m.override.apply(out.GetUntyped().GetValue()))
+	default:
+		return nil
+	}
+
+	m.override.applied.Add(1)
+
+	return nil
+}
+
+// SetCounterOverrides configures counter overrides. It must be called after Build, since the
+// metric names are validated against the descriptors of the enabled collectors.
+func (c *Collection) SetCounterOverrides(overrides CounterOverrides) error {
+	resolved := make(map[string]map[string]*counterOverride)
+	errs := make([]error, 0)
+
+	for _, collectorName := range slices.Sorted(maps.Keys(overrides)) {
+		metricsCollector, ok := c.collectors[collectorName]
+		if !ok {
+			errs = append(errs, fmt.Errorf("counter override for collector %s: collector is not enabled", collectorName))
+
+			continue
+		}
+
+		metricNames := make(map[string]struct{})
+		for _, desc := range describe(metricsCollector) {
+			metricNames[descName(desc)] = struct{}{}
+		}
+
+		resolved[collectorName] = make(map[string]*counterOverride)
+
+		for _, metricName := range slices.Sorted(maps.Keys(overrides[collectorName])) {
+			override := overrides[collectorName][metricName]
+
+			if override.Min != nil && override.Max != nil && *override.Min > *override.Max {
+				errs = append(errs, fmt.Errorf("counter override for metric %s: min is greater than max", metricName))
+
+				continue
+			}
+
+			if _, ok := metricNames[metricName]; !ok {
+				errs = append(errs, fmt.Errorf("counter override for metric %s: metric does not exist in collector %s", metricName, collectorName))
+
+				continue
+			}
+
+			resolved[collectorName][metricName] = &counterOverride{
+				CounterOverride: override,
+				collector:       collectorName,
+				metric:          metricName,
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	c.counterOverrides = resolved
+
+	return nil
+}
+
+// applyCounterOverride wraps the metric of the collector, if a counter override is configured for it.
+func (c *Collection) applyCounterOverride(name string, m prometheus.Metric) prometheus.Metric {
+	overrides, ok := c.counterOverrides[name]
+	if !ok {
+		return m
+	}
+
+	override, ok := overrides[descName(m.Desc())]
+	if !ok {
+		return m
+	}
+
+	return overriddenMetric{Metric: m, override: override}
+}
+
+func (c *Collection) collectCounterOverrides(ch chan<- prometheus.Metric) {
+	for _, override := range c.allCounterOverrides() {
+		ch <- prometheus.MustNewConstMetric(
+			c.counterOverridesAppliedDesc,
+			prometheus.CounterValue,
+			float64(override.applied.Load()),
+			override.collector,
+			override.metric,
+		)
+	}
+}
+
+// allCounterOverrides returns the counter overrides of all collectors.
+func (c *Collection) allCounterOverrides() []*counterOverride {
+	all := make([]*counterOverride, 0)
+
+	for _, overrides := range c.counterOverrides {
+		for _, override := range overrides {
+			all = append(all, override)
+		}
+	}
+
+	return all
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCounterOverridesValidation(t *testing.T) {
+	t.Parallel()
+
+	synthetic := &syntheticCollector{
+		series: 1,
+		desc:   prometheus.NewDesc("windows_synthetic_series", "Synthetic metric.", []string{"id"}, nil),
+	}
+
+	collection := New(Map{synthetic.GetName(): synthetic})
+
+	require.NoError(t, collection.SetCounterOverrides(CounterOverrides{
+		"synthetic": {"windows_synthetic_series": {Multiplier: new(2.0)}},
+	}))
+
+	require.ErrorContains(t, collection.SetCounterOverrides(CounterOverrides{
+		"synthetic": {"windows_synthetic_unknown": {Multiplier: new(2.0)}},
+	}), "metric does not exist")
+
+	require.ErrorContains(t, collection.SetCounterOverrides(CounterOverrides{
+		"cpu": {"windows_cpu_time_total": {Multiplier: new(2.0)}},
+	}), "collector is not enabled")
+
+	require.ErrorContains(t, collection.SetCounterOverrides(CounterOverrides{
+		"synthetic": {"windows_synthetic_series": {Min: new(10.0), Max: new(1.0)}},
+	}), "min is greater than max")
+}
+
+func TestCounterOverridesApplied(t *testing.T) {
+	t.Parallel()
+
+	synthetic := &syntheticCollector{
+		series: 10,
+		desc:   prometheus.NewDesc("windows_synthetic_series", "Synthetic metric.", []string{"id"}, nil),
+	}
+
+	collection := New(Map{synthetic.GetName(): synthetic})
+
+	require.NoError(t, collection.SetCounterOverrides(CounterOverrides{
+		"synthetic": {"windows_synthetic_series": {Multiplier: new(0.5), Max: new(3.0)}},
+	}))
+
+	ch := make(chan prometheus.Metric, 100)
+
+	_ = collection.collectAll(ch, slog.New(slog.DiscardHandler), 30*time.Second)
+	close(ch)
+
+	values := make([]float64, 0, synthetic.series)
+
+	for m := range ch {
+		if m.Desc() != synthetic.desc {
+			continue
+		}
+
+		var metric dto.Metric
+
+		require.NoError(t, m.Write(&metric))
+
+		values = append(values, metric.GetGauge().GetValue())
+	}
+
+	require.Equal(t, []float64{0, 0.5, 1, 1.5, 2, 2.5, 3, 3, 3, 3}, values)
+
+	for _, override := range collection.allCounterOverrides() {
+		require.Equal(t, uint64(10), override.applied.Load())
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// CollectorStatus is the result of the last collection of a single collector.
+// All fields except Name are zero if the collector has not been scraped yet.
+type CollectorStatus struct {
+	Name string `json:"name"`
+	// LastScrape is the start time of the last collection.
+	LastScrape time.Time `json:"last_scrape"`
+	// LastSuccess is the start time of the last successful collection.
+	LastSuccess time.Time     `json:"last_success"`
+	Duration    time.Duration `json:"duration"`
+	// Error is the error message of the last collection, if it failed or timed out.
+	Error string `json:"error,omitempty"`
+}
+
+// statusTracker records the last collection result of each collector.
+// It is shared between all Collections derived via WithCollectors.
+type statusTracker struct {
+	mu       sync.Mutex
+	statuses map[string]CollectorStatus
+}
+
+func newStatusTracker() *statusTracker {
+	return &statusTracker{
+		statuses: make(map[string]CollectorStatus),
+	}
+}
+
+func (s *statusTracker) record(name string, start time.Time, duration time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := s.statuses[name]
+	status.Name = name
+	status.LastScrape = start
+	status.Duration = duration
+	status.Error = ""
+
+	if err != nil {
+		status.Error = err.Error()
+	} else {
+		status.LastSuccess = start
+	}
+
+	s.statuses[name] = status
+}
+
+// Status returns the last collection result of all enabled collectors, sorted by name.
+func (c *Collection) Status() []CollectorStatus {
+	c.status.mu.Lock()
+	defer c.status.mu.Unlock()
+
+	statuses := make([]CollectorStatus, 0, len(c.collectors))
+
+	for _, name := range slices.Sorted(maps.Keys(c.collectors)) {
+		status, ok := c.status.statuses[name]
+		if !ok {
+			status = CollectorStatus{Name: name}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
@@ -18,26 +18,45 @@
 package collector
 
 import (
+	"encoding/json"
 	"log/slog"
 	"time"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus-community/windows_exporter/internal/state"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
 const DefaultCollectors = "cpu,memory,logical_disk,physical_disk,net,os,service,system"
 
 type Collection struct {
-	collectors    Map
+	collectors Map
+	// available contains all known collectors, including disabled ones.
+	available     Map
 	miSession     *mi.Session
 	startTime     time.Time
 	concurrencyCh chan struct{}
+	status        *statusTracker
+	// maxSeries is the maximum number of series per collector and scrape. 0 means unlimited.
+	maxSeries int
+	// allocationWarningThreshold is the number of bytes a collection may allocate before a warning is logged. 0 means disabled.
+	allocationWarningThreshold uint64
+	// stateStore persists the state of StatefulCollector implementations. nil means disabled.
+	stateStore *state.Store
+	// counterOverrides rescales the values of metrics, keyed by collector name and metric name.
+	counterOverrides map[string]map[string]*counterOverride
+	// clusterRoles adds the cluster_role label to metrics of clustered resources. nil means disabled.
+	clusterRoles *clusterRoles
+	// criticalCollectors fail the whole scrape, if one of them fails.
+	criticalCollectors []string
 
 	scrapeDurationDesc          *prometheus.Desc
 	collectorScrapeDurationDesc *prometheus.Desc
 	collectorScrapeSuccessDesc  *prometheus.Desc
 	collectorScrapeTimeoutDesc  *prometheus.Desc
+	collectorTruncatedDesc      *prometheus.Desc
+	counterOverridesAppliedDesc *prometheus.Desc
 }
 
 type (
@@ -56,3 +75,20 @@ type Collector interface {
 	// Close closes the collector
 	Close() error
 }
+
+// SourceCollector is an optional interface for collectors to declare the data sources they read from,
+// e.g. pdh, wmi or api. The sources are exposed as source label of the collector metrics.
+type SourceCollector interface {
+	// GetSources returns the data sources of the collector.
+	GetSources() []string
+}
+
+// StatefulCollector is an optional interface for collectors which maintain counters inside the exporter process,
+// e.g. counts of events. If state persistence is enabled, the state is restored on startup,
+// so that the counters do not reset when the exporter restarts.
+type StatefulCollector interface {
+	// SaveState returns the current state of the collector. It may be called concurrently with Collect.
+	SaveState() (json.RawMessage, error)
+	// RestoreState restores a state returned by SaveState of a previous exporter process. It is called after Build.
+	RestoreState(state json.RawMessage) error
+}
@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// describeTimeout is the timeout of the collection, which describes a collector without Describe.
+const describeTimeout = 10 * time.Second
+
+// DescribeCollector is an optional interface for collectors to describe the metrics they emit.
+// The descriptors are compared with the descriptors of all other collectors on Build,
+// so that two collectors emitting the same metric fail the startup instead of the scrape.
+// Collectors without Describe are described by the metrics of one collection.
+type DescribeCollector interface {
+	// Describe sends the descriptors of all metrics of the collector. It is called after Build.
+	Describe(ch chan<- *prometheus.Desc)
+}
+
+// validateNames returns an error if a collector is registered under a name that differs from its own name,
+// e.g. if two implementations of the same collector are registered.
+func validateNames(collectors Map) error {
+	names := make(map[string]string, len(collectors))
+
+	for _, key := range slices.Sorted(maps.Keys(collectors)) {
+		name := collectors[key].GetName()
+
+		if other, ok := names[name]; ok {
+			return fmt.Errorf("collectors %s and %s are both named %s", other, key, name)
+		}
+
+		if name != key {
+			return fmt.Errorf("collector %s is registered as %s", name, key)
+		}
+
+		names[name] = key
+	}
+
+	return nil
+}
+
+// validateDescriptors returns an error naming both collectors and the metric, if two collectors
+// emit metrics with the same name, even if the help or labels differ.
+func validateDescriptors(collectors Map) error {
+	descs := make(map[string][]*prometheus.Desc, len(collectors))
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for name, metricsCollector := range collectors {
+		wg.Go(func() {
+			collectorDescs := describe(metricsCollector)
+
+			mu.Lock()
+			descs[name] = collectorDescs
+			mu.Unlock()
+		})
+	}
+
+	wg.Wait()
+
+	owners := make(map[string]string)
+
+	for _, name := range slices.Sorted(maps.Keys(collectors)) {
+		for _, desc := range descs[name] {
+			metricName := descName(desc)
+
+			if other, ok := owners[metricName]; ok && other != name {
+				return fmt.Errorf("collectors %s and %s emit conflicting metric %s", other, name, desc)
+			}
+
+			owners[metricName] = name
+		}
+	}
+
+	return nil
+}
+
+// describe returns the descriptors of a built collector. Collectors implementing DescribeCollector describe
+// their metrics themselves, all other collectors are described by the metrics of one collection.
+func describe(metricsCollector Collector) []*prometheus.Desc {
+	describeCollector, ok := metricsCollector.(DescribeCollector)
+	if !ok {
+		return describeByCollect(metricsCollector, describeTimeout)
+	}
+
+	ch := make(chan *prometheus.Desc)
+
+	go func() {
+		describeCollector.Describe(ch)
+		close(ch)
+	}()
+
+	descs := make([]*prometheus.Desc, 0)
+
+	for desc := range ch {
+		descs = append(descs, desc)
+	}
+
+	return descs
+}
+
+// describeByCollect returns the distinct descriptors of the metrics sent by one collection of the collector,
+// like prometheus.DescribeByCollect. Metrics, which are not sent by this collection, e.g. because an instance
+// does not exist yet, are missing. A collection, which does not finish within the timeout, is abandoned.
+func describeByCollect(metricsCollector Collector, timeout time.Duration) []*prometheus.Desc {
+	ch := make(chan prometheus.Metric)
+
+	go func() {
+		defer close(ch)
+
+		_ = metricsCollector.Collect(ch, timeout)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	seen := make(map[*prometheus.Desc]struct{})
+	descs := make([]*prometheus.Desc, 0)
+
+	for {
+		select {
+		case m, ok := <-ch:
+			if !ok {
+				return descs
+			}
+
+			desc := m.Desc()
+			if _, ok := seen[desc]; ok {
+				continue
+			}
+
+			seen[desc] = struct{}{}
+			descs = append(descs, desc)
+		case <-timer.C:
+			// Drain the abandoned collection, so that the collector does not block.
+			go func() {
+				for range ch {
+				}
+			}()
+
+			return descs
+		}
+	}
+}
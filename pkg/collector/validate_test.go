@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Apache-2.0
+//
+// Copyright The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package collector
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/prometheus-community/windows_exporter/internal/mi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// describingCollector is a collector with fixed descriptors, which implements DescribeCollector.
+type describingCollector struct {
+	name  string
+	descs []*prometheus.Desc
+}
+
+func (c *describingCollector) GetName() string { return c.name }
+
+func (c *describingCollector) Build(*slog.Logger, *mi.Session) error { return nil }
+
+func (c *describingCollector) Collect(chan<- prometheus.Metric, time.Duration) error { return nil }
+
+func (c *describingCollector) Close() error { return nil }
+
+func (c *describingCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range c.descs {
+		ch <- desc
+	}
+}
+
+func TestValidateNames(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validateNames(Map{
+		"a": &describingCollector{name: "a"},
+		"b": &describingCollector{name: "b"},
+	}))
+
+	err := validateNames(Map{
+		"logical_disk":  &describingCollector{name: "logical_disk"},
+		"logical_disk2": &describingCollector{name: "logical_disk"},
+	})
+	require.EqualError(t, err, "collectors logical_disk and logical_disk2 are both named logical_disk")
+}
+
+func TestValidateDescriptors(t *testing.T) {
+	t.Parallel()
+
+	info := prometheus.NewDesc("windows_logical_disk_info", "A metric with a constant '1' value", []string{"volume"}, nil)
+	size := prometheus.NewDesc("windows_logical_disk_size_bytes", "Total space in bytes", []string{"volume"}, nil)
+	cpu := prometheus.NewDesc("windows_cpu_time_total", "Time that processor spent in different modes", []string{"core", "mode"}, nil)
+
+	require.NoError(t, validateDescriptors(Map{
+		"cpu":          &describingCollector{name: "cpu", descs: []*prometheus.Desc{cpu}},
+		"logical_disk": &describingCollector{name: "logical_disk", descs: []*prometheus.Desc{info, size}},
+		"synthetic":    &syntheticCollector{series: 1, desc: prometheus.NewDesc("windows_synthetic_series", "Synthetic metric.", []string{"id"}, nil)},
+	}))
+
+	t.Run("shared descriptor", func(t *testing.T) {
+		t.Parallel()
+
+		err := validateDescriptors(Map{
+			"logical_disk":      &describingCollector{name: "logical_disk", descs: []*prometheus.Desc{info, size}},
+			"logical_disk_fork": &describingCollector{name: "logical_disk_fork", descs: []*prometheus.Desc{size}},
+		})
+		require.ErrorContains(t, err, "collectors logical_disk and logical_disk_fork emit conflicting metric")
+		require.ErrorContains(t, err, "windows_logical_disk_size_bytes")
+	})
+
+	t.Run("same name with different labels", func(t *testing.T) {
+		t.Parallel()
+
+		err := validateDescriptors(Map{
+			"cpu":       &describingCollector{name: "cpu", descs: []*prometheus.Desc{cpu}},
+			"cpu_other": &describingCollector{name: "cpu_other", descs: []*prometheus.Desc{prometheus.NewDesc("windows_cpu_time_total", "Other", []string{"core"}, nil)}},
+		})
+		require.ErrorContains(t, err, "collectors cpu and cpu_other emit conflicting metric")
+		require.ErrorContains(t, err, "windows_cpu_time_total")
+	})
+
+	t.Run("collector without Describe", func(t *testing.T) {
+		t.Parallel()
+
+		// Collectors without Describe are described by the metrics of one collection.
+		err := validateDescriptors(Map{
+			"logical_disk": &describingCollector{name: "logical_disk", descs: []*prometheus.Desc{info, size}},
+			"synthetic":    &syntheticCollector{series: 1, desc: size},
+		})
+		require.ErrorContains(t, err, "collectors logical_disk and synthetic emit conflicting metric")
+		require.ErrorContains(t, err, "windows_logical_disk_size_bytes")
+	})
+}